@@ -30,8 +30,38 @@ const (
 	// OriginSnapshotAnnotation contains the name of the original snapshot that started the snapshot chain
 	OriginSnapshotAnnotation = TestLabelPrefix + "/origin-snapshot"
 
+	// ComponentGroupChainAnnotation contains the comma separated names of the ComponentGroups the snapshot chain went through,
+	// it's used to prevent creating snapshots for dependents in a cycle
+	ComponentGroupChainAnnotation = TestLabelPrefix + "/component-group-chain"
+
 	// MissingComponentVersionsAnnotation contains a list of ComponentVersions that cannot be found
 	MissingComponentVersionsAnnotation = TestLabelPrefix + "/missing-componentversions"
+
+	// GCLRollbackAnnotation requests the rollback of the Global Candidate List entry of a ComponentVersion to its previous
+	// promotion, its value is the name and version of the Component in the "<name>/<version>" format
+	GCLRollbackAnnotation = TestLabelPrefix + "/rollback-gcl"
+
+	// GCLRollbackReasonAnnotation contains why the rollback of the Global Candidate List entry was requested,
+	// it's recorded in the promotion history
+	GCLRollbackReasonAnnotation = TestLabelPrefix + "/rollback-gcl-reason"
+
+	// GCLRollbackCondition is the condition reporting the result of the last requested rollback of the Global Candidate List
+	GCLRollbackCondition = "GCLRolledBack"
+
+	// GCLFrozenCondition is the condition reporting whether the Global Candidate List is frozen
+	GCLFrozenCondition = "GCLFrozen"
+)
+
+// GCLFreezeLiftPolicy defines what happens to the queued promotions when the freeze of the Global Candidate List lifts
+// +kubebuilder:validation:Enum=Apply;Discard
+type GCLFreezeLiftPolicy string
+
+const (
+	// GCLFreezeLiftApply applies the queued promotions when the freeze lifts
+	GCLFreezeLiftApply GCLFreezeLiftPolicy = "Apply"
+
+	// GCLFreezeLiftDiscard discards the queued promotions when the freeze lifts
+	GCLFreezeLiftDiscard GCLFreezeLiftPolicy = "Discard"
 )
 
 // ComponentGroupSpec defines the desired state of ComponentGroup
@@ -53,9 +83,41 @@ type ComponentGroupSpec struct {
 	TestGraph map[string][]TestGraphNode `json:"testGraph,omitempty"`
 
 	// SnapshotCreator is an optional field that allows custom logic for Snapshot creation.
-	// This field is reserved for future implementation and should not be used yet.
+	// When set, the referenced Task is run after each successful build and the Snapshot spec it emits
+	// is used instead of the one prepared by the integration service.
 	// +optional
 	SnapshotCreator *SnapshotCreatorSpec `json:"snapshotCreator,omitempty"`
+
+	// Freeze stops the GlobalCandidateList from moving, e.g. during release stabilization.
+	// While the freeze is active the promotions of the frozen Components are queued
+	// in the status instead of being applied.
+	// +optional
+	Freeze *GCLFreeze `json:"freeze,omitempty"`
+}
+
+// GCLFreeze defines a freeze of the GlobalCandidateList of a ComponentGroup
+type GCLFreeze struct {
+	// Components is the list of names of the frozen Components. All the Components
+	// of the ComponentGroup are frozen when it is empty
+	// +optional
+	Components []string `json:"components,omitempty"`
+
+	// Until is when the freeze lifts. The freeze is active until it is removed
+	// from the ComponentGroup when it is not set
+	// Format: RFC3339 (e.g., "2025-08-13T12:00:00Z")
+	// +optional
+	Until *metav1.Time `json:"until,omitempty"`
+
+	// Reason why the GlobalCandidateList is frozen
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// OnLift defines whether the queued promotions are applied or discarded when
+	// the freeze lifts. The queued promotions are applied when the freeze is removed
+	// from the ComponentGroup
+	// +kubebuilder:default=Apply
+	// +optional
+	OnLift GCLFreezeLiftPolicy `json:"onLift,omitempty"`
 }
 
 // ComponentReference references a Component and its specific branch/version
@@ -105,10 +167,10 @@ type TestGraphNode struct {
 }
 
 // SnapshotCreatorSpec defines custom logic for creating snapshots.
-// This is reserved for future implementation and should not be used yet.
 type SnapshotCreatorSpec struct {
-	// TaskRef references a Tekton Task that will create the Snapshot CR.
-	// This field is reserved for future use.
+	// TaskRef references a Tekton Task that will produce the spec of the Snapshot CR.
+	// The Task receives the SNAPSHOT, BUILD_PIPELINERUN_RESULTS and GLOBAL_CANDIDATE_LIST params
+	// and must emit the Snapshot spec as json in its SNAPSHOT result.
 	// +optional
 	TaskRef *TaskRef `json:"taskRef,omitempty"`
 }
@@ -164,6 +226,96 @@ type ComponentGroupStatus struct {
 	// The list of recently promoted Components which the integration service
 	// uses to create Snapshots
 	GlobalCandidateList []ComponentState `json:"globalCandidateList,omitempty"`
+
+	// PromotionHistory keeps the recent promotions of each Component version in
+	// the GlobalCandidateList, so that a bad promotion can be rolled back
+	// +optional
+	PromotionHistory []ComponentPromotionHistory `json:"promotionHistory,omitempty"`
+
+	// QueuedPromotions are the promotions held back while their Component is
+	// frozen, they are applied or discarded when the freeze lifts
+	// +optional
+	QueuedPromotions []QueuedPromotion `json:"queuedPromotions,omitempty"`
+}
+
+// QueuedPromotion is a promotion to the GlobalCandidateList held back by a freeze,
+// only the latest promotion of each Component version is queued
+type QueuedPromotion struct {
+	// Entry is the GlobalCandidateList entry to promote
+	// +required
+	Entry ComponentState `json:"entry"`
+
+	// Snapshot which requested the promotion, it is empty for promotions
+	// requested by a push build PipelineRun
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// BuildPipelineRun which requested the promotion, it is empty for
+	// promotions requested by a Snapshot
+	// +optional
+	BuildPipelineRun string `json:"buildPipelineRun,omitempty"`
+
+	// QueuedTime is when the promotion was queued
+	// +required
+	QueuedTime metav1.Time `json:"queuedTime"`
+}
+
+// ComponentPromotionHistory is the bounded promotion history of a Component version
+type ComponentPromotionHistory struct {
+	// Name of the Component
+	// +required
+	Name string `json:"name"`
+
+	// Version of the Component
+	// +optional
+	Version string `json:"version"`
+
+	// Promotions of the Component version, the most recent first
+	// +optional
+	Promotions []PromotionRecord `json:"promotions,omitempty"`
+}
+
+// PromotionRecord describes a promotion of an image of a Component version to the GlobalCandidateList
+type PromotionRecord struct {
+	// Image which was promoted
+	// +optional
+	Image string `json:"image"`
+
+	// Git commit associated with the build of Image
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Timestamp for build of the Image
+	// +optional
+	BuildTime *metav1.Time `json:"buildTime,omitempty"`
+
+	// Snapshot which promoted the Image, it is empty for images promoted
+	// by their push build PipelineRun
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// BuildPipelineRun which promoted the Image, it is empty for images
+	// promoted by a Snapshot
+	// +optional
+	BuildPipelineRun string `json:"buildPipelineRun,omitempty"`
+
+	// PromotionTime is when the Image was promoted
+	// +required
+	PromotionTime metav1.Time `json:"promotionTime"`
+
+	// RolledBack is set when the Image was promoted by rolling back a
+	// later promotion
+	// +optional
+	RolledBack bool `json:"rolledBack,omitempty"`
+
+	// Reason why the promotion was rolled back to the Image
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Superseded is set when the promotion of the Image was rolled back, so
+	// that the following rollbacks skip it
+	// +optional
+	Superseded bool `json:"superseded,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -350,6 +350,9 @@ func TestComponentGroupConstants(t *testing.T) {
 	if OriginSnapshotAnnotation != "test.appstudio.openshift.io/origin-snapshot" {
 		t.Errorf("Expected OriginSnapshotAnnotation 'test.appstudio.openshift.io/origin-snapshot', got '%s'", OriginSnapshotAnnotation)
 	}
+	if ComponentGroupChainAnnotation != "test.appstudio.openshift.io/component-group-chain" {
+		t.Errorf("Expected ComponentGroupChainAnnotation 'test.appstudio.openshift.io/component-group-chain', got '%s'", ComponentGroupChainAnnotation)
+	}
 	if MissingComponentVersionsAnnotation != "test.appstudio.openshift.io/missing-componentversions" {
 		t.Errorf("Expected MissingComponentVersionsAnnotation 'test.appstudio.openshift.io/missing-componentversions', got '%s'", MissingComponentVersionsAnnotation)
 	}
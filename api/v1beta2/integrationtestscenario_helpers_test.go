@@ -18,6 +18,9 @@ package v1beta2
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestHasApplication(t *testing.T) {
@@ -155,3 +158,164 @@ func TestOwnerName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		its      *IntegrationTestScenario
+		expected int
+	}{
+		{
+			name: "returns 1 when no retry policy is set",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{},
+			},
+			expected: 1,
+		},
+		{
+			name: "returns maxAttempts of the retry policy",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 3},
+				},
+			},
+			expected: 3,
+		},
+		{
+			name: "returns 1 when maxAttempts of the retry policy is not set",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{},
+				},
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.its.GetMaxAttempts(); got != tt.expected {
+				t.Errorf("GetMaxAttempts() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShouldRetryOn(t *testing.T) {
+	tests := []struct {
+		name     string
+		its      *IntegrationTestScenario
+		outcome  RetryOutcome
+		expected bool
+	}{
+		{
+			name: "returns false when no retry policy is set",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{},
+			},
+			outcome:  RetryOnTestFail,
+			expected: false,
+		},
+		{
+			name: "returns false when only a single attempt is allowed",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 1, RetryOn: []RetryOutcome{RetryOnTestFail}},
+				},
+			},
+			outcome:  RetryOnTestFail,
+			expected: false,
+		},
+		{
+			name: "retries failed tests by default",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+				},
+			},
+			outcome:  RetryOnTestFail,
+			expected: true,
+		},
+		{
+			name: "doesn't retry deleted tests by default",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 2},
+				},
+			},
+			outcome:  RetryOnDeleted,
+			expected: false,
+		},
+		{
+			name: "retries the outcomes listed in the retry policy",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 2, RetryOn: []RetryOutcome{RetryOnInvalid, RetryOnDeleted}},
+				},
+			},
+			outcome:  RetryOnDeleted,
+			expected: true,
+		},
+		{
+			name: "doesn't retry the outcomes not listed in the retry policy",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					RetryPolicy: &RetryPolicy{MaxAttempts: 2, RetryOn: []RetryOutcome{RetryOnInvalid, RetryOnDeleted}},
+				},
+			},
+			outcome:  RetryOnTestFail,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.its.ShouldRetryOn(tt.outcome); got != tt.expected {
+				t.Errorf("ShouldRetryOn() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsQuarantined(t *testing.T) {
+	now := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		its      *IntegrationTestScenario
+		expected bool
+	}{
+		{
+			name: "returns false when no quarantine is set",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{},
+			},
+			expected: false,
+		},
+		{
+			name: "returns true when the quarantine hasn't expired",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					Quarantine: &Quarantine{Reason: "flaky", ExpiresAt: metav1.NewTime(now.Add(time.Hour))},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "returns false when the quarantine has expired",
+			its: &IntegrationTestScenario{
+				Spec: IntegrationTestScenarioSpec{
+					Quarantine: &Quarantine{Reason: "flaky", ExpiresAt: metav1.NewTime(now)},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.its.IsQuarantined(now); got != tt.expected {
+				t.Errorf("IsQuarantined() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
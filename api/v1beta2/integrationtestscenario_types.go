@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta2
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,6 +58,53 @@ type IntegrationTestScenarioSpec struct {
 	Contexts []TestContext `json:"contexts,omitempty"`
 	// List of IntegrationTestScenario which are blocked by the successful completion of this IntegrationTestScenario
 	Dependents []string `json:"dependents,omitempty"`
+	// RetryPolicy defines how integration PipelineRuns of this IntegrationTestScenario are retried automatically
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// When is an optional CEL expression evaluated against the Snapshot, the IntegrationTestScenario
+	// is only applied to Snapshots for which it evaluates to true, in addition to the Contexts filtering
+	// +optional
+	When string `json:"when,omitempty"`
+	// Quarantine keeps running the IntegrationTestScenario without letting its outcome block the promotion
+	// of Snapshots until the quarantine expires
+	// +optional
+	Quarantine *Quarantine `json:"quarantine,omitempty"`
+}
+
+// RetryOutcome is an outcome of an integration PipelineRun which can be retried
+// +kubebuilder:validation:Enum=TestFail;Invalid;Deleted
+type RetryOutcome string
+
+const (
+	// RetryOnTestFail retries integration PipelineRuns whose tests failed
+	RetryOnTestFail RetryOutcome = "TestFail"
+	// RetryOnInvalid retries integration PipelineRuns whose outcome couldn't be determined
+	RetryOnInvalid RetryOutcome = "Invalid"
+	// RetryOnDeleted retries integration PipelineRuns which were deleted before they finished
+	RetryOnDeleted RetryOutcome = "Deleted"
+)
+
+// RetryPolicy defines how many times and for which outcomes the integration PipelineRuns of an IntegrationTestScenario are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of integration PipelineRuns created for a Snapshot, including the first one
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +required
+	MaxAttempts int `json:"maxAttempts"`
+	// RetryOn lists the outcomes of an integration PipelineRun which trigger a new attempt, defaults to TestFail
+	// +optional
+	RetryOn []RetryOutcome `json:"retryOn,omitempty"`
+}
+
+// Quarantine defines a time-limited period during which the IntegrationTestScenario isn't required for the Snapshot to pass
+type Quarantine struct {
+	// Reason explains why the IntegrationTestScenario is quarantined
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Reason string `json:"reason"`
+	// ExpiresAt is the time when the quarantine ends and the IntegrationTestScenario becomes required again
+	// +required
+	ExpiresAt metav1.Time `json:"expiresAt"`
 }
 
 // IntegrationTestScenarioStatus defines the observed state of IntegrationTestScenario described by conditions
@@ -111,6 +160,37 @@ func (its *IntegrationTestScenario) OwnerName() string {
 	return its.Spec.ComponentGroup
 }
 
+// GetMaxAttempts returns the maximum number of attempts of the IntegrationTestScenario's integration PipelineRuns.
+// Scenarios without a RetryPolicy are attempted only once.
+func (its *IntegrationTestScenario) GetMaxAttempts() int {
+	if its.Spec.RetryPolicy == nil || its.Spec.RetryPolicy.MaxAttempts < 1 {
+		return 1
+	}
+	return its.Spec.RetryPolicy.MaxAttempts
+}
+
+// ShouldRetryOn returns true if the RetryPolicy of the IntegrationTestScenario retries integration PipelineRuns
+// with the given outcome.
+func (its *IntegrationTestScenario) ShouldRetryOn(outcome RetryOutcome) bool {
+	if its.GetMaxAttempts() < 2 {
+		return false
+	}
+	if len(its.Spec.RetryPolicy.RetryOn) == 0 {
+		return outcome == RetryOnTestFail
+	}
+	for _, retryOn := range its.Spec.RetryPolicy.RetryOn {
+		if retryOn == outcome {
+			return true
+		}
+	}
+	return false
+}
+
+// IsQuarantined returns true if the IntegrationTestScenario has a quarantine which hasn't expired at the given time.
+func (its *IntegrationTestScenario) IsQuarantined(now time.Time) bool {
+	return its.Spec.Quarantine != nil && now.Before(its.Spec.Quarantine.ExpiresAt.Time)
+}
+
 // +kubebuilder:object:root=true
 
 // IntegrationTestScenarioList contains a list of IntegrationTestScenarios
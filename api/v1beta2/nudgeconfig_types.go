@@ -57,7 +57,8 @@ type NudgeRelationship struct {
 	// +optional
 	Mode NudgeModeType `json:"mode,omitempty"`
 
-	// GatingGroup is reserved for Phase 2 group-based gating and is not enforced in Phase 1.
+	// GatingGroup groups nudges which are triggered together. The nudges of a gating group are triggered
+	// only once every source component of the group has a passing Snapshot, regardless of their mode.
 	// +kubebuilder:validation:MaxLength=63
 	// +optional
 	GatingGroup string `json:"gatingGroup,omitempty"`
@@ -82,6 +83,46 @@ type NudgeConfigStatus struct {
 	// LastValidationTime is the timestamp of the last successful validation of the nudge graph.
 	// +optional
 	LastValidationTime *metav1.Time `json:"lastValidationTime,omitempty"`
+
+	// GatingGroups is the observed state of the gating groups referenced by the nudges.
+	// +optional
+	GatingGroups []GatingGroupStatus `json:"gatingGroups,omitempty"`
+}
+
+// GatingGroupStatus describes which source components of a gating group have a passing Snapshot.
+type GatingGroupStatus struct {
+	// Name is the name of the gating group.
+	// +required
+	Name string `json:"name"`
+
+	// Members lists the source components of the gating group.
+	// +optional
+	Members []GatingGroupMemberStatus `json:"members,omitempty"`
+
+	// Outstanding lists the source components of the gating group which don't have a passing Snapshot
+	// since the last time the nudges of the gating group were triggered.
+	// +optional
+	Outstanding []string `json:"outstanding,omitempty"`
+
+	// LastTriggeredTime is the timestamp of the last time the nudges of the gating group were triggered.
+	// +optional
+	LastTriggeredTime *metav1.Time `json:"lastTriggeredTime,omitempty"`
+}
+
+// GatingGroupMemberStatus describes a single source component of a gating group.
+type GatingGroupMemberStatus struct {
+	// Component is the name of the source component.
+	// +required
+	Component string `json:"component"`
+
+	// Snapshot is the name of the passing Snapshot which satisfied the component.
+	// It is empty while the component is outstanding.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// LastUpdateTime is the timestamp of the last time the Snapshot of the component was updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SnapshotRetentionType is the type of the Snapshots a retention rule applies to.
+// +kubebuilder:validation:Enum=pr;push;group;override
+type SnapshotRetentionType string
+
+const (
+	// SnapshotRetentionTypePR applies the rule to the component Snapshots created for pull requests.
+	SnapshotRetentionTypePR SnapshotRetentionType = "pr"
+
+	// SnapshotRetentionTypePush applies the rule to the Snapshots created for pushes.
+	SnapshotRetentionTypePush SnapshotRetentionType = "push"
+
+	// SnapshotRetentionTypeGroup applies the rule to the group Snapshots.
+	SnapshotRetentionTypeGroup SnapshotRetentionType = "group"
+
+	// SnapshotRetentionTypeOverride applies the rule to the override Snapshots.
+	SnapshotRetentionTypeOverride SnapshotRetentionType = "override"
+)
+
+// SnapshotRetentionPolicySingletonName is the required name for the singleton SnapshotRetentionPolicy per namespace.
+const SnapshotRetentionPolicySingletonName = "snapshot-retention-policy"
+
+// SnapshotRetentionRule defines how many Snapshots of a type are kept, and for how long.
+// +kubebuilder:validation:XValidation:rule="has(self.maxCount) || has(self.maxAge)",message="at least one of maxCount and maxAge must be set"
+type SnapshotRetentionRule struct {
+	// SnapshotType is the type of the Snapshots the rule applies to.
+	// +required
+	SnapshotType SnapshotRetentionType `json:"snapshotType"`
+
+	// MaxCount is the number of the latest Snapshots of the type which are kept, the older ones are deleted.
+	// It is clamped to the cluster-wide limit of the PR or non-PR Snapshots when the Snapshots are garbage collected.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=512
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// MaxAge is how long the Snapshots of the type are kept, e.g. "168h". The older ones are deleted.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// SnapshotRetentionPolicySpec defines the retention of the Snapshots of a namespace.
+// +kubebuilder:validation:XValidation:rule="!has(self.rules) || self.rules.all(i, self.rules.exists_one(j, i.snapshotType == j.snapshotType))",message="duplicate snapshotType not allowed"
+type SnapshotRetentionPolicySpec struct {
+	// Rules is the list of retention rules by Snapshot type. The Snapshot types without a rule are
+	// garbage collected with the cluster-wide retention settings.
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	Rules []SnapshotRetentionRule `json:"rules,omitempty"`
+
+	// MinSnapshotsToKeepPerComponent is the number of the latest push Snapshots kept for each component
+	// regardless of the rules. The cluster-wide setting is used when it isn't set, and it can't be raised above it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSnapshotsToKeepPerComponent *int32 `json:"minSnapshotsToKeepPerComponent,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=srp
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'snapshot-retention-policy'",message="SnapshotRetentionPolicy must be named 'snapshot-retention-policy' (singleton per namespace)"
+
+// SnapshotRetentionPolicy is a namespace-scoped singleton CRD that defines the retention of the Snapshots of the namespace
+// by the Snapshot garbage collector. Exactly one SnapshotRetentionPolicy named "snapshot-retention-policy" may exist per namespace.
+type SnapshotRetentionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SnapshotRetentionPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotRetentionPolicyList contains a list of SnapshotRetentionPolicies.
+type SnapshotRetentionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnapshotRetentionPolicy `json:"items"`
+}
+
+// GetRule returns the retention rule of the given Snapshot type, or nil when the policy has no rule for it.
+func (p *SnapshotRetentionPolicy) GetRule(snapshotType SnapshotRetentionType) *SnapshotRetentionRule {
+	for i := range p.Spec.Rules {
+		if p.Spec.Rules[i].SnapshotType == snapshotType {
+			return &p.Spec.Rules[i]
+		}
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&SnapshotRetentionPolicy{}, &SnapshotRetentionPolicyList{})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"fmt"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/konflux-ci/integration-service/gitops"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification the emitted events conform to
+	SpecVersion = "1.0"
+
+	// SnapshotCreatedEventType is the type of the event emitted when a Snapshot is created
+	SnapshotCreatedEventType = "dev.konflux-ci.integration.snapshot.created.v1"
+
+	// ScenarioStartedEventType is the type of the event emitted when an integration PipelineRun is created for a scenario
+	ScenarioStartedEventType = "dev.konflux-ci.integration.scenario.started.v1"
+
+	// ScenarioFinishedEventType is the type of the event emitted when the integration PipelineRun of a scenario finished
+	ScenarioFinishedEventType = "dev.konflux-ci.integration.scenario.finished.v1"
+
+	// SnapshotPassedEventType is the type of the event emitted when a Snapshot passed all required scenarios
+	SnapshotPassedEventType = "dev.konflux-ci.integration.snapshot.passed.v1"
+
+	// SnapshotFailedEventType is the type of the event emitted when a Snapshot failed some required scenarios
+	SnapshotFailedEventType = "dev.konflux-ci.integration.snapshot.failed.v1"
+
+	// AddedToGlobalCandidateListEventType is the type of the event emitted when components are added to the Global Candidate List
+	AddedToGlobalCandidateListEventType = "dev.konflux-ci.integration.gcl.updated.v1"
+
+	// ReleaseCreatedEventType is the type of the event emitted when an automated Release is created for a Snapshot
+	ReleaseCreatedEventType = "dev.konflux-ci.integration.release.created.v1"
+
+	// SnapshotCanceledEventType is the type of the event emitted when a Snapshot is superseded or canceled
+	SnapshotCanceledEventType = "dev.konflux-ci.integration.snapshot.canceled.v1"
+)
+
+// Event is a CloudEvent emitted by the integration service
+type Event struct {
+	// ID identifies the event
+	ID string
+	// Type is one of the event types defined by this package
+	Type string
+	// Source identifies the context in which the event happened
+	Source string
+	// Subject is the name of the object the event is about
+	Subject string
+	// Time is when the event happened
+	Time time.Time
+	// Data is the JSON payload of the event
+	Data EventData
+}
+
+// EventData is the payload of the events emitted by the integration service
+type EventData struct {
+	Namespace      string `json:"namespace"`
+	Snapshot       string `json:"snapshot,omitempty"`
+	Application    string `json:"application,omitempty"`
+	ComponentGroup string `json:"componentGroup,omitempty"`
+	Component      string `json:"component,omitempty"`
+	Scenario       string `json:"scenario,omitempty"`
+	PipelineRun    string `json:"pipelineRun,omitempty"`
+	Release        string `json:"release,omitempty"`
+	ReleasePlan    string `json:"releasePlan,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+// NewEvent returns a new event of the given type about the named object in the given namespace
+func NewEvent(eventType, namespace, subject string) *Event {
+	return &Event{
+		ID:      string(uuid.NewUUID()),
+		Type:    eventType,
+		Source:  fmt.Sprintf("/integration-service/namespaces/%s", namespace),
+		Subject: subject,
+		Time:    time.Now().UTC(),
+		Data: EventData{
+			Namespace: namespace,
+		},
+	}
+}
+
+// NewSnapshotEvent returns a new event of the given type about the given Snapshot
+func NewSnapshotEvent(eventType string, snapshot *applicationapiv1alpha1.Snapshot) *Event {
+	event := NewEvent(eventType, snapshot.Namespace, snapshot.Name)
+	event.Data.Snapshot = snapshot.Name
+	event.Data.Application = snapshot.Spec.Application
+	event.Data.ComponentGroup = snapshot.Spec.ComponentGroup
+	event.Data.Component = snapshot.GetLabels()[gitops.SnapshotComponentLabel]
+	return event
+}
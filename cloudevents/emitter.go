@@ -0,0 +1,185 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/pkg/common"
+)
+
+const (
+	// SinksConfigMapName is the name of the ConfigMap configuring the CloudEvents sinks of a namespace
+	SinksConfigMapName = "integration-service-cloudevents"
+
+	// SinksConfigMapKey is the key of the sinks ConfigMap holding the sink URLs, one per line
+	SinksConfigMapKey = "sinks"
+
+	// deliveryTimeout is the timeout of the delivery of an event to a single sink, retries included
+	deliveryTimeout = 30 * time.Second
+
+	// requestTimeout is the timeout of a single request sent to a sink
+	requestTimeout = 5 * time.Second
+)
+
+// emitterRetryBackoff defines the retry backoff for transient sink errors.
+// Retries up to 3 times with exponential backoff: ~0s, ~1s, ~2s.
+var emitterRetryBackoff = wait.Backoff{
+	Steps:    3,
+	Duration: 1 * time.Second,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// Emitter sends CloudEvents to the sinks configured for the namespace of the events
+type Emitter struct {
+	logger     logr.Logger
+	client     client.Client
+	httpClient *http.Client
+	// parseSink parses a configured sink URL, rejecting the ones which aren't allowed
+	parseSink func(rawURL string) (*url.URL, error)
+}
+
+// NewEmitter returns a new Emitter which loads the sinks configuration with the given client
+func NewEmitter(logger logr.Logger, client client.Client) *Emitter {
+	return &Emitter{
+		logger:     logger,
+		client:     client,
+		httpClient: common.NewSinkHTTPClient(requestTimeout),
+		parseSink:  common.ParseSinkURL,
+	}
+}
+
+// Emit sends the event to all sinks configured for its namespace. The delivery is done in the background
+// so a slow or unavailable sink never blocks the reconciliation, failures are only logged.
+func (e *Emitter) Emit(ctx context.Context, event *Event) {
+	sinks, err := e.GetSinks(ctx, event.Data.Namespace)
+	if err != nil {
+		e.logger.Error(err, "failed to get the CloudEvents sinks, the event will not be emitted",
+			"namespace", event.Data.Namespace, "event.Type", event.Type)
+		return
+	}
+
+	for _, sink := range sinks {
+		go func(sink string) {
+			deliveryCtx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			defer cancel()
+			if err := e.Send(deliveryCtx, sink, event); err != nil {
+				e.logger.Error(err, "failed to emit CloudEvent", "sink", sink, "event.Type", event.Type, "event.Subject", event.Subject)
+			}
+		}(sink)
+	}
+}
+
+// GetSinks returns the valid sink URLs configured for the given namespace, none if the namespace has no sinks ConfigMap.
+// The sinks must be https URLs, and the ones whose host is an internal address of the cluster network are ignored.
+func (e *Emitter) GetSinks(ctx context.Context, namespace string) ([]string, error) {
+	configMap := &v1.ConfigMap{}
+	err := e.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: SinksConfigMapName}, configMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sinks := []string{}
+	for _, line := range strings.Split(configMap.Data[SinksConfigMapKey], "\n") {
+		sink := strings.TrimSpace(line)
+		if sink == "" || strings.HasPrefix(sink, "#") {
+			continue
+		}
+		parsedURL, err := e.parseSink(sink)
+		if err != nil {
+			e.logger.Info("Ignoring invalid CloudEvents sink",
+				"namespace", namespace, "configMap.Name", SinksConfigMapName, "sink", sink, "error", err.Error())
+			continue
+		}
+		sinks = append(sinks, parsedURL.String())
+	}
+	return sinks, nil
+}
+
+// Send delivers the event to the sink in the HTTP binary content mode, retrying with backoff on transient errors
+func (e *Emitter) Send(ctx context.Context, sink string, event *Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the data of CloudEvent %s: %w", event.ID, err)
+	}
+
+	var statusCode int
+	err = retry.OnError(emitterRetryBackoff, func(err error) bool {
+		// statusCode 0 means no HTTP response was received (network/timeout error), always retry
+		return statusCode == 0 || isRetryableStatusCode(statusCode)
+	}, func() error {
+		statusCode, err = e.send(ctx, sink, event, payload)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send CloudEvent %s to sink %s: %w", event.ID, sink, err)
+	}
+	return nil
+}
+
+// send posts the event to the sink and returns the HTTP status code of the response
+func (e *Emitter) send(ctx context.Context, sink string, event *Event, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", common.IntegrationServiceUserAgent)
+	req.Header.Set("ce-specversion", SpecVersion)
+	req.Header.Set("ce-id", event.ID)
+	req.Header.Set("ce-type", event.Type)
+	req.Header.Set("ce-source", event.Source)
+	req.Header.Set("ce-time", event.Time.Format(time.RFC3339Nano))
+	if event.Subject != "" {
+		req.Header.Set("ce-subject", event.Subject)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// isRetryableStatusCode returns true if the sink may accept the event when it is sent again
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusRequestTimeout || statusCode >= http.StatusInternalServerError
+}
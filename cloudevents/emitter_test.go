@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/cloudevents"
+	"github.com/konflux-ci/integration-service/gitops"
+)
+
+type receivedEvent struct {
+	header http.Header
+	data   cloudevents.EventData
+}
+
+var _ = Describe("Emitter", func() {
+	var (
+		server       *httptest.Server
+		lock         sync.Mutex
+		received     []receivedEvent
+		responseCode int
+		savedBackoff wait.Backoff
+		hasSnapshot  *applicationapiv1alpha1.Snapshot
+	)
+
+	getReceived := func() []receivedEvent {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]receivedEvent{}, received...)
+	}
+
+	newSinksConfigMap := func(sinks string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cloudevents.SinksConfigMapName,
+				Namespace: "default",
+			},
+			Data: map[string]string{
+				cloudevents.SinksConfigMapKey: sinks,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		received = nil
+		responseCode = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			data := cloudevents.EventData{}
+			Expect(json.NewDecoder(r.Body).Decode(&data)).To(Succeed())
+			received = append(received, receivedEvent{header: r.Header, data: data})
+			rw.WriteHeader(responseCode)
+		}))
+
+		hasSnapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot-sample",
+				Namespace: "default",
+				Labels: map[string]string{
+					gitops.SnapshotComponentLabel: "component-sample",
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+			},
+		}
+
+		savedBackoff = cloudevents.GetEmitterRetryBackoff()
+		cloudevents.SetEmitterRetryBackoff(wait.Backoff{
+			Steps:    3,
+			Duration: 1 * time.Millisecond,
+			Factor:   1.0,
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		cloudevents.SetEmitterRetryBackoff(savedBackoff)
+	})
+
+	It("returns the valid sinks configured for the namespace", func() {
+		emitter := cloudevents.NewEmitter(logr.Discard(), fake.NewClientBuilder().WithObjects(
+			newSinksConfigMap("# dashboards\nhttps://sink.example.com/events\n\n  https://other.example.com  \n/relative\nftp://example.com\n"+
+				"http://insecure.example.com\nhttps://127.0.0.1/events\nhttps://10.0.0.1\nhttps://169.254.169.254/latest\nhttps://[::1]:8443\n"),
+		).Build())
+
+		sinks, err := emitter.GetSinks(context.Background(), "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sinks).To(Equal([]string{"https://sink.example.com/events", "https://other.example.com"}))
+
+		sinks, err = emitter.GetSinks(context.Background(), "other-namespace")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sinks).To(BeEmpty())
+	})
+
+	It("sends the event in the binary content mode", func() {
+		emitter := cloudevents.NewEmitter(logr.Discard(), fake.NewClientBuilder().Build())
+		emitter.AllowInternalSinks()
+		event := cloudevents.NewSnapshotEvent(cloudevents.ScenarioFinishedEventType, hasSnapshot)
+		event.Data.Scenario = "scenario-sample"
+		event.Data.Status = "TestPassed"
+
+		Expect(emitter.Send(context.Background(), server.URL, event)).To(Succeed())
+		Expect(getReceived()).To(HaveLen(1))
+		header := getReceived()[0].header
+		Expect(header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(header.Get("ce-specversion")).To(Equal(cloudevents.SpecVersion))
+		Expect(header.Get("ce-id")).To(Equal(event.ID))
+		Expect(header.Get("ce-type")).To(Equal(cloudevents.ScenarioFinishedEventType))
+		Expect(header.Get("ce-source")).To(Equal("/integration-service/namespaces/default"))
+		Expect(header.Get("ce-subject")).To(Equal("snapshot-sample"))
+		Expect(getReceived()[0].data).To(Equal(cloudevents.EventData{
+			Namespace:   "default",
+			Snapshot:    "snapshot-sample",
+			Application: "application-sample",
+			Component:   "component-sample",
+			Scenario:    "scenario-sample",
+			Status:      "TestPassed",
+		}))
+	})
+
+	It("refuses to send the event to an internal address", func() {
+		emitter := cloudevents.NewEmitter(logr.Discard(), fake.NewClientBuilder().Build())
+		event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotPassedEventType, hasSnapshot)
+
+		Expect(emitter.Send(context.Background(), server.URL, event)).To(MatchError(ContainSubstring("refusing to connect to the internal address")))
+		Expect(getReceived()).To(BeEmpty())
+	})
+
+	It("retries transient errors but not client errors", func() {
+		emitter := cloudevents.NewEmitter(logr.Discard(), fake.NewClientBuilder().Build())
+		emitter.AllowInternalSinks()
+		event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotPassedEventType, hasSnapshot)
+
+		responseCode = http.StatusServiceUnavailable
+		Expect(emitter.Send(context.Background(), server.URL, event)).NotTo(Succeed())
+		Expect(getReceived()).To(HaveLen(3))
+
+		received = nil
+		responseCode = http.StatusBadRequest
+		Expect(emitter.Send(context.Background(), server.URL, event)).NotTo(Succeed())
+		Expect(getReceived()).To(HaveLen(1))
+	})
+
+	It("emits the event to all sinks of the namespace", func() {
+		emitter := cloudevents.NewEmitter(logr.Discard(), fake.NewClientBuilder().WithObjects(
+			newSinksConfigMap(server.URL+"/first\n"+server.URL+"/second"),
+		).Build())
+		emitter.AllowInternalSinks()
+
+		emitter.Emit(context.Background(), cloudevents.NewSnapshotEvent(cloudevents.SnapshotCreatedEventType, hasSnapshot))
+		Eventually(getReceived).Should(HaveLen(2))
+		for _, event := range getReceived() {
+			Expect(event.header.Get("ce-type")).To(Equal(cloudevents.SnapshotCreatedEventType))
+		}
+	})
+})
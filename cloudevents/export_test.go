@@ -0,0 +1,36 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"net/http"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// SetEmitterRetryBackoff overrides the retry backoff for testing.
+func SetEmitterRetryBackoff(b wait.Backoff) { emitterRetryBackoff = b }
+
+// GetEmitterRetryBackoff returns the current retry backoff.
+func GetEmitterRetryBackoff() wait.Backoff { return emitterRetryBackoff }
+
+// AllowInternalSinks makes the emitter accept the http sinks and the sinks of the cluster network, e.g. test servers.
+func (e *Emitter) AllowInternalSinks() {
+	e.httpClient = &http.Client{Timeout: requestTimeout}
+	e.parseSink = url.Parse
+}
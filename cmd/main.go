@@ -23,8 +23,11 @@ import (
 	"time"
 
 	controllers "github.com/konflux-ci/integration-service/internal/controller"
+	"github.com/konflux-ci/integration-service/internal/gitevents"
 	iswebhook "github.com/konflux-ci/integration-service/internal/webhook/v1beta2"
 	imetrics "github.com/konflux-ci/integration-service/pkg/metrics"
+	"github.com/konflux-ci/integration-service/pkg/snapshotgc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -36,6 +39,7 @@ import (
 
 	zap2 "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -83,12 +87,17 @@ func main() {
 		leaderElectorRetryPeriod time.Duration
 		secureMetrics            bool
 		tlsOpts                  []func(*tls.Config)
+		gitEventsAddr            string
+		enableSnapshotGC         bool
+		snapshotGCOptions        snapshotgc.CollectorOptions
 	)
 
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&gitEventsAddr, "git-events-bind-address", gitevents.DefaultBindAddress,
+		"The address the git provider events endpoints bind to. Use 0 to disable them.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -99,6 +108,21 @@ func main() {
 		"Lease Duration is the duration that non-leader candidates will wait to force acquire leadership.")
 	flag.DurationVar(&leaderElectorRetryPeriod, "leader-elector-retry-period", 2*time.Second, "RetryPeriod is the duration the "+
 		"LeaderElector clients should wait between tries of actions.")
+	flag.BoolVar(&enableSnapshotGC, "enable-snapshot-gc", false,
+		"If set, the snapshots of the tenant namespaces are garbage collected by the manager, "+
+			"as an alternative to the snapshot garbage collector CronJob.")
+	flag.DurationVar(&snapshotGCOptions.Interval, "snapshot-gc-interval", snapshotgc.DefaultCollectorInterval,
+		"The delay between the end of a snapshot garbage collection pass and the start of the next one.")
+	flag.Float64Var(&snapshotGCOptions.NamespacesPerSecond, "snapshot-gc-namespaces-per-second", snapshotgc.DefaultCollectorNamespacesPerSecond,
+		"The number of namespaces garbage collected per second during a snapshot garbage collection pass.")
+	flag.IntVar(&snapshotGCOptions.PrSnapshotsToKeep, "snapshot-gc-pr-snapshots-to-keep", snapshotgc.DefaultPrSnapshotsToKeep,
+		"Number of PR snapshots to keep after garbage collection.")
+	flag.IntVar(&snapshotGCOptions.NonPrSnapshotsToKeep, "snapshot-gc-non-pr-snapshots-to-keep", snapshotgc.DefaultNonPrSnapshotsToKeep,
+		"Number of non-PR snapshots to keep after garbage collection.")
+	flag.IntVar(&snapshotGCOptions.MinSnapshotsToKeepPerComponent, "snapshot-gc-min-snapshots-to-keep-per-component",
+		snapshotgc.DefaultMinSnapshotsToKeepPerComponent, "Number of push snapshots to keep per component.")
+	flag.BoolVar(&snapshotGCOptions.DryRun, "snapshot-gc-dry-run", false,
+		"If set, the snapshots which would be garbage collected are only reported, without deleting them.")
 
 	opts := zap.Options{
 		Development: false,
@@ -163,6 +187,11 @@ func main() {
 		LeaseDuration:          &leaseDuration,
 		RetryPeriod:            &leaderElectorRetryPeriod,
 		LeaderElectionID:       "03c7e15b.redhat.com",
+		// the configuration ConfigMaps of the tenant namespaces are read from the API server when needed,
+		// instead of caching every ConfigMap of the cluster
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -186,6 +215,18 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Snapshot")
 		os.Exit(1)
 	}
+	if gitEventsAddr != "0" {
+		if err = mgr.Add(gitevents.NewServer(gitEventsAddr, ctrl.Log.WithName("git-events"), mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to set up the git events server")
+			os.Exit(1)
+		}
+	}
+	if enableSnapshotGC {
+		if err = mgr.Add(snapshotgc.NewCollector(mgr.GetClient(), ctrl.Log.WithName("snapshot-gc"), snapshotGCOptions)); err != nil {
+			setupLog.Error(err, "unable to set up snapshot garbage collection")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
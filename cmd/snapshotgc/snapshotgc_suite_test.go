@@ -1,13 +0,0 @@
-package main
-
-import (
-	"testing"
-
-	. "github.com/onsi/ginkgo/v2"
-	. "github.com/onsi/gomega"
-)
-
-func TestSnapshotgc(t *testing.T) {
-	RegisterFailHandler(Fail)
-	RunSpecs(t, "Snapshotgc Test Suite")
-}
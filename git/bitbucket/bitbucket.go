@@ -0,0 +1,266 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/integration-service/pkg/common"
+)
+
+const (
+	// BuildStateSuccessful is the state of a successful Bitbucket build status.
+	BuildStateSuccessful = "SUCCESSFUL"
+	// BuildStateFailed is the state of a failed Bitbucket build status.
+	BuildStateFailed = "FAILED"
+	// BuildStateInProgress is the state of a running Bitbucket build status.
+	BuildStateInProgress = "INPROGRESS"
+
+	// PullRequestStateOpen is the state of an open Bitbucket pull request.
+	PullRequestStateOpen = "OPEN"
+
+	// commentedActivityAction is the action of the pull request activities which hold a comment.
+	commentedActivityAction = "COMMENTED"
+
+	// requestTimeout is the timeout of a single request sent to Bitbucket.
+	requestTimeout = 10 * time.Second
+)
+
+// BuildStatus is a build status attached to a commit in Bitbucket.
+type BuildStatus struct {
+	State       string `json:"state"`
+	Key         string `json:"key"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PullRequest is a Bitbucket pull request.
+type PullRequest struct {
+	ID      int    `json:"id"`
+	Version int    `json:"version"`
+	State   string `json:"state"`
+}
+
+// Comment is a comment on a Bitbucket pull request.
+type Comment struct {
+	ID      int64  `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+// pullRequestActivity is an entry of the activity stream of a Bitbucket pull request.
+type pullRequestActivity struct {
+	Action  string   `json:"action"`
+	Comment *Comment `json:"comment,omitempty"`
+}
+
+// page is a page of results returned by the paged Bitbucket APIs.
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// Client is a minimal client for the Bitbucket Server and Data Center REST API.
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// ClientOption is used to extend Client with optional parameters.
+type ClientOption = func(c *Client)
+
+// WithHTTPClient is an option which allows for replacement of the underlying HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient constructs a new Client for the Bitbucket instance served at baseURL, authenticated by the given HTTP access token.
+// The baseURL must include the context path of the Bitbucket instance if there is any.
+func NewClient(baseURL, token string, opts ...ClientOption) (*Client, error) {
+	parsedURL, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bitbucket base URL %s: %w", baseURL, err)
+	}
+	if parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("bitbucket base URL %s must be an absolute URL", baseURL)
+	}
+
+	client := &Client{
+		baseURL:    parsedURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
+
+// GetBuildStatuses returns all the build statuses attached to the given commit.
+func (c *Client) GetBuildStatuses(ctx context.Context, sha string) ([]BuildStatus, int, error) {
+	var buildStatuses []BuildStatus
+	statusCode, err := getAllPages(ctx, c, fmt.Sprintf("/rest/build-status/1.0/commits/%s", url.PathEscape(sha)),
+		func(values []BuildStatus) {
+			buildStatuses = append(buildStatuses, values...)
+		})
+	return buildStatuses, statusCode, err
+}
+
+// SetBuildStatus creates or updates the build status with the same key on the given commit.
+func (c *Client) SetBuildStatus(ctx context.Context, sha string, buildStatus BuildStatus) (int, error) {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/rest/build-status/1.0/commits/%s", url.PathEscape(sha)), nil, buildStatus, nil)
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, projectKey, repoSlug string, pullRequestID int) (*PullRequest, int, error) {
+	pullRequest := &PullRequest{}
+	statusCode, err := c.do(ctx, http.MethodGet, pullRequestPath(projectKey, repoSlug, pullRequestID), nil, nil, pullRequest)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return pullRequest, statusCode, nil
+}
+
+// ListPullRequestComments returns the top level comments of the given pull request.
+func (c *Client) ListPullRequestComments(ctx context.Context, projectKey, repoSlug string, pullRequestID int) ([]Comment, int, error) {
+	var comments []Comment
+	statusCode, err := getAllPages(ctx, c, pullRequestPath(projectKey, repoSlug, pullRequestID)+"/activities",
+		func(values []pullRequestActivity) {
+			for _, activity := range values {
+				if activity.Action == commentedActivityAction && activity.Comment != nil {
+					comments = append(comments, *activity.Comment)
+				}
+			}
+		})
+	return comments, statusCode, err
+}
+
+// CreatePullRequestComment adds a new comment with the given text to the pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, projectKey, repoSlug string, pullRequestID int, text string) (*Comment, int, error) {
+	comment := &Comment{}
+	statusCode, err := c.do(ctx, http.MethodPost, pullRequestPath(projectKey, repoSlug, pullRequestID)+"/comments",
+		nil, Comment{Text: text}, comment)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return comment, statusCode, nil
+}
+
+// UpdatePullRequestComment replaces the text of an existing comment, the version has to match the current version of the comment.
+func (c *Client) UpdatePullRequestComment(ctx context.Context, projectKey, repoSlug string, pullRequestID int, comment Comment) (*Comment, int, error) {
+	updatedComment := &Comment{}
+	statusCode, err := c.do(ctx, http.MethodPut, fmt.Sprintf("%s/comments/%d", pullRequestPath(projectKey, repoSlug, pullRequestID), comment.ID),
+		nil, Comment{Text: comment.Text, Version: comment.Version}, updatedComment)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return updatedComment, statusCode, nil
+}
+
+// DeletePullRequestComment deletes an existing comment, the version has to match the current version of the comment.
+func (c *Client) DeletePullRequestComment(ctx context.Context, projectKey, repoSlug string, pullRequestID int, comment Comment) (int, error) {
+	query := url.Values{"version": []string{strconv.Itoa(comment.Version)}}
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/comments/%d", pullRequestPath(projectKey, repoSlug, pullRequestID), comment.ID),
+		query, nil, nil)
+}
+
+// pullRequestPath returns the REST API path of the given pull request.
+func pullRequestPath(projectKey, repoSlug string, pullRequestID int) string {
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d",
+		url.PathEscape(projectKey), url.PathEscape(repoSlug), pullRequestID)
+}
+
+// getAllPages requests all the pages of a paged Bitbucket API and passes their values to the collect function.
+func getAllPages[T any](ctx context.Context, c *Client, path string, collect func(values []T)) (int, error) {
+	start := 0
+	for {
+		result := page[T]{}
+		statusCode, err := c.do(ctx, http.MethodGet, path, url.Values{"start": []string{strconv.Itoa(start)}}, nil, &result)
+		if err != nil {
+			return statusCode, err
+		}
+		collect(result.Values)
+		if result.IsLastPage || result.NextPageStart <= start {
+			return statusCode, nil
+		}
+		start = result.NextPageStart
+	}
+}
+
+// do sends a request to the Bitbucket REST API and decodes the response into the out parameter when provided.
+// It returns the HTTP status code of the response, or 0 if no response was received.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) (int, error) {
+	requestURL := c.baseURL.JoinPath(path)
+	requestURL.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal bitbucket request body: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bitbucket request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", common.IntegrationServiceUserAgent)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("bitbucket request %s %s failed: %w", method, requestURL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("bitbucket request %s %s failed with status %d: %s",
+			method, requestURL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+			return resp.StatusCode, fmt.Errorf("failed to decode bitbucket response of %s %s: %w", method, requestURL.Path, err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
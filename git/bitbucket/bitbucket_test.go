@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/konflux-ci/integration-service/git/bitbucket"
+	"github.com/konflux-ci/integration-service/pkg/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bitbucket client", func() {
+	const (
+		contextPath = "/bitbucket"
+		token       = "example-token"
+		sha         = "6c65b2fcaea3e1a0a92476c8b5dc89e92a85f025"
+		prPath      = contextPath + "/rest/api/1.0/projects/PROJ/repos/my-repo/pull-requests/7"
+	)
+
+	var (
+		mux    *http.ServeMux
+		server *httptest.Server
+		client *bitbucket.Client
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mux = http.NewServeMux()
+		server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token || r.Header.Get("User-Agent") != common.IntegrationServiceUserAgent {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			mux.ServeHTTP(rw, r)
+		}))
+
+		var err error
+		client, err = bitbucket.NewClient(server.URL+contextPath+"/", token)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("rejects relative base URLs", func() {
+		_, err := bitbucket.NewClient("bitbucket.example.com", token)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("sets a build status on the commit", func() {
+		mux.HandleFunc(contextPath+"/rest/build-status/1.0/commits/"+sha, func(rw http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			buildStatus := bitbucket.BuildStatus{}
+			Expect(json.NewDecoder(r.Body).Decode(&buildStatus)).To(Succeed())
+			Expect(buildStatus).To(Equal(bitbucket.BuildStatus{
+				State:       bitbucket.BuildStateSuccessful,
+				Key:         "application/scenario",
+				Name:        "application/scenario",
+				URL:         "https://console.example.com",
+				Description: "passed",
+			}))
+			rw.WriteHeader(http.StatusNoContent)
+		})
+
+		statusCode, err := client.SetBuildStatus(ctx, sha, bitbucket.BuildStatus{
+			State:       bitbucket.BuildStateSuccessful,
+			Key:         "application/scenario",
+			Name:        "application/scenario",
+			URL:         "https://console.example.com",
+			Description: "passed",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusNoContent))
+	})
+
+	It("gets the build statuses of the commit from all pages", func() {
+		mux.HandleFunc(contextPath+"/rest/build-status/1.0/commits/"+sha, func(rw http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodGet))
+			if r.URL.Query().Get("start") == "0" {
+				fmt.Fprint(rw, `{"values":[{"state":"INPROGRESS","key":"first"}],"isLastPage":false,"nextPageStart":1}`)
+				return
+			}
+			Expect(r.URL.Query().Get("start")).To(Equal("1"))
+			fmt.Fprint(rw, `{"values":[{"state":"FAILED","key":"second"}],"isLastPage":true}`)
+		})
+
+		buildStatuses, statusCode, err := client.GetBuildStatuses(ctx, sha)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusOK))
+		Expect(buildStatuses).To(HaveLen(2))
+		Expect(buildStatuses[0].Key).To(Equal("first"))
+		Expect(buildStatuses[1].State).To(Equal(bitbucket.BuildStateFailed))
+	})
+
+	It("gets the pull request", func() {
+		mux.HandleFunc(prPath, func(rw http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(rw, `{"id":7,"version":2,"state":"OPEN"}`)
+		})
+
+		pullRequest, statusCode, err := client.GetPullRequest(ctx, "PROJ", "my-repo", 7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusOK))
+		Expect(pullRequest.State).To(Equal(bitbucket.PullRequestStateOpen))
+	})
+
+	It("returns the status code of failed requests", func() {
+		mux.HandleFunc(prPath, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(rw, `{"errors":[{"message":"Pull request 7 does not exist"}]}`)
+		})
+
+		pullRequest, statusCode, err := client.GetPullRequest(ctx, "PROJ", "my-repo", 7)
+		Expect(err).To(MatchError(ContainSubstring("does not exist")))
+		Expect(statusCode).To(Equal(http.StatusNotFound))
+		Expect(pullRequest).To(BeNil())
+
+		unauthorizedClient, err := bitbucket.NewClient(server.URL+contextPath, "wrong-token")
+		Expect(err).NotTo(HaveOccurred())
+		_, statusCode, err = unauthorizedClient.GetPullRequest(ctx, "PROJ", "my-repo", 7)
+		Expect(err).To(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("lists, creates, updates and deletes the pull request comments", func() {
+		mux.HandleFunc(prPath+"/activities", func(rw http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(rw, `{"values":[
+				{"action":"COMMENTED","comment":{"id":1,"version":0,"text":"first"}},
+				{"action":"APPROVED"},
+				{"action":"COMMENTED","comment":{"id":2,"version":3,"text":"second"}}
+			],"isLastPage":true}`)
+		})
+		mux.HandleFunc(prPath+"/comments", func(rw http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			body, _ := io.ReadAll(r.Body)
+			Expect(string(body)).To(ContainSubstring(`"text":"new comment"`))
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"id":3,"version":0,"text":"new comment"}`)
+		})
+		mux.HandleFunc(prPath+"/comments/2", func(rw http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut:
+				comment := bitbucket.Comment{}
+				Expect(json.NewDecoder(r.Body).Decode(&comment)).To(Succeed())
+				Expect(comment.Version).To(Equal(3))
+				fmt.Fprintf(rw, `{"id":2,"version":4,"text":%q}`, comment.Text)
+			case http.MethodDelete:
+				Expect(r.URL.Query().Get("version")).To(Equal("4"))
+				rw.WriteHeader(http.StatusNoContent)
+			default:
+				rw.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		})
+
+		comments, _, err := client.ListPullRequestComments(ctx, "PROJ", "my-repo", 7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(2))
+		Expect(comments[1]).To(Equal(bitbucket.Comment{ID: 2, Version: 3, Text: "second"}))
+
+		created, statusCode, err := client.CreatePullRequestComment(ctx, "PROJ", "my-repo", 7, "new comment")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusCreated))
+		Expect(created.ID).To(Equal(int64(3)))
+
+		updated, statusCode, err := client.UpdatePullRequestComment(ctx, "PROJ", "my-repo", 7,
+			bitbucket.Comment{ID: 2, Version: 3, Text: "updated"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusOK))
+		Expect(updated.Version).To(Equal(4))
+		Expect(updated.Text).To(Equal("updated"))
+
+		statusCode, err = client.DeletePullRequestComment(ctx, "PROJ", "my-repo", 7, *updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusCode).To(Equal(http.StatusNoContent))
+	})
+})
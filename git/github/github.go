@@ -31,6 +31,14 @@ import (
 	"github.com/konflux-ci/integration-service/pkg/common"
 )
 
+const (
+	// MaxAnnotationsPerRequest is the maximum number of annotations GitHub accepts in a single check run request.
+	MaxAnnotationsPerRequest = 50
+
+	// RerunActionIdentifier identifies the requested action which re-runs the integration test of a check run.
+	RerunActionIdentifier = "rerun"
+)
+
 // CheckRunAdapter is an abstraction for the github.CheckRun struct.
 type CheckRunAdapter struct {
 	Owner          string
@@ -45,6 +53,11 @@ type CheckRunAdapter struct {
 	Text           string
 	StartTime      time.Time
 	CompletionTime time.Time
+	Annotations    []*ghapi.CheckRunAnnotation
+	Actions        []*ghapi.CheckRunAction
+	// DeliveredAnnotations is the number of annotations the existing CheckRun already has, GitHub appends the
+	// annotations of every update to them so these aren't sent again when the CheckRun is updated
+	DeliveredAnnotations int
 }
 
 // CommitStatusAdapter is an abstraction for the github.CommiStatus struct.
@@ -91,6 +104,7 @@ type IssuesService interface {
 type RepositoriesService interface {
 	CreateStatus(ctx context.Context, owner string, repo string, ref string, status *ghapi.RepoStatus) (*ghapi.RepoStatus, *ghapi.Response, error)
 	ListStatuses(ctx context.Context, owner, repo, ref string, opts *ghapi.ListOptions) ([]*ghapi.RepoStatus, *ghapi.Response, error)
+	GetPermissionLevel(ctx context.Context, owner, repo, user string) (*ghapi.RepositoryPermissionLevel, *ghapi.Response, error)
 }
 
 // PullRequestsService defines the methods used in the github PullRequests service.
@@ -115,6 +129,7 @@ type ClientInterface interface {
 	GetExistingCommentID(comments []*ghapi.IssueComment, componentName, scenarioName string) *int64
 	EditComment(ctx context.Context, owner string, repo string, commentID int64, body string) (int64, int, error)
 	GetPullRequest(ctx context.Context, owner string, repo string, prID int) (*ghapi.PullRequest, int, error)
+	GetUserPermission(ctx context.Context, owner string, repo string, user string) (string, int, error)
 }
 
 // Client is an abstraction around the API client.
@@ -261,15 +276,17 @@ func (c *Client) CreateCheckRun(ctx context.Context, cra *CheckRunAdapter) (*int
 	status := cra.GetStatus()
 	var statusCode int
 
+	annotations, remainingAnnotations := splitAnnotations(cra.Annotations)
 	options := ghapi.CreateCheckRunOptions{
 		Name:       cra.Name,
 		HeadSHA:    cra.SHA,
 		ExternalID: &cra.ExternalID,
 		Status:     &status,
 		Output: &ghapi.CheckRunOutput{
-			Title:   &cra.Title,
-			Summary: &cra.Summary,
-			Text:    &cra.Text,
+			Title:       &cra.Title,
+			Summary:     &cra.Summary,
+			Text:        &cra.Text,
+			Annotations: annotations,
 		},
 	}
 
@@ -290,6 +307,10 @@ func (c *Client) CreateCheckRun(ctx context.Context, cra *CheckRunAdapter) (*int
 		options.DetailsURL = &cra.DetailsURL
 	}
 
+	if len(cra.Actions) > 0 {
+		options.Actions = cra.Actions
+	}
+
 	cr, response, err := c.GetChecksService().CreateCheckRun(ctx, cra.Owner, cra.Repository, options)
 	if response != nil {
 		statusCode = response.StatusCode
@@ -306,6 +327,13 @@ func (c *Client) CreateCheckRun(ctx context.Context, cra *CheckRunAdapter) (*int
 		"Conclusion", cr.Conclusion,
 	)
 
+	if len(remainingAnnotations) > 0 {
+		statusCode, err = c.addCheckRunAnnotations(ctx, *cr.ID, cra, remainingAnnotations)
+		if err != nil {
+			return cr.ID, statusCode, err
+		}
+	}
+
 	return cr.ID, statusCode, nil
 }
 
@@ -314,13 +342,15 @@ func (c *Client) UpdateCheckRun(ctx context.Context, checkRunID int64, cra *Chec
 	status := cra.GetStatus()
 	var statusCode int
 
+	annotations, remainingAnnotations := splitAnnotations(cra.pendingAnnotations())
 	options := ghapi.UpdateCheckRunOptions{
 		Name:   cra.Name,
 		Status: &status,
 		Output: &ghapi.CheckRunOutput{
-			Title:   &cra.Title,
-			Summary: &cra.Summary,
-			Text:    &cra.Text,
+			Title:       &cra.Title,
+			Summary:     &cra.Summary,
+			Text:        &cra.Text,
+			Annotations: annotations,
 		},
 	}
 
@@ -332,6 +362,10 @@ func (c *Client) UpdateCheckRun(ctx context.Context, checkRunID int64, cra *Chec
 		options.CompletedAt = &ghapi.Timestamp{Time: cra.CompletionTime}
 	}
 
+	if len(cra.Actions) > 0 {
+		options.Actions = cra.Actions
+	}
+
 	cr, response, err := c.GetChecksService().UpdateCheckRun(ctx, cra.Owner, cra.Repository, checkRunID, options)
 	if response != nil {
 		statusCode = response.StatusCode
@@ -347,10 +381,61 @@ func (c *Client) UpdateCheckRun(ctx context.Context, checkRunID int64, cra *Chec
 		"Status", cr.Status,
 		"Conclusion", cr.Conclusion,
 	)
+
+	if len(remainingAnnotations) > 0 {
+		return c.addCheckRunAnnotations(ctx, checkRunID, cra, remainingAnnotations)
+	}
 	return statusCode, nil
 
 }
 
+// addCheckRunAnnotations adds the annotations to an existing CheckRun, GitHub appends the annotations of each update
+// to the ones the CheckRun already has so they are sent in batches of MaxAnnotationsPerRequest.
+func (c *Client) addCheckRunAnnotations(ctx context.Context, checkRunID int64, cra *CheckRunAdapter, annotations []*ghapi.CheckRunAnnotation) (int, error) {
+	var statusCode int
+	added := len(annotations)
+	for len(annotations) > 0 {
+		var batch []*ghapi.CheckRunAnnotation
+		batch, annotations = splitAnnotations(annotations)
+		options := ghapi.UpdateCheckRunOptions{
+			Name: cra.Name,
+			Output: &ghapi.CheckRunOutput{
+				Title:       &cra.Title,
+				Summary:     &cra.Summary,
+				Text:        &cra.Text,
+				Annotations: batch,
+			},
+		}
+
+		_, response, err := c.GetChecksService().UpdateCheckRun(ctx, cra.Owner, cra.Repository, checkRunID, options)
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		if err != nil {
+			return statusCode, fmt.Errorf("failed to add annotations to check run %d for owner/repo %s/%s: %w", checkRunID, cra.Owner, cra.Repository, err)
+		}
+	}
+
+	c.logger.Info("Added annotations to CheckRun", "ID", checkRunID, "CheckName", cra.Name, "Annotations", added)
+	return statusCode, nil
+}
+
+// pendingAnnotations returns the annotations the CheckRun doesn't have yet
+func (cra *CheckRunAdapter) pendingAnnotations() []*ghapi.CheckRunAnnotation {
+	if cra.DeliveredAnnotations >= len(cra.Annotations) {
+		return nil
+	}
+	return cra.Annotations[max(cra.DeliveredAnnotations, 0):]
+}
+
+// splitAnnotations returns the first MaxAnnotationsPerRequest annotations and the remaining ones
+func splitAnnotations(annotations []*ghapi.CheckRunAnnotation) ([]*ghapi.CheckRunAnnotation, []*ghapi.CheckRunAnnotation) {
+	if len(annotations) <= MaxAnnotationsPerRequest {
+		return annotations, nil
+	}
+	return annotations[:MaxAnnotationsPerRequest], annotations[MaxAnnotationsPerRequest:]
+}
+
 // GetCheckRunID returns an existing GitHub CheckRun ID if a match is found for the SHA, externalID and appID.
 func (c *Client) GetCheckRunID(ctx context.Context, owner string, repo string, SHA string, externalID string, appID int64) (*int64, int, error) {
 	filter := "all"
@@ -584,3 +669,17 @@ func (c *Client) GetPullRequest(ctx context.Context, owner string, repo string,
 
 	return pr, statusCode, err
 }
+
+// GetUserPermission returns the permission of the user on the repository, one of admin, write, read and none
+func (c *Client) GetUserPermission(ctx context.Context, owner string, repo string, user string) (string, int, error) {
+	var statusCode int
+	permissionLevel, response, err := c.GetRepositoriesService().GetPermissionLevel(ctx, owner, repo, user)
+	if response != nil {
+		statusCode = response.StatusCode
+	}
+	if err != nil {
+		return "", statusCode, fmt.Errorf("failed to get the permission of user %s on GitHub owner/repo %s/%s: %w", user, owner, repo, err)
+	}
+
+	return permissionLevel.GetPermission(), statusCode, nil
+}
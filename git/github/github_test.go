@@ -100,6 +100,29 @@ func (MockChecksService) UpdateCheckRun(
 	return &ghapi.CheckRun{ID: &id}, nil, nil
 }
 
+// AnnotationsRecordingChecksService records the number of annotations sent by each check run request
+type AnnotationsRecordingChecksService struct {
+	MockChecksService
+	createdAnnotations []int
+	updatedAnnotations []int
+}
+
+// CreateCheckRun implements github.ChecksService
+func (s *AnnotationsRecordingChecksService) CreateCheckRun(
+	ctx context.Context, owner string, repo string, opts ghapi.CreateCheckRunOptions,
+) (*ghapi.CheckRun, *ghapi.Response, error) {
+	s.createdAnnotations = append(s.createdAnnotations, len(opts.Output.Annotations))
+	return s.MockChecksService.CreateCheckRun(ctx, owner, repo, opts)
+}
+
+// UpdateCheckRun implements github.ChecksService
+func (s *AnnotationsRecordingChecksService) UpdateCheckRun(
+	ctx context.Context, owner string, repo string, checkRunID int64, opts ghapi.UpdateCheckRunOptions,
+) (*ghapi.CheckRun, *ghapi.Response, error) {
+	s.updatedAnnotations = append(s.updatedAnnotations, len(opts.Output.Annotations))
+	return s.MockChecksService.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+}
+
 type MockIssuesService struct{}
 
 // CreateComment implements github.IssuesService
@@ -149,6 +172,14 @@ func (MockRepositoriesService) ListStatuses(
 	return []*ghapi.RepoStatus{repoStatus}, nil, nil
 }
 
+// GetPermissionLevel implements github.RepositoriesService
+func (MockRepositoriesService) GetPermissionLevel(
+	ctx context.Context, owner string, repo string, user string,
+) (*ghapi.RepositoryPermissionLevel, *ghapi.Response, error) {
+	var permission = "write"
+	return &ghapi.RepositoryPermissionLevel{Permission: &permission}, nil, nil
+}
+
 type MockPullRequestsService struct {
 	GetPullRequestResult *ghapi.PullRequest
 }
@@ -287,6 +318,64 @@ var _ = Describe("Client", func() {
 		Expect(statusCode).NotTo(BeNil())
 	})
 
+	It("sends the check run annotations in batches", func() {
+		annotations := make([]*ghapi.CheckRunAnnotation, 2*github.MaxAnnotationsPerRequest+10)
+		for i := range annotations {
+			annotations[i] = &ghapi.CheckRunAnnotation{
+				Path:            ghapi.String("main.go"),
+				StartLine:       ghapi.Int(i + 1),
+				EndLine:         ghapi.Int(i + 1),
+				AnnotationLevel: ghapi.String("warning"),
+				Message:         ghapi.String("example-message"),
+			}
+		}
+		annotatedCheckRunAdapter := *checkRunAdapter
+		annotatedCheckRunAdapter.Annotations = annotations
+
+		checksSvc := &AnnotationsRecordingChecksService{}
+		client = github.NewClient(logr.Discard(), github.WithChecksService(checksSvc))
+
+		checkRunID, _, err := client.CreateCheckRun(context.TODO(), &annotatedCheckRunAdapter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*checkRunID).To(Equal(int64(10)))
+		Expect(checksSvc.createdAnnotations).To(Equal([]int{github.MaxAnnotationsPerRequest}))
+		Expect(checksSvc.updatedAnnotations).To(Equal([]int{github.MaxAnnotationsPerRequest, 10}))
+
+		checksSvc.updatedAnnotations = nil
+		_, err = client.UpdateCheckRun(context.TODO(), 1, &annotatedCheckRunAdapter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksSvc.updatedAnnotations).To(Equal([]int{github.MaxAnnotationsPerRequest, github.MaxAnnotationsPerRequest, 10}))
+	})
+
+	It("doesn't send the annotations the check run already has again", func() {
+		annotations := make([]*ghapi.CheckRunAnnotation, github.MaxAnnotationsPerRequest+10)
+		for i := range annotations {
+			annotations[i] = &ghapi.CheckRunAnnotation{
+				Path:            ghapi.String("main.go"),
+				StartLine:       ghapi.Int(i + 1),
+				EndLine:         ghapi.Int(i + 1),
+				AnnotationLevel: ghapi.String("warning"),
+				Message:         ghapi.String("example-message"),
+			}
+		}
+		annotatedCheckRunAdapter := *checkRunAdapter
+		annotatedCheckRunAdapter.Annotations = annotations
+		annotatedCheckRunAdapter.DeliveredAnnotations = github.MaxAnnotationsPerRequest
+
+		checksSvc := &AnnotationsRecordingChecksService{}
+		client = github.NewClient(logr.Discard(), github.WithChecksService(checksSvc))
+
+		_, err := client.UpdateCheckRun(context.TODO(), 1, &annotatedCheckRunAdapter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksSvc.updatedAnnotations).To(Equal([]int{10}))
+
+		checksSvc.updatedAnnotations = nil
+		annotatedCheckRunAdapter.DeliveredAnnotations = len(annotations)
+		_, err = client.UpdateCheckRun(context.TODO(), 1, &annotatedCheckRunAdapter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksSvc.updatedAnnotations).To(Equal([]int{0}))
+	})
+
 	It("can get a check run ID", func() {
 		checkRunID, statusCode, err := client.GetCheckRunID(context.TODO(), "", "", "", "example-external-id", 1)
 		Expect(err).ToNot(HaveOccurred())
@@ -372,4 +461,10 @@ var _ = Describe("Client", func() {
 		Expect(*pullRequest.State).To(Equal("opened"))
 		Expect(statusCode).NotTo(BeNil())
 	})
+
+	It("can get the permission of a user on a repository", func() {
+		permission, _, err := client.GetUserPermission(context.TODO(), "", "", "user")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(permission).To(Equal("write"))
+	})
 })
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SnapshotNudgesAnnotation contains the JSON encoded list of components nudged by the Snapshot
+	SnapshotNudgesAnnotation = "test.appstudio.openshift.io/nudges"
+
+	// ComponentBuildRequestAnnotation is the annotation used to request an action from the build-service for a Component
+	ComponentBuildRequestAnnotation = "build.appstudio.openshift.io/request"
+
+	// ComponentBuildRequestTriggerPaCBuild is the build request which triggers a new on-push build of the Component
+	ComponentBuildRequestTriggerPaCBuild = "trigger-pac-build"
+
+	// ComponentNudgedByAnnotation contains the name of the Snapshot which nudged the Component last
+	ComponentNudgedByAnnotation = "test.appstudio.openshift.io/nudged-by"
+
+	// ComponentNudgedByImageAnnotation contains the image of the source component which nudged the Component last
+	ComponentNudgedByImageAnnotation = "test.appstudio.openshift.io/nudged-by-image"
+)
+
+// NudgeRecord describes a single nudge of a target component triggered by a Snapshot
+type NudgeRecord struct {
+	// Component is the name of the nudged component
+	Component string `json:"component"`
+	// Mode is the nudge mode which triggered the nudge
+	Mode v1beta2.NudgeModeType `json:"mode"`
+	// GatingGroup is the gating group whose nudges were triggered, if any
+	GatingGroup string `json:"gatingGroup,omitempty"`
+	// Image is the image of the source component propagated to the nudged component
+	Image string `json:"image,omitempty"`
+	// NudgeTime is the time when the component was nudged
+	NudgeTime metav1.Time `json:"nudgeTime"`
+}
+
+// GetSnapshotNudgeRecords returns the nudges recorded in the annotation of the given Snapshot
+func GetSnapshotNudgeRecords(snapshot *applicationapiv1alpha1.Snapshot) ([]NudgeRecord, error) {
+	records := []NudgeRecord{}
+	value, ok := snapshot.GetAnnotations()[SnapshotNudgesAnnotation]
+	if !ok || value == "" {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal nudges annotation of snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+	return records, nil
+}
+
+// HasSnapshotNudgedComponent returns true if the given Snapshot already recorded a nudge of the component
+func HasSnapshotNudgedComponent(records []NudgeRecord, componentName string) bool {
+	return slices.ContainsFunc(records, func(record NudgeRecord) bool {
+		return record.Component == componentName
+	})
+}
+
+// AddSnapshotNudgeRecords appends the given nudges to the annotation of the Snapshot and patches it
+func AddSnapshotNudgeRecords(ctx context.Context, cl client.Client, snapshot *applicationapiv1alpha1.Snapshot, newRecords []NudgeRecord) error {
+	records, err := GetSnapshotNudgeRecords(snapshot)
+	if err != nil {
+		return err
+	}
+	records = append(records, newRecords...)
+
+	value, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal nudge records: %w", err)
+	}
+	return AnnotateSnapshot(ctx, snapshot, SnapshotNudgesAnnotation, string(value), cl)
+}
+
+// NudgeComponent requests a new on-push build of the given Component from the build-service and records the name of the
+// Snapshot which triggered the nudge along with the already built image of the source component. The nudged Component is
+// rebuilt rather than given the source image since its own image is built on top of the source one, the build-service
+// doesn't offer a request which updates a Component with an image built elsewhere.
+func NudgeComponent(ctx context.Context, cl client.Client, component *applicationapiv1alpha1.Component, snapshotName, sourceImage string) error {
+	patch := client.MergeFrom(component.DeepCopy())
+
+	_ = metadata.SetAnnotation(&component.ObjectMeta, ComponentBuildRequestAnnotation, ComponentBuildRequestTriggerPaCBuild)
+	_ = metadata.SetAnnotation(&component.ObjectMeta, ComponentNudgedByAnnotation, snapshotName)
+	if sourceImage != "" {
+		_ = metadata.SetAnnotation(&component.ObjectMeta, ComponentNudgedByImageAnnotation, sourceImage)
+	}
+
+	return cl.Patch(ctx, component, patch)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+)
+
+// EvaluateIntegrationTestScenarioWhenExpression evaluates the provided IntegrationTestScenario when CEL expression
+// against the given Snapshot and returns whether the IntegrationTestScenario applies to it.
+// Besides the 'snapshot' object itself, the expression can use the following variables:
+// component, eventType, targetBranch, sourceBranch, author, labels and annotations,
+// as well as the updatedComponentIs(name) function.
+func EvaluateIntegrationTestScenarioWhenExpression(whenExpr string, snapshot *applicationapiv1alpha1.Snapshot) (bool, error) {
+	// Empty expression: the scenario applies to every snapshot
+	if len(whenExpr) == 0 {
+		return true, nil
+	}
+
+	objMap, err := convertToCELObjectMap(snapshot)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert snapshot: %w", err)
+	}
+
+	funcs := snapshotCELFunctions{snapshot: objMap}
+	env, err := newScenarioWhenCELEnv(funcs)
+	if err != nil {
+		return false, err
+	}
+
+	ast, iss := env.Compile(whenExpr)
+	if iss != nil && iss.Err() != nil {
+		return false, fmt.Errorf("invalid cel expression: %w", iss.Err())
+	}
+
+	prog, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("failed to create cel program: %w", err)
+	}
+
+	out, _, err := prog.ContextEval(context.Background(), getScenarioWhenCELActivation(objMap, snapshot))
+	if err != nil {
+		return false, err
+	}
+
+	// Expect a boolean result
+	if b, ok := out.Value().(bool); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("cel expression did not evaluate to a boolean")
+}
+
+// ValidateIntegrationTestScenarioWhenExpression compiles the provided IntegrationTestScenario when CEL expression
+// and returns an error if it isn't valid or doesn't evaluate to a boolean.
+func ValidateIntegrationTestScenarioWhenExpression(whenExpr string) error {
+	if len(whenExpr) == 0 {
+		return nil
+	}
+
+	env, err := newScenarioWhenCELEnv(snapshotCELFunctions{})
+	if err != nil {
+		return err
+	}
+
+	ast, iss := env.Compile(whenExpr)
+	if iss != nil && iss.Err() != nil {
+		return fmt.Errorf("invalid cel expression: %w", iss.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return fmt.Errorf("cel expression must evaluate to a boolean, got %s", ast.OutputType())
+	}
+	return nil
+}
+
+// newScenarioWhenCELEnv builds the CEL environment used for the IntegrationTestScenario when expressions
+func newScenarioWhenCELEnv(funcs snapshotCELFunctions) (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.DefaultUTCTimeZone(true),
+		cel.Variable("snapshot", cel.DynType),
+		cel.Variable("component", cel.StringType),
+		cel.Variable("eventType", cel.StringType),
+		cel.Variable("targetBranch", cel.StringType),
+		cel.Variable("sourceBranch", cel.StringType),
+		cel.Variable("author", cel.StringType),
+		cel.Variable("labels", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("annotations", cel.MapType(cel.StringType, cel.StringType)),
+		// Register custom function: updatedComponentIs(name: string) -> bool
+		cel.Function("updatedComponentIs",
+			cel.Overload("updatedComponentIs_string_bool",
+				[]*cel.Type{cel.StringType},
+				cel.BoolType,
+				cel.UnaryBinding(funcs.updatedComponentIs),
+			),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL env: %w", err)
+	}
+	return env, nil
+}
+
+// getScenarioWhenCELActivation returns the variables exposed to the IntegrationTestScenario when expressions.
+// The Pipelines as Code metadata is looked up in both the Snapshot labels and annotations
+// since it isn't consistently stored in only one of them.
+func getScenarioWhenCELActivation(objMap map[string]any, snapshot *applicationapiv1alpha1.Snapshot) map[string]any {
+	labels := snapshot.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := snapshot.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	getMetadataValue := func(key string) string {
+		if value, ok := labels[key]; ok {
+			return value
+		}
+		return annotations[key]
+	}
+
+	return map[string]any{
+		"snapshot":     objMap,
+		"component":    labels[SnapshotComponentLabel],
+		"eventType":    getMetadataValue(PipelineAsCodeEventTypeLabel),
+		"targetBranch": getMetadataValue(PipelineAsCodeTargetBranchAnnotation),
+		"sourceBranch": getMetadataValue(PipelineAsCodeSourceBranchAnnotation),
+		"author":       getMetadataValue(PipelineAsCodeSenderLabel),
+		"labels":       labels,
+		"annotations":  annotations,
+	}
+}
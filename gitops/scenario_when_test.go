@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops_test
+
+import (
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("IntegrationTestScenario when expression evaluation", func() {
+	var snapshot *applicationapiv1alpha1.Snapshot
+
+	BeforeEach(func() {
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "when-snapshot",
+				Namespace: "default",
+				Labels: map[string]string{
+					gitops.SnapshotTypeLabel:                   gitops.SnapshotComponentType,
+					gitops.SnapshotComponentLabel:              "component-x",
+					gitops.PipelineAsCodeEventTypeLabel:        gitops.PipelineAsCodePullRequestType,
+					gitops.PipelineAsCodeSenderLabel:           "octocat",
+					gitops.PipelineAsCodePullRequestAnnotation: "1",
+				},
+				Annotations: map[string]string{
+					gitops.PipelineAsCodeTargetBranchAnnotation: "release-1.0",
+					gitops.PipelineAsCodeSourceBranchAnnotation: "feature",
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{Name: "component-x", ContainerImage: "quay.io/example/component-x@sha256:abc"},
+				},
+			},
+		}
+	})
+
+	DescribeTable("evaluates the expression against the Snapshot",
+		func(expr string, expected bool) {
+			result, err := gitops.EvaluateIntegrationTestScenarioWhenExpression(expr, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("empty expression", "", true),
+		Entry("changed component and target branch", `component == "component-x" && targetBranch.startsWith("release-")`, true),
+		Entry("different component", `component == "component-y"`, false),
+		Entry("event type", `eventType == "pull_request"`, true),
+		Entry("source branch", `sourceBranch == "main"`, false),
+		Entry("author", `author in ["octocat", "hubot"]`, true),
+		Entry("labels", `labels["test.appstudio.openshift.io/type"] == "component"`, true),
+		Entry("annotations", `"pac.test.appstudio.openshift.io/pull-request" in annotations`, false),
+		Entry("updatedComponentIs function", `updatedComponentIs("component-x")`, true),
+		Entry("snapshot object", `snapshot.spec.application == "application-sample"`, true),
+	)
+
+	It("returns an error for expressions which can't be evaluated", func() {
+		_, err := gitops.EvaluateIntegrationTestScenarioWhenExpression(`component ==`, snapshot)
+		Expect(err).To(HaveOccurred())
+
+		_, err = gitops.EvaluateIntegrationTestScenarioWhenExpression(`component`, snapshot)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("validates the expression without a Snapshot", func() {
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression("")).To(Succeed())
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression(`targetBranch.matches("^release-.*")`)).To(Succeed())
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression(`snapshot.spec.application == "app"`)).To(Succeed())
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression(`component ==`)).NotTo(Succeed())
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression(`component`)).NotTo(Succeed())
+		Expect(gitops.ValidateIntegrationTestScenarioWhenExpression(`unknownVariable == "x"`)).NotTo(Succeed())
+	})
+
+	It("filters the IntegrationTestScenarios using both the contexts and the when expression", func() {
+		scenario := v1beta2.IntegrationTestScenario{
+			ObjectMeta: metav1.ObjectMeta{Name: "scenario", Namespace: "default"},
+		}
+		matchingScenario := scenario.DeepCopy()
+		matchingScenario.Name = "matching"
+		matchingScenario.Spec.When = `targetBranch.startsWith("release-")`
+		notMatchingScenario := scenario.DeepCopy()
+		notMatchingScenario.Name = "not-matching"
+		notMatchingScenario.Spec.When = `targetBranch == "main"`
+		pushOnlyScenario := matchingScenario.DeepCopy()
+		pushOnlyScenario.Name = "push-only"
+		pushOnlyScenario.Spec.Contexts = []v1beta2.TestContext{{Name: "push"}}
+		brokenScenario := scenario.DeepCopy()
+		brokenScenario.Name = "broken"
+		brokenScenario.Spec.When = `labels["missing"] == "value"`
+
+		allScenarios := []v1beta2.IntegrationTestScenario{scenario, *matchingScenario, *notMatchingScenario, *pushOnlyScenario, *brokenScenario}
+		filteredScenarios := gitops.FilterIntegrationTestScenariosWithContext(&allScenarios, snapshot)
+
+		var names []string
+		for _, s := range *filteredScenarios {
+			names = append(names, s.Name)
+		}
+		// Expressions failing at runtime don't skip the scenario
+		Expect(names).To(ConsistOf("scenario", "matching", "broken"))
+	})
+})
@@ -31,6 +31,7 @@ import (
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/helpers"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
 	"github.com/konflux-ci/integration-service/pkg/metrics"
 	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
 	"github.com/konflux-ci/operator-toolkit/metadata"
@@ -67,6 +68,15 @@ const (
 	// SnapshotIntegrationTestRun contains name of test we want to trigger run
 	SnapshotIntegrationTestRun = "test.appstudio.openshift.io/run"
 
+	// SnapshotIntegrationTestRunFailed is the value of the run label which re-runs all the failed tests of the Snapshot
+	SnapshotIntegrationTestRunFailed = "failed"
+
+	// SnapshotTestOverrideRequestsAnnotation contains the pending requests to mark the tests of scenarios as passed or skipped
+	SnapshotTestOverrideRequestsAnnotation = "test.appstudio.openshift.io/override-requests"
+
+	// SnapshotTestOverridesAnnotation contains the audit trail of the test override requests processed for the Snapshot
+	SnapshotTestOverridesAnnotation = "test.appstudio.openshift.io/overrides"
+
 	// AppstudioLabelPrefix contains application, component, build-pipelinerun etc.
 	AppstudioLabelPrefix = "appstudio.openshift.io"
 
@@ -94,6 +104,10 @@ const (
 	// SnapshotStatusReportAnnotation contains metadata of tests related to status reporting to git provider
 	SnapshotStatusReportAnnotation = "test.appstudio.openshift.io/git-reporter-status"
 
+	// SnapshotPendingReportsAnnotation contains the integration test reports which couldn't be delivered to the git provider
+	// or additional reporters yet, they are retried with backoff until they are delivered or expire
+	SnapshotPendingReportsAnnotation = "test.appstudio.openshift.io/pending-reports"
+
 	// PRGroupAnnotation contains the pr group name
 	PRGroupAnnotation = "test.appstudio.openshift.io/pr-group"
 
@@ -103,9 +117,16 @@ const (
 	// PRGroupCreationAnnotation contains the info of groupsnapshot creation
 	PRGroupCreationAnnotation = "test.appstudio.openshift.io/create-groupsnapshot-status"
 
+	// DependentSnapshotsCreationAnnotation contains the info of the creation of Snapshots for dependent ComponentGroups
+	DependentSnapshotsCreationAnnotation = "test.appstudio.openshift.io/create-dependent-snapshots-status"
+
 	// GitReportingFailureAnnotation contains information about git reporting failures
 	GitReportingFailureAnnotation = "test.appstudio.openshift.io/git-reporting-failure"
 
+	// SnapshotReportersAnnotation contains the comma separated list of additional reporters the integration test statuses
+	// of the Snapshot are reported to, besides the git provider
+	SnapshotReportersAnnotation = "test.appstudio.openshift.io/reporters"
+
 	// BuildPipelineRunResultAnnotationPrefix is the prefix for annotations derived from build PipelineRun results
 	BuildPipelineRunResultAnnotationPrefix = TestLabelPrefix + "/result-"
 
@@ -193,6 +214,9 @@ const (
 	// PipelineAsCodeSHAAnnotation is the commit which triggered the pipelinerun in build service.
 	PipelineAsCodeSHAAnnotation = PipelinesAsCodePrefix + "/sha"
 
+	// PipelineAsCodeSenderLabel is the git provider user who triggered the pipelinerun in build service.
+	PipelineAsCodeSenderLabel = PipelinesAsCodePrefix + "/sender"
+
 	// PipelineAsCodePushType is the type of push event which triggered the pipelinerun in build service
 	PipelineAsCodePushType = "push"
 
@@ -230,6 +254,12 @@ const (
 	// TODO: Remove this once PaC adds full Forgejo support expected March 2026
 	PipelineAsCodeGiteaProviderType = "gitea"
 
+	// PipelineAsCodeBitbucketDataCenterProviderType is the git provider type for a Bitbucket Data Center event which triggered the pipelinerun in build service.
+	PipelineAsCodeBitbucketDataCenterProviderType = "bitbucket-datacenter"
+
+	// PipelineAsCodeBitbucketServerProviderType is the git provider type used by older PaC versions for a Bitbucket Server event.
+	PipelineAsCodeBitbucketServerProviderType = "bitbucket-server"
+
 	// PipelineAsCodeGitHubMergeQueueBranchPrefix is the prefix added to temporary branches which are created for merge queues
 	PipelineAsCodeGitHubMergeQueueBranchPrefix = "gh-readonly-queue/"
 
@@ -303,6 +333,9 @@ const (
 	// IntegrationTestStatusNeutralGithub is the status reported to github when integration test is neutral
 	IntegrationTestStatusNeutralGithub = "neutral"
 
+	// IntegrationTestStatusSkippedGithub is the status reported to github when integration test is skipped
+	IntegrationTestStatusSkippedGithub = "skipped"
+
 	// ComponentNameForGroupSnapshot is the component name used for group snapshots
 	ComponentNameForGroupSnapshot = "pr group"
 
@@ -328,6 +361,8 @@ const (
 	GitCommentPolicyAnnotation = "test.appstudio.openshift.io/comment_strategy"
 	// GitCommentPolicyAllDisabled is the value to disable all test comments for the component got pac repository
 	GitCommentPolicyAllDisabled = "disable_all"
+	// GitCommentPolicySummary is the value to report the integration test statuses of a pull request in a single summary comment
+	GitCommentPolicySummary = "summary"
 )
 
 var (
@@ -939,6 +974,86 @@ func HasSnapshotRerunLabelChanged(objectOld, objectNew client.Object) bool {
 	return false
 }
 
+// HasSnapshotTestFinishedWithPendingScenarios returns a boolean indicating whether an integration test of the Snapshot
+// has just reached a final state while other integration tests are still Pending or Blocked, e.g. waiting for their parent
+// scenarios in the ComponentGroup's TestGraph or the scenarios they depend on. If the objects passed to this function are not Snapshots or their
+// test status annotations can't be parsed, the function will return false.
+func HasSnapshotTestFinishedWithPendingScenarios(objectOld, objectNew client.Object) bool {
+	oldSnapshot, ok := objectOld.(*applicationapiv1alpha1.Snapshot)
+	if !ok {
+		return false
+	}
+	newSnapshot, ok := objectNew.(*applicationapiv1alpha1.Snapshot)
+	if !ok {
+		return false
+	}
+	if oldSnapshot.GetAnnotations()[SnapshotTestsStatusAnnotation] == newSnapshot.GetAnnotations()[SnapshotTestsStatusAnnotation] {
+		return false
+	}
+
+	oldStatuses, err := NewSnapshotIntegrationTestStatusesFromSnapshot(oldSnapshot)
+	if err != nil {
+		return false
+	}
+	newStatuses, err := NewSnapshotIntegrationTestStatusesFromSnapshot(newSnapshot)
+	if err != nil {
+		return false
+	}
+
+	hasNewlyFinishedTest, hasPendingTest := false, false
+	for _, newDetail := range newStatuses.GetStatuses() {
+		if newDetail.Status == intgteststat.IntegrationTestStatusPending || newDetail.Status == intgteststat.IntegrationTestStatusBlocked {
+			hasPendingTest = true
+			continue
+		}
+		if !newDetail.Status.IsFinal() {
+			continue
+		}
+		oldDetail, ok := oldStatuses.GetScenarioStatus(newDetail.ScenarioName)
+		if !ok || !oldDetail.Status.IsFinal() {
+			hasNewlyFinishedTest = true
+		}
+	}
+	return hasNewlyFinishedTest && hasPendingTest
+}
+
+// HasSnapshotTestRetryBeenScheduled returns a boolean indicating whether a new attempt of an integration test
+// was scheduled for the Snapshot by the retry policy of its scenario. If the objects passed to this function
+// are not Snapshots, the function will return false.
+func HasSnapshotTestRetryBeenScheduled(objectOld, objectNew client.Object) bool {
+	oldSnapshot, ok := objectOld.(*applicationapiv1alpha1.Snapshot)
+	if !ok {
+		return false
+	}
+	newSnapshot, ok := objectNew.(*applicationapiv1alpha1.Snapshot)
+	if !ok {
+		return false
+	}
+	if oldSnapshot.GetAnnotations()[SnapshotTestsStatusAnnotation] == newSnapshot.GetAnnotations()[SnapshotTestsStatusAnnotation] {
+		return false
+	}
+
+	oldStatuses, err := NewSnapshotIntegrationTestStatusesFromSnapshot(oldSnapshot)
+	if err != nil {
+		return false
+	}
+	newStatuses, err := NewSnapshotIntegrationTestStatusesFromSnapshot(newSnapshot)
+	if err != nil {
+		return false
+	}
+
+	for _, newDetail := range newStatuses.GetStatuses() {
+		if newDetail.Status != intgteststat.IntegrationTestStatusPending {
+			continue
+		}
+		oldDetail, ok := oldStatuses.GetScenarioStatus(newDetail.ScenarioName)
+		if ok && oldDetail.GetAttempt() < newDetail.GetAttempt() {
+			return true
+		}
+	}
+	return false
+}
+
 // ExtractPullRequestNumberFromMergeQueueSnapshot attempts to extract the pull request number for the Snapshot
 // If the pull request annotation is present, it returns it, otherwise it extracts it from the source branch name
 func ExtractPullRequestNumberFromMergeQueueSnapshot(snapshot *applicationapiv1alpha1.Snapshot) string {
@@ -1332,9 +1447,14 @@ func IsContextValidForSnapshot(scenarioContextName string, snapshot *application
 	return false
 }
 
-// IsScenarioApplicableToSnapshotsContext checks the contexts list for a given IntegrationTestScenario and
-// compares it against the Snapshot to determine if the scenario applies to it
+// IsScenarioApplicableToSnapshotsContext checks the contexts list and the optional when expression for a given
+// IntegrationTestScenario and compares them against the Snapshot to determine if the scenario applies to it
 func IsScenarioApplicableToSnapshotsContext(scenario *v1beta2.IntegrationTestScenario, snapshot *applicationapiv1alpha1.Snapshot) bool {
+	return isScenarioContextValidForSnapshot(scenario, snapshot) && isScenarioWhenExpressionTrueForSnapshot(scenario, snapshot)
+}
+
+// isScenarioContextValidForSnapshot returns true if any of the IntegrationTestScenario contexts is valid for the Snapshot
+func isScenarioContextValidForSnapshot(scenario *v1beta2.IntegrationTestScenario, snapshot *applicationapiv1alpha1.Snapshot) bool {
 	// If the contexts list is empty, we assume that the scenario applies to all contexts by default
 	if len(scenario.Spec.Contexts) == 0 {
 		return true
@@ -1348,6 +1468,20 @@ func IsScenarioApplicableToSnapshotsContext(scenario *v1beta2.IntegrationTestSce
 	return false
 }
 
+// isScenarioWhenExpressionTrueForSnapshot returns true if the IntegrationTestScenario doesn't define a when expression
+// or if it evaluates to true for the Snapshot. Expressions which fail to evaluate are treated as true so that
+// a broken expression never silently skips the tests, invalid expressions are reported by the scenario controller
+func isScenarioWhenExpressionTrueForSnapshot(scenario *v1beta2.IntegrationTestScenario, snapshot *applicationapiv1alpha1.Snapshot) bool {
+	if scenario.Spec.When == "" {
+		return true
+	}
+	applicable, err := EvaluateIntegrationTestScenarioWhenExpression(scenario.Spec.When, snapshot)
+	if err != nil {
+		return true
+	}
+	return applicable
+}
+
 // FilterIntegrationTestScenariosWithContext returns a filtered list of IntegrationTestScenario from the given list
 // of IntegrationTestScenarios compared against the given Snapshot based on individual IntegrationTestScenario contexts
 func FilterIntegrationTestScenariosWithContext(scenarios *[]v1beta2.IntegrationTestScenario, snapshot *applicationapiv1alpha1.Snapshot) *[]v1beta2.IntegrationTestScenario {
@@ -1368,6 +1502,22 @@ func HasPRGroupProcessed(snapshot *applicationapiv1alpha1.Snapshot) bool {
 	return metadata.HasAnnotation(snapshot, PRGroupCreationAnnotation) && !strings.Contains(snapshot.GetAnnotations()[PRGroupCreationAnnotation], "waiting for it to create a new group Snapshot for PR group")
 }
 
+// HasDependentSnapshotsProcessed checks if the Snapshots for the dependent ComponentGroups have been handled for this snapshot
+// by the snapshot adapter, to avoid creating them again when reconciling this snapshot
+func HasDependentSnapshotsProcessed(snapshot *applicationapiv1alpha1.Snapshot) bool {
+	return metadata.HasAnnotation(snapshot, DependentSnapshotsCreationAnnotation)
+}
+
+// GetSnapshotComponentGroupChain returns the names of the ComponentGroups which the chain of dependent Snapshots leading
+// to the given snapshot went through, starting with the ComponentGroup of the origin Snapshot and ending with
+// the ComponentGroup of the given snapshot
+func GetSnapshotComponentGroupChain(snapshot *applicationapiv1alpha1.Snapshot) []string {
+	if chain, ok := snapshot.GetAnnotations()[v1beta2.ComponentGroupChainAnnotation]; ok && chain != "" {
+		return strings.Split(chain, ",")
+	}
+	return []string{snapshot.Spec.ComponentGroup}
+}
+
 // GetPRGroup gets the value of label test.appstudio.openshift.io/pr-group-sha and annotation from component snapshot or pipelinerun
 func GetPRGroup(object client.Object) (string, string) {
 	if metadata.HasLabel(object, PRGroupHashLabel) && metadata.HasAnnotation(object, PRGroupAnnotation) {
@@ -1688,6 +1838,18 @@ func isComponentRepositorySettingsCommentDisabled(component *applicationapiv1alp
 	return strings.EqualFold(strings.TrimSpace(component.Spec.RepositorySettings.CommentStrategy), GitCommentPolicyAllDisabled)
 }
 
+// IsSummaryCommentEnabled checks if the integration test statuses of the given component are reported
+// in a single summary comment per pull request instead of a comment per scenario or component
+func IsSummaryCommentEnabled(component *applicationapiv1alpha1.Component) bool {
+	if component == nil {
+		return false
+	}
+	if strings.EqualFold(strings.TrimSpace(component.Spec.RepositorySettings.CommentStrategy), GitCommentPolicySummary) {
+		return true
+	}
+	return metadata.HasAnnotationWithValue(component, GitCommentPolicyAnnotation, GitCommentPolicySummary)
+}
+
 func IsCommentDisabled(ctx context.Context, adapterClient client.Client, component *applicationapiv1alpha1.Component) (bool, error) {
 	if component == nil {
 		return false, nil
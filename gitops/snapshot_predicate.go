@@ -61,6 +61,84 @@ func SnapshotIntegrationTestRerunTriggerPredicate() predicate.Predicate {
 	}
 }
 
+// SnapshotTestOverrideRequestPredicate returns a predicate which filters out all objects except
+// when requests for marking integration tests as passed or skipped are added.
+func SnapshotTestOverrideRequestPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return HasSnapshotTestOverrideRequested(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+// SnapshotTestGraphProgressPredicate returns a predicate which filters out all objects except
+// when an integration test of the Snapshot finished while other integration tests are still Pending,
+// so that scenarios waiting for their parents in the TestGraph can be started.
+func SnapshotTestGraphProgressPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return HasSnapshotTestFinishedWithPendingScenarios(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+// SnapshotIntegrationTestRetryPredicate returns a predicate which filters out all objects except
+// when a new attempt of an integration test of the Snapshot was scheduled by the retry policy of its scenario.
+func SnapshotIntegrationTestRetryPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return HasSnapshotTestRetryBeenScheduled(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+// SnapshotPendingReportsPredicate returns a predicate which filters out all objects except
+// the Snapshots with reports still owed to the git provider or additional reporters for create and delete events,
+// so that the pending reports are retried after a restart and forgotten when the Snapshot is deleted.
+func SnapshotPendingReportsPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return metadata.HasAnnotation(createEvent.Object, SnapshotPendingReportsAnnotation)
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return metadata.HasAnnotation(deleteEvent.Object, SnapshotPendingReportsAnnotation)
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+	}
+}
+
 // SnapshotTestAnnotationChangePredicate returns a predicate which filters out all objects except
 // when Snapshot annotation "test.appstudio.openshift.io/status" is changed for update events.
 func SnapshotTestAnnotationChangePredicate() predicate.Predicate {
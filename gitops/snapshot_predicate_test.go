@@ -349,4 +349,173 @@ var _ = Describe("Predicates", Ordered, func() {
 		})
 
 	})
+
+	Context("testing SnapshotTestGraphProgressPredicate predicate", func() {
+
+		var (
+			hasSnapshotParentInProgress *applicationapiv1alpha1.Snapshot
+			hasSnapshotParentFinished   *applicationapiv1alpha1.Snapshot
+			hasSnapshotAllFinished      *applicationapiv1alpha1.Snapshot
+		)
+
+		BeforeAll(func() {
+			hasSnapshotParentInProgress = &applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      snapshotAnnotationOld,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						gitops.SnapshotTestsStatusAnnotation: "[{\"scenario\":\"parent\",\"status\":\"InProgress\"},{\"scenario\":\"child\",\"status\":\"Pending\"}]",
+					},
+				},
+			}
+
+			hasSnapshotParentFinished = hasSnapshotParentInProgress.DeepCopy()
+			hasSnapshotParentFinished.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[{\"scenario\":\"parent\",\"status\":\"TestPassed\"},{\"scenario\":\"child\",\"status\":\"Pending\"}]"
+
+			hasSnapshotAllFinished = hasSnapshotParentInProgress.DeepCopy()
+			hasSnapshotAllFinished.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[{\"scenario\":\"parent\",\"status\":\"TestPassed\"},{\"scenario\":\"child\",\"status\":\"TestPassed\"}]"
+		})
+		instance := gitops.SnapshotTestGraphProgressPredicate()
+
+		It("returns true when a test finished while another test is pending", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotParentInProgress,
+				ObjectNew: hasSnapshotParentFinished,
+			}
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
+
+		It("returns true when a test finished while another test is blocked", func() {
+			hasSnapshotChildBlocked := hasSnapshotParentFinished.DeepCopy()
+			hasSnapshotChildBlocked.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[{\"scenario\":\"parent\",\"status\":\"TestPassed\"},{\"scenario\":\"child\",\"status\":\"Blocked\"}]"
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotParentInProgress,
+				ObjectNew: hasSnapshotChildBlocked,
+			}
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
+
+		It("returns false when no test is pending", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotParentFinished,
+				ObjectNew: hasSnapshotAllFinished,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+
+		It("returns false when the test status annotation is the same", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotParentFinished,
+				ObjectNew: hasSnapshotParentFinished,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+
+		It("returns false when a Snapshot is created", func() {
+			contextEvent := event.CreateEvent{
+				Object: hasSnapshotParentInProgress,
+			}
+			Expect(instance.Create(contextEvent)).To(BeFalse())
+		})
+	})
+
+	Context("testing SnapshotIntegrationTestRetryPredicate predicate", func() {
+
+		var (
+			hasSnapshotFirstAttemptInProgress *applicationapiv1alpha1.Snapshot
+			hasSnapshotSecondAttemptPending   *applicationapiv1alpha1.Snapshot
+			hasSnapshotTestRerun              *applicationapiv1alpha1.Snapshot
+		)
+
+		BeforeAll(func() {
+			hasSnapshotFirstAttemptInProgress = &applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      snapshotAnnotationOld,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						gitops.SnapshotTestsStatusAnnotation: "[{\"scenario\":\"flaky\",\"status\":\"InProgress\",\"lastUpdateTime\":\"2026-01-01T10:00:00Z\",\"maxAttempts\":3}]",
+					},
+				},
+			}
+
+			hasSnapshotSecondAttemptPending = hasSnapshotFirstAttemptInProgress.DeepCopy()
+			hasSnapshotSecondAttemptPending.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[{\"scenario\":\"flaky\",\"status\":\"Pending\",\"lastUpdateTime\":\"2026-01-01T10:05:00Z\",\"attempt\":2,\"maxAttempts\":3," +
+				"\"previousAttempts\":[{\"attempt\":1,\"status\":\"TestFail\",\"testPipelineRunName\":\"flaky-abcde\"}]}]"
+
+			hasSnapshotTestRerun = hasSnapshotFirstAttemptInProgress.DeepCopy()
+			hasSnapshotTestRerun.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[{\"scenario\":\"flaky\",\"status\":\"Pending\",\"lastUpdateTime\":\"2026-01-01T10:05:00Z\"}]"
+		})
+		instance := gitops.SnapshotIntegrationTestRetryPredicate()
+
+		It("returns true when a new attempt of a test was scheduled", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotFirstAttemptInProgress,
+				ObjectNew: hasSnapshotSecondAttemptPending,
+			}
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
+
+		It("returns false when a test was reset without a new attempt", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotFirstAttemptInProgress,
+				ObjectNew: hasSnapshotTestRerun,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+
+		It("returns false when the test status annotation is the same", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotSecondAttemptPending,
+				ObjectNew: hasSnapshotSecondAttemptPending,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+
+		It("returns false when a Snapshot is created", func() {
+			contextEvent := event.CreateEvent{
+				Object: hasSnapshotSecondAttemptPending,
+			}
+			Expect(instance.Create(contextEvent)).To(BeFalse())
+		})
+	})
+
+	Context("testing SnapshotPendingReportsPredicate predicate", func() {
+
+		var (
+			hasSnapshotWithPendingReports    *applicationapiv1alpha1.Snapshot
+			hasSnapshotWithoutPendingReports *applicationapiv1alpha1.Snapshot
+		)
+
+		BeforeAll(func() {
+			hasSnapshotWithoutPendingReports = &applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        snapshotAnnotationOld,
+					Namespace:   namespace,
+					Annotations: map[string]string{},
+				},
+			}
+
+			hasSnapshotWithPendingReports = hasSnapshotWithoutPendingReports.DeepCopy()
+			hasSnapshotWithPendingReports.Annotations[gitops.SnapshotPendingReportsAnnotation] = "{\"reports\":{\"GithubReporter/snapshot-sample\":{\"since\":\"2026-01-01T10:00:00Z\",\"attempts\":1}}}"
+		})
+		instance := gitops.SnapshotPendingReportsPredicate()
+
+		It("returns true when a Snapshot with pending reports is created or deleted", func() {
+			Expect(instance.Create(event.CreateEvent{Object: hasSnapshotWithPendingReports})).To(BeTrue())
+			Expect(instance.Delete(event.DeleteEvent{Object: hasSnapshotWithPendingReports})).To(BeTrue())
+		})
+
+		It("returns false when a Snapshot without pending reports is created or deleted", func() {
+			Expect(instance.Create(event.CreateEvent{Object: hasSnapshotWithoutPendingReports})).To(BeFalse())
+			Expect(instance.Delete(event.DeleteEvent{Object: hasSnapshotWithoutPendingReports})).To(BeFalse())
+		})
+
+		It("returns false when the pending reports of a Snapshot change", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: hasSnapshotWithoutPendingReports,
+				ObjectNew: hasSnapshotWithPendingReports,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+	})
 })
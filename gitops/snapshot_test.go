@@ -1242,6 +1242,16 @@ var _ = Describe("Gitops functions for managing Snapshots", Ordered, func() {
 				Expect(gitops.HasPRGroupProcessed(hasComSnapshot1)).To(BeFalse())
 			})
 
+			It("make sure the component group chain and the dependent snapshots processing can be found", func() {
+				Expect(gitops.GetSnapshotComponentGroupChain(hasComSnapshot1)).To(Equal([]string{hasComSnapshot1.Spec.ComponentGroup}))
+				Expect(gitops.HasDependentSnapshotsProcessed(hasComSnapshot1)).To(BeFalse())
+
+				hasComSnapshot1.Annotations[v1beta2.ComponentGroupChainAnnotation] = "parent-group,child-group"
+				hasComSnapshot1.Annotations[gitops.DependentSnapshotsCreationAnnotation] = "snapshot child-snapshot is created for grandchild-group"
+				Expect(gitops.GetSnapshotComponentGroupChain(hasComSnapshot1)).To(Equal([]string{"parent-group", "child-group"}))
+				Expect(gitops.HasDependentSnapshotsProcessed(hasComSnapshot1)).To(BeTrue())
+			})
+
 			It("Can find the correct snapshotComponent for the given component name", func() {
 				FoundSnapshotComponent := gitops.FindMatchingSnapshotComponent(hasComSnapshot1, hasComp.Name)
 				Expect(FoundSnapshotComponent.Name).To(Equal(hasComp.Name))
@@ -1432,6 +1442,23 @@ var _ = Describe("Gitops functions for managing Snapshots", Ordered, func() {
 			Expect(k8sClient.Update(ctx, hasComp)).Should(Succeed())
 		})
 
+		It("can determine if the summary comment is enabled in component annotation or repository-settings", func() {
+			Expect(gitops.IsSummaryCommentEnabled(nil)).To(BeFalse())
+			Expect(metadata.SetAnnotation(hasComp, gitops.GitCommentPolicyAnnotation, gitops.GitCommentPolicySummary)).To(Succeed())
+			Expect(gitops.IsSummaryCommentEnabled(hasComp)).To(BeTrue())
+			isCommentDisabled, err := gitops.IsCommentDisabled(ctx, k8sClient, hasComp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isCommentDisabled).To(BeFalse())
+
+			Expect(metadata.DeleteAnnotation(hasComp, gitops.GitCommentPolicyAnnotation)).To(Succeed())
+			Expect(gitops.IsSummaryCommentEnabled(hasComp)).To(BeFalse())
+			hasComp.Spec.RepositorySettings = applicationapiv1alpha1.RepositorySettings{
+				CommentStrategy: "Summary",
+			}
+			Expect(gitops.IsSummaryCommentEnabled(hasComp)).To(BeTrue())
+			hasComp.Spec.RepositorySettings = applicationapiv1alpha1.RepositorySettings{}
+		})
+
 		It("can determine if comments are not disabled in pac repository or component annotation", func() {
 			Expect(metadata.DeleteAnnotation(hasComp, gitops.GitCommentPolicyAnnotation)).To(Succeed())
 			isCommentDisabled, err := gitops.IsCommentDisabled(ctx, k8sClient, hasComp)
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestOverrideAction is the action requested by a test override
+type TestOverrideAction string
+
+const (
+	// TestOverrideActionPass marks the test of the scenario as passed
+	TestOverrideActionPass TestOverrideAction = "override"
+
+	// TestOverrideActionSkip marks the test of an optional scenario as skipped
+	TestOverrideActionSkip TestOverrideAction = "skip"
+
+	// MaxTestOverridesAuditEntries is the maximum number of processed test overrides kept in the audit trail of a Snapshot
+	MaxTestOverridesAuditEntries = 50
+)
+
+// TestOverride is a request made by a user to mark the test of a scenario as passed or skipped without running it
+type TestOverride struct {
+	// Scenario is the name of the IntegrationTestScenario
+	Scenario string `json:"scenario"`
+	// Action is the requested action
+	Action TestOverrideAction `json:"action"`
+	// Reason given by the user for the request
+	Reason string `json:"reason,omitempty"`
+	// User who made the request
+	User string `json:"user"`
+	// Source of the request, e.g. the URL of the pull request comment
+	Source string `json:"source,omitempty"`
+	// RequestTime is the time the request was made
+	RequestTime metav1.Time `json:"requestTime"`
+	// ProcessedTime is the time the request was processed, only set in the audit trail
+	ProcessedTime *metav1.Time `json:"processedTime,omitempty"`
+	// Result of the request, only set in the audit trail
+	Result string `json:"result,omitempty"`
+}
+
+// GetTestOverrideRequests returns the pending test override requests of the Snapshot
+func GetTestOverrideRequests(snapshot *applicationapiv1alpha1.Snapshot) ([]TestOverride, error) {
+	return getTestOverridesFromAnnotation(snapshot, SnapshotTestOverrideRequestsAnnotation)
+}
+
+// GetTestOverrides returns the audit trail of the test override requests processed for the Snapshot
+func GetTestOverrides(snapshot *applicationapiv1alpha1.Snapshot) ([]TestOverride, error) {
+	return getTestOverridesFromAnnotation(snapshot, SnapshotTestOverridesAnnotation)
+}
+
+// AddTestOverrideRequest adds a pending test override request to the Snapshot. The Snapshot is patched with an
+// optimistic lock so that concurrent requests are not lost, the conflict error is returned unwrapped so that the
+// caller can use RetryOnConflict.
+func AddTestOverrideRequest(ctx context.Context, adapterClient client.Client, snapshot *applicationapiv1alpha1.Snapshot, override TestOverride) error {
+	requests, err := GetTestOverrideRequests(snapshot)
+	if err != nil {
+		// invalid requests can't be processed anyway, replace them
+		requests = nil
+	}
+
+	patch := client.MergeFromWithOptions(snapshot.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	value, err := json.Marshal(append(requests, override))
+	if err != nil {
+		return fmt.Errorf("failed to marshal test override requests into JSON: %w", err)
+	}
+	if err := metadata.SetAnnotation(&snapshot.ObjectMeta, SnapshotTestOverrideRequestsAnnotation, string(value)); err != nil {
+		return fmt.Errorf("failed to add annotation %s: %w", SnapshotTestOverrideRequestsAnnotation, err)
+	}
+	return adapterClient.Patch(ctx, snapshot, patch)
+}
+
+// CompleteTestOverrideRequests removes the pending test override requests from the Snapshot, adds the processed ones
+// to its audit trail and writes the test statuses updated by the requests. The Snapshot is patched with an optimistic
+// lock so that requests added in the meantime are not lost.
+func CompleteTestOverrideRequests(ctx context.Context, adapterClient client.Client, snapshot *applicationapiv1alpha1.Snapshot, processed []TestOverride, sts *intgteststat.SnapshotIntegrationTestStatuses) error {
+	overrides, err := GetTestOverrides(snapshot)
+	if err != nil {
+		// keep the processed requests rather than failing on an audit trail which can't be parsed
+		overrides = nil
+	}
+	overrides = append(overrides, processed...)
+	if len(overrides) > MaxTestOverridesAuditEntries {
+		overrides = overrides[len(overrides)-MaxTestOverridesAuditEntries:]
+	}
+
+	patch := client.MergeFromWithOptions(snapshot.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	delete(snapshot.Annotations, SnapshotTestOverrideRequestsAnnotation)
+	if len(overrides) > 0 {
+		value, err := json.Marshal(overrides)
+		if err != nil {
+			return fmt.Errorf("failed to marshal test overrides into JSON: %w", err)
+		}
+		if err := metadata.SetAnnotation(&snapshot.ObjectMeta, SnapshotTestOverridesAnnotation, string(value)); err != nil {
+			return fmt.Errorf("failed to add annotation %s: %w", SnapshotTestOverridesAnnotation, err)
+		}
+	}
+	if sts != nil && sts.IsDirty() {
+		value, err := json.Marshal(sts)
+		if err != nil {
+			return fmt.Errorf("failed to marshal test results into JSON: %w", err)
+		}
+		if err := metadata.SetAnnotation(&snapshot.ObjectMeta, SnapshotTestsStatusAnnotation, string(value)); err != nil {
+			return fmt.Errorf("failed to add annotations: %w", err)
+		}
+	}
+
+	if err := adapterClient.Patch(ctx, snapshot, patch); err != nil {
+		// don't return wrapped err, so we can use RetryOnConflict
+		return err
+	}
+	if sts != nil {
+		sts.ResetDirty()
+	}
+	return nil
+}
+
+// getTestOverridesFromAnnotation returns the test overrides stored as a JSON list in the given annotation of the Snapshot
+func getTestOverridesFromAnnotation(snapshot *applicationapiv1alpha1.Snapshot, annotation string) ([]TestOverride, error) {
+	value, ok := snapshot.GetAnnotations()[annotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	overrides := []TestOverride{}
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %w", annotation, err)
+	}
+	return overrides, nil
+}
+
+// HasSnapshotTestOverrideRequested returns a boolean indicating whether new test override requests were added to
+// the Snapshot. If the objects passed to this function are not Snapshots, the function will return false.
+func HasSnapshotTestOverrideRequested(objectOld, objectNew client.Object) bool {
+	if oldSnapshot, ok := objectOld.(*applicationapiv1alpha1.Snapshot); ok {
+		if newSnapshot, ok := objectNew.(*applicationapiv1alpha1.Snapshot); ok {
+			newValue, ok := newSnapshot.GetAnnotations()[SnapshotTestOverrideRequestsAnnotation]
+			return ok && newValue != oldSnapshot.GetAnnotations()[SnapshotTestOverrideRequestsAnnotation]
+		}
+	}
+	return false
+}
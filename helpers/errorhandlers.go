@@ -26,6 +26,7 @@ const (
 	ReasonMissingInfoInPipelineRunError = "MissingInfoInPipelineRunError"
 	ReasonInvalidImageDigestError       = "InvalidImageDigest"
 	ReasonMissingValidComponentError    = "MissingValidComponentError"
+	ReasonSnapshotCreatorError          = "SnapshotCreatorError"
 	ReasonUnknownError                  = "UnknownError"
 	ReasonUnrecoverableMetadataError    = "UnrecoverableMetadataError"
 )
@@ -91,6 +92,17 @@ func IsMissingValidComponentError(err error) bool {
 	return getReason(err) == ReasonMissingValidComponentError
 }
 
+func NewSnapshotCreatorError(componentGroupName, message string) error {
+	return &IntegrationError{
+		Reason:  ReasonSnapshotCreatorError,
+		Message: fmt.Sprintf("The snapshot creator of componentGroup '%s' failed to create a valid snapshot: %s", componentGroupName, message),
+	}
+}
+
+func IsSnapshotCreatorError(err error) bool {
+	return getReason(err) == ReasonSnapshotCreatorError
+}
+
 func HandleLoaderError(logger IntegrationLogger, err error, resource, from string) (ctrl.Result, error) {
 	if k8serrors.IsNotFound(err) {
 		logger.Info(fmt.Sprintf("Could not get %[1]s from %[2]s.  %[1]s may have been removed.  Declining to proceed with reconciliation due to the error: %[3]v", resource, from, err))
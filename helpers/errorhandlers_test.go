@@ -101,6 +101,13 @@ var _ = Describe("Helpers for error handlers", Ordered, func() {
 			Expect(err.Error()).To(Equal("The component(s) 'componentName' is(are) invalid due to missing valid container image or git source"))
 		})
 
+		It("Can define SnapshotCreatorError", func() {
+			err := helpers.NewSnapshotCreatorError("componentGroupName", "taskRun failed")
+			Expect(helpers.IsSnapshotCreatorError(err)).To(BeTrue())
+			Expect(helpers.IsMissingValidComponentError(err)).To(BeFalse())
+			Expect(err.Error()).To(Equal("The snapshot creator of componentGroup 'componentGroupName' failed to create a valid snapshot: taskRun failed"))
+		})
+
 		It("Can handle non integration error", func() {
 			err := fmt.Errorf("failed")
 			Expect(helpers.IsMissingInfoInPipelineRunError(err)).To(BeFalse())
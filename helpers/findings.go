@@ -0,0 +1,227 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// SARIFResultsName is the name of the Tekton task result holding a SARIF log with the findings of the task
+	SARIFResultsName = "SARIF_RESULTS"
+
+	// FindingsResultsName is the name of the Tekton task result holding a JSON list of the findings of the task
+	FindingsResultsName = "TEST_FINDINGS"
+
+	// FindingLevelFailure is the level of a finding which is an error
+	FindingLevelFailure = "failure"
+
+	// FindingLevelWarning is the level of a finding which is a warning
+	FindingLevelWarning = "warning"
+
+	// FindingLevelNotice is the level of a finding which is informative
+	FindingLevelNotice = "notice"
+
+	// MaxReportedFindings is the maximum number of findings reported for the integration PipelineRun of a scenario
+	MaxReportedFindings = 500
+)
+
+// Finding is a line-level finding reported by a task of an integration PipelineRun, e.g. by a linter or a scanner
+type Finding struct {
+	// Path of the file relative to the root of the repository
+	Path string `json:"path"`
+	// StartLine is the first line of the finding, starting at 1
+	StartLine int `json:"startLine,omitempty"`
+	// EndLine is the last line of the finding, StartLine is used when not set
+	EndLine int `json:"endLine,omitempty"`
+	// Level of the finding, one of failure, warning and notice
+	Level string `json:"level,omitempty"`
+	// Title of the finding
+	Title string `json:"title,omitempty"`
+	// Message describing the finding
+	Message string `json:"message"`
+	// Rule which produced the finding, if any
+	Rule string `json:"rule,omitempty"`
+}
+
+// sarifLog is the subset of a SARIF 2.1.0 log needed to extract line-level findings
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Name string `json:"name"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+						EndLine   int `json:"endLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// ParseSARIF returns the findings of the given SARIF log which have a location in a file
+func ParseSARIF(data []byte) ([]Finding, error) {
+	sarif := sarifLog{}
+	if err := json.Unmarshal(data, &sarif); err != nil {
+		return nil, fmt.Errorf("failed to parse SARIF log: %w", err)
+	}
+
+	findings := []Finding{}
+	for _, run := range sarif.Runs {
+		for _, result := range run.Results {
+			if len(result.Locations) == 0 {
+				continue
+			}
+			location := result.Locations[0].PhysicalLocation
+			title := result.RuleID
+			if run.Tool.Driver.Name != "" && title != "" {
+				title = fmt.Sprintf("%s: %s", run.Tool.Driver.Name, title)
+			}
+			findings = append(findings, Finding{
+				Path:      location.ArtifactLocation.URI,
+				StartLine: location.Region.StartLine,
+				EndLine:   location.Region.EndLine,
+				Level:     sarifLevelToFindingLevel(result.Level),
+				Title:     title,
+				Message:   result.Message.Text,
+				Rule:      result.RuleID,
+			})
+		}
+	}
+	return normalizeFindings(findings), nil
+}
+
+// ParseFindings returns the findings of the given JSON list of findings
+func ParseFindings(data []byte) ([]Finding, error) {
+	findings := []Finding{}
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, fmt.Errorf("failed to parse findings, a JSON list of findings is expected: %w", err)
+	}
+	return normalizeFindings(findings), nil
+}
+
+// GetFindings returns the findings published by the TaskRun through the SARIF_RESULTS or TEST_FINDINGS results
+func (t *TaskRun) GetFindings() ([]Finding, error) {
+	var findings []Finding
+	var errs []error
+	for _, taskRunResult := range t.trStatus.Results {
+		var parsed []Finding
+		var err error
+		switch taskRunResult.Name {
+		case SARIFResultsName:
+			parsed, err = ParseSARIF([]byte(taskRunResult.Value.StringVal))
+		case FindingsResultsName:
+			parsed, err = ParseFindings([]byte(taskRunResult.Value.StringVal))
+		default:
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid result %s of task %s: %w", taskRunResult.Name, t.GetPipelineTaskName(), err))
+			continue
+		}
+		findings = append(findings, parsed...)
+	}
+	return findings, errors.Join(errs...)
+}
+
+// GetFindingsFromTaskRuns returns the findings published by the given TaskRuns, at most MaxReportedFindings of them.
+// The findings which could be parsed are returned along with the errors of the results which couldn't.
+func GetFindingsFromTaskRuns(taskRuns []*TaskRun) ([]Finding, error) {
+	var findings []Finding
+	var errs []error
+	for _, taskRun := range taskRuns {
+		taskRunFindings, err := taskRun.GetFindings()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		findings = append(findings, taskRunFindings...)
+	}
+	if len(findings) > MaxReportedFindings {
+		findings = findings[:MaxReportedFindings]
+	}
+	return findings, errors.Join(errs...)
+}
+
+// normalizeFindings drops the findings without a file and fills in the defaults of the others
+func normalizeFindings(findings []Finding) []Finding {
+	normalized := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		finding.Path = normalizeFindingPath(finding.Path)
+		if finding.Path == "" {
+			continue
+		}
+		if finding.StartLine < 1 {
+			finding.StartLine = 1
+		}
+		if finding.EndLine < finding.StartLine {
+			finding.EndLine = finding.StartLine
+		}
+		switch finding.Level {
+		case FindingLevelFailure, FindingLevelWarning, FindingLevelNotice:
+		default:
+			finding.Level = FindingLevelWarning
+		}
+		if finding.Message == "" {
+			finding.Message = finding.Title
+		}
+		normalized = append(normalized, finding)
+	}
+	return normalized
+}
+
+// normalizeFindingPath turns the file URI of a finding into a path relative to the root of the repository
+func normalizeFindingPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "file://") {
+		if parsed, err := url.Parse(path); err == nil {
+			path = parsed.Path
+		}
+	}
+	path = strings.TrimPrefix(path, "./")
+	return strings.TrimPrefix(path, "/")
+}
+
+// sarifLevelToFindingLevel maps the level of a SARIF result to the level of a finding
+func sarifLevelToFindingLevel(level string) string {
+	switch level {
+	case "error":
+		return FindingLevelFailure
+	case "note", "none":
+		return FindingLevelNotice
+	default:
+		// warning is the default level of SARIF results
+		return FindingLevelWarning
+	}
+}
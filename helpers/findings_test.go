@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+
+	"github.com/konflux-ci/integration-service/helpers"
+)
+
+const sarifResults = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "gosec"}},
+    "results": [
+      {
+        "ruleId": "G101",
+        "level": "error",
+        "message": {"text": "Potential hardcoded credentials"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///workspace/source/main.go"}, "region": {"startLine": 10, "endLine": 12}}}]
+      },
+      {
+        "ruleId": "G104",
+        "message": {"text": "Errors unhandled"},
+        "locations": [{"physicalLocation": {"artifactLocation": {"uri": "./pkg/util.go"}, "region": {"startLine": 3}}}]
+      },
+      {
+        "ruleId": "G000",
+        "level": "note",
+        "message": {"text": "Finding without a location"}
+      }
+    ]
+  }]
+}`
+
+var _ = Describe("Helpers for findings", func() {
+
+	newTaskRun := func(results ...tektonv1.TaskRunResult) *helpers.TaskRun {
+		return helpers.NewTaskRunFromTektonTaskRun("lint", &tektonv1.TaskRunStatus{
+			TaskRunStatusFields: tektonv1.TaskRunStatusFields{Results: results},
+		})
+	}
+
+	newResult := func(name, value string) tektonv1.TaskRunResult {
+		return tektonv1.TaskRunResult{Name: name, Value: *tektonv1.NewStructuredValues(value)}
+	}
+
+	It("parses the findings of a SARIF log", func() {
+		findings, err := helpers.ParseSARIF([]byte(sarifResults))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findings).To(Equal([]helpers.Finding{
+			{Path: "workspace/source/main.go", StartLine: 10, EndLine: 12, Level: helpers.FindingLevelFailure, Title: "gosec: G101", Message: "Potential hardcoded credentials", Rule: "G101"},
+			{Path: "pkg/util.go", StartLine: 3, EndLine: 3, Level: helpers.FindingLevelWarning, Title: "gosec: G104", Message: "Errors unhandled", Rule: "G104"},
+		}))
+	})
+
+	It("parses a JSON list of findings and fills in the defaults", func() {
+		findings, err := helpers.ParseFindings([]byte(`[
+			{"path": "Dockerfile", "title": "Pin the base image", "level": "critical"},
+			{"message": "Finding without a path"}
+		]`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findings).To(Equal([]helpers.Finding{
+			{Path: "Dockerfile", StartLine: 1, EndLine: 1, Level: helpers.FindingLevelWarning, Title: "Pin the base image", Message: "Pin the base image"},
+		}))
+
+		_, err = helpers.ParseFindings([]byte(`{"path": "Dockerfile"}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("collects the findings of the TaskRuns", func() {
+		taskRuns := []*helpers.TaskRun{
+			newTaskRun(newResult(helpers.SARIFResultsName, sarifResults)),
+			newTaskRun(newResult(helpers.FindingsResultsName, `[{"path": "Dockerfile", "startLine": 2, "level": "notice", "message": "Use COPY"}]`)),
+			newTaskRun(newResult(helpers.FindingsResultsName, `not json`), newResult(helpers.TestOutputName, `{"result": "SUCCESS"}`)),
+		}
+		findings, err := helpers.GetFindingsFromTaskRuns(taskRuns)
+		Expect(err).To(MatchError(ContainSubstring("invalid result TEST_FINDINGS of task lint")))
+		Expect(findings).To(HaveLen(3))
+		Expect(findings[2].Path).To(Equal("Dockerfile"))
+	})
+
+	It("caps the number of collected findings", func() {
+		findings := make([]string, 0, helpers.MaxReportedFindings+1)
+		for i := 0; i <= helpers.MaxReportedFindings; i++ {
+			findings = append(findings, fmt.Sprintf(`{"path": "main.go", "startLine": %d, "message": "finding"}`, i+1))
+		}
+		taskRuns := []*helpers.TaskRun{
+			newTaskRun(newResult(helpers.FindingsResultsName, "["+strings.Join(findings, ",")+"]")),
+		}
+		collected, err := helpers.GetFindingsFromTaskRuns(taskRuns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collected).To(HaveLen(helpers.MaxReportedFindings))
+	})
+})
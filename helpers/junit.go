@@ -0,0 +1,236 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	// JUnitResultsName is the name of the Tekton task result holding inline JUnit XML test results
+	JUnitResultsName = "JUNIT_RESULTS"
+
+	// JUnitResultsArtifactName is the name of the Tekton task result holding the reference of an OCI artifact
+	// with JUnit XML test results, e.g. quay.io/org/repo@sha256:...
+	JUnitResultsArtifactName = "JUNIT_RESULTS_ARTIFACT"
+
+	// TestCaseStatusPassed is the status of a test case which passed
+	TestCaseStatusPassed = "passed"
+
+	// TestCaseStatusFailed is the status of a test case which failed
+	TestCaseStatusFailed = "failed"
+
+	// TestCaseStatusError is the status of a test case which produced an error
+	TestCaseStatusError = "error"
+
+	// TestCaseStatusSkipped is the status of a test case which was skipped
+	TestCaseStatusSkipped = "skipped"
+
+	// MaxStoredTestCases is the maximum number of unsuccessful test cases stored for a scenario,
+	// the test status of all scenarios is stored in a single annotation of the Snapshot
+	MaxStoredTestCases = 20
+
+	// maxTestCaseMessageLength is the maximum length of the stored message of a test case
+	maxTestCaseMessageLength = 500
+
+	// maxArtifactsFetchDuration bounds the time spent fetching all the JUnit results artifacts of a PipelineRun,
+	// they are fetched while reconciling the PipelineRun
+	maxArtifactsFetchDuration = 15 * time.Second
+)
+
+// JUnitTestSuites is the root element of JUnit XML results with multiple test suites
+type JUnitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is a test suite in JUnit XML results, test suites can be nested
+type JUnitTestSuite struct {
+	XMLName   xml.Name         `xml:"testsuite"`
+	Name      string           `xml:"name,attr"`
+	TestCases []JUnitTestCase  `xml:"testcase"`
+	Suites    []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestCase is a test case in JUnit XML results
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure"`
+	Error     *JUnitFailure `xml:"error"`
+	Skipped   *JUnitFailure `xml:"skipped"`
+}
+
+// JUnitFailure is the failure, error or skipped element of a test case in JUnit XML results
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// TestCaseResult is the outcome of a single test case
+type TestCaseResult struct {
+	// Name of the test case
+	Name string `json:"name"`
+	// ClassName of the test case, if any
+	ClassName string `json:"className,omitempty"`
+	// Suite is the name of the test suite the test case belongs to
+	Suite string `json:"suite,omitempty"`
+	// Status of the test case, one of passed, failed, error and skipped
+	Status string `json:"status"`
+	// Message of the failure or error
+	Message string `json:"message,omitempty"`
+}
+
+// TestCaseResults summarizes the test case outcomes published by the tasks of an integration PipelineRun
+type TestCaseResults struct {
+	// Total number of test cases
+	Total int `json:"total"`
+	// Passed is the number of test cases which passed
+	Passed int `json:"passed"`
+	// Failed is the number of test cases which failed
+	Failed int `json:"failed"`
+	// Errored is the number of test cases which produced an error
+	Errored int `json:"errored"`
+	// Skipped is the number of test cases which were skipped
+	Skipped int `json:"skipped"`
+	// TestCases are the failed and errored test cases, at most MaxStoredTestCases of them are kept
+	TestCases []TestCaseResult `json:"testCases,omitempty"`
+}
+
+// ParseJUnitXML parses JUnit XML results with either a testsuites or a testsuite root element
+func ParseJUnitXML(data []byte) (*JUnitTestSuites, error) {
+	suites := &JUnitTestSuites{}
+	if err := xml.Unmarshal(data, suites); err == nil {
+		return suites, nil
+	}
+
+	suite := JUnitTestSuite{}
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit XML, a testsuites or testsuite root element is expected: %w", err)
+	}
+	suites.Suites = []JUnitTestSuite{suite}
+	return suites, nil
+}
+
+// AddJUnitTestSuites adds the outcomes of all test cases of the given test suites to the results
+func (r *TestCaseResults) AddJUnitTestSuites(suites *JUnitTestSuites) {
+	for _, suite := range suites.Suites {
+		r.addJUnitTestSuite(suite)
+	}
+}
+
+func (r *TestCaseResults) addJUnitTestSuite(suite JUnitTestSuite) {
+	for _, testCase := range suite.TestCases {
+		result := TestCaseResult{
+			Name:      testCase.Name,
+			ClassName: testCase.ClassName,
+			Suite:     suite.Name,
+		}
+		r.Total++
+		switch {
+		case testCase.Error != nil:
+			r.Errored++
+			result.Status = TestCaseStatusError
+			result.Message = testCase.Error.message()
+		case testCase.Failure != nil:
+			r.Failed++
+			result.Status = TestCaseStatusFailed
+			result.Message = testCase.Failure.message()
+		case testCase.Skipped != nil:
+			r.Skipped++
+			continue
+		default:
+			r.Passed++
+			continue
+		}
+		if len(r.TestCases) < MaxStoredTestCases {
+			r.TestCases = append(r.TestCases, result)
+		}
+	}
+	for _, nestedSuite := range suite.Suites {
+		r.addJUnitTestSuite(nestedSuite)
+	}
+}
+
+// GetOmittedTestCasesCount returns the number of failed and errored test cases which were not stored
+func (r *TestCaseResults) GetOmittedTestCasesCount() int {
+	return r.Failed + r.Errored - len(r.TestCases)
+}
+
+// message returns the message of the failure, falling back to the first line of its text
+func (f *JUnitFailure) message() string {
+	message := strings.TrimSpace(f.Message)
+	if message == "" {
+		message, _, _ = strings.Cut(strings.TrimSpace(f.Text), "\n")
+	}
+	if len(message) > maxTestCaseMessageLength {
+		message = message[:maxTestCaseMessageLength-3] + "..."
+	}
+	return message
+}
+
+// ArtifactFetcher fetches the content of the OCI artifact with the given reference
+type ArtifactFetcher func(ctx context.Context, reference string) ([]byte, error)
+
+// GetJUnitTestCaseResults collects the JUnit results published by the given TaskRuns through the JUNIT_RESULTS
+// or JUNIT_RESULTS_ARTIFACT task results. Nil is returned when none of the TaskRuns published JUnit results.
+// The results which could be collected are returned along with the errors of the ones which couldn't.
+func GetJUnitTestCaseResults(ctx context.Context, taskRuns []tektonv1.TaskRun, fetchArtifact ArtifactFetcher) (*TestCaseResults, error) {
+	ctx, cancel := context.WithTimeout(ctx, maxArtifactsFetchDuration)
+	defer cancel()
+
+	var results *TestCaseResults
+	var errs []error
+	for _, taskRun := range taskRuns {
+		for _, taskRunResult := range taskRun.Status.Results {
+			var data []byte
+			switch taskRunResult.Name {
+			case JUnitResultsName:
+				data = []byte(taskRunResult.Value.StringVal)
+			case JUnitResultsArtifactName:
+				artifact, err := fetchArtifact(ctx, strings.TrimSpace(taskRunResult.Value.StringVal))
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to fetch JUnit results artifact of taskRun %s: %w", taskRun.Name, err))
+					continue
+				}
+				data = artifact
+			default:
+				continue
+			}
+
+			suites, err := ParseJUnitXML(bytes.TrimSpace(data))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid JUnit results of taskRun %s: %w", taskRun.Name, err))
+				continue
+			}
+			if results == nil {
+				results = &TestCaseResults{}
+			}
+			results.AddJUnitTestSuites(suites)
+		}
+	}
+	return results, errors.Join(errs...)
+}
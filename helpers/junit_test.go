@@ -0,0 +1,266 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/helpers"
+)
+
+const junitResults = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="e2e">
+    <testcase name="TestLogin" classname="auth">
+      <failure message="expected 200, got 500">stack trace</failure>
+    </testcase>
+    <testcase name="TestLogout" classname="auth"/>
+    <testsuite name="setup">
+      <testcase name="TestDatabase">
+        <error>connection refused
+at db.go:42</error>
+      </testcase>
+    </testsuite>
+  </testsuite>
+  <testsuite name="unit">
+    <testcase name="TestSkipped"><skipped/></testcase>
+  </testsuite>
+</testsuites>`
+
+var _ = Describe("Helpers for JUnit results", func() {
+
+	newTaskRun := func(name string, results ...tektonv1.TaskRunResult) tektonv1.TaskRun {
+		taskRun := tektonv1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		taskRun.Status.Results = results
+		return taskRun
+	}
+
+	newResult := func(name, value string) tektonv1.TaskRunResult {
+		return tektonv1.TaskRunResult{Name: name, Value: *tektonv1.NewStructuredValues(value)}
+	}
+
+	It("parses JUnit XML results with nested test suites", func() {
+		suites, err := helpers.ParseJUnitXML([]byte(junitResults))
+		Expect(err).NotTo(HaveOccurred())
+
+		results := &helpers.TestCaseResults{}
+		results.AddJUnitTestSuites(suites)
+		Expect(results.Total).To(Equal(4))
+		Expect(results.Passed).To(Equal(1))
+		Expect(results.Failed).To(Equal(1))
+		Expect(results.Errored).To(Equal(1))
+		Expect(results.Skipped).To(Equal(1))
+		Expect(results.TestCases).To(Equal([]helpers.TestCaseResult{
+			{Name: "TestLogin", ClassName: "auth", Suite: "e2e", Status: helpers.TestCaseStatusFailed, Message: "expected 200, got 500"},
+			{Name: "TestDatabase", Suite: "setup", Status: helpers.TestCaseStatusError, Message: "connection refused"},
+		}))
+		Expect(results.GetOmittedTestCasesCount()).To(Equal(0))
+	})
+
+	It("parses JUnit XML results with a single test suite and caps the stored test cases", func() {
+		testCases := strings.Repeat(`<testcase name="TestFlaky"><failure message="flaky"/></testcase>`, helpers.MaxStoredTestCases+5)
+		suites, err := helpers.ParseJUnitXML([]byte(`<testsuite name="flaky">` + testCases + `</testsuite>`))
+		Expect(err).NotTo(HaveOccurred())
+
+		results := &helpers.TestCaseResults{}
+		results.AddJUnitTestSuites(suites)
+		Expect(results.Failed).To(Equal(helpers.MaxStoredTestCases + 5))
+		Expect(results.TestCases).To(HaveLen(helpers.MaxStoredTestCases))
+		Expect(results.GetOmittedTestCasesCount()).To(Equal(5))
+	})
+
+	It("fails to parse results which aren't JUnit XML", func() {
+		_, err := helpers.ParseJUnitXML([]byte(`{"result": "SUCCESS"}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("collects the inline and artifact JUnit results of the TaskRuns", func() {
+		fetchArtifact := func(ctx context.Context, reference string) ([]byte, error) {
+			if reference == "quay.io/org/junit:missing" {
+				return nil, fmt.Errorf("not found")
+			}
+			return []byte(`<testsuite name="artifact"><testcase name="TestFromArtifact"/></testsuite>`), nil
+		}
+
+		results, err := helpers.GetJUnitTestCaseResults(context.Background(), []tektonv1.TaskRun{
+			newTaskRun("task-inline", newResult(helpers.JUnitResultsName, junitResults)),
+			newTaskRun("task-artifact", newResult(helpers.JUnitResultsArtifactName, "quay.io/org/junit:latest")),
+			newTaskRun("task-missing", newResult(helpers.JUnitResultsArtifactName, "quay.io/org/junit:missing")),
+			newTaskRun("task-other", newResult(helpers.TestOutputName, `{"result": "SUCCESS"}`)),
+		}, fetchArtifact)
+		Expect(err).To(MatchError(ContainSubstring("task-missing")))
+		Expect(results).NotTo(BeNil())
+		Expect(results.Total).To(Equal(5))
+		Expect(results.Passed).To(Equal(2))
+	})
+
+	It("returns no results when no TaskRun published JUnit results", func() {
+		results, err := helpers.GetJUnitTestCaseResults(context.Background(), []tektonv1.TaskRun{
+			newTaskRun("task-other", newResult(helpers.TestOutputName, `{"result": "SUCCESS"}`)),
+		}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeNil())
+	})
+
+	It("fetches the JUnit results artifact from a registry with anonymous token authentication", func() {
+		junitLayer := []byte(junitResults)
+		sum := sha256.Sum256(junitLayer)
+		layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/token":
+				Expect(r.URL.Query().Get("scope")).To(MatchRegexp(`^repository:org/\w+:pull$`))
+				_ = json.NewEncoder(rw).Encode(map[string]string{"token": "anonymous"})
+			case r.Header.Get("Authorization") != "Bearer anonymous":
+				rw.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+				rw.WriteHeader(http.StatusUnauthorized)
+			case r.URL.Path == "/v2/org/junit/manifests/latest":
+				_ = json.NewEncoder(rw).Encode(map[string]any{
+					"mediaType": "application/vnd.oci.image.manifest.v1+json",
+					"layers": []map[string]any{
+						{"mediaType": "text/plain", "digest": "sha256:0000", "size": 4},
+						{"mediaType": "application/xml", "digest": layerDigest, "size": len(junitLayer)},
+					},
+				})
+			case r.URL.Path == "/v2/org/junit/blobs/"+layerDigest:
+				_, _ = rw.Write(junitLayer)
+			default:
+				rw.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		registry := strings.TrimPrefix(server.URL, "http://")
+		content, err := helpers.FetchJUnitArtifact(context.Background(), registry+"/org/junit:latest", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(junitLayer))
+
+		_, err = helpers.FetchJUnitArtifact(context.Background(), registry+"/org/other:latest", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("authenticates to the token endpoint of the registry with the pull credentials", func() {
+		junitLayer := []byte(junitResults)
+		sum := sha256.Sum256(junitLayer)
+		layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/token":
+				if username, password, ok := r.BasicAuth(); !ok || username != "robot" || password != "secret" {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_ = json.NewEncoder(rw).Encode(map[string]string{"token": "private"})
+			case r.Header.Get("Authorization") != "Bearer private":
+				rw.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+				rw.WriteHeader(http.StatusUnauthorized)
+			case r.URL.Path == "/v2/org/junit/manifests/latest":
+				_ = json.NewEncoder(rw).Encode(map[string]any{
+					"layers": []map[string]any{{"mediaType": "application/xml", "digest": layerDigest, "size": len(junitLayer)}},
+				})
+			case r.URL.Path == "/v2/org/junit/blobs/"+layerDigest:
+				_, _ = rw.Write(junitLayer)
+			default:
+				rw.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		reference := strings.TrimPrefix(server.URL, "http://") + "/org/junit:latest"
+		_, err := helpers.FetchJUnitArtifact(context.Background(), reference, nil)
+		Expect(err).To(HaveOccurred())
+		content, err := helpers.FetchJUnitArtifact(context.Background(), reference, &helpers.RegistryCredentials{Username: "robot", Password: "secret"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(Equal(junitLayer))
+	})
+
+	It("refuses the token realms which aren't served by the registry", func() {
+		tokenRequested := false
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			tokenRequested = true
+			_ = json.NewEncoder(rw).Encode(map[string]string{"token": "anonymous"})
+		}))
+		defer tokenServer.Close()
+		registryServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token"`, tokenServer.URL))
+			rw.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer registryServer.Close()
+
+		reference := strings.TrimPrefix(registryServer.URL, "http://") + "/org/junit:latest"
+		_, err := helpers.FetchJUnitArtifact(context.Background(), reference, &helpers.RegistryCredentials{Username: "robot", Password: "secret"})
+		Expect(err).To(MatchError(ContainSubstring("is not served by the registry")))
+		Expect(tokenRequested).To(BeFalse())
+	})
+
+	It("gets the registry credentials from the pull secrets of the service account", func() {
+		dockerConfig := func(auths string) *v1.Secret {
+			return &v1.Secret{
+				Type: v1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{v1.DockerConfigJsonKey: []byte(`{"auths": {` + auths + `}}`)},
+			}
+		}
+		registrySecret := dockerConfig(`"quay.io": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("registry:password")) + `"}`)
+		registrySecret.ObjectMeta = metav1.ObjectMeta{Name: "registry-secret", Namespace: "default"}
+		orgSecret := dockerConfig(`"https://quay.io/org": {"username": "org", "password": "password"}`)
+		orgSecret.ObjectMeta = metav1.ObjectMeta{Name: "org-secret", Namespace: "default"}
+		cl := fake.NewClientBuilder().WithObjects(
+			&v1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "konflux-integration-runner", Namespace: "default"},
+				ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry-secret"}, {Name: "missing-secret"}},
+				Secrets:          []v1.ObjectReference{{Name: "org-secret"}},
+			},
+			registrySecret,
+			orgSecret,
+		).Build()
+
+		credentials, err := helpers.GetRegistryCredentials(context.Background(), cl, "default", "konflux-integration-runner", "quay.io/org/junit:latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(Equal(&helpers.RegistryCredentials{Username: "org", Password: "password"}))
+
+		credentials, err = helpers.GetRegistryCredentials(context.Background(), cl, "default", "konflux-integration-runner", "quay.io/other/junit:latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(Equal(&helpers.RegistryCredentials{Username: "registry", Password: "password"}))
+
+		credentials, err = helpers.GetRegistryCredentials(context.Background(), cl, "default", "konflux-integration-runner", "ghcr.io/org/junit:latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(BeNil())
+
+		credentials, err = helpers.GetRegistryCredentials(context.Background(), cl, "default", "other", "quay.io/org/junit:latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(BeNil())
+	})
+})
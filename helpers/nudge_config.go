@@ -0,0 +1,173 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+)
+
+const (
+	// NudgeConfigValidCondition is the condition for marking whether the nudge graph of the NudgeConfig is valid.
+	NudgeConfigValidCondition = "Valid"
+
+	// NudgeConfigValidReason is the reason that's set when the nudge graph passed validation.
+	NudgeConfigValidReason = "Valid"
+
+	// NudgeConfigCycleDetectedReason is the reason that's set when the nudge graph contains a cycle.
+	NudgeConfigCycleDetectedReason = "CycleDetected"
+
+	// NudgeConfigUnknownComponentsReason is the reason that's set when the nudge graph references components which don't exist.
+	NudgeConfigUnknownComponentsReason = "UnknownComponents"
+)
+
+// SetNudgeConfigStatusAsValid sets the Valid status condition of the NudgeConfig to true
+// and records the time of the validation.
+func SetNudgeConfigStatusAsValid(nudgeConfig *v1beta2.NudgeConfig, message string) {
+	meta.SetStatusCondition(&nudgeConfig.Status.Conditions, metav1.Condition{
+		Type:               NudgeConfigValidCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             NudgeConfigValidReason,
+		Message:            message,
+		ObservedGeneration: nudgeConfig.Generation,
+	})
+	now := metav1.Now()
+	nudgeConfig.Status.LastValidationTime = &now
+}
+
+// SetNudgeConfigStatusAsInvalid sets the Valid status condition of the NudgeConfig to false with the given reason.
+func SetNudgeConfigStatusAsInvalid(nudgeConfig *v1beta2.NudgeConfig, reason, message string) {
+	meta.SetStatusCondition(&nudgeConfig.Status.Conditions, metav1.Condition{
+		Type:               NudgeConfigValidCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: nudgeConfig.Generation,
+	})
+}
+
+// IsNudgeConfigValid returns true if the nudge graph of the NudgeConfig passed validation for its current generation.
+func IsNudgeConfigValid(nudgeConfig *v1beta2.NudgeConfig) bool {
+	condition := meta.FindStatusCondition(nudgeConfig.Status.Conditions, NudgeConfigValidCondition)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == nudgeConfig.Generation
+}
+
+// GetNudgeGatingGroupMembers returns the sorted source components of each gating group referenced by the nudges.
+func GetNudgeGatingGroupMembers(nudgeConfig *v1beta2.NudgeConfig) map[string][]string {
+	groupMembers := make(map[string][]string)
+	for _, nudge := range nudgeConfig.Spec.Nudges {
+		if nudge.GatingGroup == "" || slices.Contains(groupMembers[nudge.GatingGroup], nudge.From) {
+			continue
+		}
+		groupMembers[nudge.GatingGroup] = append(groupMembers[nudge.GatingGroup], nudge.From)
+	}
+	for _, members := range groupMembers {
+		slices.Sort(members)
+	}
+	return groupMembers
+}
+
+// AlignNudgeConfigGatingGroups aligns the gating groups in the status of the NudgeConfig with the gating groups
+// referenced by its nudges. Snapshots which already satisfied members that are still part of a group are kept.
+func AlignNudgeConfigGatingGroups(nudgeConfig *v1beta2.NudgeConfig) {
+	groupMembers := GetNudgeGatingGroupMembers(nudgeConfig)
+
+	groupNames := make([]string, 0, len(groupMembers))
+	for groupName := range groupMembers {
+		groupNames = append(groupNames, groupName)
+	}
+	slices.Sort(groupNames)
+
+	var gatingGroups []v1beta2.GatingGroupStatus
+	for _, groupName := range groupNames {
+		groupStatus := v1beta2.GatingGroupStatus{Name: groupName}
+		if existing := GetNudgeGatingGroupStatus(nudgeConfig, groupName); existing != nil {
+			groupStatus.LastTriggeredTime = existing.LastTriggeredTime
+		}
+
+		for _, component := range groupMembers[groupName] {
+			memberStatus := v1beta2.GatingGroupMemberStatus{Component: component}
+			if existing := getGatingGroupMemberStatus(GetNudgeGatingGroupStatus(nudgeConfig, groupName), component); existing != nil {
+				memberStatus = *existing
+			}
+			groupStatus.Members = append(groupStatus.Members, memberStatus)
+		}
+		updateGatingGroupOutstandingMembers(&groupStatus)
+		gatingGroups = append(gatingGroups, groupStatus)
+	}
+
+	nudgeConfig.Status.GatingGroups = gatingGroups
+}
+
+// GetNudgeGatingGroupStatus returns the status of the given gating group of the NudgeConfig,
+// or nil if the group has no status yet.
+func GetNudgeGatingGroupStatus(nudgeConfig *v1beta2.NudgeConfig, groupName string) *v1beta2.GatingGroupStatus {
+	for i := range nudgeConfig.Status.GatingGroups {
+		if nudgeConfig.Status.GatingGroups[i].Name == groupName {
+			return &nudgeConfig.Status.GatingGroups[i]
+		}
+	}
+	return nil
+}
+
+// SetNudgeGatingGroupMemberSnapshot sets the Snapshot satisfying the given component in the status of the gating group.
+// An empty snapshot name marks the component as outstanding again. Nothing is changed if the component
+// is not a member of the gating group.
+func SetNudgeGatingGroupMemberSnapshot(nudgeConfig *v1beta2.NudgeConfig, groupName, component, snapshotName string) {
+	groupStatus := GetNudgeGatingGroupStatus(nudgeConfig, groupName)
+	memberStatus := getGatingGroupMemberStatus(groupStatus, component)
+	if memberStatus == nil {
+		return
+	}
+
+	now := metav1.Now()
+	memberStatus.Snapshot = snapshotName
+	memberStatus.LastUpdateTime = &now
+	updateGatingGroupOutstandingMembers(groupStatus)
+}
+
+// IsNudgeGatingGroupSatisfied returns true if every member of the gating group has a passing Snapshot.
+func IsNudgeGatingGroupSatisfied(groupStatus *v1beta2.GatingGroupStatus) bool {
+	return groupStatus != nil && len(groupStatus.Members) > 0 && len(groupStatus.Outstanding) == 0
+}
+
+// getGatingGroupMemberStatus returns the status of the given component in the gating group, or nil if it's not a member.
+func getGatingGroupMemberStatus(groupStatus *v1beta2.GatingGroupStatus, component string) *v1beta2.GatingGroupMemberStatus {
+	if groupStatus == nil {
+		return nil
+	}
+	for i := range groupStatus.Members {
+		if groupStatus.Members[i].Component == component {
+			return &groupStatus.Members[i]
+		}
+	}
+	return nil
+}
+
+// updateGatingGroupOutstandingMembers recalculates the members of the gating group which don't have a passing Snapshot.
+func updateGatingGroupOutstandingMembers(groupStatus *v1beta2.GatingGroupStatus) {
+	groupStatus.Outstanding = nil
+	for _, member := range groupStatus.Members {
+		if member.Snapshot == "" {
+			groupStatus.Outstanding = append(groupStatus.Outstanding, member.Component)
+		}
+	}
+}
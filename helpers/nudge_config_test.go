@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/helpers"
+)
+
+var _ = Describe("Helpers for NudgeConfig status", func() {
+
+	var nudgeConfig *v1beta2.NudgeConfig
+
+	BeforeEach(func() {
+		nudgeConfig = &v1beta2.NudgeConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       v1beta2.NudgeConfigSingletonName,
+				Namespace:  "default",
+				Generation: 1,
+			},
+		}
+	})
+
+	It("marks the NudgeConfig as valid and records the validation time", func() {
+		helpers.SetNudgeConfigStatusAsValid(nudgeConfig, "nudge graph is valid")
+		Expect(helpers.IsNudgeConfigValid(nudgeConfig)).To(BeTrue())
+		Expect(nudgeConfig.Status.LastValidationTime).NotTo(BeNil())
+	})
+
+	It("marks the NudgeConfig as invalid with the given reason", func() {
+		helpers.SetNudgeConfigStatusAsInvalid(nudgeConfig, helpers.NudgeConfigCycleDetectedReason, "cycle detected")
+		Expect(helpers.IsNudgeConfigValid(nudgeConfig)).To(BeFalse())
+		Expect(nudgeConfig.Status.LastValidationTime).To(BeNil())
+		condition := meta.FindStatusCondition(nudgeConfig.Status.Conditions, helpers.NudgeConfigValidCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal(helpers.NudgeConfigCycleDetectedReason))
+	})
+
+	It("doesn't consider the NudgeConfig valid when its spec changed after validation", func() {
+		helpers.SetNudgeConfigStatusAsValid(nudgeConfig, "nudge graph is valid")
+		nudgeConfig.Generation = 2
+		Expect(helpers.IsNudgeConfigValid(nudgeConfig)).To(BeFalse())
+	})
+
+	Context("When the nudges use gating groups", func() {
+		BeforeEach(func() {
+			nudgeConfig.Spec.Nudges = []v1beta2.NudgeRelationship{
+				{From: "comp-b", To: "comp-c", GatingGroup: "group-1"},
+				{From: "comp-a", To: "comp-c", GatingGroup: "group-1"},
+				{From: "comp-a", To: "comp-d", GatingGroup: "group-1"},
+				{From: "comp-a", To: "comp-e"},
+			}
+		})
+
+		It("returns the sorted members of each gating group", func() {
+			Expect(helpers.GetNudgeGatingGroupMembers(nudgeConfig)).To(Equal(map[string][]string{
+				"group-1": {"comp-a", "comp-b"},
+			}))
+		})
+
+		It("aligns the status with the gating groups while keeping satisfied members", func() {
+			helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+			groupStatus := helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1")
+			Expect(groupStatus).NotTo(BeNil())
+			Expect(groupStatus.Outstanding).To(Equal([]string{"comp-a", "comp-b"}))
+
+			helpers.SetNudgeGatingGroupMemberSnapshot(nudgeConfig, "group-1", "comp-a", "snapshot-a")
+			nudgeConfig.Spec.Nudges = append(nudgeConfig.Spec.Nudges, v1beta2.NudgeRelationship{From: "comp-f", To: "comp-c", GatingGroup: "group-1"})
+			helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+
+			groupStatus = helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1")
+			Expect(groupStatus.Members).To(HaveLen(3))
+			Expect(groupStatus.Members[0].Snapshot).To(Equal("snapshot-a"))
+			Expect(groupStatus.Outstanding).To(Equal([]string{"comp-b", "comp-f"}))
+		})
+
+		It("considers the gating group satisfied only once every member has a passing Snapshot", func() {
+			helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+			helpers.SetNudgeGatingGroupMemberSnapshot(nudgeConfig, "group-1", "comp-a", "snapshot-a")
+			Expect(helpers.IsNudgeGatingGroupSatisfied(helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1"))).To(BeFalse())
+
+			helpers.SetNudgeGatingGroupMemberSnapshot(nudgeConfig, "group-1", "comp-b", "snapshot-b")
+			Expect(helpers.IsNudgeGatingGroupSatisfied(helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1"))).To(BeTrue())
+
+			helpers.SetNudgeGatingGroupMemberSnapshot(nudgeConfig, "group-1", "comp-b", "")
+			Expect(helpers.IsNudgeGatingGroupSatisfied(helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1"))).To(BeFalse())
+			Expect(helpers.GetNudgeGatingGroupStatus(nudgeConfig, "group-1").Outstanding).To(Equal([]string{"comp-b"}))
+		})
+
+		It("removes gating groups which are no longer referenced", func() {
+			helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+			nudgeConfig.Spec.Nudges = nudgeConfig.Spec.Nudges[3:]
+			helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+			Expect(nudgeConfig.Status.GatingGroups).To(BeEmpty())
+		})
+	})
+})
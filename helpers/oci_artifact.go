@@ -0,0 +1,290 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/konflux-ci/integration-service/pkg/common"
+)
+
+const (
+	// maxArtifactManifestSize is the maximum size of the manifest of a fetched OCI artifact
+	maxArtifactManifestSize = 1 << 20
+
+	// maxArtifactBlobSize is the maximum size of the layer of a fetched OCI artifact
+	maxArtifactBlobSize = 10 << 20
+
+	// artifactRequestTimeout is the timeout of a single request sent to a registry, the artifacts are fetched
+	// while reconciling the integration PipelineRun
+	artifactRequestTimeout = 5 * time.Second
+)
+
+// manifestMediaTypes are the manifest media types accepted when fetching an OCI artifact
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// artifactManifest is the subset of an OCI image manifest needed to fetch the layer of an artifact
+type artifactManifest struct {
+	MediaType string               `json:"mediaType"`
+	Layers    []artifactDescriptor `json:"layers"`
+}
+
+// artifactDescriptor is the descriptor of a layer of an OCI artifact
+type artifactDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+var artifactHTTPClient = &http.Client{Timeout: artifactRequestTimeout}
+
+// FetchJUnitArtifact fetches the JUnit XML results stored in the OCI artifact with the given reference.
+// The layer with a JUnit or XML media type is preferred, the first layer is used otherwise.
+// The registry is accessed with the given credentials, or anonymously when they are nil.
+func FetchJUnitArtifact(ctx context.Context, reference string, credentials *RegistryCredentials) ([]byte, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact reference %s: %w", reference, err)
+	}
+	registryURL := fmt.Sprintf("%s://%s", ref.Context().Registry.Scheme(), ref.Context().RegistryStr())
+	repository := ref.Context().RepositoryStr()
+	fetcher := &artifactFetcher{
+		registryScheme: ref.Context().Registry.Scheme(),
+		registryHost:   ref.Context().RegistryStr(),
+		scope:          fmt.Sprintf("repository:%s:pull", repository),
+		credentials:    credentials,
+	}
+
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", registryURL, repository, ref.Identifier())
+	manifestBody, err := fetcher.get(ctx, manifestURL, strings.Join(manifestMediaTypes, ","), maxArtifactManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest of artifact %s: %w", reference, err)
+	}
+	manifest := artifactManifest{}
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of artifact %s: %w", reference, err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("artifact %s has no layers", reference)
+	}
+
+	layer := manifest.Layers[0]
+	for _, candidate := range manifest.Layers {
+		if strings.Contains(candidate.MediaType, "junit") || strings.Contains(candidate.MediaType, "xml") {
+			layer = candidate
+			break
+		}
+	}
+	if layer.Size > maxArtifactBlobSize {
+		return nil, fmt.Errorf("layer %s of artifact %s exceeds the maximum size of %d bytes", layer.Digest, reference, maxArtifactBlobSize)
+	}
+
+	blobURL := fmt.Sprintf("%s/v2/%s/blobs/%s", registryURL, repository, layer.Digest)
+	blob, err := fetcher.get(ctx, blobURL, "", maxArtifactBlobSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch layer %s of artifact %s: %w", layer.Digest, reference, err)
+	}
+	if err := verifyDigest(blob, layer.Digest); err != nil {
+		return nil, fmt.Errorf("layer of artifact %s: %w", reference, err)
+	}
+	return blob, nil
+}
+
+// artifactFetcher sends requests to a registry, authenticating with a bearer token or the basic credentials
+// when the registry requires it
+type artifactFetcher struct {
+	registryScheme string
+	registryHost   string
+	scope          string
+	credentials    *RegistryCredentials
+	token          string
+	basicAuth      bool
+}
+
+// get fetches the given registry URL, authenticating as the registry asks for if it responds with 401
+func (f *artifactFetcher) get(ctx context.Context, rawURL, accept string, maxSize int64) ([]byte, error) {
+	resp, err := f.do(ctx, rawURL, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && f.token == "" && !f.basicAuth {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err = f.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = f.do(ctx, rawURL, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry responded with status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("response exceeds the maximum size of %d bytes", maxSize)
+	}
+	return body, nil
+}
+
+func (f *artifactFetcher) do(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", common.IntegrationServiceUserAgent)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case f.token != "":
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	case f.basicAuth:
+		req.SetBasicAuth(f.credentials.Username, f.credentials.Password)
+	}
+	return artifactHTTPClient.Do(req)
+}
+
+// authenticate prepares the authentication of the next requests from the WWW-Authenticate challenge of the registry
+func (f *artifactFetcher) authenticate(ctx context.Context, challenge string) error {
+	scheme, _, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	if strings.EqualFold(scheme, "Basic") && f.credentials != nil {
+		f.basicAuth = true
+		return nil
+	}
+	token, err := f.getToken(ctx, challenge)
+	if err != nil {
+		return err
+	}
+	f.token = token
+	return nil
+}
+
+// getToken obtains a pull token from the token endpoint advertised by the Bearer challenge, with the credentials
+// of the fetcher or anonymously. The token endpoint must be served by the registry host, so the credentials are
+// never sent to another host and the registry can't make the controller send requests to arbitrary URLs.
+func (f *artifactFetcher) getToken(ctx context.Context, challenge string) (string, error) {
+	params := parseBearerChallenge(challenge)
+	if params["realm"] == "" {
+		return "", fmt.Errorf("registry requires authentication which is not supported: %q", challenge)
+	}
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %s: %w", params["realm"], err)
+	}
+	if tokenURL.Host != f.registryHost || (tokenURL.Scheme != "https" && tokenURL.Scheme != f.registryScheme) {
+		return "", fmt.Errorf("token realm %s is not served by the registry %s", params["realm"], f.registryHost)
+	}
+	query := tokenURL.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = f.scope
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", common.IntegrationServiceUserAgent)
+	if f.credentials != nil {
+		req.SetBasicAuth(f.credentials.Username, f.credentials.Password)
+	}
+	resp, err := artifactHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to obtain registry token, token endpoint responded with status %d", resp.StatusCode)
+	}
+
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxArtifactManifestSize)).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", fmt.Errorf("token endpoint returned no registry token")
+}
+
+// parseBearerChallenge returns the parameters of a WWW-Authenticate Bearer challenge,
+// e.g. Bearer realm="https://quay.io/v2/auth",service="quay.io",scope="repository:org/repo:pull"
+func parseBearerChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	scheme, rest, found := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return params
+	}
+	for rest != "" {
+		var key, value string
+		key, rest, found = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if !found {
+			break
+		}
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return params
+}
+
+// verifyDigest checks that the content matches the given sha256 digest
+func verifyDigest(content []byte, digest string) error {
+	algorithm, expected, found := strings.Cut(digest, ":")
+	if !found || algorithm != "sha256" {
+		return fmt.Errorf("unsupported digest %s", digest)
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != expected {
+		return fmt.Errorf("content doesn't match digest %s", digest)
+	}
+	return nil
+}
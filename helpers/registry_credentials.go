@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegistryCredentials are the credentials used to pull from a registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// dockerConfigJSON is the content of the .dockerconfigjson key of a kubernetes.io/dockerconfigjson Secret
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+// dockerConfigAuth is the entry of a registry in a dockerconfigjson Secret
+type dockerConfigAuth struct {
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// GetRegistryCredentials returns the credentials for the repository of the given image reference from the pull
+// secrets of the service account in the namespace. The entry of the most specific registry or repository path is
+// used, nil is returned when none of the pull secrets has credentials for the repository.
+func GetRegistryCredentials(ctx context.Context, cl client.Client, namespace, serviceAccountName, reference string) (*RegistryCredentials, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %s: %w", reference, err)
+	}
+	repository := ref.Context().RegistryStr() + "/" + ref.Context().RepositoryStr()
+
+	serviceAccount := &v1.ServiceAccount{}
+	err = cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: serviceAccountName}, serviceAccount)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get service account %s: %w", serviceAccountName, err)
+	}
+	secretNames := []string{}
+	for _, secret := range serviceAccount.ImagePullSecrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+	for _, secret := range serviceAccount.Secrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+
+	var credentials *RegistryCredentials
+	matchedPath := ""
+	for _, secretName := range secretNames {
+		secret := &v1.Secret{}
+		err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get pull secret %s: %w", secretName, err)
+		}
+		if secret.Type != v1.SecretTypeDockerConfigJson {
+			continue
+		}
+		config := dockerConfigJSON{}
+		if err := json.Unmarshal(secret.Data[v1.DockerConfigJsonKey], &config); err != nil {
+			continue
+		}
+		for registryPath, auth := range config.Auths {
+			registryPath = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registryPath, "https://"), "http://"), "/")
+			if len(registryPath) <= len(matchedPath) || (repository != registryPath && !strings.HasPrefix(repository, registryPath+"/")) {
+				continue
+			}
+			if authCredentials := auth.credentials(); authCredentials != nil {
+				credentials, matchedPath = authCredentials, registryPath
+			}
+		}
+	}
+	return credentials, nil
+}
+
+// credentials returns the credentials of the entry, from either the auth field or the username and password ones
+func (a dockerConfigAuth) credentials() *RegistryCredentials {
+	if a.Username != "" && a.Password != "" {
+		return &RegistryCredentials{Username: a.Username, Password: a.Password}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+	if err != nil {
+		return nil
+	}
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found || username == "" {
+		return nil
+	}
+	return &RegistryCredentials{Username: username, Password: password}
+}
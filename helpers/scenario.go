@@ -17,6 +17,8 @@ limitations under the License.
 package helpers
 
 import (
+	"time"
+
 	"github.com/konflux-ci/operator-toolkit/metadata"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,23 +41,37 @@ const (
 // SetScenarioIntegrationStatusAsInvalid sets the IntegrationTestScenarioValid status condition for the Scenario to invalid.
 func SetScenarioIntegrationStatusAsInvalid(scenario *v1beta2.IntegrationTestScenario, message string) {
 	meta.SetStatusCondition(&scenario.Status.Conditions, metav1.Condition{
-		Type:    IntegrationTestScenarioValid,
-		Status:  metav1.ConditionFalse,
-		Reason:  AppStudioIntegrationStatusInvalid,
-		Message: message,
+		Type:               IntegrationTestScenarioValid,
+		Status:             metav1.ConditionFalse,
+		Reason:             AppStudioIntegrationStatusInvalid,
+		Message:            message,
+		ObservedGeneration: scenario.Generation,
 	})
 }
 
 // SetScenarioIntegrationStatusAsValid sets the IntegrationTestScenarioValid integration status condition for the Scenario to valid.
 func SetScenarioIntegrationStatusAsValid(scenario *v1beta2.IntegrationTestScenario, message string) {
 	meta.SetStatusCondition(&scenario.Status.Conditions, metav1.Condition{
-		Type:    IntegrationTestScenarioValid,
-		Status:  metav1.ConditionTrue,
-		Reason:  AppStudioIntegrationStatusValid,
-		Message: message,
+		Type:               IntegrationTestScenarioValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             AppStudioIntegrationStatusValid,
+		Message:            message,
+		ObservedGeneration: scenario.Generation,
 	})
 }
 
+// IsScenarioValidForCurrentGeneration returns true if the Scenario has been marked as valid for its current generation.
+func IsScenarioValidForCurrentGeneration(scenario *v1beta2.IntegrationTestScenario) bool {
+	condition := meta.FindStatusCondition(scenario.Status.Conditions, IntegrationTestScenarioValid)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == scenario.Generation
+}
+
 func IsIntegrationTestScenarioOptional(scenario *v1beta2.IntegrationTestScenario) bool {
 	return metadata.HasLabelWithValue(scenario, "test.appstudio.openshift.io/optional", "true")
 }
+
+// IsIntegrationTestScenarioQuarantined returns true if the Scenario is currently quarantined. A quarantined Scenario still
+// runs and is reported, but it isn't required for the Snapshot to pass until its quarantine expires.
+func IsIntegrationTestScenarioQuarantined(scenario *v1beta2.IntegrationTestScenario) bool {
+	return scenario.IsQuarantined(time.Now())
+}
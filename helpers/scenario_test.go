@@ -17,12 +17,15 @@ limitations under the License.
 package helpers_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/helpers"
 )
 
 var _ = Describe("Gitops functions for managing Snapshots", Ordered, func() {
@@ -69,4 +72,32 @@ var _ = Describe("Gitops functions for managing Snapshots", Ordered, func() {
 		err := k8sClient.Delete(ctx, integrationTestScenario)
 		Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
 	})
+
+	It("can tell whether the scenario is valid for its current generation", func() {
+		scenario := integrationTestScenario.DeepCopy()
+		Expect(helpers.IsScenarioValidForCurrentGeneration(scenario)).To(BeFalse())
+
+		helpers.SetScenarioIntegrationStatusAsInvalid(scenario, "pipeline can't be resolved")
+		Expect(helpers.IsScenarioValidForCurrentGeneration(scenario)).To(BeFalse())
+
+		helpers.SetScenarioIntegrationStatusAsValid(scenario, "valid")
+		Expect(helpers.IsScenarioValidForCurrentGeneration(scenario)).To(BeTrue())
+
+		scenario.Generation++
+		Expect(helpers.IsScenarioValidForCurrentGeneration(scenario)).To(BeFalse())
+	})
+
+	It("can tell whether the scenario is quarantined", func() {
+		scenario := integrationTestScenario.DeepCopy()
+		Expect(helpers.IsIntegrationTestScenarioQuarantined(scenario)).To(BeFalse())
+
+		scenario.Spec.Quarantine = &v1beta2.Quarantine{
+			Reason:    "flaky test",
+			ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+		}
+		Expect(helpers.IsIntegrationTestScenarioQuarantined(scenario)).To(BeTrue())
+
+		scenario.Spec.Quarantine.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Minute))
+		Expect(helpers.IsIntegrationTestScenarioQuarantined(scenario)).To(BeFalse())
+	})
 })
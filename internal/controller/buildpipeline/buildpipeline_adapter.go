@@ -30,6 +30,7 @@ import (
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/cloudevents"
 	"github.com/konflux-ci/integration-service/gitops"
 	h "github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
@@ -43,6 +44,8 @@ import (
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -58,6 +61,7 @@ type Adapter struct {
 	client          client.Client
 	context         context.Context
 	status          status.StatusInterface
+	cloudEvents     *cloudevents.Emitter
 }
 
 // NewAdapterWithApplication creates and returns an Adapter instance when the component belongs to an Application
@@ -74,6 +78,7 @@ func NewAdapterWithApplication(context context.Context, pipelineRun *tektonv1.Pi
 		client:          client,
 		context:         context,
 		status:          status.NewStatus(logger.Logger, client),
+		cloudEvents:     cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -91,6 +96,7 @@ func NewAdapter(context context.Context, pipelineRun *tektonv1.PipelineRun, comp
 		client:          client,
 		context:         context,
 		status:          status.NewStatus(logger.Logger, client),
+		cloudEvents:     cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -105,10 +111,11 @@ func (a *Adapter) EnsureGlobalCandidateImageUpdated() (controller.OperationResul
 
 	// TODO: remove branch when we remove old application-specific code
 	var err error
+	queued := false
 	if a.application != nil {
 		err = a.updateGCLForBuildPLR()
 	} else { // ComponentGroup behavior
-		err = snapshot.UpdateGCLForBuildPLR(a.context, a.client, a.loader, a.componentGroups, a.pipelineRun, a.component.Name)
+		queued, err = snapshot.UpdateGCLForBuildPLR(a.context, a.client, a.loader, a.componentGroups, a.pipelineRun, a.component.Name)
 	}
 	if err != nil {
 		// TODO: remove HandleLoaderError when we remove application-specific code
@@ -122,8 +129,20 @@ func (a *Adapter) EnsureGlobalCandidateImageUpdated() (controller.OperationResul
 			Reason:          fmt.Sprintf("Failed to set Global Candidate List for component %s due to error %s", a.component.Name, err.Error()),
 			LastUpdatedTime: time.Now().Format(time.RFC3339),
 		}
+	} else if queued {
+		// the promotion is applied by the ComponentGroup controller when the freeze lifts
+		a.logger.Info("Global Candidate List is frozen, the promotion of the component has been queued", "component.Namespace", a.component.Namespace, "component.Name", a.component.Name)
+		addedToGlobalCandidateListStatus = gitops.AddedToGlobalCandidateListStatus{
+			Result:          true,
+			Reason:          "The promotion was queued because the Global Candidate List is frozen",
+			LastUpdatedTime: time.Now().Format(time.RFC3339),
+		}
 	} else {
 		a.logger.Info("Global Candidate List has been updated for component", "component.Namespace", a.component.Namespace, "component.Name", a.component.Name)
+		event := cloudevents.NewEvent(cloudevents.AddedToGlobalCandidateListEventType, a.component.Namespace, a.component.Name)
+		event.Data.Component = a.component.Name
+		event.Data.PipelineRun = a.pipelineRun.Name
+		a.cloudEvents.Emit(a.context, event)
 		addedToGlobalCandidateListStatus = gitops.AddedToGlobalCandidateListStatus{
 			Result:          true,
 			Reason:          gitops.Success,
@@ -218,12 +237,41 @@ func (a *Adapter) EnsureSnapshotExists() (result controller.OperationResult, err
 		return a.checkForOneSnapshotPerComponentGroup(&canRemoveFinalizer, existingSnapshots)
 	}
 
+	// Prepare the snapshots for all ComponentGroups before creating any of them, so that the build pipelineRun
+	// isn't annotated with a snapshot while the snapshot creator of another ComponentGroup is still running
+	expectedSnapshots := make([]*applicationapiv1alpha1.Snapshot, 0, len(*a.componentGroups))
+	snapshotCreatorsFinished := true
 	for _, componentGroup := range *a.componentGroups {
+		componentGroup := componentGroup //G601
 		expectedSnapshot, err := snapshot.PrepareSnapshotForPipelineRun(a.context, a.client, a.pipelineRun, a.component.Name, &componentGroup)
 		if err != nil {
 			return a.updatePipelineRunWithCustomizedError(&canRemoveFinalizer, err, a.context, a.pipelineRun, a.client, a.logger)
 		}
 
+		if componentGroup.Spec.SnapshotCreator != nil && componentGroup.Spec.SnapshotCreator.TaskRef != nil {
+			createdSnapshotSpec, err := a.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, &componentGroup)
+			if err != nil {
+				return a.updatePipelineRunWithCustomizedError(&canRemoveFinalizer, err, a.context, a.pipelineRun, a.client, a.logger)
+			}
+			if createdSnapshotSpec == nil {
+				snapshotCreatorsFinished = false
+				continue
+			}
+			createdSnapshotSpec.ComponentGroup = componentGroup.Name
+			expectedSnapshot.Spec = *createdSnapshotSpec
+		}
+
+		expectedSnapshots = append(expectedSnapshots, expectedSnapshot)
+	}
+
+	if !snapshotCreatorsFinished {
+		a.logger.Info("Waiting for the snapshot creator TaskRuns to finish before creating the Snapshots")
+		return controller.ContinueProcessing()
+	}
+
+	for i, expectedSnapshot := range expectedSnapshots {
+		componentGroup := (*a.componentGroups)[i]
+
 		// Try to create snapshot, retry with suffix on collision
 		err = snapshot.CreateSnapshotWithCollisionHandling(a.context, a.client, a.pipelineRun, expectedSnapshot, componentGroup, a.logger)
 		if err != nil {
@@ -234,6 +282,7 @@ func (a *Adapter) EnsureSnapshotExists() (result controller.OperationResult, err
 		a.logger.LogAuditEvent("Created new Snapshot", expectedSnapshot, h.LogActionAdd,
 			"snapshot.Name", expectedSnapshot.Name,
 			"snapshot.Spec.Components", expectedSnapshot.Spec.Components)
+		a.cloudEvents.Emit(a.context, cloudevents.NewSnapshotEvent(cloudevents.SnapshotCreatedEventType, expectedSnapshot))
 
 		err = a.annotateBuildPipelineRunWithSnapshot(expectedSnapshot)
 		if err != nil {
@@ -335,6 +384,7 @@ func (a *Adapter) EnsureSnapshotExistsApplication() (result controller.Operation
 	a.logger.LogAuditEvent("Created new Snapshot", expectedSnapshot, h.LogActionAdd,
 		"snapshot.Name", expectedSnapshot.Name,
 		"snapshot.Spec.Components", expectedSnapshot.Spec.Components)
+	a.cloudEvents.Emit(a.context, cloudevents.NewSnapshotEvent(cloudevents.SnapshotCreatedEventType, expectedSnapshot))
 
 	err = a.annotateBuildPipelineRunWithSnapshot(expectedSnapshot)
 	if err != nil {
@@ -688,6 +738,7 @@ func (a *Adapter) EnsureSupercededSnapshotsCanceled() (result controller.Operati
 
 	// Mark snapshots as canceled
 	for _, snapshot := range *snapshots {
+		wasCanceled := gitops.IsSnapshotMarkedAsCanceled(&snapshot)
 		err := retry.OnError(retry.DefaultRetry, func(e error) bool { return true }, func() error {
 			var e error
 			if !gitops.HaveAppStudioTestsFinished(&snapshot) && !gitops.IgnoreSupersession(snapshot.ObjectMeta) {
@@ -702,6 +753,11 @@ func (a *Adapter) EnsureSupercededSnapshotsCanceled() (result controller.Operati
 		})
 		if err != nil {
 			a.logger.Error(err, fmt.Sprintf("Could not cancel test pipelines for snapshot %s", snapshot.Name))
+		} else if !wasCanceled {
+			event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotCanceledEventType, &snapshot)
+			event.Data.PipelineRun = a.pipelineRun.Name
+			event.Data.Message = "Canceled - Superseded by new build"
+			a.cloudEvents.Emit(a.context, event)
 		}
 	}
 	return controller.ContinueProcessing()
@@ -1094,7 +1150,8 @@ func (a *Adapter) handleSnapshotCreationFailure(canRemoveFinalizer *bool, cerr e
 // updates build PipelineRun annotation with this error and exits
 func (a *Adapter) updatePipelineRunWithCustomizedError(canRemoveFinalizer *bool, cerr error, context context.Context, pipelineRun *tektonv1.PipelineRun, client client.Client, logger h.IntegrationLogger) (result controller.OperationResult, err error) {
 	// If PipelineRun result returns cusomized error update PLR annotation and exit
-	if h.IsMissingInfoInPipelineRunError(cerr) || h.IsInvalidImageDigestError(cerr) || h.IsMissingValidComponentError(cerr) {
+	if h.IsMissingInfoInPipelineRunError(cerr) || h.IsInvalidImageDigestError(cerr) || h.IsMissingValidComponentError(cerr) ||
+		h.IsSnapshotCreatorError(cerr) {
 		// update the build PLR annotation with the error cusomized Reason and Value
 		if annotateErr := tekton.AnnotateBuildPipelineRunWithCreateSnapshotAnnotation(context, pipelineRun, client, cerr); annotateErr != nil {
 			logger.Error(annotateErr, "Could not add create snapshot annotation to build pipelineRun", h.CreateSnapshotAnnotationName, pipelineRun)
@@ -1107,6 +1164,76 @@ func (a *Adapter) updatePipelineRunWithCustomizedError(canRemoveFinalizer *bool,
 
 }
 
+// getSnapshotSpecFromSnapshotCreator runs the snapshot creator Task of the given ComponentGroup for the build
+// pipelineRun and returns the validated Snapshot spec emitted by it. A nil spec without an error is returned while
+// the snapshot creator TaskRun is being created or is still running, the TaskRun watch will trigger a new reconcile
+// once it finishes.
+func (a *Adapter) getSnapshotSpecFromSnapshotCreator(expectedSnapshot *applicationapiv1alpha1.Snapshot, componentGroup *v1beta2.ComponentGroup) (*applicationapiv1alpha1.SnapshotSpec, error) {
+	taskRuns, err := a.loader.GetSnapshotCreatorTaskRuns(a.context, a.client, a.pipelineRun, componentGroup)
+	if err != nil {
+		a.logger.Error(err, "Failed to get the snapshot creator TaskRuns for the build pipelineRun",
+			"componentGroup.Name", componentGroup.Name)
+		return nil, err
+	}
+
+	if len(*taskRuns) == 0 {
+		taskRun := tekton.NewSnapshotCreatorTaskRun(a.pipelineRun, componentGroup).
+			WithSnapshotCreatorParams(a.pipelineRun, componentGroup, expectedSnapshot).
+			AsTaskRun()
+		err = ctrl.SetControllerReference(componentGroup, taskRun, a.client.Scheme())
+		if err != nil {
+			a.logger.Error(err, "Failed to set the ComponentGroup as the owner of the snapshot creator TaskRun",
+				"componentGroup.Name", componentGroup.Name)
+			return nil, err
+		}
+		err = a.client.Create(a.context, taskRun)
+		if err != nil {
+			a.logger.Error(err, "Failed to create the snapshot creator TaskRun",
+				"componentGroup.Name", componentGroup.Name)
+			return nil, err
+		}
+		a.logger.LogAuditEvent("Created snapshot creator TaskRun", taskRun, h.LogActionAdd,
+			"componentGroup.Name", componentGroup.Name,
+			"taskRun.Name", taskRun.Name)
+		return nil, nil
+	}
+
+	// Only one TaskRun is expected, pick the most recent one in case a retried reconcile created another
+	taskRun := &(*taskRuns)[0]
+	for i := range *taskRuns {
+		if taskRun.CreationTimestamp.Before(&(*taskRuns)[i].CreationTimestamp) {
+			taskRun = &(*taskRuns)[i]
+		}
+	}
+
+	if !taskRun.IsDone() {
+		a.logger.Info("The snapshot creator TaskRun hasn't finished yet",
+			"componentGroup.Name", componentGroup.Name,
+			"taskRun.Name", taskRun.Name)
+		return nil, nil
+	}
+
+	if !taskRun.IsSuccessful() {
+		message := fmt.Sprintf("taskRun %s didn't succeed", taskRun.Name)
+		if condition := taskRun.Status.GetCondition(apis.ConditionSucceeded); condition != nil && condition.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, condition.Message)
+		}
+		return nil, h.NewSnapshotCreatorError(componentGroup.Name, message)
+	}
+
+	snapshotSpec, err := tekton.GetSnapshotSpecFromSnapshotCreatorTaskRun(taskRun)
+	if err != nil {
+		return nil, h.NewSnapshotCreatorError(componentGroup.Name, err.Error())
+	}
+
+	err = snapshot.ValidateCreatedSnapshotComponents(a.context, snapshotSpec)
+	if err != nil {
+		return nil, h.NewSnapshotCreatorError(componentGroup.Name, err.Error())
+	}
+
+	return snapshotSpec, nil
+}
+
 // addPRGroupToBuildPLRMetadata will add pr-group info gotten from souce-branch to annotation
 // and also the string in sha format to metadata label
 func (a *Adapter) addPRGroupToBuildPLRMetadata(pipelineRun *tektonv1.PipelineRun) (*tektonv1.PipelineRun, error) {
@@ -1374,6 +1374,169 @@ var _ = Describe("Pipeline Adapter", Ordered, func() {
 		})
 	})
 
+	When("the ComponentGroup defines a snapshot creator", func() {
+		var (
+			snapshotCreatorCompGroup *v1beta2.ComponentGroup
+			expectedSnapshot         *applicationapiv1alpha1.Snapshot
+			snapshotCreatorTaskRun   *tektonv1.TaskRun
+		)
+
+		BeforeEach(func() {
+			snapshotCreatorCompGroup = hasCompGroup.DeepCopy()
+			snapshotCreatorCompGroup.Spec.SnapshotCreator = &v1beta2.SnapshotCreatorSpec{
+				TaskRef: &v1beta2.TaskRef{
+					Resolver: "git",
+					Params: []v1beta2.ResolverParameter{
+						{Name: "url", Value: "https://github.com/konflux-ci/integration-examples"},
+						{Name: "revision", Value: "main"},
+						{Name: "pathInRepo", Value: "tasks/create-snapshot.yaml"},
+					},
+				},
+			}
+
+			expectedSnapshot = &applicationapiv1alpha1.Snapshot{
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					ComponentGroup: snapshotCreatorCompGroup.Name,
+					Components: []applicationapiv1alpha1.SnapshotComponent{
+						{
+							Name:           hasComp.Name,
+							Version:        "v1",
+							ContainerImage: SampleImage,
+							Source: applicationapiv1alpha1.ComponentSource{
+								ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+									GitSource: &applicationapiv1alpha1.GitSource{
+										URL:      SampleRepoLink,
+										Revision: SampleCommit,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			snapshotCreatorTaskRun = tekton.NewSnapshotCreatorTaskRun(buildPipelineRun, snapshotCreatorCompGroup).AsTaskRun()
+			snapshotCreatorTaskRun.Name = "component-group-sample-snapshot-creator-sample"
+			snapshotCreatorTaskRun.CreationTimestamp = metav1.Now()
+
+			adapter = NewAdapter(ctx, buildPipelineRun, hasComp, &[]v1beta2.ComponentGroup{*snapshotCreatorCompGroup}, logger, loader.NewMockLoader(), k8sClient)
+		})
+
+		It("creates the snapshot creator TaskRun and waits for it", func() {
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.SnapshotCreatorTaskRunsContextKey,
+					Resource:   []tektonv1.TaskRun{},
+				},
+			})
+
+			snapshotSpec, err := adapter.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, snapshotCreatorCompGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshotSpec).To(BeNil())
+
+			taskRuns := &tektonv1.TaskRunList{}
+			Expect(k8sClient.List(ctx, taskRuns, client.InNamespace(snapshotCreatorCompGroup.Namespace), client.MatchingLabels{
+				tektonconsts.PipelinesTypeLabel:        tektonconsts.TaskRunSnapshotCreatorType,
+				tektonconsts.BuildPipelineRunNameLabel: buildPipelineRun.Name,
+			})).To(Succeed())
+			Expect(taskRuns.Items).To(HaveLen(1))
+			Expect(taskRuns.Items[0].Spec.Params).To(HaveLen(3))
+			Expect(metav1.IsControlledBy(&taskRuns.Items[0], snapshotCreatorCompGroup)).To(BeTrue())
+			Expect(k8sClient.Delete(ctx, &taskRuns.Items[0])).To(Succeed())
+		})
+
+		It("waits while the snapshot creator TaskRun is running", func() {
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.SnapshotCreatorTaskRunsContextKey,
+					Resource:   []tektonv1.TaskRun{*snapshotCreatorTaskRun},
+				},
+			})
+
+			snapshotSpec, err := adapter.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, snapshotCreatorCompGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshotSpec).To(BeNil())
+		})
+
+		It("returns a snapshot creator error when the TaskRun failed", func() {
+			snapshotCreatorTaskRun.Status.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  "False",
+				Message: "step create-snapshot failed",
+			})
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.SnapshotCreatorTaskRunsContextKey,
+					Resource:   []tektonv1.TaskRun{*snapshotCreatorTaskRun},
+				},
+			})
+
+			snapshotSpec, err := adapter.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, snapshotCreatorCompGroup)
+			Expect(snapshotSpec).To(BeNil())
+			Expect(helpers.IsSnapshotCreatorError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("step create-snapshot failed"))
+		})
+
+		It("returns a snapshot creator error when the emitted spec is invalid", func() {
+			snapshotCreatorTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: "True",
+			})
+			snapshotCreatorTaskRun.Status.Results = []tektonv1.TaskRunResult{
+				{Name: "SNAPSHOT", Value: *tektonv1.NewStructuredValues(`{"components": []}`)},
+			}
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.SnapshotCreatorTaskRunsContextKey,
+					Resource:   []tektonv1.TaskRun{*snapshotCreatorTaskRun},
+				},
+			})
+
+			snapshotSpec, err := adapter.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, snapshotCreatorCompGroup)
+			Expect(snapshotSpec).To(BeNil())
+			Expect(helpers.IsSnapshotCreatorError(err)).To(BeTrue())
+		})
+
+		It("returns the Snapshot spec emitted by the succeeded TaskRun, including extra components", func() {
+			emittedSpec := expectedSnapshot.Spec.DeepCopy()
+			extraComponent := emittedSpec.Components[0]
+			extraComponent.Name = "injected-component"
+			emittedSpec.Components = append(emittedSpec.Components, extraComponent)
+			emittedSpecJSON, err := json.Marshal(emittedSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			snapshotCreatorTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: "True",
+			})
+			snapshotCreatorTaskRun.Status.Results = []tektonv1.TaskRunResult{
+				{Name: "SNAPSHOT", Value: *tektonv1.NewStructuredValues(string(emittedSpecJSON))},
+			}
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.SnapshotCreatorTaskRunsContextKey,
+					Resource:   []tektonv1.TaskRun{*snapshotCreatorTaskRun},
+				},
+			})
+
+			snapshotSpec, err := adapter.getSnapshotSpecFromSnapshotCreator(expectedSnapshot, snapshotCreatorCompGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshotSpec).NotTo(BeNil())
+			Expect(snapshotSpec.Components).To(HaveLen(2))
+			Expect(snapshotSpec.Components[1].Name).To(Equal("injected-component"))
+		})
+
+		It("annotates the build pipelineRun and stops processing on snapshot creator errors", func() {
+			var canRemoveFinalizer bool
+			result, err := adapter.updatePipelineRunWithCustomizedError(&canRemoveFinalizer,
+				helpers.NewSnapshotCreatorError(snapshotCreatorCompGroup.Name, "taskRun failed"),
+				adapter.context, adapter.pipelineRun, adapter.client, adapter.logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.CancelRequest).To(BeTrue())
+			Expect(canRemoveFinalizer).To(BeTrue())
+		})
+	})
+
 	When("Snapshot already exists", func() {
 		It("ensures snapshot creation is skipped when snapshot already exists", func() {
 			var buf bytes.Buffer
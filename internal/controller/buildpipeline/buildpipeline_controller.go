@@ -33,9 +33,13 @@ import (
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // Reconciler reconciles a build PipelineRun object
@@ -57,12 +61,13 @@ func NewIntegrationReconciler(client client.Client, logger *logr.Logger, scheme
 //+kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns/finalizers,verbs=update
-//+kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tekton.dev,resources=taskruns,verbs=get;list;watch;create
 //+kubebuilder:rbac:groups=tekton.dev,resources=taskruns/status,verbs=get
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications/finalizers,verbs=update
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications,verbs=get;list;watch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 //+kubebuilder:rbac:groups=pipelinesascode.tekton.dev,resources=repositories,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -200,14 +205,35 @@ func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) er
 	}
 
 	return ctrl.NewControllerManagedBy(manager).
-		For(&tektonv1.PipelineRun{}).
-		Named("buildpipelinerun").
-		WithEventFilter(predicate.Or(
+		For(&tektonv1.PipelineRun{}, builder.WithPredicates(predicate.Or(
 			tekton.BuildPipelineRunSignedAndSucceededPredicate(),
 			tekton.BuildPipelineRunGroupInfoAddedPredicate(),
 			tekton.BuildPipelineRunFailedPredicate(),
 			tekton.BuildPipelineRunCreatedPredicate(),
 			tekton.BuildPipelineRunDeletingPredicate(),
-		)).
+		))).
+		Watches(
+			&tektonv1.TaskRun{},
+			handler.EnqueueRequestsFromMapFunc(mapSnapshotCreatorTaskRunToBuildPipelineRun),
+			builder.WithPredicates(tekton.SnapshotCreatorTaskRunFinishedPredicate()),
+		).
+		Named("buildpipelinerun").
 		Complete(controller)
 }
+
+// mapSnapshotCreatorTaskRunToBuildPipelineRun maps a snapshot creator TaskRun to the build PipelineRun it was created for.
+func mapSnapshotCreatorTaskRunToBuildPipelineRun(ctx context.Context, taskRun client.Object) []reconcile.Request {
+	buildPipelineRunName, found := taskRun.GetLabels()[tektonconsts.BuildPipelineRunNameLabel]
+	if !found {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: taskRun.GetNamespace(),
+				Name:      buildPipelineRunName,
+			},
+		},
+	}
+}
@@ -18,11 +18,19 @@ package componentgroup
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/cloudevents"
 	h "github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/snapshot"
 	"github.com/konflux-ci/operator-toolkit/controller"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,6 +42,7 @@ type Adapter struct {
 	loader         loader.ObjectLoader
 	client         client.Client
 	context        context.Context
+	cloudEvents    *cloudevents.Emitter
 }
 
 // NewAdapter creates and returns an Adapter instance.
@@ -50,6 +59,7 @@ func NewAdapter(
 		loader:         loader,
 		client:         client,
 		context:        ctx,
+		cloudEvents:    cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -88,9 +98,11 @@ func (a *Adapter) alignGCLWithSpecComponents(mostRecentComponentGroup *v1beta2.C
 	}
 
 	var newGCL []v1beta2.ComponentState
+	specComponents := make(map[string]bool)
 
 	for _, component := range mostRecentComponentGroup.Spec.Components {
 		componentVersion := h.GetComponentVersionString(component.Name, component.ComponentVersion.Name)
+		specComponents[componentVersion] = true
 		if existingComponent, ok := gclComponents[componentVersion]; ok {
 			newGCL = append(newGCL, existingComponent)
 		} else {
@@ -106,6 +118,175 @@ func (a *Adapter) alignGCLWithSpecComponents(mostRecentComponentGroup *v1beta2.C
 	}
 
 	mostRecentComponentGroup.Status.GlobalCandidateList = newGCL
+
+	// the promotion history of the removed components isn't kept either
+	mostRecentComponentGroup.Status.PromotionHistory = slices.DeleteFunc(mostRecentComponentGroup.Status.PromotionHistory, func(history v1beta2.ComponentPromotionHistory) bool {
+		_, ok := specComponents[h.GetComponentVersionString(history.Name, history.Version)]
+		return !ok
+	})
+	mostRecentComponentGroup.Status.QueuedPromotions = slices.DeleteFunc(mostRecentComponentGroup.Status.QueuedPromotions, func(promotion v1beta2.QueuedPromotion) bool {
+		_, ok := specComponents[h.GetComponentVersionString(promotion.Entry.Name, promotion.Entry.Version)]
+		return !ok
+	})
+
 	err := a.client.Status().Patch(a.context, mostRecentComponentGroup, patch)
 	return err
 }
+
+// EnsureGCLRollbackProcessed ensures that the rollback of a Global Candidate List entry requested by the
+// GCLRollbackAnnotation annotation is applied once. The request is removed before the rollback is applied,
+// the result of the rollback is reported in the GCLRollbackCondition condition.
+func (a *Adapter) EnsureGCLRollbackProcessed() (controller.OperationResult, error) {
+	componentVersion, ok := a.componentGroup.GetAnnotations()[v1beta2.GCLRollbackAnnotation]
+	if !ok {
+		return controller.ContinueProcessing()
+	}
+	reason := a.componentGroup.GetAnnotations()[v1beta2.GCLRollbackReasonAnnotation]
+
+	// the optimistic lock ensures that the same request isn't processed twice
+	patch := client.MergeFromWithOptions(a.componentGroup.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	delete(a.componentGroup.Annotations, v1beta2.GCLRollbackAnnotation)
+	delete(a.componentGroup.Annotations, v1beta2.GCLRollbackReasonAnnotation)
+	if err := a.client.Patch(a.context, a.componentGroup, patch); err != nil {
+		a.logger.Error(err, "Failed to remove the Global Candidate List rollback request from the ComponentGroup")
+		return controller.RequeueWithError(err)
+	}
+
+	name, version, _ := strings.Cut(componentVersion, "/")
+	var restored *v1beta2.PromotionRecord
+	var rollbackErr error
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cg, err := a.loader.GetComponentGroup(a.context, a.client, a.componentGroup.Name, a.componentGroup.Namespace)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(cg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		restored, rollbackErr = snapshot.RollbackGCLEntry(cg, name, version, reason)
+		condition := metav1.Condition{
+			Type:    v1beta2.GCLRollbackCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Succeeded",
+			Message: fmt.Sprintf("Rolled back ComponentVersion %s", componentVersion),
+		}
+		if rollbackErr != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Failed"
+			condition.Message = fmt.Sprintf("Failed to roll back ComponentVersion %s: %s", componentVersion, rollbackErr.Error())
+		} else {
+			condition.Message = fmt.Sprintf("%s to %s", condition.Message, restored.Image)
+		}
+		meta.SetStatusCondition(&cg.Status.Conditions, condition)
+		return a.client.Status().Patch(a.context, cg, patch)
+	})
+	if err != nil {
+		a.logger.Error(err, "Failed to roll back the Global Candidate List entry, the rollback has to be requested again",
+			"componentVersion", componentVersion)
+		return controller.RequeueWithError(err)
+	}
+	if rollbackErr != nil {
+		a.logger.Error(rollbackErr, "Rejected the Global Candidate List rollback request", "componentVersion", componentVersion)
+		return controller.ContinueProcessing()
+	}
+
+	a.logger.LogAuditEvent("Rolled back the Global Candidate List entry of the ComponentVersion", a.componentGroup, h.LogActionUpdate,
+		"componentVersion", componentVersion, "image", restored.Image, "commit", restored.Commit, "reason", reason)
+	return controller.ContinueProcessing()
+}
+
+// EnsureQueuedPromotionsProcessed ensures that the promotions queued while the Global Candidate List was frozen are
+// applied, or discarded when the freeze requests it, once the freeze of their Component lifts. The freeze is reported
+// in the GCLFrozenCondition condition and the ComponentGroup is reconciled again when the freeze ends.
+func (a *Adapter) EnsureQueuedPromotionsProcessed() (controller.OperationResult, error) {
+	if a.componentGroup.Spec.Freeze == nil && len(a.componentGroup.Status.QueuedPromotions) == 0 &&
+		meta.FindStatusCondition(a.componentGroup.Status.Conditions, v1beta2.GCLFrozenCondition) == nil {
+		return controller.ContinueProcessing()
+	}
+
+	now := time.Now()
+	var until *metav1.Time
+	var applied, discarded []v1beta2.QueuedPromotion
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cg, err := a.loader.GetComponentGroup(a.context, a.client, a.componentGroup.Name, a.componentGroup.Namespace)
+		if err != nil {
+			return err
+		}
+		until = nil
+		if cg.Spec.Freeze != nil {
+			until = cg.Spec.Freeze.Until
+		}
+		patch := client.MergeFromWithOptions(cg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		applied, discarded = snapshot.ProcessQueuedPromotions(cg, now)
+		conditionChanged := meta.SetStatusCondition(&cg.Status.Conditions, newGCLFrozenCondition(cg, now))
+		if len(applied) == 0 && len(discarded) == 0 && !conditionChanged {
+			return nil
+		}
+		return a.client.Status().Patch(a.context, cg, patch)
+	})
+	if err != nil {
+		a.logger.Error(err, "Failed to process the promotions queued while the Global Candidate List was frozen")
+		return controller.RequeueWithError(err)
+	}
+
+	for _, promotion := range applied {
+		a.logger.LogAuditEvent("Applied the queued promotion to the Global Candidate List", a.componentGroup, h.LogActionUpdate,
+			"componentVersion", h.GetComponentVersionString(promotion.Entry.Name, promotion.Entry.Version),
+			"image", promotion.Entry.LastPromotedImage, "snapshot", promotion.Snapshot, "buildPipelineRun", promotion.BuildPipelineRun)
+		a.cloudEvents.Emit(a.context, newQueuedPromotionEvent(a.componentGroup, promotion))
+	}
+	for _, promotion := range discarded {
+		a.logger.LogAuditEvent("Discarded the queued promotion to the Global Candidate List", a.componentGroup, h.LogActionUpdate,
+			"componentVersion", h.GetComponentVersionString(promotion.Entry.Name, promotion.Entry.Version),
+			"image", promotion.Entry.LastPromotedImage, "snapshot", promotion.Snapshot, "buildPipelineRun", promotion.BuildPipelineRun)
+	}
+
+	if until != nil && now.Before(until.Time) {
+		return controller.RequeueAfter(until.Sub(now), nil)
+	}
+	return controller.ContinueProcessing()
+}
+
+// newQueuedPromotionEvent returns the event reporting that the given queued promotion was applied to the
+// Global Candidate List of the componentGroup, as the promotion would have been reported without the freeze
+func newQueuedPromotionEvent(componentGroup *v1beta2.ComponentGroup, promotion v1beta2.QueuedPromotion) *cloudevents.Event {
+	subject := promotion.Snapshot
+	if subject == "" {
+		subject = promotion.Entry.Name
+	}
+	event := cloudevents.NewEvent(cloudevents.AddedToGlobalCandidateListEventType, componentGroup.Namespace, subject)
+	event.Data.ComponentGroup = componentGroup.Name
+	event.Data.Component = promotion.Entry.Name
+	event.Data.Snapshot = promotion.Snapshot
+	event.Data.PipelineRun = promotion.BuildPipelineRun
+	return event
+}
+
+// newGCLFrozenCondition returns the GCLFrozenCondition condition reporting the freeze of the Global Candidate List
+// of the given componentGroup at the given time
+func newGCLFrozenCondition(componentGroup *v1beta2.ComponentGroup, now time.Time) metav1.Condition {
+	freeze := componentGroup.Spec.Freeze
+	if freeze == nil || (freeze.Until != nil && !now.Before(freeze.Until.Time)) {
+		return metav1.Condition{
+			Type:    v1beta2.GCLFrozenCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NotFrozen",
+			Message: "The Global Candidate List is not frozen",
+		}
+	}
+
+	message := "The Global Candidate List of all the Components is frozen"
+	if len(freeze.Components) > 0 {
+		message = fmt.Sprintf("The Global Candidate List of the Components %s is frozen", strings.Join(freeze.Components, ", "))
+	}
+	if freeze.Until != nil {
+		message = fmt.Sprintf("%s until %s", message, freeze.Until.UTC().Format(time.RFC3339))
+	}
+	if freeze.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, freeze.Reason)
+	}
+	return metav1.Condition{
+		Type:    v1beta2.GCLFrozenCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Frozen",
+		Message: message,
+	}
+}
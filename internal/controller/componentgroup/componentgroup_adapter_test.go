@@ -26,10 +26,12 @@ import (
 	"github.com/tonglil/buflogr"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/cloudevents"
 	"github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -200,4 +202,173 @@ var _ = Describe("ComponentGroup Adapter", Ordered, func() {
 			Expect(result.RequeueRequest).To(BeFalse())
 		})
 	})
+
+	When("EnsureGCLRollbackProcessed is called with a rollback request", func() {
+		const previousImage = "quay.io/example/image@sha256:000111222333"
+
+		BeforeEach(func() {
+			setGCL([]v1beta2.ComponentState{
+				{Name: "comp-a", Version: "main", LastPromotedImage: SampleImage},
+				{Name: "comp-b", Version: "v1"},
+			})
+			cg := fetchFreshCG()
+			cg.Status.PromotionHistory = []v1beta2.ComponentPromotionHistory{
+				{
+					Name:    "comp-a",
+					Version: "main",
+					Promotions: []v1beta2.PromotionRecord{
+						{Image: SampleImage, Snapshot: "snapshot-new", PromotionTime: metav1.Now()},
+						{Image: previousImage, Snapshot: "snapshot-old", PromotionTime: metav1.Now()},
+					},
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, cg)).To(Succeed())
+
+			cg = fetchFreshCG()
+			patch := client.MergeFrom(cg.DeepCopy())
+			cg.Annotations = map[string]string{
+				v1beta2.GCLRollbackAnnotation:       "comp-a/main",
+				v1beta2.GCLRollbackReasonAnnotation: "the new image is broken",
+			}
+			Expect(k8sClient.Patch(ctx, cg, patch)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(fetchFreshCG().Annotations).To(HaveKey(v1beta2.GCLRollbackAnnotation))
+				g.Expect(fetchFreshCG().Status.PromotionHistory).To(HaveLen(1))
+			}, time.Second*10).Should(Succeed())
+			adapter = NewAdapter(ctx, fetchFreshCG(), logger, loader.NewLoader(), k8sClient)
+		})
+
+		It("rolls the GCL entry back to its previous promotion once", func() {
+			result, err := adapter.EnsureGCLRollbackProcessed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.CancelRequest).To(BeFalse())
+			Expect(result.RequeueRequest).To(BeFalse())
+
+			Eventually(func(g Gomega) {
+				updated := fetchFreshCG()
+				g.Expect(updated.Annotations).NotTo(HaveKey(v1beta2.GCLRollbackAnnotation))
+				g.Expect(updated.Annotations).NotTo(HaveKey(v1beta2.GCLRollbackReasonAnnotation))
+				g.Expect(updated.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal(previousImage))
+
+				promotions := updated.Status.PromotionHistory[0].Promotions
+				g.Expect(promotions).To(HaveLen(3))
+				g.Expect(promotions[0].Image).To(Equal(previousImage))
+				g.Expect(promotions[0].RolledBack).To(BeTrue())
+				g.Expect(promotions[0].Reason).To(Equal("the new image is broken"))
+
+				condition := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.GCLRollbackCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			}, time.Second*10).Should(Succeed())
+
+			// the request was removed, so it isn't applied again
+			result, err = adapter.EnsureGCLRollbackProcessed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeFalse())
+			Expect(fetchFreshCG().Status.PromotionHistory[0].Promotions).To(HaveLen(3))
+		})
+	})
+
+	When("EnsureQueuedPromotionsProcessed is called for a frozen ComponentGroup", func() {
+		const queuedImage = "quay.io/example/image@sha256:444555666777"
+
+		BeforeEach(func() {
+			setGCL([]v1beta2.ComponentState{
+				{Name: "comp-a", Version: "main", LastPromotedImage: SampleImage},
+				{Name: "comp-b", Version: "v1"},
+			})
+			cg := fetchFreshCG()
+			cg.Status.QueuedPromotions = []v1beta2.QueuedPromotion{
+				{
+					Entry:      v1beta2.ComponentState{Name: "comp-a", Version: "main", LastPromotedImage: queuedImage},
+					Snapshot:   "snapshot-queued",
+					QueuedTime: metav1.Now(),
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, cg)).To(Succeed())
+
+			cg = fetchFreshCG()
+			patch := client.MergeFrom(cg.DeepCopy())
+			cg.Spec.Freeze = &v1beta2.GCLFreeze{
+				Components: []string{"comp-a"},
+				Until:      &metav1.Time{Time: time.Now().Add(time.Hour)},
+				Reason:     "release stabilization",
+			}
+			Expect(k8sClient.Patch(ctx, cg, patch)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(fetchFreshCG().Spec.Freeze).NotTo(BeNil())
+				g.Expect(fetchFreshCG().Status.QueuedPromotions).To(HaveLen(1))
+			}, time.Second*10).Should(Succeed())
+			adapter = NewAdapter(ctx, fetchFreshCG(), logger, loader.NewLoader(), k8sClient)
+		})
+
+		AfterEach(func() {
+			cg := fetchFreshCG()
+			patch := client.MergeFrom(cg.DeepCopy())
+			cg.Spec.Freeze = nil
+			Expect(k8sClient.Patch(ctx, cg, patch)).To(Succeed())
+		})
+
+		It("keeps the queued promotions until the freeze lifts and applies them then", func() {
+			result, err := adapter.EnsureQueuedPromotionsProcessed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(result.RequeueDelay).To(BeNumerically("~", time.Hour, time.Minute))
+
+			Eventually(func(g Gomega) {
+				updated := fetchFreshCG()
+				g.Expect(updated.Status.QueuedPromotions).To(HaveLen(1))
+				g.Expect(updated.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal(SampleImage))
+				condition := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.GCLFrozenCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(condition.Message).To(ContainSubstring("release stabilization"))
+			}, time.Second*10).Should(Succeed())
+
+			// lift the freeze
+			cg := fetchFreshCG()
+			patch := client.MergeFrom(cg.DeepCopy())
+			cg.Spec.Freeze.Until = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(k8sClient.Patch(ctx, cg, patch)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(fetchFreshCG().Spec.Freeze.Until.Time).To(BeTemporally("<", time.Now()))
+			}, time.Second*10).Should(Succeed())
+			adapter = NewAdapter(ctx, fetchFreshCG(), logger, loader.NewLoader(), k8sClient)
+
+			result, err = adapter.EnsureQueuedPromotionsProcessed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeFalse())
+
+			Eventually(func(g Gomega) {
+				updated := fetchFreshCG()
+				g.Expect(updated.Status.QueuedPromotions).To(BeEmpty())
+				g.Expect(updated.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal(queuedImage))
+				condition := meta.FindStatusCondition(updated.Status.Conditions, v1beta2.GCLFrozenCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			}, time.Second*10).Should(Succeed())
+		})
+
+		It("reports the applied queued promotions as added to the Global Candidate List", func() {
+			cg := fetchFreshCG()
+			event := newQueuedPromotionEvent(cg, v1beta2.QueuedPromotion{
+				Entry:            v1beta2.ComponentState{Name: "comp-a", Version: "main", LastPromotedImage: queuedImage},
+				BuildPipelineRun: "build-plr",
+			})
+			Expect(event.Type).To(Equal(cloudevents.AddedToGlobalCandidateListEventType))
+			Expect(event.Subject).To(Equal("comp-a"))
+			Expect(event.Data.Namespace).To(Equal(cg.Namespace))
+			Expect(event.Data.ComponentGroup).To(Equal(cg.Name))
+			Expect(event.Data.Component).To(Equal("comp-a"))
+			Expect(event.Data.PipelineRun).To(Equal("build-plr"))
+
+			event = newQueuedPromotionEvent(cg, v1beta2.QueuedPromotion{
+				Entry:    v1beta2.ComponentState{Name: "comp-a", Version: "main", LastPromotedImage: queuedImage},
+				Snapshot: "override-snapshot",
+			})
+			Expect(event.Subject).To(Equal("override-snapshot"))
+			Expect(event.Data.Snapshot).To(Equal("override-snapshot"))
+			Expect(event.Data.PipelineRun).To(BeEmpty())
+		})
+	})
 })
@@ -52,6 +52,7 @@ func NewComponentGroupReconciler(client client.Client, logger *logr.Logger, sche
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -73,12 +74,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	return controller.ReconcileHandler([]controller.Operation{
 		adapter.EnsureGCLAlignedWithSpecComponents,
+		adapter.EnsureGCLRollbackProcessed,
+		adapter.EnsureQueuedPromotionsProcessed,
 	})
 }
 
 // AdapterInterface is an interface defining all the operations that should be defined in a ComponentGroup adapter.
 type AdapterInterface interface {
 	EnsureGCLAlignedWithSpecComponents() (controller.OperationResult, error)
+	EnsureGCLRollbackProcessed() (controller.OperationResult, error)
+	EnsureQueuedPromotionsProcessed() (controller.OperationResult, error)
 }
 
 // SetupController creates a new ComponentGroup controller and adds it to the Manager.
@@ -87,7 +92,8 @@ func SetupController(manager ctrl.Manager, log *logr.Logger) error {
 }
 
 // setupControllerWithManager sets up the controller with the Manager which monitors ComponentGroups
-// and filters events to only create events and spec.components updates.
+// and filters events to only create events, spec.components updates, Global Candidate List rollback requests and
+// spec.freeze updates.
 func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) error {
 	return ctrl.NewControllerManagedBy(manager).
 		For(&v1beta2.ComponentGroup{}).
@@ -95,6 +101,8 @@ func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) er
 		WithEventFilter(predicate.Or(
 			componentGroupCreatedPredicate(),
 			componentGroupSpecComponentsChangedPredicate(),
+			componentGroupGCLRollbackRequestedPredicate(),
+			componentGroupSpecFreezeChangedPredicate(),
 		)).
 		Complete(controller)
 }
@@ -140,3 +148,51 @@ func componentGroupSpecComponentsChangedPredicate() predicate.Predicate {
 		},
 	}
 }
+
+// componentGroupGCLRollbackRequestedPredicate returns a predicate that passes only when
+// the rollback of a Global Candidate List entry is requested on an update event.
+func componentGroupGCLRollbackRequestedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			newRequest, ok := e.ObjectNew.GetAnnotations()[v1beta2.GCLRollbackAnnotation]
+			if !ok {
+				return false
+			}
+			oldRequest, ok := e.ObjectOld.GetAnnotations()[v1beta2.GCLRollbackAnnotation]
+			return !ok || oldRequest != newRequest
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// componentGroupSpecFreezeChangedPredicate returns a predicate that passes only when
+// spec.freeze has changed on an update event.
+func componentGroupSpecFreezeChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCG, ok1 := e.ObjectOld.(*v1beta2.ComponentGroup)
+			newCG, ok2 := e.ObjectNew.(*v1beta2.ComponentGroup)
+			if !ok1 || !ok2 {
+				return false
+			}
+			return !reflect.DeepEqual(oldCG.Spec.Freeze, newCG.Spec.Freeze)
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/gitops"
 	h "github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
@@ -30,7 +31,9 @@ import (
 
 	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
 	"github.com/konflux-ci/operator-toolkit/controller"
+	"github.com/konflux-ci/operator-toolkit/metadata"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -75,9 +78,21 @@ func (a *Adapter) EnsureStatusReportedInSnapshot() (controller.OperationResult,
 	var detail string
 	var err error
 
+	scenarioName := a.pipelineRun.Labels[tektonconsts.ScenarioNameLabel]
+	scenario, err := a.getScenarioForRetry()
+	if err != nil {
+		a.logger.Error(err, "Failed to get the IntegrationTestScenario of the integration PipelineRun", "scenario.Name", scenarioName)
+		return controller.RequeueWithError(err)
+	}
+
+	// the JUnit results are collected outside of the retry loop, fetching them from a registry may be slow
+	testCaseResults := a.getJUnitTestCaseResults()
+
+	retried := false
 	// pipelines run in parallel and have great potential to cause conflict on update
 	// thus `RetryOnConflict` is easy solution here, given the snapshot must be loaded specifically here
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		retried = false
 
 		a.snapshot, err = a.loader.GetSnapshotFromPipelineRun(a.context, a.client, a.pipelineRun)
 		if err != nil {
@@ -93,9 +108,30 @@ func (a *Adapter) EnsureStatusReportedInSnapshot() (controller.OperationResult,
 		if err != nil {
 			return err
 		}
-		statuses.UpdateTestStatusIfChanged(a.pipelineRun.Labels[tektonconsts.ScenarioNameLabel], pipelinerunStatus, detail)
-		if err = statuses.UpdateTestPipelineRunName(a.pipelineRun.Labels[tektonconsts.ScenarioNameLabel], a.pipelineRun.Name); err != nil {
-			return err
+
+		// the outcome of a pipelineRun which was already retried is recorded in the previous attempts of the scenario
+		if testDetail, ok := statuses.GetScenarioStatus(scenarioName); ok && testDetail.IsPreviousAttempt(a.pipelineRun.Name) {
+			a.logger.Info("Integration PipelineRun belongs to a previous attempt of the IntegrationTestScenario, skipping the status update",
+				"pipelineRun.Name", a.pipelineRun.Name, "scenario.Name", scenarioName)
+			retried = true
+			return nil
+		}
+
+		if a.shouldRetryIntegrationPipelineRun(scenario, statuses, pipelinerunStatus) {
+			retried = true
+			if err = statuses.RetryTest(scenarioName, pipelinerunStatus, detail); err != nil {
+				return err
+			}
+		} else {
+			statuses.UpdateTestStatusIfChanged(scenarioName, pipelinerunStatus, detail)
+			if err = statuses.UpdateTestPipelineRunName(scenarioName, a.pipelineRun.Name); err != nil {
+				return err
+			}
+			if testCaseResults != nil {
+				if err = statuses.UpdateTestCaseResults(scenarioName, testCaseResults); err != nil {
+					return err
+				}
+			}
 		}
 
 		// don't return wrapped err for retries
@@ -107,6 +143,17 @@ func (a *Adapter) EnsureStatusReportedInSnapshot() (controller.OperationResult,
 		return controller.RequeueWithError(fmt.Errorf("failed to update test status in snapshot: %w", err))
 	}
 
+	// Remove the finalizer from retried Integration PLRs since their outcome is already recorded in the Snapshot
+	if retried {
+		a.logger.LogAuditEvent("Integration PipelineRun was retried by a new attempt of the IntegrationTestScenario", a.pipelineRun, h.LogActionUpdate,
+			"scenario.Name", scenarioName, "status", pipelinerunStatus.String())
+		err = h.RemoveFinalizerFromPipelineRun(a.context, a.client, a.logger, a.pipelineRun, h.IntegrationPipelineRunFinalizer)
+		if err != nil {
+			return controller.RequeueWithError(fmt.Errorf("failed to remove the finalizer: %w", err))
+		}
+		return controller.ContinueProcessing()
+	}
+
 	// Remove the finalizer from Integration PLRs if the snapshot is not group or component type and its PLR has finished
 	if (!gitops.IsGroupSnapshot(a.snapshot) && !gitops.IsComponentSnapshot(a.snapshot)) && (h.HasPipelineRunFinished(a.pipelineRun) ||
 		pipelinerunStatus == intgteststat.IntegrationTestStatusDeleted) {
@@ -119,6 +166,102 @@ func (a *Adapter) EnsureStatusReportedInSnapshot() (controller.OperationResult,
 	return controller.ContinueProcessing()
 }
 
+// getScenarioForRetry returns the IntegrationTestScenario of the integration PipelineRun when the PipelineRun has
+// finished or is being deleted, so that its retry policy can be evaluated. Nil is returned when the PipelineRun
+// is still running or its IntegrationTestScenario doesn't exist anymore.
+func (a *Adapter) getScenarioForRetry() (*v1beta2.IntegrationTestScenario, error) {
+	if !h.HasPipelineRunFinished(a.pipelineRun) && a.pipelineRun.GetDeletionTimestamp() == nil {
+		return nil, nil
+	}
+
+	scenarioName, ok := a.pipelineRun.Labels[tektonconsts.ScenarioNameLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	scenario, err := a.loader.GetScenario(a.context, a.client, scenarioName, a.pipelineRun.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// getJUnitTestCaseResults returns the test case results published as JUnit XML by the tasks of the finished
+// integration PipelineRun, either inline or as an OCI artifact. Nil is returned when the PipelineRun hasn't finished
+// or none of its tasks published JUnit results. Errors are only logged since the test case results are informative.
+func (a *Adapter) getJUnitTestCaseResults() *h.TestCaseResults {
+	if !h.HasPipelineRunFinished(a.pipelineRun) {
+		return nil
+	}
+
+	taskRuns, err := a.loader.GetAllTaskRunsWithMatchingPipelineRunLabel(a.context, a.client, a.pipelineRun)
+	if err != nil {
+		a.logger.Error(err, "Failed to get the TaskRuns of the integration PipelineRun, JUnit results will not be collected",
+			"pipelineRun.Name", a.pipelineRun.Name)
+		return nil
+	}
+	if taskRuns == nil {
+		return nil
+	}
+
+	// the artifacts are pulled with the pull secrets of the service account the PipelineRun runs with
+	serviceAccountName := a.pipelineRun.Spec.TaskRunTemplate.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = tektonconsts.DefaultIntegrationPipelineServiceAccount
+	}
+	fetchArtifact := func(ctx context.Context, reference string) ([]byte, error) {
+		credentials, err := h.GetRegistryCredentials(ctx, a.client, a.pipelineRun.Namespace, serviceAccountName, reference)
+		if err != nil {
+			return nil, err
+		}
+		return h.FetchJUnitArtifact(ctx, reference, credentials)
+	}
+
+	testCaseResults, err := h.GetJUnitTestCaseResults(a.context, *taskRuns, fetchArtifact)
+	if err != nil {
+		a.logger.Error(err, "Failed to collect some of the JUnit results of the integration PipelineRun",
+			"pipelineRun.Name", a.pipelineRun.Name)
+	}
+	return testCaseResults
+}
+
+// shouldRetryIntegrationPipelineRun returns true if the retry policy of the IntegrationTestScenario retries
+// the given outcome of the integration PipelineRun and there are attempts left for the scenario. The maximum
+// number of attempts is recorded in the scenario's test status in both cases. PipelineRuns of a canceled Snapshot, or
+// cancelled because their Snapshot was superseded, are never retried.
+func (a *Adapter) shouldRetryIntegrationPipelineRun(scenario *v1beta2.IntegrationTestScenario, statuses *intgteststat.SnapshotIntegrationTestStatuses, pipelinerunStatus intgteststat.IntegrationTestStatus) bool {
+	if scenario == nil {
+		return false
+	}
+
+	if gitops.IsSnapshotMarkedAsCanceled(a.snapshot) || metadata.HasAnnotation(a.pipelineRun, gitops.PRGroupCancelledAnnotation) {
+		return false
+	}
+
+	testDetail, ok := statuses.GetScenarioStatus(scenario.Name)
+	if !ok || testDetail.TestPipelineRunName != a.pipelineRun.Name {
+		return false
+	}
+	statuses.SetTestMaxAttempts(scenario.Name, scenario.GetMaxAttempts())
+
+	var outcome v1beta2.RetryOutcome
+	switch pipelinerunStatus {
+	case intgteststat.IntegrationTestStatusTestFail:
+		outcome = v1beta2.RetryOnTestFail
+	case intgteststat.IntegrationTestStatusTestInvalid:
+		outcome = v1beta2.RetryOnInvalid
+	case intgteststat.IntegrationTestStatusDeleted:
+		outcome = v1beta2.RetryOnDeleted
+	default:
+		return false
+	}
+
+	return scenario.ShouldRetryOn(outcome) && testDetail.HasAttemptsLeft()
+}
+
 // EnsureIntegrationPipelineRunLogURL ensures that the integration pipeline run log URL is annotated if available.
 func (a *Adapter) EnsureIntegrationPipelineRunLogURL() (controller.OperationResult, error) {
 	// var err error
@@ -573,6 +573,131 @@ var _ = Describe("Pipeline Adapter", Ordered, func() {
 				Expect(detail.TestPipelineRunName).To(Equal(integrationPipelineRunComponentFailed.Name))
 
 			})
+
+			It("ensures a new attempt is scheduled when the retry policy of the scenario retries failed tests", func() {
+				controllerutil.AddFinalizer(integrationPipelineRunComponentFailed, "test.appstudio.openshift.io/pipelinerun")
+				statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				statuses.UpdateTestStatusIfChanged(integrationTestScenarioFailed.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenarioFailed.Name, integrationPipelineRunComponentFailed.Name)).To(Succeed())
+				Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasSnapshot, statuses, k8sClient)).To(Succeed())
+
+				retriedScenario := integrationTestScenarioFailed.DeepCopy()
+				retriedScenario.Spec.RetryPolicy = &v1beta2.RetryPolicy{MaxAttempts: 2}
+				adapter.context = toolkit.GetMockedContext(adapter.context, []toolkit.MockData{
+					{
+						ContextKey: loader.GetScenarioContextKey,
+						Resource:   retriedScenario,
+					},
+				})
+
+				result, err := adapter.EnsureStatusReportedInSnapshot()
+				Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+				statuses, err = gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenarioFailed.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusPending))
+				Expect(detail.TestPipelineRunName).To(BeEmpty())
+				Expect(detail.GetAttempt()).To(Equal(2))
+				Expect(detail.MaxAttempts).To(Equal(2))
+				Expect(detail.IsPreviousAttempt(integrationPipelineRunComponentFailed.Name)).To(BeTrue())
+				Expect(detail.PreviousAttempts[0].Status).To(Equal(intgteststat.IntegrationTestStatusTestFail))
+				Expect(controllerutil.ContainsFinalizer(integrationPipelineRunComponentFailed, "test.appstudio.openshift.io/pipelinerun")).To(BeFalse())
+			})
+
+			It("ensures the tests of a superseded Snapshot aren't retried", func() {
+				controllerutil.AddFinalizer(integrationPipelineRunComponentFailed, "test.appstudio.openshift.io/pipelinerun")
+				statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				statuses.UpdateTestStatusIfChanged(integrationTestScenarioFailed.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenarioFailed.Name, integrationPipelineRunComponentFailed.Name)).To(Succeed())
+				Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasSnapshot, statuses, k8sClient)).To(Succeed())
+				Expect(gitops.MarkSnapshotAsCanceled(ctx, k8sClient, hasSnapshot, "superseded by a newer Snapshot")).To(Succeed())
+
+				retriedScenario := integrationTestScenarioFailed.DeepCopy()
+				retriedScenario.Spec.RetryPolicy = &v1beta2.RetryPolicy{MaxAttempts: 2}
+				adapter.context = toolkit.GetMockedContext(adapter.context, []toolkit.MockData{
+					{
+						ContextKey: loader.GetScenarioContextKey,
+						Resource:   retriedScenario,
+					},
+				})
+
+				result, err := adapter.EnsureStatusReportedInSnapshot()
+				Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+				statuses, err = gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenarioFailed.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestFail))
+				Expect(detail.TestPipelineRunName).To(Equal(integrationPipelineRunComponentFailed.Name))
+				Expect(detail.GetAttempt()).To(Equal(1))
+			})
+
+			It("ensures a PipelineRun cancelled for a newer Snapshot isn't retried", func() {
+				controllerutil.AddFinalizer(integrationPipelineRunComponentFailed, "test.appstudio.openshift.io/pipelinerun")
+				integrationPipelineRunComponentFailed.Annotations[gitops.PRGroupCancelledAnnotation] = "true"
+				statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				statuses.UpdateTestStatusIfChanged(integrationTestScenarioFailed.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenarioFailed.Name, integrationPipelineRunComponentFailed.Name)).To(Succeed())
+				Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasSnapshot, statuses, k8sClient)).To(Succeed())
+
+				retriedScenario := integrationTestScenarioFailed.DeepCopy()
+				retriedScenario.Spec.RetryPolicy = &v1beta2.RetryPolicy{MaxAttempts: 2}
+				adapter.context = toolkit.GetMockedContext(adapter.context, []toolkit.MockData{
+					{
+						ContextKey: loader.GetScenarioContextKey,
+						Resource:   retriedScenario,
+					},
+				})
+
+				result, err := adapter.EnsureStatusReportedInSnapshot()
+				Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+				statuses, err = gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenarioFailed.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).NotTo(Equal(intgteststat.IntegrationTestStatusPending))
+				Expect(detail.TestPipelineRunName).To(Equal(integrationPipelineRunComponentFailed.Name))
+			})
+
+			It("ensures the last attempt allowed by the retry policy of the scenario isn't retried", func() {
+				controllerutil.AddFinalizer(integrationPipelineRunComponentFailed, "test.appstudio.openshift.io/pipelinerun")
+				statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				statuses.UpdateTestStatusIfChanged(integrationTestScenarioFailed.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenarioFailed.Name, integrationPipelineRunComponentFailed.Name)).To(Succeed())
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenarioFailed.Name)
+				Expect(ok).To(BeTrue())
+				detail.Attempt = 2
+				Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasSnapshot, statuses, k8sClient)).To(Succeed())
+
+				retriedScenario := integrationTestScenarioFailed.DeepCopy()
+				retriedScenario.Spec.RetryPolicy = &v1beta2.RetryPolicy{MaxAttempts: 2}
+				adapter.context = toolkit.GetMockedContext(adapter.context, []toolkit.MockData{
+					{
+						ContextKey: loader.GetScenarioContextKey,
+						Resource:   retriedScenario,
+					},
+				})
+
+				result, err := adapter.EnsureStatusReportedInSnapshot()
+				Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+				statuses, err = gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+				Expect(err).ToNot(HaveOccurred())
+				detail, ok = statuses.GetScenarioStatus(integrationTestScenarioFailed.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestFail))
+				Expect(detail.TestPipelineRunName).To(Equal(integrationPipelineRunComponentFailed.Name))
+				Expect(detail.GetAttempt()).To(Equal(2))
+				Expect(detail.MaxAttempts).To(Equal(2))
+			})
 		})
 
 	})
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	h "github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/pkg/dag"
+	"github.com/konflux-ci/operator-toolkit/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Adapter holds the objects needed to reconcile a NudgeConfig.
+type Adapter struct {
+	nudgeConfig *v1beta2.NudgeConfig
+	logger      h.IntegrationLogger
+	loader      loader.ObjectLoader
+	client      client.Client
+	context     context.Context
+}
+
+// NewAdapter creates and returns an Adapter instance.
+func NewAdapter(
+	ctx context.Context,
+	nudgeConfig *v1beta2.NudgeConfig,
+	logger h.IntegrationLogger,
+	loader loader.ObjectLoader,
+	client client.Client,
+) *Adapter {
+	return &Adapter{
+		nudgeConfig: nudgeConfig,
+		logger:      logger,
+		loader:      loader,
+		client:      client,
+		context:     ctx,
+	}
+}
+
+// EnsureNudgeGraphValidated is an operation that will ensure that the nudge graph of the NudgeConfig
+// doesn't contain any cycles and references only existing Components, and reflect the result
+// in the Valid condition of the NudgeConfig. The status of the gating groups is aligned with the nudges as well.
+func (a *Adapter) EnsureNudgeGraphValidated() (controller.OperationResult, error) {
+	// the gating groups status is also updated by the nudging of Snapshots, so avoid overwriting it
+	patch := client.MergeFromWithOptions(a.nudgeConfig.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	err := dag.ValidateNudgeGraph(a.nudgeConfig.Spec.Nudges)
+	if err != nil {
+		a.logger.Info("NudgeConfig contains a cycle", "error", err.Error())
+		h.SetNudgeConfigStatusAsInvalid(a.nudgeConfig, h.NudgeConfigCycleDetectedReason, err.Error())
+	} else {
+		unknownComponents, err := a.getUnknownComponents()
+		if err != nil {
+			a.logger.Error(err, "Failed to get Components for NudgeConfig validation")
+			return controller.RequeueWithError(err)
+		}
+
+		if len(unknownComponents) > 0 {
+			message := fmt.Sprintf("nudge graph references components which don't exist: %s", strings.Join(unknownComponents, ", "))
+			a.logger.Info("NudgeConfig references unknown components", "components", unknownComponents)
+			h.SetNudgeConfigStatusAsInvalid(a.nudgeConfig, h.NudgeConfigUnknownComponentsReason, message)
+		} else {
+			h.SetNudgeConfigStatusAsValid(a.nudgeConfig, "nudge graph is valid")
+		}
+	}
+
+	h.AlignNudgeConfigGatingGroups(a.nudgeConfig)
+
+	err = a.client.Status().Patch(a.context, a.nudgeConfig, patch)
+	if err != nil {
+		a.logger.Error(err, "Failed to update the status of the NudgeConfig")
+		return controller.RequeueWithError(err)
+	}
+
+	return controller.ContinueProcessing()
+}
+
+// getUnknownComponents returns the sorted names of the components referenced by the nudge graph
+// which don't exist in the namespace of the NudgeConfig.
+func (a *Adapter) getUnknownComponents() ([]string, error) {
+	components, err := a.loader.GetAllComponentsInNamespace(a.context, a.client, a.nudgeConfig.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	existingComponents := make(map[string]bool, len(*components))
+	for _, component := range *components {
+		existingComponents[component.Name] = true
+	}
+
+	var unknownComponents []string
+	for _, nudge := range a.nudgeConfig.Spec.Nudges {
+		for _, name := range []string{nudge.From, nudge.To} {
+			if !existingComponents[name] && !slices.Contains(unknownComponents, name) {
+				unknownComponents = append(unknownComponents, name)
+			}
+		}
+	}
+
+	slices.Sort(unknownComponents)
+	return unknownComponents, nil
+}
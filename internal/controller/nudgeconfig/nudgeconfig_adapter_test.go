@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"bytes"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tonglil/buflogr"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	toolkit "github.com/konflux-ci/operator-toolkit/loader"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NudgeConfig Adapter", Ordered, func() {
+	var (
+		adapter     *Adapter
+		logger      helpers.IntegrationLogger
+		nudgeConfig *v1beta2.NudgeConfig
+		components  []applicationapiv1alpha1.Component
+	)
+
+	// setNudges overwrites the nudges of the NudgeConfig on the cluster and returns its latest version.
+	setNudges := func(nudges []v1beta2.NudgeRelationship) *v1beta2.NudgeConfig {
+		fresh := &v1beta2.NudgeConfig{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nudgeConfig), fresh)).To(Succeed())
+		fresh.Spec.Nudges = nudges
+		Expect(k8sClient.Update(ctx, fresh)).To(Succeed())
+		return fresh
+	}
+
+	BeforeAll(func() {
+		logger = helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&bytes.Buffer{})}
+
+		components = []applicationapiv1alpha1.Component{
+			{ObjectMeta: metav1.ObjectMeta{Name: "comp-a", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "comp-b", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "comp-c", Namespace: "default"}},
+		}
+
+		nudgeConfig = &v1beta2.NudgeConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1beta2.NudgeConfigSingletonName,
+				Namespace: "default",
+			},
+		}
+		Expect(k8sClient.Create(ctx, nudgeConfig)).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		err := k8sClient.Delete(ctx, nudgeConfig)
+		Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("can create a new Adapter instance", func() {
+		Expect(reflect.TypeOf(NewAdapter(ctx, nudgeConfig, logger, loader.NewMockLoader(), k8sClient))).
+			To(Equal(reflect.TypeOf(&Adapter{})))
+	})
+
+	When("the nudge graph references only existing components", func() {
+		It("marks the NudgeConfig as valid", func() {
+			updated := setNudges([]v1beta2.NudgeRelationship{
+				{From: "comp-a", To: "comp-b", Mode: v1beta2.NudgeModeImmediate},
+				{From: "comp-b", To: "comp-c", Mode: v1beta2.NudgeModeValidated},
+			})
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.AllComponentsInNamespaceContextKey,
+					Resource:   components,
+				},
+			})
+			adapter = NewAdapter(mockContext, updated, logger, loader.NewMockLoader(), k8sClient)
+
+			result, err := adapter.EnsureNudgeGraphValidated()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			Eventually(func(g Gomega) {
+				fresh := &v1beta2.NudgeConfig{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nudgeConfig), fresh)).To(Succeed())
+				g.Expect(helpers.IsNudgeConfigValid(fresh)).To(BeTrue())
+				g.Expect(fresh.Status.LastValidationTime).NotTo(BeNil())
+			}, time.Second*10).Should(Succeed())
+		})
+	})
+
+	When("the nudge graph contains a cycle", func() {
+		It("marks the NudgeConfig as invalid", func() {
+			updated := setNudges([]v1beta2.NudgeRelationship{
+				{From: "comp-a", To: "comp-b"},
+				{From: "comp-b", To: "comp-c"},
+				{From: "comp-c", To: "comp-a"},
+			})
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.AllComponentsInNamespaceContextKey,
+					Resource:   components,
+				},
+			})
+			adapter = NewAdapter(mockContext, updated, logger, loader.NewMockLoader(), k8sClient)
+
+			result, err := adapter.EnsureNudgeGraphValidated()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			Eventually(func(g Gomega) {
+				fresh := &v1beta2.NudgeConfig{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nudgeConfig), fresh)).To(Succeed())
+				g.Expect(helpers.IsNudgeConfigValid(fresh)).To(BeFalse())
+				condition := meta.FindStatusCondition(fresh.Status.Conditions, helpers.NudgeConfigValidCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Reason).To(Equal(helpers.NudgeConfigCycleDetectedReason))
+			}, time.Second*10).Should(Succeed())
+		})
+	})
+
+	When("the nudge graph references components which don't exist", func() {
+		It("marks the NudgeConfig as invalid and lists the unknown components", func() {
+			updated := setNudges([]v1beta2.NudgeRelationship{
+				{From: "comp-a", To: "comp-missing"},
+				{From: "comp-unknown", To: "comp-b"},
+			})
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.AllComponentsInNamespaceContextKey,
+					Resource:   components,
+				},
+			})
+			adapter = NewAdapter(mockContext, updated, logger, loader.NewMockLoader(), k8sClient)
+
+			result, err := adapter.EnsureNudgeGraphValidated()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			Eventually(func(g Gomega) {
+				fresh := &v1beta2.NudgeConfig{}
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nudgeConfig), fresh)).To(Succeed())
+				condition := meta.FindStatusCondition(fresh.Status.Conditions, helpers.NudgeConfigValidCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(helpers.NudgeConfigUnknownComponentsReason))
+				g.Expect(condition.Message).To(ContainSubstring("comp-missing, comp-unknown"))
+			}, time.Second*10).Should(Succeed())
+		})
+	})
+})
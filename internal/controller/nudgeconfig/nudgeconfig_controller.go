@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/operator-toolkit/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reconciler reconciles a NudgeConfig object.
+type Reconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// NewNudgeConfigReconciler creates and returns a Reconciler.
+func NewNudgeConfigReconciler(client client.Client, logger *logr.Logger, scheme *runtime.Scheme) *Reconciler {
+	return &Reconciler{
+		Client: client,
+		Log:    logger.WithName("nudgeconfig"),
+		Scheme: scheme,
+	}
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=nudgeconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=nudgeconfigs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=components,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshots,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := helpers.IntegrationLogger{Logger: r.Log.WithValues("nudgeconfig", req.NamespacedName)}
+	loader := loader.NewLoader()
+
+	nudgeConfig := &v1beta2.NudgeConfig{}
+	err := r.Get(ctx, req.NamespacedName, nudgeConfig)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get NudgeConfig for", "req", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	adapter := NewAdapter(ctx, nudgeConfig, logger, loader, r.Client)
+
+	return controller.ReconcileHandler([]controller.Operation{
+		adapter.EnsureNudgeGraphValidated,
+	})
+}
+
+// AdapterInterface is an interface defining all the operations that should be defined in a NudgeConfig adapter.
+type AdapterInterface interface {
+	EnsureNudgeGraphValidated() (controller.OperationResult, error)
+}
+
+// SetupController creates the NudgeConfig controllers and adds them to the Manager. One controller validates
+// the NudgeConfig, the other one nudges the target components once the Snapshots of source components are ready.
+func SetupController(manager ctrl.Manager, log *logr.Logger) error {
+	err := setupControllerWithManager(manager, NewNudgeConfigReconciler(manager.GetClient(), log, manager.GetScheme()))
+	if err != nil {
+		return err
+	}
+
+	return setupSnapshotControllerWithManager(manager, NewSnapshotReconciler(manager.GetClient(), log, manager.GetScheme(),
+		manager.GetEventRecorderFor("nudgeconfig-controller")))
+}
+
+// setupControllerWithManager sets up the controller with the Manager which monitors NudgeConfigs and
+// the creation and deletion of Components, since those can change the result of the validation.
+func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) error {
+	return ctrl.NewControllerManagedBy(manager).
+		For(&v1beta2.NudgeConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&applicationapiv1alpha1.Component{},
+			handler.EnqueueRequestsFromMapFunc(mapComponentToNudgeConfig),
+			builder.WithPredicates(ComponentCreatedOrDeletedPredicate()),
+		).
+		Named("nudgeconfig").
+		Complete(controller)
+}
+
+// mapComponentToNudgeConfig maps a Component to the singleton NudgeConfig of its namespace.
+func mapComponentToNudgeConfig(ctx context.Context, component client.Object) []reconcile.Request {
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: component.GetNamespace(),
+				Name:      v1beta2.NudgeConfigSingletonName,
+			},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/gitops"
+	h "github.com/konflux-ci/integration-service/helpers"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NudgeSnapshotMaxAge is the maximum age of a Snapshot for its create event to trigger nudges.
+// Create events are also triggered for all existing Snapshots upon service re-sync, which must
+// not result in nudging components for old builds again.
+const NudgeSnapshotMaxAge = 1 * time.Hour
+
+// ComponentCreatedOrDeletedPredicate returns a predicate which passes only for Component
+// create and delete events.
+func ComponentCreatedOrDeletedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+	}
+}
+
+// NudgeSourceSnapshotPredicate returns a predicate which passes only for push component Snapshots
+// which were just created (for immediate nudges) or whose testing has just finished (for validated nudges).
+func NudgeSourceSnapshotPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			if snapshot, ok := createEvent.Object.(*applicationapiv1alpha1.Snapshot); ok {
+				return gitops.IsComponentSnapshotCreatedByPACPushEvent(snapshot) &&
+					h.IsObjectYoungerThanThreshold(snapshot, NudgeSnapshotMaxAge)
+			}
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if snapshot, ok := e.ObjectNew.(*applicationapiv1alpha1.Snapshot); ok {
+				return gitops.IsComponentSnapshotCreatedByPACPushEvent(snapshot) &&
+					gitops.HasSnapshotTestingChangedToFinished(e.ObjectOld, e.ObjectNew)
+			}
+			return false
+		},
+	}
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
+	h "github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/operator-toolkit/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// NudgedEventReason is the reason of the event recorded on a Snapshot when it nudged a component
+	NudgedEventReason = "Nudged"
+
+	// NudgeFailedEventReason is the reason of the event recorded on a Snapshot when it failed to nudge a component
+	NudgeFailedEventReason = "NudgeFailed"
+)
+
+// SnapshotAdapter holds the objects needed to nudge the components depending on the component of a Snapshot.
+type SnapshotAdapter struct {
+	snapshot *applicationapiv1alpha1.Snapshot
+	logger   h.IntegrationLogger
+	loader   loader.ObjectLoader
+	client   client.Client
+	context  context.Context
+	recorder record.EventRecorder
+}
+
+// NewSnapshotAdapter creates and returns a SnapshotAdapter instance.
+func NewSnapshotAdapter(
+	ctx context.Context,
+	snapshot *applicationapiv1alpha1.Snapshot,
+	logger h.IntegrationLogger,
+	loader loader.ObjectLoader,
+	client client.Client,
+	recorder record.EventRecorder,
+) *SnapshotAdapter {
+	return &SnapshotAdapter{
+		snapshot: snapshot,
+		logger:   logger,
+		loader:   loader,
+		client:   client,
+		context:  ctx,
+		recorder: recorder,
+	}
+}
+
+// EnsureNudgesTriggered is an operation that will ensure that all components nudged by the component of the Snapshot
+// are triggered according to the NudgeConfig of the namespace. Components with the immediate mode are nudged right away,
+// components with the validated mode only after the integration tests of the Snapshot passed. Nudges belonging to a gating
+// group are triggered together once every source component of the group has a passing Snapshot. Each nudge is recorded
+// as an event and in the annotation of the Snapshot, so that the same component isn't nudged twice by the same Snapshot.
+func (a *SnapshotAdapter) EnsureNudgesTriggered() (controller.OperationResult, error) {
+	if !gitops.IsComponentSnapshotCreatedByPACPushEvent(a.snapshot) || gitops.IsSnapshotMarkedAsInvalid(a.snapshot) {
+		return controller.ContinueProcessing()
+	}
+
+	nudgeConfig, err := a.loader.GetNudgeConfig(a.context, a.client, a.snapshot.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return controller.ContinueProcessing()
+		}
+		a.logger.Error(err, "Failed to get the NudgeConfig")
+		return controller.RequeueWithError(err)
+	}
+
+	if !h.IsNudgeConfigValid(nudgeConfig) {
+		a.logger.Info("NudgeConfig is not valid, no components will be nudged", "nudgeConfig", nudgeConfig.Name)
+		return controller.ContinueProcessing()
+	}
+
+	records, err := gitops.GetSnapshotNudgeRecords(a.snapshot)
+	if err != nil {
+		// re-nudging all components could trigger duplicate builds, so stop here instead
+		a.logger.Error(err, "Failed to read the nudges recorded on the Snapshot")
+		return controller.StopProcessing()
+	}
+
+	sourceComponent := a.snapshot.Labels[gitops.SnapshotComponentLabel]
+	testsSucceeded := gitops.HaveAppStudioTestsSucceeded(a.snapshot)
+	var readyNudges []v1beta2.NudgeRelationship
+	for _, nudge := range nudgeConfig.Spec.Nudges {
+		if nudge.From != sourceComponent || nudge.GatingGroup != "" {
+			continue
+		}
+		if getNudgeMode(nudge) == v1beta2.NudgeModeValidated && !testsSucceeded {
+			continue
+		}
+		readyNudges = append(readyNudges, nudge)
+	}
+
+	satisfiedGroups, err := a.updateGatingGroups(nudgeConfig, sourceComponent, testsSucceeded)
+	if err != nil {
+		a.logger.Error(err, "Failed to update the gating groups of the NudgeConfig")
+		return controller.RequeueWithError(err)
+	}
+	for _, nudge := range nudgeConfig.Spec.Nudges {
+		if nudge.GatingGroup != "" && getGatingGroup(satisfiedGroups, nudge.GatingGroup) != nil {
+			readyNudges = append(readyNudges, nudge)
+		}
+	}
+
+	var newRecords []gitops.NudgeRecord
+	var nudgeErr error
+	for _, nudge := range readyNudges {
+		if gitops.HasSnapshotNudgedComponent(records, nudge.To) || gitops.HasSnapshotNudgedComponent(newRecords, nudge.To) {
+			continue
+		}
+
+		sourceImage := gitops.FindMatchingSnapshotComponent(a.snapshot, nudge.From).ContainerImage
+		if nudge.GatingGroup != "" {
+			sourceImage, nudgeErr = a.getGatedNudgeSourceImage(nudge, getGatingGroup(satisfiedGroups, nudge.GatingGroup))
+			if nudgeErr != nil {
+				break
+			}
+			if sourceImage == "" {
+				continue
+			}
+		}
+		nudgeErr = a.nudgeComponent(nudge, sourceImage)
+		if nudgeErr != nil {
+			break
+		}
+		newRecords = append(newRecords, gitops.NudgeRecord{
+			Component:   nudge.To,
+			Mode:        getNudgeMode(nudge),
+			GatingGroup: nudge.GatingGroup,
+			Image:       sourceImage,
+			NudgeTime:   metav1.Now(),
+		})
+	}
+
+	// record the nudges which already happened even if a later one failed
+	if len(newRecords) > 0 {
+		err = gitops.AddSnapshotNudgeRecords(a.context, a.client, a.snapshot, newRecords)
+		if err != nil {
+			a.logger.Error(err, "Failed to record the nudges on the Snapshot")
+			return controller.RequeueWithError(err)
+		}
+	}
+
+	if nudgeErr != nil {
+		return controller.RequeueWithError(nudgeErr)
+	}
+
+	// the members of the triggered gating groups are reset once all their nudges happened, so that the group
+	// is only triggered again when every member has a new passing Snapshot
+	if len(satisfiedGroups) > 0 {
+		err = a.resetGatingGroups(satisfiedGroups)
+		if err != nil {
+			a.logger.Error(err, "Failed to reset the triggered gating groups of the NudgeConfig")
+			return controller.RequeueWithError(err)
+		}
+	}
+
+	return controller.ContinueProcessing()
+}
+
+// updateGatingGroups records the result of the Snapshot testing for the source component in the status of all gating
+// groups the component is a member of. A passing Snapshot satisfies the component, a failing one makes it outstanding again.
+// Snapshots which finished testing before the last trigger of a gating group don't update it, since the group was reset after
+// their result was used. It returns the status of the gating groups which are satisfied by the passing Snapshot and whose
+// nudges should be triggered.
+func (a *SnapshotAdapter) updateGatingGroups(nudgeConfig *v1beta2.NudgeConfig, sourceComponent string, testsSucceeded bool) ([]v1beta2.GatingGroupStatus, error) {
+	var memberOfGroups []string
+	for groupName, members := range h.GetNudgeGatingGroupMembers(nudgeConfig) {
+		if slices.Contains(members, sourceComponent) {
+			memberOfGroups = append(memberOfGroups, groupName)
+		}
+	}
+	if len(memberOfGroups) == 0 || !gitops.HaveAppStudioTestsFinished(a.snapshot) {
+		return nil, nil
+	}
+	slices.Sort(memberOfGroups)
+
+	snapshotName := ""
+	if testsSucceeded {
+		snapshotName = a.snapshot.Name
+	}
+
+	var finishTime metav1.Time
+	if condition, ok := gitops.GetTestSucceededCondition(a.snapshot); ok {
+		finishTime = condition.LastTransitionTime
+	}
+
+	var satisfiedGroups []v1beta2.GatingGroupStatus
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		satisfiedGroups = nil
+		latestNudgeConfig, err := a.loader.GetNudgeConfig(a.context, a.client, a.snapshot.Namespace)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(latestNudgeConfig.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		h.AlignNudgeConfigGatingGroups(latestNudgeConfig)
+		now := metav1.Now()
+		for _, groupName := range memberOfGroups {
+			groupStatus := h.GetNudgeGatingGroupStatus(latestNudgeConfig, groupName)
+			if groupStatus.LastTriggeredTime == nil || !finishTime.Before(groupStatus.LastTriggeredTime) {
+				h.SetNudgeGatingGroupMemberSnapshot(latestNudgeConfig, groupName, sourceComponent, snapshotName)
+			}
+			if testsSucceeded && h.IsNudgeGatingGroupSatisfied(groupStatus) {
+				groupStatus.LastTriggeredTime = &now
+				satisfiedGroups = append(satisfiedGroups, *groupStatus.DeepCopy())
+			}
+		}
+
+		return a.client.Status().Patch(a.context, latestNudgeConfig, patch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(satisfiedGroups) > 0 {
+		a.logger.Info("Gating groups are satisfied, triggering their nudges", "gatingGroups", getGatingGroupNames(satisfiedGroups))
+	}
+	return satisfiedGroups, nil
+}
+
+// resetGatingGroups marks the members of the triggered gating groups as outstanding again. Members which got a new passing
+// Snapshot since the groups were triggered keep it, so that it counts towards the next trigger of the group.
+func (a *SnapshotAdapter) resetGatingGroups(triggeredGroups []v1beta2.GatingGroupStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestNudgeConfig, err := a.loader.GetNudgeConfig(a.context, a.client, a.snapshot.Namespace)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(latestNudgeConfig.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		for _, triggeredGroup := range triggeredGroups {
+			groupStatus := h.GetNudgeGatingGroupStatus(latestNudgeConfig, triggeredGroup.Name)
+			if groupStatus == nil {
+				continue
+			}
+			for _, triggeredMember := range triggeredGroup.Members {
+				for _, member := range groupStatus.Members {
+					if member.Component == triggeredMember.Component && member.Snapshot == triggeredMember.Snapshot {
+						h.SetNudgeGatingGroupMemberSnapshot(latestNudgeConfig, triggeredGroup.Name, member.Component, "")
+					}
+				}
+			}
+		}
+
+		return a.client.Status().Patch(a.context, latestNudgeConfig, patch)
+	})
+}
+
+// getGatedNudgeSourceImage returns the image of the source component of the gated nudge from the Snapshot which satisfied
+// the component in the triggered gating group, which isn't necessarily the Snapshot being processed. An empty image is
+// returned, and a warning event recorded, if that Snapshot doesn't exist anymore.
+func (a *SnapshotAdapter) getGatedNudgeSourceImage(nudge v1beta2.NudgeRelationship, triggeredGroup *v1beta2.GatingGroupStatus) (string, error) {
+	memberSnapshot := a.snapshot
+	for _, member := range triggeredGroup.Members {
+		if member.Component != nudge.From || member.Snapshot == a.snapshot.Name {
+			continue
+		}
+		memberSnapshot = &applicationapiv1alpha1.Snapshot{}
+		err := a.client.Get(a.context, types.NamespacedName{Namespace: a.snapshot.Namespace, Name: member.Snapshot}, memberSnapshot)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				a.logger.Info("Snapshot of the gating group member doesn't exist", "component", nudge.From, "memberSnapshot", member.Snapshot)
+				a.recorder.Eventf(a.snapshot, corev1.EventTypeWarning, NudgeFailedEventReason,
+					"Failed to nudge component %s: the Snapshot %s of the gating group member %s doesn't exist", nudge.To, member.Snapshot, nudge.From)
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get the Snapshot %s of the gating group member %s: %w", member.Snapshot, nudge.From, err)
+		}
+	}
+	return gitops.FindMatchingSnapshotComponent(memberSnapshot, nudge.From).ContainerImage, nil
+}
+
+// nudgeComponent triggers a new build of the target component of the nudge, propagating the image of the source component
+// in the Snapshot, and records an event about it on the Snapshot. A target component which doesn't exist is reported as
+// a warning event without returning an error.
+func (a *SnapshotAdapter) nudgeComponent(nudge v1beta2.NudgeRelationship, sourceImage string) error {
+	componentName := nudge.To
+	component, err := a.loader.GetComponent(a.context, a.client, componentName, a.snapshot.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			a.logger.Info("Nudged component doesn't exist", "component", componentName)
+			a.recorder.Eventf(a.snapshot, corev1.EventTypeWarning, NudgeFailedEventReason,
+				"Failed to nudge component %s: component not found", componentName)
+			return nil
+		}
+		a.logger.Error(err, "Failed to get the nudged component", "component", componentName)
+		return err
+	}
+
+	err = gitops.NudgeComponent(a.context, a.client, component, a.snapshot.Name, sourceImage)
+	if err != nil {
+		a.logger.Error(err, "Failed to nudge component", "component", componentName)
+		a.recorder.Eventf(a.snapshot, corev1.EventTypeWarning, NudgeFailedEventReason,
+			"Failed to nudge component %s: %s", componentName, err.Error())
+		return fmt.Errorf("failed to nudge component %s: %w", componentName, err)
+	}
+
+	a.logger.LogAuditEvent("Nudged component", component, h.LogActionUpdate, "snapshot", a.snapshot.Name,
+		"mode", getNudgeMode(nudge), "gatingGroup", nudge.GatingGroup, "sourceImage", sourceImage)
+	if nudge.GatingGroup != "" {
+		a.recorder.Eventf(a.snapshot, corev1.EventTypeNormal, NudgedEventReason,
+			"Nudged component %s with image %s (gating group %s)", componentName, sourceImage, nudge.GatingGroup)
+	} else {
+		a.recorder.Eventf(a.snapshot, corev1.EventTypeNormal, NudgedEventReason,
+			"Nudged component %s with image %s (%s mode)", componentName, sourceImage, getNudgeMode(nudge))
+	}
+	return nil
+}
+
+// getGatingGroup returns the status of the named gating group from the given ones, or nil if it isn't one of them.
+func getGatingGroup(groups []v1beta2.GatingGroupStatus, groupName string) *v1beta2.GatingGroupStatus {
+	for i := range groups {
+		if groups[i].Name == groupName {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// getGatingGroupNames returns the names of the given gating groups.
+func getGatingGroupNames(groups []v1beta2.GatingGroupStatus) []string {
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return names
+}
+
+// getNudgeMode returns the mode of the nudge, defaulting to the immediate mode.
+func getNudgeMode(nudge v1beta2.NudgeRelationship) v1beta2.NudgeModeType {
+	if nudge.Mode == "" {
+		return v1beta2.NudgeModeImmediate
+	}
+	return nudge.Mode
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"bytes"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tonglil/buflogr"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	toolkit "github.com/konflux-ci/operator-toolkit/loader"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NudgeConfig Snapshot Adapter", Ordered, func() {
+	var (
+		adapter       *SnapshotAdapter
+		logger        helpers.IntegrationLogger
+		recorder      *record.FakeRecorder
+		nudgeConfig   *v1beta2.NudgeConfig
+		snapshot      *applicationapiv1alpha1.Snapshot
+		sourceComp    *applicationapiv1alpha1.Component
+		immediateComp *applicationapiv1alpha1.Component
+		validatedComp *applicationapiv1alpha1.Component
+	)
+
+	const (
+		SampleRepoLink = "https://github.com/devfile-samples/devfile-sample-java-springboot-basic"
+		SampleImage    = "quay.io/redhat-appstudio/sample-image@sha256:841328df1b9f8c4087adbdcfec6cc99ac8308805dea83f6d415d6fb8d40227c1"
+	)
+
+	newComponent := func(name string) *applicationapiv1alpha1.Component {
+		return &applicationapiv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: applicationapiv1alpha1.ComponentSpec{
+				ComponentName:  name,
+				Application:    "application-sample",
+				ContainerImage: SampleImage,
+				Source: applicationapiv1alpha1.ComponentSource{
+					ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+						GitSource: &applicationapiv1alpha1.GitSource{
+							URL: SampleRepoLink,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	fetchComponent := func(component *applicationapiv1alpha1.Component) *applicationapiv1alpha1.Component {
+		fresh := &applicationapiv1alpha1.Component{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(component), fresh)).To(Succeed())
+		return fresh
+	}
+
+	BeforeAll(func() {
+		logger = helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&bytes.Buffer{})}
+
+		sourceComp = newComponent("nudge-source")
+		immediateComp = newComponent("nudge-immediate-target")
+		validatedComp = newComponent("nudge-validated-target")
+		for _, component := range []*applicationapiv1alpha1.Component{sourceComp, immediateComp, validatedComp} {
+			Expect(k8sClient.Create(ctx, component)).Should(Succeed())
+		}
+
+		nudgeConfig = &v1beta2.NudgeConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1beta2.NudgeConfigSingletonName,
+				Namespace: "default",
+			},
+			Spec: v1beta2.NudgeConfigSpec{
+				Nudges: []v1beta2.NudgeRelationship{
+					{From: sourceComp.Name, To: immediateComp.Name, Mode: v1beta2.NudgeModeImmediate},
+					{From: sourceComp.Name, To: validatedComp.Name, Mode: v1beta2.NudgeModeValidated},
+					{From: immediateComp.Name, To: validatedComp.Name, Mode: v1beta2.NudgeModeImmediate},
+				},
+			},
+		}
+		helpers.SetNudgeConfigStatusAsValid(nudgeConfig, "nudge graph is valid")
+
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nudge-source-snapshot",
+				Namespace: "default",
+				Labels: map[string]string{
+					gitops.SnapshotTypeLabel:            gitops.SnapshotComponentType,
+					gitops.SnapshotComponentLabel:       sourceComp.Name,
+					gitops.PipelineAsCodeEventTypeLabel: gitops.PipelineAsCodePushType,
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{
+						Name:           sourceComp.Name,
+						ContainerImage: SampleImage,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, snapshot)).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		for _, object := range []client.Object{snapshot, sourceComp, immediateComp, validatedComp} {
+			err := k8sClient.Delete(ctx, object)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		}
+	})
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("can create a new SnapshotAdapter instance", func() {
+		Expect(reflect.TypeOf(NewSnapshotAdapter(ctx, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder))).
+			To(Equal(reflect.TypeOf(&SnapshotAdapter{})))
+	})
+
+	It("doesn't nudge any component when the NudgeConfig is not valid", func() {
+		invalidNudgeConfig := nudgeConfig.DeepCopy()
+		helpers.SetNudgeConfigStatusAsInvalid(invalidNudgeConfig, helpers.NudgeConfigCycleDetectedReason, "cycle")
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.NudgeConfigContextKey,
+				Resource:   invalidNudgeConfig,
+			},
+		})
+		adapter = NewSnapshotAdapter(mockContext, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+		Expect(snapshot.Annotations).NotTo(HaveKey(gitops.SnapshotNudgesAnnotation))
+	})
+
+	It("nudges only the immediate targets while the Snapshot is being tested", func() {
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.NudgeConfigContextKey,
+				Resource:   nudgeConfig,
+			},
+		})
+		adapter = NewSnapshotAdapter(mockContext, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+		Eventually(func(g Gomega) {
+			updated := &applicationapiv1alpha1.Component{}
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(immediateComp), updated)).To(Succeed())
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentBuildRequestAnnotation, gitops.ComponentBuildRequestTriggerPaCBuild))
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentNudgedByAnnotation, snapshot.Name))
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentNudgedByImageAnnotation, SampleImage))
+		}, time.Second*10).Should(Succeed())
+		Expect(fetchComponent(validatedComp).Annotations).NotTo(HaveKey(gitops.ComponentBuildRequestAnnotation))
+
+		records, err := gitops.GetSnapshotNudgeRecords(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Component).To(Equal(immediateComp.Name))
+		Expect(records[0].Mode).To(Equal(v1beta2.NudgeModeImmediate))
+		Expect(records[0].Image).To(Equal(SampleImage))
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(NudgedEventReason))
+	})
+
+	It("nudges the validated targets once the Snapshot passed its tests without nudging the others again", func() {
+		Expect(gitops.MarkSnapshotAsPassed(ctx, k8sClient, snapshot, "tests passed")).To(Succeed())
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.NudgeConfigContextKey,
+				Resource:   nudgeConfig,
+			},
+		})
+		adapter = NewSnapshotAdapter(mockContext, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+		Eventually(func(g Gomega) {
+			updated := &applicationapiv1alpha1.Component{}
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(validatedComp), updated)).To(Succeed())
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentBuildRequestAnnotation, gitops.ComponentBuildRequestTriggerPaCBuild))
+		}, time.Second*10).Should(Succeed())
+
+		records, err := gitops.GetSnapshotNudgeRecords(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+		Expect(gitops.HasSnapshotNudgedComponent(records, validatedComp.Name)).To(BeTrue())
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(validatedComp.Name))
+	})
+
+	It("reports a warning event when a nudged component doesn't exist", func() {
+		missingNudgeConfig := nudgeConfig.DeepCopy()
+		missingNudgeConfig.Spec.Nudges = append(missingNudgeConfig.Spec.Nudges,
+			v1beta2.NudgeRelationship{From: sourceComp.Name, To: "nudge-missing-target", Mode: v1beta2.NudgeModeImmediate})
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.NudgeConfigContextKey,
+				Resource:   missingNudgeConfig,
+			},
+		})
+		adapter = NewSnapshotAdapter(mockContext, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(NudgeFailedEventReason))
+	})
+})
+
+var _ = Describe("NudgeConfig Snapshot Adapter with gating groups", Ordered, func() {
+	var (
+		logger      helpers.IntegrationLogger
+		recorder    *record.FakeRecorder
+		nudgeConfig *v1beta2.NudgeConfig
+		components  []*applicationapiv1alpha1.Component
+		snapshots   []*applicationapiv1alpha1.Snapshot
+	)
+
+	const (
+		SampleRepoLink = "https://github.com/devfile-samples/devfile-sample-java-springboot-basic"
+		SampleImage    = "quay.io/redhat-appstudio/sample-image@sha256:841328df1b9f8c4087adbdcfec6cc99ac8308805dea83f6d415d6fb8d40227c1"
+		GatingGroup    = "gating-group"
+	)
+
+	// imageOf returns the image built for the component in the gated Snapshots, distinct for each component
+	imageOf := func(componentName string) string {
+		return "quay.io/redhat-appstudio/" + componentName + "@sha256:841328df1b9f8c4087adbdcfec6cc99ac8308805dea83f6d415d6fb8d40227c1"
+	}
+
+	newPassingSnapshot := func(name, componentName string) *applicationapiv1alpha1.Snapshot {
+		snapshot := &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					gitops.SnapshotTypeLabel:            gitops.SnapshotComponentType,
+					gitops.SnapshotComponentLabel:       componentName,
+					gitops.PipelineAsCodeEventTypeLabel: gitops.PipelineAsCodePushType,
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{
+						Name:           componentName,
+						ContainerImage: imageOf(componentName),
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, snapshot)).Should(Succeed())
+		Expect(gitops.MarkSnapshotAsPassed(ctx, k8sClient, snapshot, "tests passed")).To(Succeed())
+		snapshots = append(snapshots, snapshot)
+		return snapshot
+	}
+
+	fetchNudgeConfig := func() *v1beta2.NudgeConfig {
+		fresh := &v1beta2.NudgeConfig{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(nudgeConfig), fresh)).To(Succeed())
+		return fresh
+	}
+
+	BeforeAll(func() {
+		logger = helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&bytes.Buffer{})}
+
+		for _, name := range []string{"gated-source-a", "gated-source-b", "gated-target"} {
+			component := &applicationapiv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: applicationapiv1alpha1.ComponentSpec{
+					ComponentName:  name,
+					Application:    "application-sample",
+					ContainerImage: SampleImage,
+					Source: applicationapiv1alpha1.ComponentSource{
+						ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+							GitSource: &applicationapiv1alpha1.GitSource{
+								URL: SampleRepoLink,
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, component)).Should(Succeed())
+			components = append(components, component)
+		}
+
+		nudgeConfig = &v1beta2.NudgeConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1beta2.NudgeConfigSingletonName,
+				Namespace: "default",
+			},
+			Spec: v1beta2.NudgeConfigSpec{
+				Nudges: []v1beta2.NudgeRelationship{
+					{From: "gated-source-a", To: "gated-target", GatingGroup: GatingGroup},
+					{From: "gated-source-b", To: "gated-target", GatingGroup: GatingGroup},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, nudgeConfig)).Should(Succeed())
+		helpers.SetNudgeConfigStatusAsValid(nudgeConfig, "nudge graph is valid")
+		helpers.AlignNudgeConfigGatingGroups(nudgeConfig)
+		Expect(k8sClient.Status().Update(ctx, nudgeConfig)).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		objects := []client.Object{nudgeConfig}
+		for _, component := range components {
+			objects = append(objects, component)
+		}
+		for _, snapshot := range snapshots {
+			objects = append(objects, snapshot)
+		}
+		for _, object := range objects {
+			err := k8sClient.Delete(ctx, object)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		}
+	})
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("doesn't nudge the gated components while a member of the gating group is outstanding", func() {
+		snapshot := newPassingSnapshot("gated-source-a-snapshot", "gated-source-a")
+		adapter := NewSnapshotAdapter(ctx, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+
+		Eventually(func(g Gomega) {
+			groupStatus := helpers.GetNudgeGatingGroupStatus(fetchNudgeConfig(), GatingGroup)
+			g.Expect(groupStatus).NotTo(BeNil())
+			g.Expect(groupStatus.Outstanding).To(Equal([]string{"gated-source-b"}))
+			g.Expect(groupStatus.Members[0].Snapshot).To(Equal(snapshot.Name))
+			g.Expect(groupStatus.LastTriggeredTime).To(BeNil())
+		}, time.Second*10).Should(Succeed())
+	})
+
+	It("nudges the gated components once every member of the gating group has a passing Snapshot", func() {
+		snapshot := newPassingSnapshot("gated-source-b-snapshot", "gated-source-b")
+		adapter := NewSnapshotAdapter(ctx, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		Eventually(func(g Gomega) {
+			g.Expect(helpers.GetNudgeGatingGroupStatus(fetchNudgeConfig(), GatingGroup).Members[0].Snapshot).NotTo(BeEmpty())
+		}, time.Second*10).Should(Succeed())
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+		Eventually(func(g Gomega) {
+			updated := &applicationapiv1alpha1.Component{}
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(components[2]), updated)).To(Succeed())
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentNudgedByAnnotation, snapshot.Name))
+			// the first nudge of the group is from gated-source-a, its image comes from the Snapshot which satisfied it
+			g.Expect(updated.Annotations).To(HaveKeyWithValue(gitops.ComponentNudgedByImageAnnotation, imageOf("gated-source-a")))
+
+			groupStatus := helpers.GetNudgeGatingGroupStatus(fetchNudgeConfig(), GatingGroup)
+			g.Expect(groupStatus.Outstanding).To(Equal([]string{"gated-source-a", "gated-source-b"}))
+			g.Expect(groupStatus.LastTriggeredTime).NotTo(BeNil())
+		}, time.Second*10).Should(Succeed())
+
+		records, err := gitops.GetSnapshotNudgeRecords(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].GatingGroup).To(Equal(GatingGroup))
+		Expect(records[0].Image).To(Equal(imageOf("gated-source-a")))
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(GatingGroup))
+	})
+
+	It("doesn't trigger the gating group again until every member has a new passing Snapshot", func() {
+		snapshot := newPassingSnapshot("gated-source-a-snapshot-2", "gated-source-a")
+		adapter := NewSnapshotAdapter(ctx, snapshot, logger, loader.NewMockLoader(), k8sClient, recorder)
+
+		result, err := adapter.EnsureNudgesTriggered()
+		Expect(!result.CancelRequest && err == nil).To(BeTrue())
+		Expect(recorder.Events).To(BeEmpty())
+
+		Eventually(func(g Gomega) {
+			groupStatus := helpers.GetNudgeGatingGroupStatus(fetchNudgeConfig(), GatingGroup)
+			g.Expect(groupStatus.Outstanding).To(Equal([]string{"gated-source-b"}))
+			g.Expect(groupStatus.Members[0].Snapshot).To(Equal(snapshot.Name))
+		}, time.Second*10).Should(Succeed())
+
+		records, err := gitops.GetSnapshotNudgeRecords(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+})
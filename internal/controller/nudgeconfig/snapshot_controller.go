@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nudgeconfig
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/operator-toolkit/controller"
+	toolkitpredicates "github.com/konflux-ci/operator-toolkit/predicates"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// SnapshotReconciler reconciles the Snapshots of the components which nudge other components.
+type SnapshotReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// NewSnapshotReconciler creates and returns a SnapshotReconciler.
+func NewSnapshotReconciler(client client.Client, logger *logr.Logger, scheme *runtime.Scheme, recorder record.EventRecorder) *SnapshotReconciler {
+	return &SnapshotReconciler{
+		Client:   client,
+		Log:      logger.WithName("nudgeconfig-snapshot"),
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *SnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := helpers.IntegrationLogger{Logger: r.Log.WithValues("snapshot", req.NamespacedName)}
+	loader := loader.NewLoader()
+
+	snapshot := &applicationapiv1alpha1.Snapshot{}
+	err := r.Get(ctx, req.NamespacedName, snapshot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get Snapshot for", "req", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	adapter := NewSnapshotAdapter(ctx, snapshot, logger, loader, r.Client, r.Recorder)
+
+	return controller.ReconcileHandler([]controller.Operation{
+		adapter.EnsureNudgesTriggered,
+	})
+}
+
+// SnapshotAdapterInterface is an interface defining all the operations that should be defined in a nudge Snapshot adapter.
+type SnapshotAdapterInterface interface {
+	EnsureNudgesTriggered() (controller.OperationResult, error)
+}
+
+// setupSnapshotControllerWithManager sets up the controller with the Manager which monitors push component Snapshots
+// and filters events to new Snapshots and Snapshots which finished testing.
+func setupSnapshotControllerWithManager(manager ctrl.Manager, controller *SnapshotReconciler) error {
+	return ctrl.NewControllerManagedBy(manager).
+		For(&applicationapiv1alpha1.Snapshot{}).
+		Named("nudgeconfig-snapshot").
+		WithEventFilter(
+			predicate.And(
+				toolkitpredicates.IgnoreBackups{},
+				NudgeSourceSnapshotPredicate(),
+			),
+		).
+		Complete(controller)
+}
@@ -18,15 +18,23 @@ package scenario
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
 	h "github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/tekton"
 	"github.com/konflux-ci/operator-toolkit/controller"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Adapter holds the objects needed to reconcile a Release.
+// ScenarioValidationRetryInterval is the interval after which invalid IntegrationTestScenarios are validated again.
+const ScenarioValidationRetryInterval = 5 * time.Minute
+
+// Adapter holds the objects needed to reconcile an IntegrationTestScenario.
 type Adapter struct {
 	scenario *v1beta2.IntegrationTestScenario
 	logger   h.IntegrationLogger
@@ -47,6 +55,110 @@ func NewAdapter(context context.Context, scenario *v1beta2.IntegrationTestScenar
 	}
 }
 
-func (a *Adapter) EnsurePlaceholder() (controller.OperationResult, error) {
+// EnsureCreatedScenarioIsValid is an operation that ensures that the IntegrationTestScenario is valid: the Application
+// or ComponentGroup it references exists, its optional when expression compiles, its ResolverRef resolves to a pipeline which can be parsed and its params match
+// the params declared by that pipeline. The outcome is recorded in the IntegrationTestScenarioValid condition, invalid
+// scenarios are validated again after ScenarioValidationRetryInterval. Scenarios which are already valid for their
+// current generation only get their Application or ComponentGroup checked again, failures to resolve the pipeline
+// are retried without marking the scenario as invalid.
+func (a *Adapter) EnsureCreatedScenarioIsValid() (controller.OperationResult, error) {
+	if a.scenario.GetDeletionTimestamp() != nil {
+		return controller.ContinueProcessing()
+	}
+
+	var validationMessage string
+	var err error
+	isAlreadyValid := h.IsScenarioValidForCurrentGeneration(a.scenario)
+	if isAlreadyValid {
+		validationMessage, err = a.validateScenarioOwner()
+	} else {
+		validationMessage, err = a.validateScenario()
+	}
+	if err != nil {
+		a.logger.Error(err, "Failed to validate the IntegrationTestScenario")
+		return controller.RequeueWithError(err)
+	}
+	if isAlreadyValid && validationMessage == "" {
+		return controller.ContinueProcessing()
+	}
+
+	patch := client.MergeFrom(a.scenario.DeepCopy())
+	if validationMessage != "" {
+		h.SetScenarioIntegrationStatusAsInvalid(a.scenario, validationMessage)
+	} else {
+		h.SetScenarioIntegrationStatusAsValid(a.scenario, "IntegrationTestScenario is valid")
+	}
+	err = a.client.Status().Patch(a.context, a.scenario, patch)
+	if err != nil {
+		a.logger.Error(err, "Failed to update the status of the IntegrationTestScenario")
+		return controller.RequeueWithError(err)
+	}
+
+	if validationMessage != "" {
+		a.logger.Info("IntegrationTestScenario is invalid, it will be validated again later",
+			"reason", validationMessage,
+			"retryInterval", ScenarioValidationRetryInterval.String())
+		return controller.RequeueAfter(ScenarioValidationRetryInterval, nil)
+	}
+
+	a.logger.LogAuditEvent("IntegrationTestScenario marked as valid", a.scenario, h.LogActionUpdate)
 	return controller.ContinueProcessing()
 }
+
+// validateScenario runs the validation checks of the IntegrationTestScenario. The returned message describes why the
+// scenario is invalid and is empty for valid scenarios, the error is set for transient errors and failures to resolve
+// the pipeline, which should be retried instead of marking the scenario as invalid.
+func (a *Adapter) validateScenario() (string, error) {
+	validationMessage, err := a.validateScenarioOwner()
+	if validationMessage != "" || err != nil {
+		return validationMessage, err
+	}
+
+	if err := gitops.ValidateIntegrationTestScenarioWhenExpression(a.scenario.Spec.When); err != nil {
+		return fmt.Sprintf("the when expression of the IntegrationTestScenario is invalid: %s", err), nil
+	}
+
+	pipelineSpec, err := tekton.GetIntegrationTestScenarioPipelineSpec(a.context, a.client, a.loader, a.scenario)
+	if tekton.IsInvalidPipelineError(err) {
+		return err.Error(), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	// The params can't be checked when the resolved PipelineRun references its Pipeline
+	if pipelineSpec == nil {
+		return "", nil
+	}
+
+	warnings, err := tekton.ValidateIntegrationTestScenarioParams(a.scenario, pipelineSpec)
+	if err != nil {
+		return err.Error(), nil
+	}
+	for _, warning := range warnings {
+		a.logger.Info("IntegrationTestScenario param warning", "warning", warning)
+	}
+
+	return "", nil
+}
+
+// validateScenarioOwner checks that the Application or ComponentGroup referenced by the IntegrationTestScenario exists.
+// The returned message describes why the scenario is invalid and is empty when the reference is valid.
+func (a *Adapter) validateScenarioOwner() (string, error) {
+	if a.scenario.HasApplication() {
+		_, err := a.loader.GetApplicationFromScenario(a.context, a.client, a.scenario)
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("application '%s' referenced by the IntegrationTestScenario doesn't exist", a.scenario.Spec.Application), nil
+		}
+		return "", err
+	}
+
+	if a.scenario.HasComponentGroup() {
+		_, err := a.loader.GetComponentGroup(a.context, a.client, a.scenario.Spec.ComponentGroup, a.scenario.Namespace)
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("componentGroup '%s' referenced by the IntegrationTestScenario doesn't exist", a.scenario.Spec.ComponentGroup), nil
+		}
+		return "", err
+	}
+
+	return "the IntegrationTestScenario doesn't reference an application or a componentGroup", nil
+}
@@ -29,8 +29,16 @@ import (
 	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/helpers"
 
+	"github.com/konflux-ci/integration-service/tekton"
+	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
+	toolkit "github.com/konflux-ci/operator-toolkit/loader"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knative "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 var _ = Describe("Scenario Adapter", Ordered, func() {
@@ -147,4 +155,127 @@ var _ = Describe("Scenario Adapter", Ordered, func() {
 	It("can create a new Adapter instance with invalid scenario", func() {
 		Expect(reflect.TypeOf(NewAdapter(ctx, invalidScenario, logger, loader.NewMockLoader(), k8sClient))).To(Equal(reflect.TypeOf(&Adapter{})))
 	})
+
+	When("EnsureCreatedScenarioIsValid is called", func() {
+		It("marks a scenario referencing a non-existent application as invalid and requeues", func() {
+			adapter = NewAdapter(ctx, invalidScenario, logger, loader.NewMockLoader(), k8sClient)
+			result, err := adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueDelay).To(Equal(ScenarioValidationRetryInterval))
+
+			condition := meta.FindStatusCondition(invalidScenario.Status.Conditions, helpers.IntegrationTestScenarioValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("application 'perpetum-mobile' referenced by the IntegrationTestScenario doesn't exist"))
+		})
+
+		It("requeues a scenario whose pipeline can't be resolved without marking it as invalid", func() {
+			failedResolution := resolutionv1beta1.ResolutionRequest{
+				Status: resolutionv1beta1.ResolutionRequestStatus{
+					Status: duckv1.Status{
+						Conditions: []knative.Condition{
+							{
+								Type:    knative.ConditionSucceeded,
+								Status:  corev1.ConditionFalse,
+								Message: "repository not found",
+							},
+						},
+					},
+				},
+			}
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.ResolutionRequestContextKey,
+					Resource:   failedResolution,
+				},
+			})
+
+			result, err := adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("repository not found"))
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(meta.FindStatusCondition(integrationTestScenario.Status.Conditions, helpers.IntegrationTestScenarioValid)).To(BeNil())
+		})
+
+		It("marks a scenario whose pipeline resolves and matches its params as valid", func() {
+			resolvedPipelineRun := `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  generateName: integration-pipelinerun-
+spec:
+  pipelineSpec:
+    params:
+      - name: SNAPSHOT
+        type: string
+    tasks: []
+`
+			resolution := resolutionv1beta1.ResolutionRequest{
+				Status: resolutionv1beta1.ResolutionRequestStatus{
+					ResolutionRequestStatusFields: resolutionv1beta1.ResolutionRequestStatusFields{
+						Data: tekton.GenerateCleanData(resolvedPipelineRun),
+					},
+					Status: duckv1.Status{
+						Conditions: []knative.Condition{
+							{
+								Type:   knative.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			}
+			integrationTestScenario.Spec.ResolverRef.ResourceKind = tektonconsts.ResourceKindPipelineRun
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.ResolutionRequestContextKey,
+					Resource:   resolution,
+				},
+			})
+
+			result, err := adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.CancelRequest).To(BeFalse())
+			Expect(result.RequeueRequest).To(BeFalse())
+			Expect(helpers.IsScenarioValidForCurrentGeneration(integrationTestScenario)).To(BeTrue())
+
+			// The scenario isn't validated again while its generation doesn't change
+			adapter.context = ctx
+			result, err = adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeFalse())
+		})
+
+		It("marks a scenario with an invalid when expression as invalid", func() {
+			integrationTestScenario.Spec.When = `targetBranch.startsWith("release-") &&`
+			integrationTestScenario.Generation++
+
+			result, err := adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueDelay).To(Equal(ScenarioValidationRetryInterval))
+
+			condition := meta.FindStatusCondition(integrationTestScenario.Status.Conditions, helpers.IntegrationTestScenarioValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("the when expression of the IntegrationTestScenario is invalid"))
+		})
+
+		It("marks a valid scenario as invalid once its application is deleted", func() {
+			integrationTestScenario.Spec.When = ""
+			helpers.SetScenarioIntegrationStatusAsValid(integrationTestScenario, "IntegrationTestScenario is valid")
+			Expect(k8sClient.Delete(ctx, hasApp)).To(Succeed())
+			Eventually(func() bool {
+				_, err := adapter.loader.GetApplicationFromScenario(ctx, k8sClient, integrationTestScenario)
+				return errors.IsNotFound(err)
+			}).Should(BeTrue())
+
+			result, err := adapter.EnsureCreatedScenarioIsValid()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueDelay).To(Equal(ScenarioValidationRetryInterval))
+
+			condition := meta.FindStatusCondition(integrationTestScenario.Status.Conditions, helpers.IntegrationTestScenarioValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("application 'application-sample' referenced by the IntegrationTestScenario doesn't exist"))
+		})
+	})
 })
@@ -22,13 +22,20 @@ import (
 	"github.com/konflux-ci/integration-service/loader"
 
 	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/operator-toolkit/controller"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // Reconciler reconciles an scenario object
@@ -55,6 +62,7 @@ func NewScenarioReconciler(client client.Client, logger *logr.Logger, scheme *ru
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications/status,verbs=get
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups,verbs=get;list;watch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups/status,verbs=get
+//+kubebuilder:rbac:groups=resolution.tekton.dev,resources=resolutionrequests,verbs=create;get;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -76,13 +84,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	adapter := NewAdapter(ctx, scenario, logger, loader, r.Client)
 
 	return controller.ReconcileHandler([]controller.Operation{
-		adapter.EnsurePlaceholder,
+		adapter.EnsureCreatedScenarioIsValid,
 	})
 }
 
 // AdapterInterface is an interface defining all the operations that should be defined in an Integration adapter.
 type AdapterInterface interface {
-	EnsurePlaceholder() (controller.OperationResult, error)
+	EnsureCreatedScenarioIsValid() (controller.OperationResult, error)
 }
 
 // SetupController creates a new Integration controller and adds it to the Manager.
@@ -90,9 +98,69 @@ func SetupController(manager ctrl.Manager, log *logr.Logger) error {
 	return setupControllerWithManager(manager, NewScenarioReconciler(manager.GetClient(), log, manager.GetScheme()))
 }
 
+// setupControllerWithManager sets up the controller with the Manager which monitors IntegrationTestScenarios and
+// the deletion of Applications and ComponentGroups, which invalidates the scenarios referencing them.
 func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) error {
+	mapOwnerToScenarios := func(ctx context.Context, object client.Object) []reconcile.Request {
+		return controller.mapOwnerToScenarios(ctx, object)
+	}
 
 	return ctrl.NewControllerManagedBy(manager).
-		For(&v1beta2.IntegrationTestScenario{}).
+		For(&v1beta2.IntegrationTestScenario{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&applicationapiv1alpha1.Application{},
+			handler.EnqueueRequestsFromMapFunc(mapOwnerToScenarios),
+			builder.WithPredicates(deletedPredicate()),
+		).
+		Watches(
+			&v1beta2.ComponentGroup{},
+			handler.EnqueueRequestsFromMapFunc(mapOwnerToScenarios),
+			builder.WithPredicates(deletedPredicate()),
+		).
 		Complete(controller)
 }
+
+// mapOwnerToScenarios maps an Application or a ComponentGroup to the IntegrationTestScenarios referencing it.
+func (r *Reconciler) mapOwnerToScenarios(ctx context.Context, object client.Object) []reconcile.Request {
+	var scenarios *[]v1beta2.IntegrationTestScenario
+	var err error
+	switch owner := object.(type) {
+	case *applicationapiv1alpha1.Application:
+		scenarios, err = loader.NewLoader().GetAllIntegrationTestScenariosForApplication(ctx, r.Client, owner)
+	case *v1beta2.ComponentGroup:
+		scenarios, err = loader.NewLoader().GetAllIntegrationTestScenariosForComponentGroup(ctx, r.Client, owner)
+	default:
+		return nil
+	}
+	if err != nil {
+		r.Log.Error(err, "Failed to get the IntegrationTestScenarios referencing a deleted object",
+			"name", object.GetName(), "namespace", object.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(*scenarios))
+	for _, scenario := range *scenarios {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: scenario.Namespace, Name: scenario.Name},
+		})
+	}
+	return requests
+}
+
+// deletedPredicate returns a predicate which passes only for delete events.
+func deletedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+	}
+}
@@ -18,6 +18,7 @@ package scenario
 
 import (
 	"reflect"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,6 +35,7 @@ import (
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/tekton"
 	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -158,6 +160,8 @@ var _ = Describe("ScenarioController", Ordered, func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		scenarioReconciler = NewScenarioReconciler(k8sClient, &logf.Log, &scheme)
+		// No resolver is running in the test environment, don't wait long for the scenario resolutions
+		tekton.ScenarioResolutionTimeout = time.Second
 
 	})
 	AfterAll(func() {
@@ -177,7 +181,15 @@ var _ = Describe("ScenarioController", Ordered, func() {
 	It("can Reconcile function prepare the adapter and return the result of the reconcile handling operation", func() {
 		result, err := scenarioReconciler.Reconcile(ctx, req)
 		Expect(reflect.TypeOf(result)).To(Equal(reflect.TypeOf(reconcile.Result{})))
-		Expect(err).ToNot(HaveOccurred())
+		// The pipeline can't be resolved in the test environment, the validation is retried
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to resolve the pipeline"))
+	})
+
+	It("can map a deleted Application to the IntegrationTestScenarios referencing it", func() {
+		Eventually(func() []reconcile.Request {
+			return scenarioReconciler.mapOwnerToScenarios(ctx, hasApp)
+		}).Should(ConsistOf(req))
 	})
 
 	It("can setup a new controller manager with the given reconciler", func() {
@@ -27,13 +27,16 @@ import (
 	"time"
 
 	clienterrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/cloudevents"
 	"github.com/konflux-ci/integration-service/gitops"
 	h "github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/pkg/dag"
 	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
 	"github.com/konflux-ci/integration-service/pkg/metrics"
 	"github.com/konflux-ci/integration-service/release"
@@ -67,6 +70,7 @@ type Adapter struct {
 	client         client.Client
 	context        context.Context
 	status         status.StatusInterface
+	cloudEvents    *cloudevents.Emitter
 }
 
 // NewAdapter creates and returns an Adapter instance.
@@ -81,6 +85,7 @@ func NewAdapterWithApplication(context context.Context, snapshot *applicationapi
 		client:         client,
 		context:        context,
 		status:         status.NewStatus(logger.Logger, client),
+		cloudEvents:    cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -95,6 +100,82 @@ func NewAdapter(context context.Context, snapshot *applicationapiv1alpha1.Snapsh
 		client:         client,
 		context:        context,
 		status:         status.NewStatus(logger.Logger, client),
+		cloudEvents:    cloudevents.NewEmitter(logger.Logger, client),
+	}
+}
+
+// EnsureTestOverridesApplied is an operation that will ensure that the tests which users requested to override
+// are marked as passed or skipped, and that the requests are recorded in the audit trail of the Snapshot.
+func (a *Adapter) EnsureTestOverridesApplied() (controller.OperationResult, error) {
+	requests, err := gitops.GetTestOverrideRequests(a.snapshot)
+	if err != nil {
+		a.logger.Error(err, "Failed to parse the test override requests of the Snapshot, dropping them")
+	}
+	if len(requests) == 0 && err == nil {
+		return controller.ContinueProcessing()
+	}
+
+	testStatuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(a.snapshot)
+	if err != nil {
+		return controller.RequeueWithError(err)
+	}
+
+	processedTime := metav1.Now()
+	for i := range requests {
+		requests[i].ProcessedTime = &processedTime
+		requests[i].Result = a.applyTestOverride(&requests[i], testStatuses)
+	}
+	testsOverridden := testStatuses.IsDirty()
+
+	if err = gitops.CompleteTestOverrideRequests(a.context, a.client, a.snapshot, requests, testStatuses); err != nil {
+		a.logger.Error(err, "Failed to record the processed test override requests")
+		return controller.RequeueWithError(err)
+	}
+	for _, request := range requests {
+		a.logger.LogAuditEvent(fmt.Sprintf("Test override request processed: %s", request.Result), a.snapshot, h.LogActionUpdate,
+			"scenario", request.Scenario, "action", request.Action, "user", request.User, "reason", request.Reason, "source", request.Source)
+	}
+
+	if testsOverridden {
+		if err = gitops.ResetSnapshotStatusConditions(a.context, a.client, a.snapshot, "Integration test override applied for Snapshot"); err != nil {
+			a.logger.Error(err, "Failed to reset snapshot status conditions")
+			return controller.RequeueWithError(err)
+		}
+	}
+
+	return controller.ContinueProcessing()
+}
+
+// applyTestOverride marks the test of the requested scenario as passed or skipped, and returns the result of the request.
+// Tests which are running or about to run can't be overridden since their results would replace the override.
+func (a *Adapter) applyTestOverride(request *gitops.TestOverride, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) string {
+	status, found := testStatuses.GetScenarioStatus(request.Scenario)
+	if !found {
+		return fmt.Sprintf("ignored, scenario %s has no test status", request.Scenario)
+	}
+	switch status.Status {
+	case intgteststat.IntegrationTestStatusInProgress:
+		return fmt.Sprintf("ignored, the test of scenario %s is in progress", request.Scenario)
+	case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusBlocked:
+		return fmt.Sprintf("ignored, the test of scenario %s is about to run", request.Scenario)
+	}
+
+	details := fmt.Sprintf("by %s", request.User)
+	if request.Reason != "" {
+		details = fmt.Sprintf("%s: %s", details, request.Reason)
+	}
+	switch request.Action {
+	case gitops.TestOverrideActionPass:
+		testStatuses.UpdateTestStatusIfChanged(request.Scenario, intgteststat.IntegrationTestStatusTestPassed, "Overridden as passed "+details)
+		return fmt.Sprintf("the test of scenario %s was marked as passed", request.Scenario)
+	case gitops.TestOverrideActionSkip:
+		if !status.IsOptionalScenario {
+			return fmt.Sprintf("ignored, scenario %s is required and can only be overridden", request.Scenario)
+		}
+		testStatuses.UpdateTestStatusIfChanged(request.Scenario, intgteststat.IntegrationTestStatusTestSkipped, "Skipped "+details)
+		return fmt.Sprintf("the test of scenario %s was marked as skipped", request.Scenario)
+	default:
+		return fmt.Sprintf("ignored, unknown action %q", request.Action)
 	}
 }
 
@@ -115,7 +196,13 @@ func (a *Adapter) EnsureRerunPipelineRunsExist() (controller.OperationResult, er
 		return controller.RequeueWithError(err)
 	}
 
-	skipScenarioRerunCount, opResult, err := a.handleScenarioReruns(integrationTestScenarios, testStatuses)
+	testGraph, err := a.getTestGraphForRerun(runLabelValue, integrationTestScenarios)
+	if err != nil {
+		a.logger.Error(err, "Failed to get IntegrationTestScenarios for the TestGraph of the Snapshot")
+		return controller.RequeueWithError(err)
+	}
+
+	skipScenarioRerunCount, opResult, err := a.handleScenarioReruns(integrationTestScenarios, testStatuses, testGraph)
 	if opResult.CancelRequest || err != nil {
 		return opResult, err
 	}
@@ -158,6 +245,10 @@ func (a *Adapter) getScenariosToRerun(runLabelValue string) (*[]v1beta2.Integrat
 		return scenarios, controller.OperationResult{}, nil
 	}
 
+	if runLabelValue == gitops.SnapshotIntegrationTestRunFailed {
+		return a.getFailedScenariosToRerun()
+	}
+
 	scenario, err := a.loader.GetScenario(a.context, a.client, runLabelValue, namespace)
 	if err != nil {
 		if clienterrors.IsNotFound(err) {
@@ -175,20 +266,100 @@ func (a *Adapter) getScenariosToRerun(runLabelValue string) (*[]v1beta2.Integrat
 	return &[]v1beta2.IntegrationTestScenario{*scenario}, controller.OperationResult{}, nil
 }
 
+// getFailedScenariosToRerun returns the IntegrationTestScenarios applicable to the Snapshot whose tests failed
+func (a *Adapter) getFailedScenariosToRerun() (*[]v1beta2.IntegrationTestScenario, controller.OperationResult, error) {
+	var scenarios *[]v1beta2.IntegrationTestScenario
+	var err error
+	// TODO: remove application-specific branch after deprecation
+	if a.application != nil {
+		scenarios, err = a.loader.GetAllIntegrationTestScenariosForSnapshotApplication(a.context, a.client, a.application, a.snapshot)
+	} else {
+		scenarios, err = a.loader.GetAllIntegrationTestScenariosForSnapshot(a.context, a.client, a.componentGroup, a.snapshot)
+	}
+	if err != nil {
+		a.logger.Error(err, "Failed to get IntegrationTestScenarios", "Namespace", a.snapshot.Namespace)
+		opResult, err := controller.RequeueWithError(err)
+		return nil, opResult, err
+	}
+
+	testStatuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(a.snapshot)
+	if err != nil {
+		opResult, err := controller.RequeueWithError(err)
+		return nil, opResult, err
+	}
+
+	failedScenarios := []v1beta2.IntegrationTestScenario{}
+	if scenarios != nil {
+		for _, scenario := range *scenarios {
+			status, found := testStatuses.GetScenarioStatus(scenario.Name)
+			if found && status.Status.IsFailed() {
+				failedScenarios = append(failedScenarios, scenario)
+			}
+		}
+	}
+	if len(failedScenarios) == 0 {
+		a.logger.Info("None of the Scenarios applicable to the Snapshot failed, nothing to re-run")
+		if err = gitops.RemoveIntegrationTestRerunLabel(a.context, a.client, a.snapshot); err != nil {
+			opResult, err := controller.RequeueWithError(err)
+			return nil, opResult, err
+		}
+		opResult, _ := controller.ContinueProcessing()
+		return nil, opResult, nil
+	}
+	return &failedScenarios, controller.OperationResult{}, nil
+}
+
+// getTestGraphForRerun returns the test graph used to order the re-run scenarios. The dependents of all scenarios
+// applicable to the Snapshot are taken into account, not only the dependents of the re-run ones.
+func (a *Adapter) getTestGraphForRerun(runLabelValue string, scenariosToRerun *[]v1beta2.IntegrationTestScenario) (map[string][]v1beta2.TestGraphNode, error) {
+	if runLabelValue == "all" {
+		return a.getTestGraph(scenariosToRerun), nil
+	}
+
+	var scenarios *[]v1beta2.IntegrationTestScenario
+	var err error
+	// TODO: remove application-specific branch after deprecation
+	if a.application != nil {
+		scenarios, err = a.loader.GetAllIntegrationTestScenariosForSnapshotApplication(a.context, a.client, a.application, a.snapshot)
+	} else {
+		scenarios, err = a.loader.GetAllIntegrationTestScenariosForSnapshot(a.context, a.client, a.componentGroup, a.snapshot)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scenarios == nil {
+		scenarios = scenariosToRerun
+	}
+	return a.getTestGraph(scenarios), nil
+}
+
 // handleScenarioReruns iterates through scenarios, rerunning tests as needed and updating test statuses.
-func (a *Adapter) handleScenarioReruns(scenarios *[]v1beta2.IntegrationTestScenario, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) (int, controller.OperationResult, error) {
+// All requested scenarios are reset before any pipelineRun is created so that the test graph
+// is evaluated against the re-run statuses rather than the results of the previous run.
+func (a *Adapter) handleScenarioReruns(scenarios *[]v1beta2.IntegrationTestScenario, testStatuses *intgteststat.SnapshotIntegrationTestStatuses, testGraph map[string][]v1beta2.TestGraphNode) (int, controller.OperationResult, error) {
 	skipScenarioRerunCount := 0
+	scenariosToRerun := []v1beta2.IntegrationTestScenario{}
 	for _, scenario := range *scenarios {
 		scenario := scenario
 		status, found := testStatuses.GetScenarioStatus(scenario.Name)
-		if found && (status.Status == intgteststat.IntegrationTestStatusInProgress || status.Status == intgteststat.IntegrationTestStatusPending) {
-			a.logger.Info("Skipping re-run for IntegrationTestScenario since it's in 'InProgress' or 'Pending' state", "Scenario", scenario.Name)
+		if found && (status.Status == intgteststat.IntegrationTestStatusInProgress || status.Status == intgteststat.IntegrationTestStatusPending ||
+			status.Status == intgteststat.IntegrationTestStatusBlocked) {
+			a.logger.Info("Skipping re-run for IntegrationTestScenario since it's in 'InProgress', 'Pending' or 'Blocked' state", "Scenario", scenario.Name)
 			skipScenarioRerunCount++
 			continue
 		}
 
 		isOptionalScenario := h.IsIntegrationTestScenarioOptional(&scenario)
 		testStatuses.ResetStatus(scenario.Name, isOptionalScenario)
+		a.resetSkippedDependentScenarios(scenario.Name, testStatuses, testGraph)
+		scenariosToRerun = append(scenariosToRerun, scenario)
+	}
+
+	for _, scenario := range scenariosToRerun {
+		scenario := scenario
+		if !a.isScenarioRunnableInTestGraph(scenario.Name, testStatuses, testGraph) {
+			continue
+		}
 		if opResult, err := a.rerunIntegrationPipelinerunForScenario(&scenario, testStatuses); opResult.CancelRequest || err != nil {
 			a.logger.Error(err, "Failed to create rerun pipelinerun for IntegrationTestScenario", "Scenario", scenario.Name)
 			return -1, opResult, err
@@ -197,8 +368,67 @@ func (a *Adapter) handleScenarioReruns(scenarios *[]v1beta2.IntegrationTestScena
 	return skipScenarioRerunCount, controller.OperationResult{}, nil
 }
 
+// resetSkippedDependentScenarios resets the scenarios which were skipped because of the given scenario
+// back to Pending, so that they are run again once the given scenario finishes.
+func (a *Adapter) resetSkippedDependentScenarios(scenarioName string, testStatuses *intgteststat.SnapshotIntegrationTestStatuses, testGraph map[string][]v1beta2.TestGraphNode) {
+	if len(testGraph) == 0 {
+		return
+	}
+
+	for _, dependentName := range dag.GetDependentScenarios(testGraph, scenarioName) {
+		dependentStatus, ok := testStatuses.GetScenarioStatus(dependentName)
+		if ok && dependentStatus.Status == intgteststat.IntegrationTestStatusTestSkipped {
+			a.logger.Info("Resetting skipped IntegrationTestScenario since the scenario it depends on is re-run",
+				"Scenario", dependentName, "ParentScenario", scenarioName)
+			testStatuses.ResetStatus(dependentName, dependentStatus.IsOptionalScenario)
+		}
+	}
+}
+
+// getTestGraph returns the test graph used to order the given scenarios. It is composed of the TestGraph
+// of the ComponentGroup the Snapshot belongs to, if any, and the dependents declared by the scenarios.
+func (a *Adapter) getTestGraph(scenarios *[]v1beta2.IntegrationTestScenario) map[string][]v1beta2.TestGraphNode {
+	var testGraph map[string][]v1beta2.TestGraphNode
+	// TODO: remove when we deprecate old application model, applications don't have a TestGraph
+	if a.componentGroup != nil {
+		testGraph = a.componentGroup.Spec.TestGraph
+	}
+	if scenarios == nil {
+		return testGraph
+	}
+	return dag.MergeScenarioDependents(testGraph, *scenarios...)
+}
+
+// isScenarioRunnableInTestGraph checks whether all parents of the scenario in the test graph have finished.
+// Scenarios waiting for their parents are marked as blocked, scenarios whose failFast parent
+// didn't pass are marked as skipped. Scenarios are always runnable when there is no test graph.
+func (a *Adapter) isScenarioRunnableInTestGraph(scenarioName string, testStatuses *intgteststat.SnapshotIntegrationTestStatuses, testGraph map[string][]v1beta2.TestGraphNode) bool {
+	if len(testGraph) == 0 {
+		return true
+	}
+
+	state, parents := dag.EvaluateScenario(testGraph, scenarioName, testStatuses)
+	switch state {
+	case dag.ScenarioBlocked:
+		a.logger.Info("IntegrationTestScenario is waiting for its parent scenarios to finish",
+			"Scenario", scenarioName, "ParentScenarios", parents)
+		testStatuses.UpdateTestStatusIfChanged(scenarioName, intgteststat.IntegrationTestStatusBlocked,
+			fmt.Sprintf("Waiting for parent scenario(s) to finish: %s", strings.Join(parents, ", ")))
+		return false
+	case dag.ScenarioSkipped:
+		a.logger.Info("Skipping IntegrationTestScenario since its failFast parent scenarios didn't pass",
+			"Scenario", scenarioName, "ParentScenarios", parents)
+		testStatuses.UpdateTestStatusIfChanged(scenarioName, intgteststat.IntegrationTestStatusTestSkipped,
+			fmt.Sprintf("Skipped because parent scenario(s) didn't pass: %s", strings.Join(parents, ", ")))
+		return false
+	default:
+		return true
+	}
+}
+
 // rerunIntegrationPipelinerunForScenario creates a pipelinerun for the given scenario and updates its status.
 func (a *Adapter) rerunIntegrationPipelinerunForScenario(scenario *v1beta2.IntegrationTestScenario, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) (controller.OperationResult, error) {
+	testStatuses.SetTestMaxAttempts(scenario.Name, scenario.GetMaxAttempts())
 	pipelineRun, err := a.createIntegrationPipelineRun(scenario)
 	if err != nil {
 		return a.HandlePipelineCreationError(err, scenario, testStatuses)
@@ -321,6 +551,7 @@ func (a *Adapter) initializeTestStatusesWithDefer(integrationTestScenarios *[]v1
 func (a *Adapter) processSingleScenario(
 	integrationTestScenario *v1beta2.IntegrationTestScenario,
 	testStatuses *intgteststat.SnapshotIntegrationTestStatuses,
+	testGraph map[string][]v1beta2.TestGraphNode,
 ) error {
 	// Check if an existing integration pipelineRun is registered in the Snapshot's status
 	// We rely on this because the actual pipelineRun CR may have been pruned by this point
@@ -332,7 +563,17 @@ func (a *Adapter) processSingleScenario(
 		return nil
 	}
 
+	// Scenarios already skipped by the TestGraph are only run again when explicitly re-run
+	if ok && integrationTestScenarioStatus.Status == intgteststat.IntegrationTestStatusTestSkipped {
+		return nil
+	}
+
+	if !a.isScenarioRunnableInTestGraph(integrationTestScenario.Name, testStatuses, testGraph) {
+		return nil
+	}
+
 	// Create new pipeline run
+	testStatuses.SetTestMaxAttempts(integrationTestScenario.Name, integrationTestScenario.GetMaxAttempts())
 	pipelineRun, err := a.createIntegrationPipelineRun(integrationTestScenario)
 	if err != nil {
 		a.logger.Error(err, "Failed to create pipelineRun for snapshot and scenario",
@@ -350,10 +591,17 @@ func (a *Adapter) processSingleScenario(
 	}
 
 	// Update status for successful creation
-	gitops.PrepareToRegisterIntegrationPipelineRunStarted(a.snapshot) // don't count re-runs
-	testStatuses.UpdateTestStatusIfChanged(
-		integrationTestScenario.Name, intgteststat.IntegrationTestStatusInProgress,
-		fmt.Sprintf("IntegrationTestScenario pipeline '%s' has been created", pipelineRun.Name))
+	if ok && integrationTestScenarioStatus.GetAttempt() > 1 {
+		testStatuses.UpdateTestStatusIfChanged(
+			integrationTestScenario.Name, intgteststat.IntegrationTestStatusInProgress,
+			fmt.Sprintf("IntegrationTestScenario pipeline '%s' has been created for attempt %d/%d", pipelineRun.Name,
+				integrationTestScenarioStatus.GetAttempt(), integrationTestScenarioStatus.MaxAttempts))
+	} else {
+		gitops.PrepareToRegisterIntegrationPipelineRunStarted(a.snapshot) // don't count re-runs
+		testStatuses.UpdateTestStatusIfChanged(
+			integrationTestScenario.Name, intgteststat.IntegrationTestStatusInProgress,
+			fmt.Sprintf("IntegrationTestScenario pipeline '%s' has been created", pipelineRun.Name))
+	}
 
 	if err = testStatuses.UpdateTestPipelineRunName(integrationTestScenario.Name, pipelineRun.Name); err != nil {
 		// it doesn't make sense to restart reconciliation here, it will be eventually updated by integrationpipeline adapter
@@ -364,23 +612,57 @@ func (a *Adapter) processSingleScenario(
 }
 
 // processAllScenarios iterates through all scenarios and creates pipelines
+// When scenarios reach a final state without running (e.g. skipped by the test graph), the still pending
+// or blocked scenarios are processed again so that the result is propagated to their dependents within a single reconciliation.
 func (a *Adapter) processAllScenarios(
 	integrationTestScenarios *[]v1beta2.IntegrationTestScenario,
 	testStatuses *intgteststat.SnapshotIntegrationTestStatuses,
 ) error {
 	var errsForPLRCreation error
 
-	for _, integrationTestScenario := range *integrationTestScenarios {
-		integrationTestScenario := integrationTestScenario //G601
-		err := a.processSingleScenario(&integrationTestScenario, testStatuses)
-		if err != nil {
-			errsForPLRCreation = errors.Join(errsForPLRCreation, err)
+	testGraph := a.getTestGraph(integrationTestScenarios)
+	scenariosToProcess := *integrationTestScenarios
+	for len(scenariosToProcess) > 0 {
+		finishedCount := countFinishedScenarios(testStatuses)
+		for _, integrationTestScenario := range scenariosToProcess {
+			integrationTestScenario := integrationTestScenario //G601
+			err := a.processSingleScenario(&integrationTestScenario, testStatuses, testGraph)
+			if err != nil {
+				errsForPLRCreation = errors.Join(errsForPLRCreation, err)
+			}
+		}
+		if countFinishedScenarios(testStatuses) == finishedCount {
+			break
 		}
+		scenariosToProcess = getPendingScenarios(integrationTestScenarios, testStatuses)
 	}
 
 	return errsForPLRCreation
 }
 
+// countFinishedScenarios returns the number of scenarios which are in a final state
+func countFinishedScenarios(testStatuses *intgteststat.SnapshotIntegrationTestStatuses) int {
+	count := 0
+	for _, detail := range testStatuses.GetStatuses() {
+		if detail.Status.IsFinal() {
+			count++
+		}
+	}
+	return count
+}
+
+// getPendingScenarios returns the scenarios which are still in Pending or Blocked state
+func getPendingScenarios(integrationTestScenarios *[]v1beta2.IntegrationTestScenario, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) []v1beta2.IntegrationTestScenario {
+	pendingScenarios := []v1beta2.IntegrationTestScenario{}
+	for _, integrationTestScenario := range *integrationTestScenarios {
+		detail, ok := testStatuses.GetScenarioStatus(integrationTestScenario.Name)
+		if ok && (detail.Status == intgteststat.IntegrationTestStatusPending || detail.Status == intgteststat.IntegrationTestStatusBlocked) {
+			pendingScenarios = append(pendingScenarios, integrationTestScenario)
+		}
+	}
+	return pendingScenarios
+}
+
 // cancelOldPipelinesIfNeeded cancels old pipelines for non-push events
 func (a *Adapter) cancelOldPipelinesIfNeeded() {
 	if !gitops.IsSnapshotCreatedByPACPushEvent(a.snapshot) {
@@ -425,6 +707,9 @@ func (a *Adapter) markSnapshotPassedIfNoRequiredScenarios() (controller.Operatio
 		a.logger.LogAuditEvent("Snapshot marked as successful. No required IntegrationTestScenarios found, skipped testing",
 			a.snapshot, h.LogActionUpdate,
 			"snapshot.Status", a.snapshot.Status)
+		event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotPassedEventType, a.snapshot)
+		event.Data.Message = "No required IntegrationTestScenarios found, skipped testing"
+		a.cloudEvents.Emit(a.context, event)
 	}
 
 	return controller.ContinueProcessing()
@@ -488,10 +773,11 @@ func (a *Adapter) EnsureGlobalCandidateImageUpdated() (controller.OperationResul
 	// TODO: remove this function call when we deprecate old application model
 	// also remove function `updateGCLForOverrideSnapshot` entirely
 	var err error
+	queued := false
 	if a.application != nil {
 		err = a.updateGCLForOverrideSnapshot()
 	} else {
-		err = snapshot.UpdateGCLForOverrideSnapshot(a.context, a.client, a.loader, a.componentGroup, a.snapshot, a.logger)
+		queued, err = snapshot.UpdateGCLForOverrideSnapshot(a.context, a.client, a.loader, a.componentGroup, a.snapshot, a.logger)
 	}
 	if err != nil {
 		return controller.RequeueWithError(err)
@@ -502,6 +788,10 @@ func (a *Adapter) EnsureGlobalCandidateImageUpdated() (controller.OperationResul
 		Reason:          "The Snapshot's component(s) was/were added to the global candidate list",
 		LastUpdatedTime: time.Now().Format(time.RFC3339),
 	}
+	if queued {
+		// the queued promotions are applied by the ComponentGroup controller when the freeze lifts
+		addedToGlobalCandidateListStatus.Reason = "The promotion of the Snapshot's component(s) was queued because the global candidate list is frozen"
+	}
 
 	annotationJson, err := json.Marshal(addedToGlobalCandidateListStatus)
 	if err != nil {
@@ -515,6 +805,11 @@ func (a *Adapter) EnsureGlobalCandidateImageUpdated() (controller.OperationResul
 		a.logger.Error(err, "Failed to update the Snapshot's status to AddedToGlobalCandidateList")
 		return controller.RequeueWithError(err)
 	}
+	if queued {
+		a.logger.Info("Global Candidate List is frozen, the promotion of the Snapshot's component(s) has been queued")
+		return controller.ContinueProcessing()
+	}
+	a.cloudEvents.Emit(a.context, cloudevents.NewSnapshotEvent(cloudevents.AddedToGlobalCandidateListEventType, a.snapshot))
 
 	return controller.ContinueProcessing()
 }
@@ -821,6 +1116,8 @@ func (a *Adapter) EnsureGroupSnapshotExist() (controller.OperationResult, error)
 		return controller.RequeueWithError(err)
 	}
 
+	a.cloudEvents.Emit(a.context, cloudevents.NewSnapshotEvent(cloudevents.SnapshotCreatedEventType, groupSnapshot))
+
 	// notify all component snapshots that group snapshot is created for them
 	err = gitops.NotifyComponentSnapshotsInGroupSnapshot(a.context, a.client, componentSnapshotInfos, fmt.Sprintf("Group snapshot %s/%s is created for pr group %s", groupSnapshot.Namespace, groupSnapshot.Name, prGroup))
 	if err != nil {
@@ -830,6 +1127,100 @@ func (a *Adapter) EnsureGroupSnapshotExist() (controller.OperationResult, error)
 	return controller.ContinueProcessing()
 }
 
+// EnsureDependentSnapshotsExist is an operation that ensures Snapshots are created for the ComponentGroups depending on the
+// ComponentGroup of a component snapshot. They are composed of the Global Candidate List of the dependent ComponentGroup and
+// the component built for the snapshot. Dependents which were already visited by the snapshot chain are skipped to prevent cycles.
+func (a *Adapter) EnsureDependentSnapshotsExist() (controller.OperationResult, error) {
+	if a.componentGroup == nil || len(a.componentGroup.Spec.Dependents) == 0 {
+		return controller.ContinueProcessing()
+	}
+
+	if !gitops.IsComponentSnapshot(a.snapshot) {
+		a.logger.Info("The snapshot is not a component snapshot, no need to create snapshots for dependent ComponentGroups")
+		return controller.ContinueProcessing()
+	}
+
+	if gitops.HasDependentSnapshotsProcessed(a.snapshot) {
+		a.logger.Info("The dependent ComponentGroups have been processed for this component snapshot, no need to process them again")
+		return controller.ContinueProcessing()
+	}
+
+	if gitops.IsSnapshotMarkedAsInvalid(a.snapshot) || gitops.IsSnapshotMarkedAsCanceled(a.snapshot) || gitops.HaveAppStudioTestsFinished(a.snapshot) {
+		a.logger.Info("The snapshot is invalid, canceled or its testing already finished, no need to create snapshots for dependent ComponentGroups")
+		return controller.ContinueProcessing()
+	}
+
+	componentName := a.snapshot.Labels[gitops.SnapshotComponentLabel]
+	builtComponent := gitops.FindMatchingSnapshotComponent(a.snapshot, componentName)
+	chain := gitops.GetSnapshotComponentGroupChain(a.snapshot)
+	var messages []string
+	for _, dependentName := range a.componentGroup.Spec.Dependents {
+		if slices.Contains(chain, dependentName) {
+			a.logger.Info("The dependent ComponentGroup was already visited by the snapshot chain, skipping it to prevent a cycle",
+				"dependent", dependentName, "chain", chain)
+			messages = append(messages, fmt.Sprintf("skipped %s to prevent a cycle", dependentName))
+			continue
+		}
+
+		dependent, err := a.loader.GetComponentGroup(a.context, a.client, dependentName, a.snapshot.Namespace)
+		if err != nil {
+			if clienterrors.IsNotFound(err) {
+				a.logger.Info("The dependent ComponentGroup doesn't exist, skipping it", "dependent", dependentName)
+				messages = append(messages, fmt.Sprintf("skipped %s as it doesn't exist", dependentName))
+				continue
+			}
+			a.logger.Error(err, "Failed to get the dependent ComponentGroup", "dependent", dependentName)
+			return controller.RequeueWithError(err)
+		}
+
+		// the build of the component creates a snapshot for each ComponentGroup containing it already
+		if componentGroupContainsComponent(dependent, builtComponent) {
+			a.logger.Info("The dependent ComponentGroup contains the built component, skipping it", "dependent", dependentName)
+			messages = append(messages, fmt.Sprintf("skipped %s as it contains component %s", dependentName, componentName))
+			continue
+		}
+
+		dependentSnapshot, err := snapshot.PrepareDependentSnapshot(a.context, a.client, dependent, a.snapshot)
+		if err != nil {
+			a.logger.Error(err, "Failed to prepare the snapshot for the dependent ComponentGroup", "dependent", dependentName)
+			messages = append(messages, fmt.Sprintf("failed to prepare snapshot for %s due to error %s", dependentName, err.Error()))
+			continue
+		}
+
+		created, err := snapshot.CreateDependentSnapshot(a.context, a.client, dependentSnapshot, a.logger)
+		if err != nil {
+			a.logger.Error(err, "Failed to create the snapshot for the dependent ComponentGroup", "dependent", dependentName)
+			return controller.RequeueWithError(err)
+		}
+		if created {
+			a.logger.LogAuditEvent("Created a new Snapshot for the dependent ComponentGroup", dependentSnapshot, h.LogActionAdd,
+				"dependent", dependentName,
+				"parentSnapshot", a.snapshot.Name)
+			a.cloudEvents.Emit(a.context, cloudevents.NewSnapshotEvent(cloudevents.SnapshotCreatedEventType, dependentSnapshot))
+		}
+		messages = append(messages, fmt.Sprintf("snapshot %s is created for %s", dependentSnapshot.Name, dependentName))
+	}
+
+	err := gitops.AnnotateSnapshot(a.context, a.snapshot, gitops.DependentSnapshotsCreationAnnotation, strings.Join(messages, "; "), a.client)
+	if err != nil {
+		a.logger.Error(err, "Failed to annotate the snapshot with the status of the dependent snapshots creation")
+		return controller.RequeueWithError(err)
+	}
+
+	return controller.ContinueProcessing()
+}
+
+// componentGroupContainsComponent checks if the given ComponentGroup contains the version of the given snapshot component.
+func componentGroupContainsComponent(componentGroup *v1beta2.ComponentGroup, snapshotComponent applicationapiv1alpha1.SnapshotComponent) bool {
+	for _, component := range componentGroup.Spec.Components {
+		if component.Name == snapshotComponent.Name &&
+			(snapshotComponent.Version == "" || component.ComponentVersion.Name == snapshotComponent.Version) {
+			return true
+		}
+	}
+	return false
+}
+
 // createMissingReleasesForReleasePlans checks if there's existing Releases for a given list of ReleasePlans and creates
 // new ones if they are missing. In case the Releases can't be created, an error will be returned.
 func (a *Adapter) createMissingReleasesForReleasePlans(releasePlans *[]releasev1alpha1.ReleasePlan, snapshot *applicationapiv1alpha1.Snapshot) error {
@@ -881,6 +1272,10 @@ func (a *Adapter) createAutomatedRelease(releasePlan *releasev1alpha1.ReleasePla
 	if err != nil {
 		return err
 	}
+	event := cloudevents.NewSnapshotEvent(cloudevents.ReleaseCreatedEventType, snapshot)
+	event.Data.Release = newRelease.Name
+	event.Data.ReleasePlan = releasePlan.Name
+	a.cloudEvents.Emit(a.context, event)
 
 	patch := client.MergeFrom(newRelease.DeepCopy())
 	newRelease.SetAutomated()
@@ -954,6 +1349,10 @@ func (a *Adapter) createIntegrationPipelineRun(integrationTestScenario *v1beta2.
 
 	a.logger.LogAuditEvent("IntegrationTestscenario pipeline has been created", pipelineRun, h.LogActionAdd,
 		"integrationTestScenario.Name", integrationTestScenario.Name)
+	event := cloudevents.NewSnapshotEvent(cloudevents.ScenarioStartedEventType, a.snapshot)
+	event.Data.Scenario = integrationTestScenario.Name
+	event.Data.PipelineRun = pipelineRun.Name
+	a.cloudEvents.Emit(a.context, event)
 	if gitops.IsSnapshotNotStarted(a.snapshot) {
 		err := gitops.MarkSnapshotIntegrationStatusAsInProgress(a.context, a.client, a.snapshot, "Snapshot starts being tested by the integrationPipelineRun")
 		if err != nil {
@@ -1339,6 +1738,9 @@ func (a *Adapter) checkAndCancelOldSnapshotsPipelineRun() error {
 			a.logger.Error(err, "Failed to mark snapshot as canceled", "snapshot.Name", &sortedSnapshots[i].Name)
 			return err
 		}
+		event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotCanceledEventType, &sortedSnapshots[i])
+		event.Data.Message = "Snapshot canceled/superseded"
+		a.cloudEvents.Emit(a.context, event)
 		err = a.cancelAllPipelineRunsForSnapshot(&sortedSnapshots[i])
 		if err != nil {
 			a.logger.Error(err, "failed to cancel all integration pipelinerun for older snapshot", "snapshot.Name", &sortedSnapshots[i].Name)
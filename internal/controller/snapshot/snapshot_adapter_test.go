@@ -25,6 +25,7 @@ import (
 
 	"github.com/tonglil/buflogr"
 	"go.uber.org/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -3285,6 +3286,515 @@ var _ = Describe("Snapshot Adapter", Ordered, func() {
 		})
 	})
 
+	Describe("TestGraph ordering", func() {
+		var (
+			buf                    bytes.Buffer
+			compGroupWithTestGraph *v1beta2.ComponentGroup
+			grandchildScenario     *v1beta2.IntegrationTestScenario
+			scenarios              []v1beta2.IntegrationTestScenario
+			statuses               *intgteststat.SnapshotIntegrationTestStatuses
+		)
+
+		BeforeEach(func() {
+			// integrationTestScenario -> integrationTestScenario1 -> grandchildScenario, all with failFast
+			grandchildScenario = integrationTestScenario1.DeepCopy()
+			grandchildScenario.Name = "grandchild-scenario"
+
+			compGroupWithTestGraph = hasCompGroup.DeepCopy()
+			compGroupWithTestGraph.Spec.TestGraph = map[string][]v1beta2.TestGraphNode{
+				integrationTestScenario1.Name: {{Name: integrationTestScenario.Name, FailFast: true}},
+				grandchildScenario.Name:       {{Name: integrationTestScenario1.Name, FailFast: true}},
+			}
+			scenarios = []v1beta2.IntegrationTestScenario{*grandchildScenario, *integrationTestScenario1, *integrationTestScenario}
+
+			var err error
+			statuses, err = intgteststat.NewSnapshotIntegrationTestStatuses("")
+			Expect(err).To(Succeed())
+			statuses.InitStatuses(&scenarios)
+
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			adapter = NewAdapter(ctx, hasCGSnapshot, compGroupWithTestGraph, log, loader.NewMockLoader(), k8sClient)
+		})
+
+		It("keeps child scenarios blocked while their parent is running", func() {
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+			Expect(statuses.UpdateTestPipelineRunName(integrationTestScenario.Name, "pipelinerun-parent")).To(Succeed())
+
+			Expect(adapter.processAllScenarios(&scenarios, statuses)).To(Succeed())
+
+			detail, ok := statuses.GetScenarioStatus(integrationTestScenario1.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusBlocked))
+			Expect(detail.TestPipelineRunName).To(BeEmpty())
+			Expect(detail.Details).To(ContainSubstring(integrationTestScenario.Name))
+
+			detail, ok = statuses.GetScenarioStatus(grandchildScenario.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusBlocked))
+			Expect(detail.TestPipelineRunName).To(BeEmpty())
+		})
+
+		It("skips all failFast descendants when the parent fails", func() {
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "failed")
+			Expect(statuses.UpdateTestPipelineRunName(integrationTestScenario.Name, "pipelinerun-parent")).To(Succeed())
+
+			Expect(adapter.processAllScenarios(&scenarios, statuses)).To(Succeed())
+
+			for _, name := range []string{integrationTestScenario1.Name, grandchildScenario.Name} {
+				detail, ok := statuses.GetScenarioStatus(name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestSkipped))
+				Expect(detail.TestPipelineRunName).To(BeEmpty())
+			}
+		})
+
+		It("resets skipped descendants when the parent is re-run", func() {
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "failed")
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario1.Name, intgteststat.IntegrationTestStatusTestSkipped, "skipped")
+			statuses.UpdateTestStatusIfChanged(grandchildScenario.Name, intgteststat.IntegrationTestStatusTestSkipped, "skipped")
+
+			adapter.resetSkippedDependentScenarios(integrationTestScenario.Name, statuses, adapter.getTestGraph(&scenarios))
+
+			for _, name := range []string{integrationTestScenario1.Name, grandchildScenario.Name} {
+				detail, ok := statuses.GetScenarioStatus(name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusPending))
+			}
+		})
+
+		When("the scenarios declare their dependents", func() {
+			BeforeEach(func() {
+				// integrationTestScenario -> integrationTestScenario1 declared only by the scenario itself
+				parentScenario := integrationTestScenario.DeepCopy()
+				parentScenario.Spec.Dependents = []string{integrationTestScenario1.Name}
+				scenarios = []v1beta2.IntegrationTestScenario{*integrationTestScenario1, *parentScenario}
+
+				var err error
+				statuses, err = intgteststat.NewSnapshotIntegrationTestStatuses("")
+				Expect(err).To(Succeed())
+				statuses.InitStatuses(&scenarios)
+
+				log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+				adapter = NewAdapter(ctx, hasCGSnapshot, hasCompGroup, log, loader.NewMockLoader(), k8sClient)
+			})
+
+			It("blocks the dependent scenario while the scenario it depends on is running", func() {
+				statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenario.Name, "pipelinerun-parent")).To(Succeed())
+
+				Expect(adapter.processAllScenarios(&scenarios, statuses)).To(Succeed())
+
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenario1.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusBlocked))
+				Expect(detail.TestPipelineRunName).To(BeEmpty())
+				Expect(detail.Details).To(ContainSubstring(integrationTestScenario.Name))
+			})
+
+			It("skips the dependent scenario when the scenario it depends on fails", func() {
+				statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "failed")
+				Expect(statuses.UpdateTestPipelineRunName(integrationTestScenario.Name, "pipelinerun-parent")).To(Succeed())
+
+				Expect(adapter.processAllScenarios(&scenarios, statuses)).To(Succeed())
+
+				detail, ok := statuses.GetScenarioStatus(integrationTestScenario1.Name)
+				Expect(ok).To(BeTrue())
+				Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestSkipped))
+				Expect(detail.TestPipelineRunName).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Retry policy", func() {
+		var (
+			buf              bytes.Buffer
+			retriedScenario  *v1beta2.IntegrationTestScenario
+			statuses         *intgteststat.SnapshotIntegrationTestStatuses
+			createdPLRs      []string
+			previousPLRName  = "pipelinerun-first-attempt"
+			retriedScenarios []v1beta2.IntegrationTestScenario
+		)
+
+		BeforeEach(func() {
+			retriedScenario = integrationTestScenario.DeepCopy()
+			retriedScenario.Spec.RetryPolicy = &v1beta2.RetryPolicy{MaxAttempts: 3}
+			retriedScenarios = []v1beta2.IntegrationTestScenario{*retriedScenario}
+
+			var err error
+			statuses, err = intgteststat.NewSnapshotIntegrationTestStatuses("")
+			Expect(err).To(Succeed())
+			statuses.InitStatuses(&retriedScenarios)
+
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			adapter = NewAdapter(ctx, hasCGSnapshot, hasCompGroup, log, loader.NewMockLoader(), k8sClient)
+		})
+
+		AfterEach(func() {
+			for _, name := range createdPLRs {
+				err := k8sClient.Delete(ctx, &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+				Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+			}
+			createdPLRs = nil
+		})
+
+		It("records the maximum number of attempts when the first attempt is created", func() {
+			Expect(adapter.processAllScenarios(&retriedScenarios, statuses)).To(Succeed())
+
+			detail, ok := statuses.GetScenarioStatus(retriedScenario.Name)
+			Expect(ok).To(BeTrue())
+			createdPLRs = append(createdPLRs, detail.TestPipelineRunName)
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusInProgress))
+			Expect(detail.GetAttempt()).To(Equal(1))
+			Expect(detail.MaxAttempts).To(Equal(3))
+		})
+
+		It("creates a new pipelineRun for the next attempt of a retried scenario", func() {
+			statuses.UpdateTestStatusIfChanged(retriedScenario.Name, intgteststat.IntegrationTestStatusInProgress, "running")
+			statuses.SetTestMaxAttempts(retriedScenario.Name, 3)
+			Expect(statuses.UpdateTestPipelineRunName(retriedScenario.Name, previousPLRName)).To(Succeed())
+			Expect(statuses.RetryTest(retriedScenario.Name, intgteststat.IntegrationTestStatusTestFail, "Integration test failed")).To(Succeed())
+
+			Expect(adapter.processAllScenarios(&retriedScenarios, statuses)).To(Succeed())
+
+			detail, ok := statuses.GetScenarioStatus(retriedScenario.Name)
+			Expect(ok).To(BeTrue())
+			createdPLRs = append(createdPLRs, detail.TestPipelineRunName)
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusInProgress))
+			Expect(detail.TestPipelineRunName).NotTo(BeEmpty())
+			Expect(detail.TestPipelineRunName).NotTo(Equal(previousPLRName))
+			Expect(detail.Details).To(ContainSubstring("for attempt 2/3"))
+			Expect(detail.IsPreviousAttempt(previousPLRName)).To(BeTrue())
+		})
+	})
+
+	Describe("EnsureTestOverridesApplied", func() {
+		var (
+			buf bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			statuses, err := intgteststat.NewSnapshotIntegrationTestStatuses("")
+			Expect(err).To(Succeed())
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "Integration test failed")
+			Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasCGSnapshot, statuses, k8sClient)).Should(Succeed())
+
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			adapter = NewAdapter(ctx, hasCGSnapshot, hasCompGroup, log, loader.NewMockLoader(), k8sClient)
+		})
+
+		// add the requests to the Snapshot without patching it, it would trigger reconciliation in background
+		// and test wouldn't test anything
+		addTestOverrideRequest := func(action gitops.TestOverrideAction) {
+			requests := fmt.Sprintf(`[{"scenario":%q,"action":%q,"reason":"known flake","user":"user-1","requestTime":"2026-10-01T10:00:00Z"}]`,
+				integrationTestScenario.Name, action)
+			hasCGSnapshot.Annotations[gitops.SnapshotTestOverrideRequestsAnnotation] = requests
+		}
+
+		It("marks the test of an overridden scenario as passed and records the override", func() {
+			addTestOverrideRequest(gitops.TestOverrideActionPass)
+
+			result, err := adapter.EnsureTestOverridesApplied()
+			Expect(err).To(Succeed())
+			Expect(result.CancelRequest).To(BeFalse())
+
+			statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			detail, ok := statuses.GetScenarioStatus(integrationTestScenario.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestPassed))
+			Expect(detail.Details).To(Equal("Overridden as passed by user-1: known flake"))
+
+			Expect(hasCGSnapshot.GetAnnotations()).NotTo(HaveKey(gitops.SnapshotTestOverrideRequestsAnnotation))
+			overrides, err := gitops.GetTestOverrides(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			Expect(overrides).To(HaveLen(1))
+			Expect(overrides[0].User).To(Equal("user-1"))
+			Expect(overrides[0].ProcessedTime).NotTo(BeNil())
+			Expect(overrides[0].Result).To(ContainSubstring("marked as passed"))
+
+			condition := meta.FindStatusCondition(hasCGSnapshot.Status.Conditions, gitops.AppStudioIntegrationStatusCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(Equal("Integration test override applied for Snapshot"))
+		})
+
+		It("doesn't skip the test of a required scenario", func() {
+			addTestOverrideRequest(gitops.TestOverrideActionSkip)
+
+			result, err := adapter.EnsureTestOverridesApplied()
+			Expect(err).To(Succeed())
+			Expect(result.CancelRequest).To(BeFalse())
+
+			statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			detail, ok := statuses.GetScenarioStatus(integrationTestScenario.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestFail))
+
+			overrides, err := gitops.GetTestOverrides(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			Expect(overrides).To(HaveLen(1))
+			Expect(overrides[0].Result).To(ContainSubstring("is required and can only be overridden"))
+		})
+
+		expectOverrideIgnoredForStatus := func(testStatus intgteststat.IntegrationTestStatus) {
+			statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, testStatus, "Test is about to run")
+			Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasCGSnapshot, statuses, k8sClient)).Should(Succeed())
+			addTestOverrideRequest(gitops.TestOverrideActionPass)
+
+			result, err := adapter.EnsureTestOverridesApplied()
+			Expect(err).To(Succeed())
+			Expect(result.CancelRequest).To(BeFalse())
+
+			statuses, err = gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			detail, ok := statuses.GetScenarioStatus(integrationTestScenario.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(testStatus))
+
+			overrides, err := gitops.GetTestOverrides(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			Expect(overrides).To(HaveLen(1))
+			Expect(overrides[0].Result).To(ContainSubstring("is about to run"))
+		}
+
+		It("doesn't override the test of a pending scenario", func() {
+			expectOverrideIgnoredForStatus(intgteststat.IntegrationTestStatusPending)
+		})
+
+		It("doesn't override the test of a blocked scenario", func() {
+			expectOverrideIgnoredForStatus(intgteststat.IntegrationTestStatusBlocked)
+		})
+	})
+
+	Describe("EnsureRerunPipelineRunsExist for the failed scenarios", func() {
+		var (
+			buf bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			statuses, err := intgteststat.NewSnapshotIntegrationTestStatuses("")
+			Expect(err).To(Succeed())
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "Integration test failed")
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario1.Name, intgteststat.IntegrationTestStatusTestPassed, "Integration test passed")
+			Expect(statuses.UpdateTestPipelineRunName(integrationTestScenario1.Name, "pipelinerun-passed")).To(Succeed())
+			Expect(gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasCGSnapshot, statuses, k8sClient)).Should(Succeed())
+
+			// add rerun label
+			// we cannot update it into k8s DB via patch, it would trigger reconciliation in background
+			// and test wouldn't test anything
+			hasCGSnapshot.Labels[gitops.SnapshotIntegrationTestRun] = gitops.SnapshotIntegrationTestRunFailed
+
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			adapter = NewAdapter(ctx, hasCGSnapshot, hasCompGroup, log, loader.NewMockLoader(), k8sClient)
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.ComponentGroupContextKey,
+					Resource:   hasCompGroup,
+				},
+				{
+					ContextKey: loader.ComponentContextKey,
+					Resource:   hasComp,
+				},
+				{
+					ContextKey: loader.SnapshotContextKey,
+					Resource:   hasCGSnapshot,
+				},
+				{
+					ContextKey: loader.SnapshotComponentsContextKey,
+					Resource:   []applicationapiv1alpha1.Component{*hasComp},
+				},
+				{
+					ContextKey: loader.AllIntegrationTestScenariosForSnapshotContextKey,
+					Resource:   []v1beta2.IntegrationTestScenario{*integrationTestScenario, *integrationTestScenario1},
+				},
+			})
+		})
+
+		It("re-runs only the scenarios whose test failed", func() {
+			result, err := adapter.EnsureRerunPipelineRunsExist()
+			Expect(err).To(Succeed())
+			Expect(result.CancelRequest).To(BeFalse())
+
+			statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasCGSnapshot)
+			Expect(err).To(Succeed())
+			detail, ok := statuses.GetScenarioStatus(integrationTestScenario.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.TestPipelineRunName).ToNot(BeEmpty())
+
+			detail, ok = statuses.GetScenarioStatus(integrationTestScenario1.Name)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusTestPassed))
+			Expect(detail.TestPipelineRunName).To(Equal("pipelinerun-passed"))
+
+			Expect(hasCGSnapshot.GetLabels()).NotTo(HaveKey(gitops.SnapshotIntegrationTestRun))
+		})
+	})
+
+	Describe("EnsureDependentSnapshotsExist", func() {
+		var (
+			buf                    bytes.Buffer
+			compGroupWithDependent *v1beta2.ComponentGroup
+			dependentCompGroup     *v1beta2.ComponentGroup
+			parentSnapshot         *applicationapiv1alpha1.Snapshot
+		)
+
+		BeforeEach(func() {
+			dependentCompGroup = &v1beta2.ComponentGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependent-component-group",
+					Namespace: "default",
+				},
+				Spec: v1beta2.ComponentGroupSpec{
+					Components: []v1beta2.ComponentReference{
+						{
+							Name: "dependent-component",
+							ComponentVersion: v1beta2.ComponentVersionReference{
+								Name:     "v1",
+								Revision: "main",
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, dependentCompGroup)).Should(Succeed())
+			dependentCompGroup.Status = v1beta2.ComponentGroupStatus{
+				GlobalCandidateList: []v1beta2.ComponentState{
+					{
+						Name:               "dependent-component",
+						Version:            "v1",
+						URL:                SampleRepoLink,
+						LastPromotedImage:  sample_image + "@" + sampleDigest,
+						LastPromotedCommit: sample_commit,
+					},
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, dependentCompGroup)).Should(Succeed())
+
+			// the ComponentGroup of the parent snapshot is listed as well to verify the cycle protection
+			compGroupWithDependent = hasCompGroup.DeepCopy()
+			compGroupWithDependent.Spec.Dependents = []string{dependentCompGroup.Name, hasCompGroup.Name, "missing-component-group"}
+
+			parentSnapshot = &applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "parent-snapshot-sample",
+					Namespace: "default",
+					Labels: map[string]string{
+						gitops.SnapshotTypeLabel:            gitops.SnapshotComponentType,
+						gitops.SnapshotComponentLabel:       "parent-component",
+						gitops.PipelineAsCodeEventTypeLabel: gitops.PipelineAsCodePushType,
+						gitops.ComponentGroupNameLabel:      hasCompGroup.Name,
+					},
+					Annotations: map[string]string{
+						gitops.BuildPipelineRunStartTime: strconv.FormatInt(plrstarttime, 10),
+					},
+				},
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					ComponentGroup: hasCompGroup.Name,
+					Components: []applicationapiv1alpha1.SnapshotComponent{
+						{
+							Name:           "parent-component",
+							Version:        "v1",
+							ContainerImage: sample_image + "@" + sampleDigest,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, parentSnapshot)).Should(Succeed())
+
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			adapter = NewAdapter(ctx, parentSnapshot, compGroupWithDependent, log, loader.NewMockLoader(), k8sClient)
+		})
+
+		AfterEach(func() {
+			snapshots := &applicationapiv1alpha1.SnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace("default"),
+				client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentCompGroup.Name})).To(Succeed())
+			for i := range snapshots.Items {
+				Expect(k8sClient.Delete(ctx, &snapshots.Items[i])).To(Succeed())
+			}
+			err := k8sClient.Delete(ctx, parentSnapshot)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Delete(ctx, dependentCompGroup)
+			Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("creates a snapshot for the dependent ComponentGroup and skips the cycle and missing ones", func() {
+			result, err := adapter.EnsureDependentSnapshotsExist()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			snapshots := &applicationapiv1alpha1.SnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace("default"),
+				client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentCompGroup.Name})).To(Succeed())
+			Expect(snapshots.Items).To(HaveLen(1))
+			dependentSnapshot := snapshots.Items[0]
+			Expect(dependentSnapshot.Spec.ComponentGroup).To(Equal(dependentCompGroup.Name))
+			Expect(dependentSnapshot.Spec.Components).To(HaveLen(2))
+			Expect(dependentSnapshot.Annotations[v1beta2.ParentSnapshotAnnotation]).To(Equal(parentSnapshot.Name))
+			Expect(dependentSnapshot.Annotations[v1beta2.OriginSnapshotAnnotation]).To(Equal(parentSnapshot.Name))
+			Expect(dependentSnapshot.Labels[gitops.PipelineAsCodeEventTypeLabel]).To(Equal(gitops.PipelineAsCodePushType))
+
+			Expect(gitops.HasDependentSnapshotsProcessed(parentSnapshot)).To(BeTrue())
+			message := parentSnapshot.Annotations[gitops.DependentSnapshotsCreationAnnotation]
+			Expect(message).To(ContainSubstring(dependentSnapshot.Name))
+			Expect(message).To(ContainSubstring("skipped " + hasCompGroup.Name + " to prevent a cycle"))
+			Expect(message).To(ContainSubstring("skipped missing-component-group as it doesn't exist"))
+		})
+
+		It("doesn't create snapshots for dependents already visited by the snapshot chain", func() {
+			parentSnapshot.Annotations[v1beta2.ComponentGroupChainAnnotation] = dependentCompGroup.Name + "," + hasCompGroup.Name
+			compGroupWithDependent.Spec.Dependents = []string{dependentCompGroup.Name}
+
+			result, err := adapter.EnsureDependentSnapshotsExist()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			snapshots := &applicationapiv1alpha1.SnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace("default"),
+				client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentCompGroup.Name})).To(Succeed())
+			Expect(snapshots.Items).To(BeEmpty())
+			Expect(gitops.HasDependentSnapshotsProcessed(parentSnapshot)).To(BeTrue())
+		})
+
+		It("doesn't create snapshots for dependents containing the built component", func() {
+			dependentCompGroup.Spec.Components = append(dependentCompGroup.Spec.Components, v1beta2.ComponentReference{
+				Name:             "parent-component",
+				ComponentVersion: v1beta2.ComponentVersionReference{Name: "v1", Revision: "main"},
+			})
+			Expect(k8sClient.Update(ctx, dependentCompGroup)).Should(Succeed())
+			compGroupWithDependent.Spec.Dependents = []string{dependentCompGroup.Name}
+
+			result, err := adapter.EnsureDependentSnapshotsExist()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			snapshots := &applicationapiv1alpha1.SnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace("default"),
+				client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentCompGroup.Name})).To(Succeed())
+			Expect(snapshots.Items).To(BeEmpty())
+			Expect(parentSnapshot.Annotations[gitops.DependentSnapshotsCreationAnnotation]).To(ContainSubstring("contains component parent-component"))
+		})
+
+		It("skips snapshots which were already processed or finished testing", func() {
+			parentSnapshot.Annotations[gitops.DependentSnapshotsCreationAnnotation] = "processed"
+			result, err := adapter.EnsureDependentSnapshotsExist()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			delete(parentSnapshot.Annotations, gitops.DependentSnapshotsCreationAnnotation)
+			meta.SetStatusCondition(&parentSnapshot.Status.Conditions, metav1.Condition{
+				Type:   gitops.AppStudioTestSucceededCondition,
+				Status: metav1.ConditionTrue,
+				Reason: gitops.AppStudioTestSucceededConditionSatisfied,
+			})
+			result, err = adapter.EnsureDependentSnapshotsExist()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			snapshots := &applicationapiv1alpha1.SnapshotList{}
+			Expect(k8sClient.List(ctx, snapshots, client.InNamespace("default"),
+				client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentCompGroup.Name})).To(Succeed())
+			Expect(snapshots.Items).To(BeEmpty())
+		})
+	})
+
 	When("Adapter is created for override snapshot [APPLICATION]", func() {
 		var buf bytes.Buffer
 
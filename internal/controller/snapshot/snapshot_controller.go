@@ -70,6 +70,7 @@ func NewSnapshotReconciler(client client.Client, logger *logr.Logger, scheme *ru
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releaseplans,verbs=get;list;watch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releaseplans/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=resolution.tekton.dev,resources=resolutionrequests,verbs=create;get;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -178,9 +179,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	return controller.ReconcileHandler([]controller.Operation{
 		adapter.EnsureGroupSnapshotExist,
+		adapter.EnsureDependentSnapshotsExist,
 		adapter.EnsureOverrideSnapshotValid,
 		adapter.EnsureAllReleasesExist,
 		adapter.EnsureGlobalCandidateImageUpdated,
+		adapter.EnsureTestOverridesApplied,
 		adapter.EnsureRerunPipelineRunsExist,
 		adapter.EnsureIntegrationPipelineRunsExist,
 	})
@@ -189,7 +192,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 // AdapterInterface is an interface defining all the operations that should be defined in an Integration adapter.
 type AdapterInterface interface {
 	EnsureGroupSnapshotExist() (controller.OperationResult, error)
+	EnsureDependentSnapshotsExist() (controller.OperationResult, error)
 	EnsureAllReleasesExist() (controller.OperationResult, error)
+	EnsureTestOverridesApplied() (controller.OperationResult, error)
 	EnsureRerunPipelineRunsExist() (controller.OperationResult, error)
 	EnsureIntegrationPipelineRunsExist() (controller.OperationResult, error)
 	EnsureGlobalCandidateImageUpdated() (controller.OperationResult, error)
@@ -231,6 +236,9 @@ func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) er
 				predicate.Or(
 					gitops.IntegrationSnapshotChangePredicate(),
 					gitops.SnapshotIntegrationTestRerunTriggerPredicate(),
+					gitops.SnapshotTestOverrideRequestPredicate(),
+					gitops.SnapshotTestGraphProgressPredicate(),
+					gitops.SnapshotIntegrationTestRetryPredicate(),
 				),
 			),
 		).
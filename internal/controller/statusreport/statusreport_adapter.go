@@ -28,12 +28,15 @@ import (
 	"github.com/konflux-ci/operator-toolkit/controller"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/cloudevents"
 	"github.com/konflux-ci/integration-service/gitops"
 	"github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
 	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/integration-service/pkg/metrics"
 	"github.com/konflux-ci/integration-service/status"
 	"github.com/konflux-ci/operator-toolkit/metadata"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -53,6 +56,7 @@ type Adapter struct {
 	client         client.Client
 	context        context.Context
 	status         status.StatusInterface
+	cloudEvents    *cloudevents.Emitter
 }
 
 // NewAdapter creates and returns an Adapter instance for the ComponentGroup model.
@@ -68,6 +72,7 @@ func NewAdapter(context context.Context, snapshot *applicationapiv1alpha1.Snapsh
 		client:         client,
 		context:        context,
 		status:         status.NewStatus(logger.Logger, client),
+		cloudEvents:    cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -85,6 +90,7 @@ func NewAdapterWithApplication(context context.Context, snapshot *applicationapi
 		client:         client,
 		context:        context,
 		status:         status.NewStatus(logger.Logger, client),
+		cloudEvents:    cloudevents.NewEmitter(logger.Logger, client),
 	}
 }
 
@@ -107,11 +113,20 @@ func (a *Adapter) EnsureSnapshotTestStatusReportedToGitProvider() (controller.Op
 	if err != nil {
 		a.logger.Error(err, "failed to report test status to git provider for snapshot",
 			"snapshot.Namespace", a.snapshot.Namespace, "snapshot.Name", a.snapshot.Name, "isErrorRecoverable", isErrorRecoverable)
-		if helpers.IsObjectYoungerThanThreshold(a.snapshot, SnapshotRetryTimeout) && isErrorRecoverable {
-			return controller.RequeueWithError(err)
-		} else {
+		if !isErrorRecoverable {
 			return controller.ContinueProcessing()
 		}
+		// the reports which failed to be delivered are kept on the snapshot and retried with backoff until they expire
+		if pendingReports, pendingErr := status.NewPendingReportsFromSnapshot(a.snapshot); pendingErr == nil {
+			if retryDelay, ok := pendingReports.NextRetryDelay(time.Now()); ok {
+				a.logger.Info("Retrying the pending reports later", "retryDelay", retryDelay.String())
+				return controller.RequeueAfter(retryDelay, nil)
+			}
+		}
+		if helpers.IsObjectYoungerThanThreshold(a.snapshot, SnapshotRetryTimeout) {
+			return controller.RequeueWithError(err)
+		}
+		return controller.ContinueProcessing()
 	}
 	testStatuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(a.snapshot)
 	if err != nil {
@@ -134,6 +149,16 @@ func (a *Adapter) EnsureSnapshotTestStatusReportedToGitProvider() (controller.Op
 				continue
 			}
 
+			// the finalizer is removed only once per PipelineRun, emit the event before that to not emit it again later
+			if controllerutil.ContainsFinalizer(pipelineRun, helpers.IntegrationPipelineRunFinalizer) {
+				event := cloudevents.NewSnapshotEvent(cloudevents.ScenarioFinishedEventType, a.snapshot)
+				event.Data.Scenario = testDetails.ScenarioName
+				event.Data.PipelineRun = pipelineRunName
+				event.Data.Status = testDetails.Status.String()
+				event.Data.Message = testDetails.Details
+				a.cloudEvents.Emit(a.context, event)
+			}
+
 			err = helpers.RemoveFinalizerFromPipelineRun(a.context, a.client, a.logger, pipelineRun, helpers.IntegrationPipelineRunFinalizer)
 			if err != nil {
 				return controller.RequeueWithError(err)
@@ -207,6 +232,9 @@ func (a *Adapter) EnsureSnapshotFinishedAllTests() (controller.OperationResult,
 			}
 			a.logger.LogAuditEvent(fmt.Sprintf("Snapshot integration status condition marked as passed, all of %d required Integration PipelineRuns succeeded", len(*integrationTestScenarios)),
 				a.snapshot, helpers.LogActionUpdate)
+			event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotPassedEventType, a.snapshot)
+			event.Data.Message = "All Integration Pipeline tests passed"
+			a.cloudEvents.Emit(a.context, event)
 		}
 	} else if !gitops.IsSnapshotMarkedAsFailed(a.snapshot) {
 		err = gitops.MarkSnapshotAsFailed(a.context, a.client, a.snapshot, "Some Integration pipeline tests failed")
@@ -216,6 +244,9 @@ func (a *Adapter) EnsureSnapshotFinishedAllTests() (controller.OperationResult,
 		}
 		a.logger.LogAuditEvent("Snapshot integration status condition marked as failed, some tests within Integration PipelineRuns failed",
 			a.snapshot, helpers.LogActionUpdate)
+		event := cloudevents.NewSnapshotEvent(cloudevents.SnapshotFailedEventType, a.snapshot)
+		event.Data.Message = "Some Integration pipeline tests failed"
+		a.cloudEvents.Emit(a.context, event)
 	}
 
 	return controller.ContinueProcessing()
@@ -291,14 +322,23 @@ func (a *Adapter) EnsureGroupSnapshotCreationStatusReportedToGitProvider() (cont
 }
 
 // determineIfAllRequiredIntegrationTestsFinishedAndPassed checks if all Integration tests finished and passed for the given
-// list of integrationTestScenarios.
+// list of integrationTestScenarios. Quarantined integrationTestScenarios aren't required until their quarantine expires.
 func (a *Adapter) determineIfAllRequiredIntegrationTestsFinishedAndPassed(integrationTestScenarios *[]v1beta2.IntegrationTestScenario, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) (bool, bool) {
 	allIntegrationTestsFinished, allIntegrationTestsPassed := true, true
+	integrationTestsRequired := 0
 	integrationTestsFinished := 0
 	integrationTestsPassed := 0
 
 	for _, integrationTestScenario := range *integrationTestScenarios {
 		integrationTestScenario := integrationTestScenario // G601
+		if helpers.IsIntegrationTestScenarioQuarantined(&integrationTestScenario) {
+			a.logger.Info("Integration test scenario is quarantined and isn't required",
+				"integrationTestScenario.Name", integrationTestScenario.Name,
+				"quarantine.Reason", integrationTestScenario.Spec.Quarantine.Reason,
+				"quarantine.ExpiresAt", integrationTestScenario.Spec.Quarantine.ExpiresAt)
+			continue
+		}
+		integrationTestsRequired++
 		testDetails, ok := testStatuses.GetScenarioStatus(integrationTestScenario.Name)
 		if !ok || !testDetails.Status.IsFinal() {
 			allIntegrationTestsFinished = false
@@ -312,7 +352,7 @@ func (a *Adapter) determineIfAllRequiredIntegrationTestsFinishedAndPassed(integr
 		}
 
 	}
-	a.logger.Info(fmt.Sprintf("%[1]d out of %[3]d required integration tests finished, %[2]d out of %[3]d required integration tests passed", integrationTestsFinished, integrationTestsPassed, len(*integrationTestScenarios)))
+	a.logger.Info(fmt.Sprintf("%[1]d out of %[3]d required integration tests finished, %[2]d out of %[3]d required integration tests passed", integrationTestsFinished, integrationTestsPassed, integrationTestsRequired))
 	return allIntegrationTestsFinished, allIntegrationTestsPassed
 }
 
@@ -363,12 +403,22 @@ func (a *Adapter) ReportSnapshotStatus(testedSnapshot *applicationapiv1alpha1.Sn
 		srs, _ = status.NewSnapshotReportStatus("")
 	}
 
+	pendingReports, err := status.NewPendingReportsFromSnapshot(testedSnapshot)
+	if err != nil {
+		a.logger.Error(err, "failed to get pending reports for snapshot",
+			"snapshot.NameSpace", testedSnapshot.Namespace, "snapshot.Name", testedSnapshot.Name)
+		pendingReports, _ = status.NewPendingReports("")
+	}
+
 	// Report the integration test status to pr/commit included in the tested component snapshot
-	// or the component snapshot included in group snapshot
+	// or the component snapshot included in group snapshot, as well as to the additional reporters of the snapshot
+	var reportErrs []error
+	isErrorRecoverable := false
 	for _, destinationComponentSnapshot := range destinationSnapshots {
-		reporter := a.status.GetReporter(destinationComponentSnapshot)
+		reporters := status.GetSinkReporters(a.logger.Logger, a.client, destinationComponentSnapshot)
 
-		if reporter == nil {
+		gitReporter := a.status.GetReporter(destinationComponentSnapshot)
+		if gitReporter == nil {
 			errMessage := fmt.Sprintf("no suitable git reporter found for snapshot %s/%s - missing required git provider labels/annotations", destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name)
 			a.logger.Error(nil, "Failed to get git reporter for snapshot - missing required labels/annotations", "snapshot.Namespace", destinationComponentSnapshot.Namespace, "snapshot.Name", destinationComponentSnapshot.Name)
 
@@ -377,55 +427,124 @@ func (a *Adapter) ReportSnapshotStatus(testedSnapshot *applicationapiv1alpha1.Sn
 			if annotationErr != nil {
 				a.logger.Error(annotationErr, "Failed to annotate snapshot with git reporting failure")
 			}
-			// use continue to skip to next snapshot if one fails
-			continue
+		} else {
+			reporters = append([]status.ReporterInterface{gitReporter}, reporters...)
 		}
 
-		a.logger.Info(fmt.Sprintf("Detected reporter: %s", reporter.GetReporterName()), "destinationComponentSnapshot.Name", destinationComponentSnapshot.Name, "testedSnapshot", testedSnapshot.Name)
+		// each reporter keeps its own report status, so a failing reporter neither blocks nor repeats the reports of the others
+		for _, reporter := range reporters {
+			recoverable, reportErr := a.reportSnapshotStatusToReporter(reporter, integrationTestStatusDetails, testedSnapshot, destinationComponentSnapshot, srs)
+			recoverable = a.updatePendingReport(pendingReports, reporter.GetReporterName(), destinationComponentSnapshot, recoverable, reportErr)
+			if reportErr != nil {
+				reportErrs = append(reportErrs, reportErr)
+				isErrorRecoverable = isErrorRecoverable || recoverable
+			}
+		}
+	}
 
-		if statusCode, err := reporter.Initialize(a.context, destinationComponentSnapshot); err != nil {
-			a.logger.Error(err, "Failed to initialize reporter", "reporter", reporter.GetReporterName(), "statusCode", statusCode)
-			isErrorRecoverable := !helpers.IsUnrecoverableMetadataError(err) && !reporter.ReturnCodeIsUnrecoverable(statusCode)
+	if err := status.WritePendingReports(a.context, a.client, testedSnapshot, pendingReports); err != nil {
+		a.logger.Error(err, "failed to write pending reports of snapshot",
+			"snapshot.Namespace", testedSnapshot.Namespace, "snapshot.Name", testedSnapshot.Name)
+		reportErrs = append(reportErrs, err)
+		isErrorRecoverable = true
+	}
+	metrics.RegisterPendingGitReports(client.ObjectKeyFromObject(testedSnapshot).String(),
+		pendingReports.CountByReporter(), pendingReports.OldestByReporter())
 
-			if helpers.IsUnrecoverableMetadataError(err) {
-				errMessage := fmt.Sprintf("unrecoverable metadata error during git reporter initialization for snapshot %s/%s: %s", destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name, err.Error())
-				annotationErr := gitops.AnnotateSnapshot(a.context, destinationComponentSnapshot, gitops.GitReportingFailureAnnotation, errMessage, a.client)
-				if annotationErr != nil {
-					a.logger.Error(annotationErr, "Failed to annotate snapshot with git reporting failure")
-				}
-			}
+	if len(reportErrs) > 0 {
+		return isErrorRecoverable, e.Join(reportErrs...)
+	}
 
-			return isErrorRecoverable, fmt.Errorf("failed to initialize reporter: %w", err)
-		}
+	a.logger.Info(fmt.Sprintf("Successfully updated the %s annotation", gitops.SnapshotStatusReportAnnotation), "snapshot.Name", testedSnapshot.Name)
 
-		a.logger.Info("Reporter initialized", "reporter", reporter.GetReporterName())
+	return true, nil
+}
 
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			err := a.iterateIntegrationTestStatusDetailsInStatusReport(reporter, integrationTestStatusDetails, testedSnapshot, destinationComponentSnapshot, srs)
-			if err != nil {
-				a.logger.Error(err, fmt.Sprintf("failed to report integration test status for snapshot %s/%s",
-					destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name))
-				return fmt.Errorf("failed to report integration test status for snapshot %s/%s: %w",
-					destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name, err)
-			}
-			if err := status.WriteSnapshotReportStatus(a.context, a.client, testedSnapshot, srs); err != nil {
-				a.logger.Error(err, "failed to write snapshot report status metadata")
-				return fmt.Errorf("failed to write snapshot report status metadata: %w", err)
+// reportSnapshotStatusToReporter initializes the given reporter for the destination snapshot and reports the integration test statuses to it
+func (a *Adapter) reportSnapshotStatusToReporter(reporter status.ReporterInterface,
+	integrationTestStatusDetails []*intgteststat.IntegrationTestStatusDetail,
+	testedSnapshot *applicationapiv1alpha1.Snapshot,
+	destinationComponentSnapshot *applicationapiv1alpha1.Snapshot,
+	srs *status.SnapshotReportStatus) (bool, error) {
+	a.logger.Info(fmt.Sprintf("Detected reporter: %s", reporter.GetReporterName()), "destinationComponentSnapshot.Name", destinationComponentSnapshot.Name, "testedSnapshot", testedSnapshot.Name)
+
+	if statusCode, err := reporter.Initialize(a.context, destinationComponentSnapshot); err != nil {
+		a.logger.Error(err, "Failed to initialize reporter", "reporter", reporter.GetReporterName(), "statusCode", statusCode)
+		isErrorRecoverable := !helpers.IsUnrecoverableMetadataError(err) && !reporter.ReturnCodeIsUnrecoverable(statusCode)
+
+		if helpers.IsUnrecoverableMetadataError(err) {
+			errMessage := fmt.Sprintf("unrecoverable metadata error during git reporter initialization for snapshot %s/%s: %s", destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name, err.Error())
+			annotationErr := gitops.AnnotateSnapshot(a.context, destinationComponentSnapshot, gitops.GitReportingFailureAnnotation, errMessage, a.client)
+			if annotationErr != nil {
+				a.logger.Error(annotationErr, "Failed to annotate snapshot with git reporting failure")
 			}
-			return err
-		})
+		}
 
+		return isErrorRecoverable, fmt.Errorf("failed to initialize reporter: %w", err)
 	}
 
+	a.logger.Info("Reporter initialized", "reporter", reporter.GetReporterName())
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := a.iterateIntegrationTestStatusDetailsInStatusReport(reporter, integrationTestStatusDetails, testedSnapshot, destinationComponentSnapshot, srs)
+		if err != nil {
+			a.logger.Error(err, fmt.Sprintf("failed to report integration test status for snapshot %s/%s",
+				destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name))
+			return fmt.Errorf("failed to report integration test status for snapshot %s/%s: %w",
+				destinationComponentSnapshot.Namespace, destinationComponentSnapshot.Name, err)
+		}
+		if err := status.WriteSnapshotReportStatus(a.context, a.client, testedSnapshot, srs); err != nil {
+			a.logger.Error(err, "failed to write snapshot report status metadata")
+			return fmt.Errorf("failed to write snapshot report status metadata: %w", err)
+		}
+		return err
+	})
+
 	if err != nil {
-		return true, fmt.Errorf("issue occurred during generating or updating report status: %w", err)
+		return true, fmt.Errorf("issue occurred during generating or updating report status to %s: %w", reporter.GetReporterName(), err)
 	}
 
-	a.logger.Info(fmt.Sprintf("Successfully updated the %s annotation", gitops.SnapshotStatusReportAnnotation), "snapshot.Name", testedSnapshot.Name)
-
 	return true, nil
 }
 
+// updatePendingReport keeps track of the reports the reporter failed to deliver to the destination snapshot and forgets them
+// once delivered. It returns whether a failed delivery should be retried: unrecoverable failures and reports which have been
+// failing for longer than status.PendingReportRetryTimeout are given up on.
+func (a *Adapter) updatePendingReport(pendingReports *status.PendingReports, reporterName string,
+	destinationComponentSnapshot *applicationapiv1alpha1.Snapshot, recoverable bool, reportErr error) bool {
+	now := time.Now()
+	if reportErr == nil {
+		if delivered := pendingReports.Remove(reporterName, destinationComponentSnapshot.Name); delivered != nil {
+			a.logger.Info("Delivered the pending reports", "reporter", reporterName,
+				"destinationSnapshot.Name", destinationComponentSnapshot.Name, "since", delivered.Since, "attempts", delivered.Attempts+1)
+			metrics.RegisterDeliveredPendingGitReport(reporterName, delivered.Since)
+		}
+		return true
+	}
+
+	if !recoverable {
+		pendingReports.Remove(reporterName, destinationComponentSnapshot.Name)
+		metrics.RegisterDroppedGitReport(reporterName, "unrecoverable")
+		return false
+	}
+
+	pendingReport := pendingReports.RecordFailure(reporterName, destinationComponentSnapshot.Name, reportErr, now)
+	if !pendingReport.IsExpired(now) {
+		return true
+	}
+
+	pendingReports.Remove(reporterName, destinationComponentSnapshot.Name)
+	metrics.RegisterDroppedGitReport(reporterName, "expired")
+	errMessage := fmt.Sprintf("gave up reporting to %s after %d failed attempts since %s: %s",
+		reporterName, pendingReport.Attempts, pendingReport.Since.Format(time.RFC3339), pendingReport.LastError)
+	a.logger.Error(reportErr, "Gave up on the pending reports", "reporter", reporterName,
+		"destinationSnapshot.Name", destinationComponentSnapshot.Name, "since", pendingReport.Since, "attempts", pendingReport.Attempts)
+	if annotationErr := gitops.AnnotateSnapshot(a.context, destinationComponentSnapshot, gitops.GitReportingFailureAnnotation, errMessage, a.client); annotationErr != nil {
+		a.logger.Error(annotationErr, "Failed to annotate snapshot with git reporting failure")
+	}
+	return false
+}
+
 // ReportGroupSnapshotCreationStatus report the group snapshot creation status back to the git provider according the filtered integration test scenarios
 func (a *Adapter) ReportGroupSnapshotCreationStatus(snapshot *applicationapiv1alpha1.Snapshot, integrationTestScenarios *[]v1beta2.IntegrationTestScenario,
 	integrationTestStatus intgteststat.IntegrationTestStatus, componentNameOrPrGroup string) (bool, error) {
@@ -513,7 +632,7 @@ func (a *Adapter) iterateIntegrationTestStatusDetailsInStatusReport(reporter sta
 	// check if there is any integration test status update to report
 	var hasUpdatedIntegrationTest bool
 	for _, integrationTestStatusDetail := range integrationTestStatusDetails {
-		if srs.IsNewer(integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime) {
+		if srs.IsNewerForReporter(reporter.GetReporterName(), integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime) {
 			hasUpdatedIntegrationTest = true
 			a.logger.Info("Integration Test contains new status updates", "scenario.Name", integrationTestStatusDetail.ScenarioName, "destinationSnapshot.Name", destinationSnapshot.Name, "testedSnapshot", testedSnapshot.Name)
 			break
@@ -525,6 +644,8 @@ func (a *Adapter) iterateIntegrationTestStatusDetailsInStatusReport(reporter sta
 		return nil
 	}
 
+	summaryComponent := a.getSummaryCommentComponent(reporter, destinationSnapshot)
+
 	for _, integrationTestStatusDetail := range integrationTestStatusDetails {
 		// set isFinalStatus to true if there is at least one integration test status is in final status, which means the comment for integration test might not be updated again to have only one comment for each component
 		if integrationTestStatusDetail.Status.IsFinal() {
@@ -539,23 +660,40 @@ func (a *Adapter) iterateIntegrationTestStatusDetailsInStatusReport(reporter sta
 			}
 			return fmt.Errorf("failed to generate test report: %w", reportErr)
 		}
+		testReport.SkipComment = summaryComponent != nil
+		// line-level findings are listed in a code quality section of the comment since comments can't annotate code
+		findingsSummary, err := status.FormatFindingsSummary(testReport.Findings)
+		if err != nil {
+			return fmt.Errorf("failed to generate code quality summary for integration test scenario %s/%s : %w", testedSnapshot.Namespace, testReport.ScenarioName, err)
+		}
 		// split passed and failing integration test report in gitlab comment to show failing tests on top
-		if integrationTestStatusDetail.Status == intgteststat.IntegrationTestStatusTestPassed {
+		if testReport.Comment != "" {
+			// the comment rendered with the report templates of the namespace replaces the default one
+			commentForEachTest, err := status.FormatTestReportComment(*testReport)
+			if err != nil {
+				return fmt.Errorf("failed to generate comment for status of integration test scenario %s/%s : %w", testedSnapshot.Namespace, testReport.ScenarioName, err)
+			}
+			if integrationTestStatusDetail.Status == intgteststat.IntegrationTestStatusTestPassed {
+				commentForPassedIntegrationTests = append(commentForPassedIntegrationTests, commentForEachTest+findingsSummary)
+			} else {
+				commentForFailingIntegrationTests = append(commentForFailingIntegrationTests, commentForEachTest+findingsSummary)
+			}
+		} else if integrationTestStatusDetail.Status == intgteststat.IntegrationTestStatusTestPassed {
 			// generate comment for passed integration test with short text which has pipelinerun link but without task run details
 			commentForEachTest, err := status.FormatCommentForSuccessfulTest(testReport.Summary, testReport.ShortText)
 			if err != nil {
 				return fmt.Errorf("failed to generate comment for status of integration test scenario %s/%s : %w", testedSnapshot.Namespace, testReport.ScenarioName, err)
 			}
-			commentForPassedIntegrationTests = append(commentForPassedIntegrationTests, commentForEachTest)
+			commentForPassedIntegrationTests = append(commentForPassedIntegrationTests, commentForEachTest+findingsSummary)
 		} else {
 			commentForEachTest, err := status.FormatComment(testReport.Summary, testReport.Text)
 			if err != nil {
 				return fmt.Errorf("failed to generate comment for status of integration test scenario %s/%s : %w", testedSnapshot.Namespace, testReport.ScenarioName, err)
 			}
-			commentForFailingIntegrationTests = append(commentForFailingIntegrationTests, commentForEachTest)
+			commentForFailingIntegrationTests = append(commentForFailingIntegrationTests, commentForEachTest+findingsSummary)
 		}
 
-		if srs.IsNewer(integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime) {
+		if srs.IsNewerForReporter(reporter.GetReporterName(), integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime) {
 			a.logger.Info("Integration Test contains new status updates", "scenario.Name", integrationTestStatusDetail.ScenarioName, "destinationSnapshot.Name", destinationSnapshot.Name, "testedSnapshot", testedSnapshot.Name)
 
 		} else {
@@ -585,12 +723,17 @@ func (a *Adapter) iterateIntegrationTestStatusDetailsInStatusReport(reporter sta
 			"testedSnapshot.Name", testedSnapshot.Name,
 			"destinationSnapshot.Name", destinationSnapshot.Name,
 			"testStatus", integrationTestStatusDetail.Status)
-		srs.SetLastUpdateTime(integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime)
+		srs.SetReporterLastUpdateTime(reporter.GetReporterName(), integrationTestStatusDetail.ScenarioName, destinationSnapshot.Name, integrationTestStatusDetail.LastUpdateTime)
+	}
+
+	if summaryComponent != nil {
+		return a.updateSummaryComment(reporter, summaryComponent, destinationSnapshot, isFinalStatus)
 	}
 
-	// update integration test status comment for gitlab and forgejo reporters when comment is not disabled
+	// update integration test status comment for gitlab, forgejo and bitbucket reporters when comment is not disabled
 	if reporter.GetReporterName() == status.GitLabProvider ||
-		reporter.GetReporterName() == status.ForgejoProvider {
+		reporter.GetReporterName() == status.ForgejoProvider ||
+		reporter.GetReporterName() == status.BitbucketProvider {
 		loader := loader.NewLoader()
 		// get the destination snapshot's component to check if comment is disabled for all comments for pac repository or integration test
 		component, err := loader.GetComponentFromSnapshot(a.context, a.client, destinationSnapshot)
@@ -628,6 +771,55 @@ func (a *Adapter) iterateIntegrationTestStatusDetailsInStatusReport(reporter sta
 	return nil
 }
 
+// getSummaryCommentComponent returns the component of the destination snapshot when its integration test statuses are reported
+// by the git reporter in the summary comment of the pull request, nil otherwise
+func (a *Adapter) getSummaryCommentComponent(reporter status.ReporterInterface, destinationSnapshot *applicationapiv1alpha1.Snapshot) *applicationapiv1alpha1.Component {
+	_, isPullRequest := destinationSnapshot.GetAnnotations()[gitops.PipelineAsCodePullRequestAnnotation]
+	if !isPullRequest || status.IsSinkReporter(reporter.GetReporterName()) {
+		return nil
+	}
+	component, err := loader.NewLoader().GetComponentFromSnapshot(a.context, a.client, destinationSnapshot)
+	if err != nil {
+		a.logger.Error(err, "failed to get component to check the comment strategy, falling back to the comments of each scenario or component",
+			"snapshot.Namespace", destinationSnapshot.Namespace, "snapshot.Name", destinationSnapshot.Name)
+		return nil
+	}
+	if !gitops.IsSummaryCommentEnabled(component) {
+		return nil
+	}
+	return component
+}
+
+// updateSummaryComment updates the summary comment of the pull request the destination snapshot was built from with the
+// integration test statuses of all Snapshots of the pull request, unless comments are disabled for the component
+func (a *Adapter) updateSummaryComment(reporter status.ReporterInterface, component *applicationapiv1alpha1.Component,
+	destinationSnapshot *applicationapiv1alpha1.Snapshot, isFinalStatus bool) error {
+	isCommentDisabled, err := gitops.IsCommentDisabled(a.context, a.client, component)
+	if err != nil {
+		a.logger.Error(err, fmt.Sprintf("failed to check if comment is disabled for component %s/%s", component.Namespace, component.Name))
+		return fmt.Errorf("failed to check if comment is disabled for component %s/%s: %w", component.Namespace, component.Name, err)
+	}
+	if isCommentDisabled {
+		a.logger.Info("All comments are disabled for PAC repository in component, skipping updating integration test summary comment", "component.Namespace", component.Namespace, "component.Name", component.Name)
+		return nil
+	}
+
+	summary, err := status.GeneratePullRequestSummary(a.context, a.client, destinationSnapshot)
+	if err != nil {
+		return fmt.Errorf("failed to generate integration test summary comment for snapshot %s/%s: %w", destinationSnapshot.Namespace, destinationSnapshot.Name, err)
+	}
+	statusCode, err := reporter.UpdateStatusInComment(status.SummaryCommentTitle, summary, isFinalStatus)
+	if err != nil {
+		if reporter.ReturnCodeIsUnrecoverable(statusCode) {
+			a.logger.Error(err, fmt.Sprintf("failed to update integration test summary comment for snapshot %s/%s, the statusCode %d is not easily recoverable", destinationSnapshot.Namespace, destinationSnapshot.Name, statusCode))
+			return nil
+		}
+		return fmt.Errorf("failed to update integration test summary comment: %w", err)
+	}
+	a.logger.Info("Successfully updated integration test summary comment", "destinationSnapshot.Name", destinationSnapshot.Name)
+	return nil
+}
+
 // getDestinationSnapshots gets the component snapshots that include the git provider info the report will be reported to
 func (a *Adapter) getDestinationSnapshots(testedSnapshot *applicationapiv1alpha1.Snapshot) ([]*applicationapiv1alpha1.Snapshot, error) {
 	destinationSnapshots := make([]*applicationapiv1alpha1.Snapshot, 0)
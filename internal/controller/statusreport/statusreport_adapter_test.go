@@ -604,6 +604,62 @@ var _ = Describe("Snapshot Adapter", Ordered, func() {
 		})
 	})
 
+	When("New Adapter is created for a push-type Snapshot whose failed test is quarantined [APPLICATION]", func() {
+		BeforeEach(func() {
+			buf = bytes.Buffer{}
+			log := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+
+			statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(hasSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			statuses.UpdateTestStatusIfChanged(integrationTestScenario.Name, intgteststat.IntegrationTestStatusTestFail, "Failed test")
+			err = gitops.WriteIntegrationTestStatusesIntoSnapshot(ctx, hasSnapshot, statuses, k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			quarantinedScenario := integrationTestScenario.DeepCopy()
+			quarantinedScenario.Spec.Quarantine = &v1beta2.Quarantine{
+				Reason:    "flaky test",
+				ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+			}
+
+			adapter = NewAdapterWithApplication(ctx, hasSnapshot, hasApp, log, loader.NewMockLoader(), k8sClient)
+			adapter.context = toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: loader.ApplicationContextKey,
+					Resource:   hasApp,
+				},
+				{
+					ContextKey: loader.ComponentContextKey,
+					Resource:   hasComp,
+				},
+				{
+					ContextKey: loader.SnapshotContextKey,
+					Resource:   hasSnapshot,
+				},
+				{
+					ContextKey: loader.RequiredIntegrationTestScenariosForSnapshotContextKey,
+					Resource:   []v1beta2.IntegrationTestScenario{*quarantinedScenario},
+				},
+				{
+					ContextKey: loader.ApplicationComponentsContextKey,
+					Resource:   []applicationapiv1alpha1.Component{*hasComp},
+				},
+			})
+		})
+
+		It("ensures the quarantined test doesn't fail the Snapshot", func() {
+			result, err := adapter.EnsureSnapshotFinishedAllTests()
+			Expect(!result.CancelRequest && err == nil).To(BeTrue())
+
+			Expect(meta.IsStatusConditionTrue(hasSnapshot.Status.Conditions, gitops.AppStudioTestSucceededCondition)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(hasSnapshot.Status.Conditions, gitops.AppStudioIntegrationStatusCondition)).To(BeTrue())
+
+			expectedLogEntry := "Integration test scenario is quarantined and isn't required"
+			Expect(buf.String()).Should(ContainSubstring(expectedLogEntry))
+			expectedLogEntry = "0 out of 0 required integration tests finished"
+			Expect(buf.String()).Should(ContainSubstring(expectedLogEntry))
+		})
+	})
+
 	When("New Adapter is created for a push-type Snapshot that has no tests [APPLICATION]", func() {
 		BeforeEach(func() {
 			buf = bytes.Buffer{}
@@ -869,6 +925,34 @@ var _ = Describe("Snapshot Adapter", Ordered, func() {
 			Expect(statusCode).NotTo(BeNil())
 		})
 
+		It("keeps the reports which failed to be delivered pending and retries them later", func() {
+			mockStatus.EXPECT().GetReporter(gomock.Any()).Return(mockReporter).Times(2)
+			mockReporter.EXPECT().Initialize(gomock.Any(), gomock.Any()).Return(503, fmt.Errorf("service unavailable"))
+			mockReporter.EXPECT().ReturnCodeIsUnrecoverable(503).Return(false)
+
+			hasPRSnapshot.Annotations["test.appstudio.openshift.io/status"] = "[{\"scenario\":\"scenario1\",\"status\":\"InProgress\",\"startTime\":\"2023-07-26T16:57:49+02:00\",\"lastUpdateTime\":\"2023-08-26T17:57:50+02:00\",\"details\":\"Test in progress\"}]"
+			hasPRSnapshot.Annotations["test.appstudio.openshift.io/git-reporter-status"] = "{\"scenarios\":{\"scenario1-snapshot-pr-sample\":{\"lastUpdateTime\":\"2023-08-26T17:57:49+02:00\"}}}"
+			adapter = NewAdapterWithApplication(ctx, hasPRSnapshot, hasApp, logger, loader.NewMockLoader(), k8sClient)
+			adapter.status = mockStatus
+			result, err := adapter.EnsureSnapshotTestStatusReportedToGitProvider()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeTrue())
+			Expect(result.RequeueDelay).To(BeNumerically("~", time.Minute, time.Second))
+			Expect(hasPRSnapshot.Annotations).To(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+
+			pendingReports, err := status.NewPendingReportsFromSnapshot(hasPRSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingReports.CountByReporter()).To(Equal(map[string]int{"mocked-reporter": 1}))
+
+			// the pending report is forgotten once it is delivered
+			mockReporter.EXPECT().Initialize(gomock.Any(), gomock.Any()).Return(0, nil)
+			mockReporter.EXPECT().ReportStatus(gomock.Any(), gomock.Any()).Times(1)
+			result, err = adapter.EnsureSnapshotTestStatusReportedToGitProvider()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueRequest).To(BeFalse())
+			Expect(hasPRSnapshot.Annotations).NotTo(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+		})
+
 		It("add annotation to snapshot when no git provider is found", func() {
 			// Create a snapshot WITHOUT git provider info but WITH proper component labels
 			snapshotWithoutGitProvider := &applicationapiv1alpha1.Snapshot{
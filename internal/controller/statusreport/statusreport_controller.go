@@ -24,6 +24,8 @@ import (
 	"github.com/konflux-ci/integration-service/gitops"
 	"github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/pkg/metrics"
+	"github.com/konflux-ci/integration-service/status"
 	"github.com/konflux-ci/operator-toolkit/controller"
 	toolkitpredicates "github.com/konflux-ci/operator-toolkit/predicates"
 	toolkitutils "github.com/konflux-ci/operator-toolkit/utils"
@@ -54,6 +56,7 @@ func NewStatusReportReconciler(client client.Client, logger *logr.Logger, scheme
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshots/status,verbs=get
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications,verbs=get;list;watch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications/status,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -66,10 +69,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	snapshot := &applicationapiv1alpha1.Snapshot{}
 	err := r.Get(ctx, req.NamespacedName, snapshot)
 	if err != nil {
-		logger.Error(err, "Failed to get snapshot for", "req", req.NamespacedName)
 		if errors.IsNotFound(err) {
+			// the reports still pending for a deleted snapshot aren't owed anymore
+			metrics.RegisterPendingGitReports(req.NamespacedName.String(), nil, nil)
 			return ctrl.Result{}, nil
 		}
+		logger.Error(err, "Failed to get snapshot for", "req", req.NamespacedName)
 
 		return ctrl.Result{}, err
 	}
@@ -79,6 +84,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	// the pending report metrics are kept in memory, register the pending reports of the snapshot before reporting
+	// so that they are rebuilt from the annotation after a restart even if no delivery is due yet
+	if pendingReports, err := status.NewPendingReportsFromSnapshot(snapshot); err == nil {
+		metrics.RegisterPendingGitReports(req.NamespacedName.String(), pendingReports.CountByReporter(), pendingReports.OldestByReporter())
+	}
+
 	var adapter *Adapter
 	// TODO: remove application branch when old application-specific code is removed
 	if snapshot.Spec.Application != "" {
@@ -128,7 +139,10 @@ func setupControllerWithManager(manager ctrl.Manager, controller *Reconciler) er
 		WithEventFilter(
 			predicate.And(
 				toolkitpredicates.IgnoreBackups{},
-				gitops.SnapshotTestAnnotationChangePredicate(),
+				predicate.Or(
+					gitops.SnapshotTestAnnotationChangePredicate(),
+					gitops.SnapshotPendingReportsPredicate(),
+				),
 			)).
 		Complete(controller)
 }
@@ -0,0 +1,371 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+const (
+	// RetestCommand re-runs the test of a scenario, e.g. "/retest e2e-tests"
+	RetestCommand = "retest"
+
+	// RetestFailedCommand re-runs the failed tests, e.g. "/retest-failed"
+	RetestFailedCommand = "retest-failed"
+
+	// SkipCommand marks the test of an optional scenario as skipped, e.g. "/skip flaky-tests known issue"
+	SkipCommand = "skip"
+
+	// OverrideCommand marks the test of a scenario as passed, e.g. "/override e2e-tests broken test environment"
+	OverrideCommand = "override"
+
+	// maxCommandsPerComment is the maximum number of commands run for a single comment
+	maxCommandsPerComment = 10
+)
+
+// Permission is the level of access a user has to the repository of a pull request
+type Permission int
+
+const (
+	// PermissionNone allows no integration test commands
+	PermissionNone Permission = iota
+	// PermissionWrite allows re-running tests, it's granted to the users who can push to the repository
+	PermissionWrite
+	// PermissionMaintain allows overriding and skipping tests, it's granted to the maintainers of the repository
+	PermissionMaintain
+)
+
+// Command is an integration test command found in a pull request comment
+type Command struct {
+	// Name of the command, without the leading slash
+	Name string
+	// Scenario the command applies to, empty for /retest-failed
+	Scenario string
+	// Reason given for overriding or skipping the test
+	Reason string
+}
+
+// RequiredPermission returns the permission a user needs to run the command
+func (c Command) RequiredPermission() Permission {
+	switch c.Name {
+	case OverrideCommand, SkipCommand:
+		return PermissionMaintain
+	default:
+		return PermissionWrite
+	}
+}
+
+// String returns the command as written in a comment, without its reason
+func (c Command) String() string {
+	return strings.TrimSpace(fmt.Sprintf("/%s %s", c.Name, c.Scenario))
+}
+
+// isRerun returns true if the command re-runs tests, which is requested with the single run label of the Snapshot
+func (c Command) isRerun() bool {
+	return c.Name == RetestCommand || c.Name == RetestFailedCommand
+}
+
+// PullRequest identifies the pull or merge request a comment was made on
+type PullRequest struct {
+	// GitProviders are the git provider types the Snapshots of the pull request may be labeled with
+	GitProviders []string
+	// Owner of the repository
+	Owner string
+	// Repository name
+	Repository string
+	// ProjectID of the GitLab target project, the owner is not compared when set
+	ProjectID string
+	// Number of the pull request
+	Number int
+}
+
+// CommentEvent is a comment made by a user on a pull request
+type CommentEvent struct {
+	PullRequest PullRequest
+	// User who made the comment
+	User string
+	// Body of the comment
+	Body string
+	// URL of the comment, recorded in the audit trail of the test overrides
+	URL string
+}
+
+// ParseCommands returns the integration test commands of a pull request comment. Each command is on its own line:
+// "/retest <scenario>", "/retest-failed", "/skip <scenario> [reason]" or "/override <scenario> <reason>".
+// A bare "/retest" is left to Pipelines as Code, and invalid commands are ignored.
+func ParseCommands(comment string) []Command {
+	commands := []Command{}
+	for _, line := range strings.Split(comment, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+			continue
+		}
+		command := Command{Name: strings.TrimPrefix(fields[0], "/")}
+		if len(fields) > 1 {
+			command.Scenario = fields[1]
+		}
+		if len(fields) > 2 {
+			command.Reason = strings.Join(fields[2:], " ")
+		}
+		if !isValidCommand(command) {
+			continue
+		}
+		commands = append(commands, command)
+		if len(commands) == maxCommandsPerComment {
+			break
+		}
+	}
+	return commands
+}
+
+// isValidCommand returns true if the command is known and has the arguments it needs
+func isValidCommand(command Command) bool {
+	switch command.Name {
+	case RetestFailedCommand:
+		return command.Scenario == ""
+	case RetestCommand, SkipCommand:
+		return isValidScenarioName(command.Scenario)
+	case OverrideCommand:
+		return isValidScenarioName(command.Scenario) && command.Reason != ""
+	default:
+		return false
+	}
+}
+
+// isValidScenarioName returns true if the name can be the name of an IntegrationTestScenario and the value of the run label
+func isValidScenarioName(name string) bool {
+	return len(validation.IsDNS1123Subdomain(name)) == 0 && len(validation.IsValidLabelValue(name)) == 0
+}
+
+// getPullRequestSnapshots returns the Snapshots built for the latest commit of the pull request
+func getPullRequestSnapshots(ctx context.Context, c client.Client, pr PullRequest) ([]*applicationapiv1alpha1.Snapshot, error) {
+	snapshots := &applicationapiv1alpha1.SnapshotList{}
+	if err := c.List(ctx, snapshots, client.MatchingLabels{gitops.PipelineAsCodePullRequestAnnotation: strconv.Itoa(pr.Number)}); err != nil {
+		return nil, fmt.Errorf("failed to list Snapshots of pull request %d: %w", pr.Number, err)
+	}
+
+	var latest *applicationapiv1alpha1.Snapshot
+	prSnapshots := []*applicationapiv1alpha1.Snapshot{}
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if !isSnapshotOfPullRequest(snapshot, pr) {
+			continue
+		}
+		prSnapshots = append(prSnapshots, snapshot)
+		if latest == nil || latest.CreationTimestamp.Before(&snapshot.CreationTimestamp) {
+			latest = snapshot
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	// the Snapshots of previous commits are superseded, only the ones of the latest commit are tested
+	latestSHA := latest.GetLabels()[gitops.PipelineAsCodeSHALabel]
+	return slices.DeleteFunc(prSnapshots, func(snapshot *applicationapiv1alpha1.Snapshot) bool {
+		return snapshot.GetLabels()[gitops.PipelineAsCodeSHALabel] != latestSHA
+	}), nil
+}
+
+// isSnapshotOfPullRequest returns true if the Snapshot was built for the pull request of the repository
+func isSnapshotOfPullRequest(snapshot *applicationapiv1alpha1.Snapshot, pr PullRequest) bool {
+	labels := snapshot.GetLabels()
+	annotations := snapshot.GetAnnotations()
+
+	provider := labels[gitops.PipelineAsCodeGitProviderLabel]
+	if provider == "" {
+		provider = annotations[gitops.PipelineAsCodeGitProviderAnnotation]
+	}
+	if provider != "" && !slices.Contains(pr.GitProviders, provider) {
+		return false
+	}
+
+	if pr.ProjectID != "" {
+		return annotations[gitops.PipelineAsCodeTargetProjectIDAnnotation] == pr.ProjectID
+	}
+	return strings.EqualFold(labels[gitops.PipelineAsCodeURLOrgLabel], pr.Owner) &&
+		strings.EqualFold(labels[gitops.PipelineAsCodeURLRepositoryLabel], pr.Repository)
+}
+
+// getVerifiedSnapshots returns the Snapshots whose Pipelines as Code Repository webhook secret validates the event.
+// Anyone who can create Snapshots can set the pull request labels they are found by, so each Snapshot is checked
+// against the secret of the Repository of its own namespace and only the ones the event is authenticated for are kept.
+func getVerifiedSnapshots(ctx context.Context, c client.Client, logger logr.Logger, snapshots []*applicationapiv1alpha1.Snapshot, verify func(secret []byte) bool) []*applicationapiv1alpha1.Snapshot {
+	verified := []*applicationapiv1alpha1.Snapshot{}
+	for _, snapshot := range snapshots {
+		secret, err := status.GetPACGitProviderWebhookSecret(ctx, c, snapshot)
+		if err != nil {
+			logger.Info("Failed to get the webhook secret to verify the event for the Snapshot, skipping it",
+				"snapshot.Namespace", snapshot.Namespace, "snapshot.Name", snapshot.Name, "error", err.Error())
+			continue
+		}
+		if verify(secret) {
+			verified = append(verified, snapshot)
+		}
+	}
+	return verified
+}
+
+// runCommands runs the commands of the comment on the Snapshots of the pull request, refusing the commands the user
+// doesn't have the permission for, and returns the HTTP status code of the outcome. The permission of the user is
+// resolved for each namespace with the credentials of the Repository the Snapshots of the namespace were built from.
+// The Snapshots of the namespaces the permission can't be resolved for are skipped. The skipped namespaces and
+// the re-run commands dropped because the Snapshot already got one are reported in the error returned with the 202.
+func runCommands(ctx context.Context, c client.Client, logger logr.Logger, snapshots []*applicationapiv1alpha1.Snapshot, commands []Command, event CommentEvent, getPermission func(*applicationapiv1alpha1.Snapshot) (Permission, error)) (int, error) {
+	permissions := map[string]Permission{}
+	skippedNamespaces := []string{}
+	for _, snapshot := range snapshots {
+		if _, ok := permissions[snapshot.Namespace]; ok || slices.Contains(skippedNamespaces, snapshot.Namespace) {
+			continue
+		}
+		permission, err := getPermission(snapshot)
+		if err != nil {
+			logger.Error(err, "Failed to get the permission of the user, skipping the Snapshots of the namespace",
+				"namespace", snapshot.Namespace, "user", event.User)
+			skippedNamespaces = append(skippedNamespaces, snapshot.Namespace)
+			continue
+		}
+		permissions[snapshot.Namespace] = permission
+	}
+	snapshots = slices.DeleteFunc(slices.Clone(snapshots), func(snapshot *applicationapiv1alpha1.Snapshot) bool {
+		return slices.Contains(skippedNamespaces, snapshot.Namespace)
+	})
+
+	applied, refused := 0, 0
+	// the run label holds a single re-run, so a Snapshot gets the first re-run command of the comment which applies to it
+	reruns := map[*applicationapiv1alpha1.Snapshot]Command{}
+	dropped := []string{}
+	for _, command := range commands {
+		for _, snapshot := range snapshots {
+			if permissions[snapshot.Namespace] < command.RequiredPermission() {
+				logger.Info("Refusing integration test command, the user doesn't have the required permission on the repository",
+					"snapshot.Namespace", snapshot.Namespace, "snapshot.Name", snapshot.Name,
+					"command", command.Name, "scenario", command.Scenario, "user", event.User)
+				refused++
+				continue
+			}
+			if rerun, ok := reruns[snapshot]; ok && command.isRerun() {
+				logger.Info("Dropping integration test command, the comment already requested a re-run of the Snapshot",
+					"snapshot.Namespace", snapshot.Namespace, "snapshot.Name", snapshot.Name,
+					"command", command.Name, "scenario", command.Scenario, "rerun.Command", rerun.Name, "rerun.Scenario", rerun.Scenario)
+				dropped = append(dropped, fmt.Sprintf("%s on Snapshot %s/%s", command, snapshot.Namespace, snapshot.Name))
+				continue
+			}
+			ok, err := runCommand(ctx, c, snapshot, command, event)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to run command /%s on Snapshot %s/%s: %w", command.Name, snapshot.Namespace, snapshot.Name, err)
+			}
+			if ok {
+				logger.Info("Ran integration test command from pull request comment",
+					"snapshot.Namespace", snapshot.Namespace, "snapshot.Name", snapshot.Name,
+					"command", command.Name, "scenario", command.Scenario, "user", event.User, "comment", event.URL)
+				applied++
+				if command.isRerun() {
+					reruns[snapshot] = command
+				}
+			}
+		}
+	}
+
+	notes := []string{}
+	if len(skippedNamespaces) > 0 {
+		notes = append(notes, fmt.Sprintf("failed to get the permission of user %s, skipped the Snapshots of the namespaces: %s", event.User, strings.Join(skippedNamespaces, ", ")))
+	}
+	if len(dropped) > 0 {
+		notes = append(notes, fmt.Sprintf("a single re-run can be requested per Snapshot, dropped the commands: %s", strings.Join(dropped, ", ")))
+	}
+
+	switch {
+	case len(snapshots) == 0:
+		// the permission couldn't be resolved for any namespace, the event can be delivered again to run the commands
+		return http.StatusInternalServerError, errors.New(strings.Join(notes, "; "))
+	case applied > 0 && len(notes) > 0:
+		return http.StatusAccepted, errors.New(strings.Join(notes, "; "))
+	case applied > 0:
+		return http.StatusAccepted, nil
+	case refused > 0:
+		notes = append([]string{fmt.Sprintf("user %s doesn't have the permission to run the commands", event.User)}, notes...)
+		return http.StatusForbidden, errors.New(strings.Join(notes, "; "))
+	default:
+		notes = append([]string{"no test of the pull request matches the commands"}, notes...)
+		return http.StatusNotFound, errors.New(strings.Join(notes, "; "))
+	}
+}
+
+// runCommand runs the command on the Snapshot, and returns false if the command doesn't apply to the Snapshot
+func runCommand(ctx context.Context, c client.Client, snapshot *applicationapiv1alpha1.Snapshot, command Command, event CommentEvent) (bool, error) {
+	testStatuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(snapshot)
+	if err != nil {
+		return false, nil
+	}
+
+	if command.Name == RetestFailedCommand {
+		for _, testStatus := range testStatuses.GetStatuses() {
+			if testStatus.Status.IsFailed() {
+				return true, gitops.AddIntegrationTestRerunLabel(ctx, c, snapshot, gitops.SnapshotIntegrationTestRunFailed)
+			}
+		}
+		return false, nil
+	}
+
+	if _, ok := testStatuses.GetScenarioStatus(command.Scenario); !ok {
+		return false, nil
+	}
+	switch command.Name {
+	case RetestCommand:
+		return true, gitops.AddIntegrationTestRerunLabel(ctx, c, snapshot, command.Scenario)
+	default:
+		action := gitops.TestOverrideActionPass
+		if command.Name == SkipCommand {
+			action = gitops.TestOverrideActionSkip
+		}
+		override := gitops.TestOverride{
+			Scenario:    command.Scenario,
+			Action:      action,
+			Reason:      command.Reason,
+			User:        event.User,
+			Source:      event.URL,
+			RequestTime: metav1.Now(),
+		}
+		return true, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			err := gitops.AddTestOverrideRequest(ctx, c, snapshot, override)
+			if err != nil {
+				// refresh the Snapshot to add the request to the latest ones
+				if getErr := c.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot); getErr != nil {
+					return getErr
+				}
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/konflux-ci/integration-service/internal/gitevents"
+)
+
+var _ = Describe("Pull request comment commands", func() {
+
+	DescribeTable("parses the integration test commands of a comment",
+		func(comment string, expected []gitevents.Command) {
+			Expect(gitevents.ParseCommands(comment)).To(Equal(expected))
+		},
+		Entry("retest of a scenario", "/retest scenario-1",
+			[]gitevents.Command{{Name: gitevents.RetestCommand, Scenario: "scenario-1"}}),
+		Entry("retest of the failed scenarios", "/retest-failed",
+			[]gitevents.Command{{Name: gitevents.RetestFailedCommand}}),
+		Entry("override with a reason", "/override scenario-1 the test environment is down",
+			[]gitevents.Command{{Name: gitevents.OverrideCommand, Scenario: "scenario-1", Reason: "the test environment is down"}}),
+		Entry("skip without a reason", "/skip scenario-2",
+			[]gitevents.Command{{Name: gitevents.SkipCommand, Scenario: "scenario-2"}}),
+		Entry("several commands among text", "Looks flaky.\n/retest scenario-1\r\n  /retest scenario-2\nThanks",
+			[]gitevents.Command{{Name: gitevents.RetestCommand, Scenario: "scenario-1"}, {Name: gitevents.RetestCommand, Scenario: "scenario-2"}}),
+		Entry("bare retest left to Pipelines as Code", "/retest", []gitevents.Command{}),
+		Entry("override without a reason", "/override scenario-1", []gitevents.Command{}),
+		Entry("invalid scenario name", "/retest Scenario_1", []gitevents.Command{}),
+		Entry("unknown command", "/test scenario-1", []gitevents.Command{}),
+		Entry("command not at the start of a line", "please /retest scenario-1", []gitevents.Command{}),
+	)
+
+	It("limits the number of commands of a comment", func() {
+		comment := ""
+		for i := 0; i < 20; i++ {
+			comment += "/retest scenario-1\n"
+		}
+		Expect(gitevents.ParseCommands(comment)).To(HaveLen(10))
+	})
+
+	It("requires the maintainers to override or skip tests", func() {
+		Expect(gitevents.Command{Name: gitevents.RetestCommand}.RequiredPermission()).To(Equal(gitevents.PermissionWrite))
+		Expect(gitevents.Command{Name: gitevents.RetestFailedCommand}.RequiredPermission()).To(Equal(gitevents.PermissionWrite))
+		Expect(gitevents.Command{Name: gitevents.OverrideCommand}.RequiredPermission()).To(Equal(gitevents.PermissionMaintain))
+		Expect(gitevents.Command{Name: gitevents.SkipCommand}.RequiredPermission()).To(Equal(gitevents.PermissionMaintain))
+	})
+})
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+)
+
+// SetPermission makes the handler grant the given permission to every user instead of asking GitHub
+func (h *GitHubHandler) SetPermission(permission Permission) {
+	h.getPermission = func(context.Context, *applicationapiv1alpha1.Snapshot, string, string, string) (Permission, error) {
+		return permission, nil
+	}
+}
+
+// SetPermission makes the handler grant the given permission to every user instead of asking GitLab
+func (h *GitLabHandler) SetPermission(permission Permission) {
+	h.getPermission = func(context.Context, *applicationapiv1alpha1.Snapshot, int64, int64) (Permission, error) {
+		return permission, nil
+	}
+}
+
+// SetPermission makes the handler grant the given permission to every user instead of asking Forgejo
+func (h *ForgejoHandler) SetPermission(permission Permission) {
+	h.getPermission = func(context.Context, *applicationapiv1alpha1.Snapshot, string, string, string) (Permission, error) {
+		return permission, nil
+	}
+}
+
+// SetPermissionFunc makes the handler ask the given function for the permission of the user on the Repository of a Snapshot
+func (h *ForgejoHandler) SetPermissionFunc(getPermission func(*applicationapiv1alpha1.Snapshot) (Permission, error)) {
+	h.getPermission = func(_ context.Context, snapshot *applicationapiv1alpha1.Snapshot, _, _, _ string) (Permission, error) {
+		return getPermission(snapshot)
+	}
+}
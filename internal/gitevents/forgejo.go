@@ -0,0 +1,207 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v3"
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/pkg/common"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+const (
+	// ForgejoEndpointPath is the path the Forgejo events endpoint is served at
+	ForgejoEndpointPath = "/forgejo-events"
+
+	// forgejoIssueCommentEvent is the type of the events of issue and pull request comments
+	forgejoIssueCommentEvent = "issue_comment"
+)
+
+// forgejoIssueCommentPayload is the part of the payload of a Forgejo issue_comment event the handler needs
+type forgejoIssueCommentPayload struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int              `json:"number"`
+		PullRequest *json.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+	IsPull  bool `json:"is_pull"`
+	Comment struct {
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	} `json:"comment"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// ForgejoHandler handles the Forgejo webhook events of pull request comments which run integration test commands
+type ForgejoHandler struct {
+	logger logr.Logger
+	client client.Client
+	// getPermission returns the permission of the user on the repository the Snapshot was built from
+	getPermission func(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, owner, repo, user string) (Permission, error)
+}
+
+// NewForgejoHandler returns a new ForgejoHandler which finds and updates the Snapshots with the given client
+func NewForgejoHandler(logger logr.Logger, client client.Client) *ForgejoHandler {
+	h := &ForgejoHandler{
+		logger: logger,
+		client: client,
+	}
+	h.getPermission = h.getForgejoPermission
+	return h
+}
+
+// ServeHTTP runs the integration test commands of the pull request comment the Forgejo webhook event is about,
+// once the event signature is verified with the webhook secret of the Pipelines as Code Repository. Other events
+// are acknowledged and ignored.
+func (h *ForgejoHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if getForgejoHeader(r, "Event") != forgejoIssueCommentEvent {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to read the event: %s", err), http.StatusBadRequest)
+		return
+	}
+	event := &forgejoIssueCommentPayload{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		http.Error(rw, fmt.Sprintf("failed to parse the event: %s", err), http.StatusBadRequest)
+		return
+	}
+	if event.Action != createdAction || (!event.IsPull && event.Issue.PullRequest == nil) {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	statusCode, err := h.runCommentCommands(r.Context(), getForgejoHeader(r, "Signature"), payload, event)
+	if err != nil {
+		// the commands dropped along with the accepted ones are logged when dropped
+		if statusCode != http.StatusAccepted {
+			h.logger.Error(err, "failed to run the integration test commands of the pull request comment",
+				"comment.URL", event.Comment.HTMLURL)
+		}
+		http.Error(rw, err.Error(), statusCode)
+		return
+	}
+	rw.WriteHeader(statusCode)
+}
+
+// runCommentCommands runs the integration test commands of the pull request comment on the Snapshots of the pull request
+func (h *ForgejoHandler) runCommentCommands(ctx context.Context, signature string, payload []byte, event *forgejoIssueCommentPayload) (int, error) {
+	commands := ParseCommands(event.Comment.Body)
+	if len(commands) == 0 {
+		return http.StatusOK, nil
+	}
+
+	commentEvent := CommentEvent{
+		PullRequest: PullRequest{
+			GitProviders: []string{gitops.PipelineAsCodeForgejoProviderType, gitops.PipelineAsCodeGiteaProviderType},
+			Owner:        event.Repository.Owner.Login,
+			Repository:   event.Repository.Name,
+			Number:       event.Issue.Number,
+		},
+		User: event.Sender.Login,
+		Body: event.Comment.Body,
+		URL:  event.Comment.HTMLURL,
+	}
+	snapshots, err := getPullRequestSnapshots(ctx, h.client, commentEvent.PullRequest)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// the signature can only be verified with the webhook secrets of the Repositories the Snapshots were built from,
+	// the event is unauthorized when none of them validates it, whether Snapshots exist for the pull request or not
+	snapshots = getVerifiedSnapshots(ctx, h.client, h.logger, snapshots, func(secret []byte) bool {
+		ok, err := forgejo.VerifyWebhookSignature(string(secret), signature, payload)
+		return err == nil && ok
+	})
+	if len(snapshots) == 0 {
+		return http.StatusUnauthorized, fmt.Errorf("invalid signature for the Snapshots of pull request %d of %s/%s", commentEvent.PullRequest.Number, commentEvent.PullRequest.Owner, commentEvent.PullRequest.Repository)
+	}
+
+	return runCommands(ctx, h.client, h.logger, snapshots, commands, commentEvent, func(snapshot *applicationapiv1alpha1.Snapshot) (Permission, error) {
+		return h.getPermission(ctx, snapshot, commentEvent.PullRequest.Owner, commentEvent.PullRequest.Repository, commentEvent.User)
+	})
+}
+
+// getForgejoPermission returns the permission of the user on the repository, using the token of the Pipelines as Code
+// Repository. Owners and administrators are the maintainers.
+func (h *ForgejoHandler) getForgejoPermission(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, owner, repo, user string) (Permission, error) {
+	token, err := status.GetPACGitProviderToken(ctx, h.client, snapshot)
+	if err != nil {
+		return PermissionNone, err
+	}
+	repoURL, err := url.Parse(snapshot.GetAnnotations()[gitops.PipelineAsCodeRepoURLAnnotation])
+	if err != nil {
+		return PermissionNone, fmt.Errorf("failed to parse repo-url: %w", err)
+	}
+	// forgejo client automatically adds /api/v1 to all paths
+	apiURL := fmt.Sprintf("%s://%s", repoURL.Scheme, repoURL.Host)
+
+	fjClient, err := forgejo.NewClient(apiURL, forgejo.SetToken(token), forgejo.SetUserAgent(common.IntegrationServiceUserAgent), forgejo.SetContext(ctx))
+	if err != nil {
+		return PermissionNone, fmt.Errorf("failed to create forgejo client: %w", err)
+	}
+	result, resp, err := fjClient.CollaboratorPermission(owner, repo, user)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return PermissionNone, nil
+		}
+		return PermissionNone, err
+	}
+	switch result.Permission {
+	case forgejo.AccessModeOwner, forgejo.AccessModeAdmin:
+		return PermissionMaintain, nil
+	case forgejo.AccessModeWrite:
+		return PermissionWrite, nil
+	default:
+		return PermissionNone, nil
+	}
+}
+
+// getForgejoHeader returns the value of the Forgejo webhook header, falling back to the Gitea one
+// which is still sent by Forgejo and by the Gitea instances Pipelines as Code also supports
+func getForgejoHeader(r *http.Request, name string) string {
+	if value := r.Header.Get("X-Forgejo-" + name); value != "" {
+		return value
+	}
+	return r.Header.Get("X-Gitea-" + name)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/internal/gitevents"
+)
+
+var _ = Describe("Forgejo events handler", func() {
+
+	const repoURL = "https://codeberg.org/org/repo"
+
+	var (
+		k8sClient client.Client
+		handler   *gitevents.ForgejoHandler
+	)
+
+	sendEvent := func(eventType, payload, secret string) *httptest.ResponseRecorder {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+		request := httptest.NewRequest(http.MethodPost, gitevents.ForgejoEndpointPath, strings.NewReader(payload))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Forgejo-Event", eventType)
+		request.Header.Set("X-Forgejo-Signature", hex.EncodeToString(mac.Sum(nil)))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	commentPayload := func(comment string) string {
+		return fmt.Sprintf(`{"action": "created", "is_pull": true, "issue": {"number": 1},
+			"comment": {"body": %q, "html_url": "https://codeberg.org/org/repo/pulls/1#issuecomment-1"},
+			"repository": {"name": "repo", "owner": {"login": "org"}}, "sender": {"login": "user-1"}}`, comment)
+	}
+
+	getRerunLabel := func() string {
+		snapshot := &applicationapiv1alpha1.Snapshot{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "snapshot-1"}, snapshot)).To(Succeed())
+		return snapshot.GetLabels()[gitops.SnapshotIntegrationTestRun]
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(pacv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "forgejo-secret", Namespace: "default"},
+				Data:       map[string][]byte{"hook-secret": []byte(webhookSecret)},
+			},
+			&pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL: repoURL,
+					GitProvider: &pacv1alpha1.GitProvider{
+						Secret:        &pacv1alpha1.Secret{Name: "forgejo-secret", Key: "token"},
+						WebhookSecret: &pacv1alpha1.Secret{Name: "forgejo-secret", Key: "hook-secret"},
+					},
+				},
+			},
+			&applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "snapshot-1",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(time.Now()),
+					Labels: map[string]string{
+						gitops.PipelineAsCodeSHALabel:              "abc123",
+						gitops.PipelineAsCodePullRequestAnnotation: "1",
+						gitops.PipelineAsCodeURLOrgLabel:           "org",
+						gitops.PipelineAsCodeURLRepositoryLabel:    "repo",
+						gitops.PipelineAsCodeGitProviderLabel:      gitops.PipelineAsCodeGiteaProviderType,
+					},
+					Annotations: map[string]string{
+						gitops.PipelineAsCodeRepoURLAnnotation: repoURL,
+						gitops.SnapshotTestsStatusAnnotation:   `[{"scenario":"scenario-1","status":"TestFail","lastUpdateTime":"2026-10-01T10:00:00Z"}]`,
+					},
+				},
+			},
+		).Build()
+		handler = gitevents.NewForgejoHandler(logr.Discard(), k8sClient)
+		handler.SetPermission(gitevents.PermissionWrite)
+	})
+
+	It("runs the commands of a pull request comment", func() {
+		response := sendEvent("issue_comment", commentPayload("/retest-failed"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRerunLabel()).To(Equal(gitops.SnapshotIntegrationTestRunFailed))
+	})
+
+	It("only runs the first re-run command of a Snapshot", func() {
+		response := sendEvent("issue_comment", commentPayload("/retest-failed\n/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(response.Body.String()).To(ContainSubstring("/retest scenario-1 on Snapshot default/snapshot-1"))
+		Expect(getRerunLabel()).To(Equal(gitops.SnapshotIntegrationTestRunFailed))
+	})
+
+	It("rejects events with an invalid signature", func() {
+		response := sendEvent("issue_comment", commentPayload("/retest scenario-1"), "wrong-secret")
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		Expect(getRerunLabel()).To(BeEmpty())
+	})
+
+	It("rejects events on a pull request without Snapshots", func() {
+		payload := strings.Replace(commentPayload("/retest scenario-1"), `"number": 1`, `"number": 2`, 1)
+		response := sendEvent("issue_comment", payload, webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("resolves the permission of the user for the Repository of each Snapshot", func() {
+		namespaces := []string{}
+		handler.SetPermissionFunc(func(snapshot *applicationapiv1alpha1.Snapshot) (gitevents.Permission, error) {
+			namespaces = append(namespaces, snapshot.Namespace)
+			return gitevents.PermissionWrite, nil
+		})
+		response := sendEvent("issue_comment", commentPayload("/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(namespaces).To(Equal([]string{"default"}))
+	})
+
+	It("skips the Snapshots of the namespaces the permission of the user can't be resolved for", func() {
+		ctx := context.Background()
+		for _, obj := range []client.Object{
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "forgejo-secret", Namespace: "tenant-2"},
+				Data:       map[string][]byte{"hook-secret": []byte(webhookSecret)},
+			},
+			&pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "tenant-2"},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL: repoURL,
+					GitProvider: &pacv1alpha1.GitProvider{
+						Secret:        &pacv1alpha1.Secret{Name: "forgejo-secret", Key: "token"},
+						WebhookSecret: &pacv1alpha1.Secret{Name: "forgejo-secret", Key: "hook-secret"},
+					},
+				},
+			},
+		} {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+		}
+		snapshot := &applicationapiv1alpha1.Snapshot{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "snapshot-1"}, snapshot)).To(Succeed())
+		otherSnapshot := &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "snapshot-1",
+				Namespace:   "tenant-2",
+				Labels:      snapshot.GetLabels(),
+				Annotations: snapshot.GetAnnotations(),
+			},
+		}
+		Expect(k8sClient.Create(ctx, otherSnapshot)).To(Succeed())
+
+		handler.SetPermissionFunc(func(snapshot *applicationapiv1alpha1.Snapshot) (gitevents.Permission, error) {
+			if snapshot.Namespace == "tenant-2" {
+				return gitevents.PermissionNone, fmt.Errorf("forgejo is unavailable")
+			}
+			return gitevents.PermissionWrite, nil
+		})
+		response := sendEvent("issue_comment", commentPayload("/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(response.Body.String()).To(ContainSubstring("skipped the Snapshots of the namespaces: tenant-2"))
+		Expect(getRerunLabel()).To(Equal("scenario-1"))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherSnapshot), otherSnapshot)).To(Succeed())
+		Expect(otherSnapshot.GetLabels()).ToNot(HaveKey(gitops.SnapshotIntegrationTestRun))
+	})
+
+	It("fails when the permission of the user can't be resolved for any namespace", func() {
+		handler.SetPermissionFunc(func(*applicationapiv1alpha1.Snapshot) (gitevents.Permission, error) {
+			return gitevents.PermissionNone, fmt.Errorf("forgejo is unavailable")
+		})
+		response := sendEvent("issue_comment", commentPayload("/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusInternalServerError))
+		Expect(getRerunLabel()).To(BeEmpty())
+	})
+
+	It("refuses the commands the user doesn't have the permission for", func() {
+		response := sendEvent("issue_comment", commentPayload("/skip scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("ignores other events and comments on issues", func() {
+		Expect(sendEvent("push", `{"ref": "refs/heads/main"}`, webhookSecret).Code).To(Equal(http.StatusOK))
+		payload := `{"action": "created", "is_pull": false, "issue": {"number": 1}, "comment": {"body": "/retest scenario-1"},
+			"repository": {"name": "repo", "owner": {"login": "org"}}, "sender": {"login": "user-1"}}`
+		Expect(sendEvent("issue_comment", payload, webhookSecret).Code).To(Equal(http.StatusOK))
+		Expect(getRerunLabel()).To(BeEmpty())
+	})
+})
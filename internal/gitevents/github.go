@@ -0,0 +1,330 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	ghapi "github.com/google/go-github/v45/github"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/git/github"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+const (
+	// GitHubEndpointPath is the path the GitHub events endpoint is served at
+	GitHubEndpointPath = "/github-events"
+
+	// maxPayloadSize is the maximum size of an accepted event payload
+	maxPayloadSize = 5 << 20
+
+	// checkRunEventType is the type of the GitHub webhook events about check runs
+	checkRunEventType = "check_run"
+
+	// issueCommentEventType is the type of the GitHub webhook events about comments on issues and pull requests
+	issueCommentEventType = "issue_comment"
+
+	// createdAction is the action of the events sent when a comment is created
+	createdAction = "created"
+
+	// rerequestedAction is the action of the check_run event sent when a user clicks "Re-run" on a check run
+	rerequestedAction = "rerequested"
+
+	// requestedActionAction is the action of the check_run event sent when a user clicks a requested action of a check run
+	requestedActionAction = "requested_action"
+)
+
+// GitHubHandler handles the GitHub App webhook events which re-run the integration tests reported as check runs,
+// and the integration test commands of pull request comments
+type GitHubHandler struct {
+	logger logr.Logger
+	client client.Client
+	// getPermission returns the permission of the user on the repository the Snapshot was built from
+	getPermission func(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, owner, repo, user string) (Permission, error)
+}
+
+// NewGitHubHandler returns a new GitHubHandler which finds and updates the Snapshots with the given client
+func NewGitHubHandler(logger logr.Logger, client client.Client) *GitHubHandler {
+	h := &GitHubHandler{
+		logger: logger,
+		client: client,
+	}
+	h.getPermission = h.getGitHubPermission
+	return h
+}
+
+// ServeHTTP verifies the signature of the GitHub webhook event and re-runs the integration test of the check run
+// the event is about when a re-run was requested, or runs the commands of the pull request comment the event is
+// about. Other events are acknowledged and ignored.
+func (h *GitHubHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+
+	secret, err := h.getWebhookSecret(ctx)
+	if err != nil {
+		h.logger.Error(err, "failed to get the GitHub App webhook secret")
+		http.Error(rw, "failed to verify the event", http.StatusInternalServerError)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(rw, r.Body, maxPayloadSize)
+	payload, err := ghapi.ValidatePayload(r, secret)
+	if err != nil {
+		h.logger.Info("Rejecting GitHub event with an invalid signature", "error", err.Error())
+		http.Error(rw, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType := ghapi.WebHookType(r)
+	if eventType != checkRunEventType && eventType != issueCommentEventType {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	event, err := ghapi.ParseWebHook(eventType, payload)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("invalid %s event: %s", eventType, err), http.StatusBadRequest)
+		return
+	}
+
+	statusCode := http.StatusOK
+	switch event := event.(type) {
+	case *ghapi.CheckRunEvent:
+		if !isRerunRequested(event) {
+			break
+		}
+		statusCode, err = h.rerunCheckRun(ctx, event)
+		if err != nil {
+			h.logger.Error(err, "failed to re-run the integration test of the check run",
+				"checkRun.ExternalID", event.GetCheckRun().GetExternalID(), "checkRun.HeadSHA", event.GetCheckRun().GetHeadSHA())
+		}
+	case *ghapi.IssueCommentEvent:
+		if event.GetAction() != createdAction || !event.GetIssue().IsPullRequest() {
+			break
+		}
+		statusCode, err = h.runCommentCommands(ctx, event)
+		// the commands dropped along with the accepted ones are logged when dropped
+		if err != nil && statusCode != http.StatusAccepted {
+			h.logger.Error(err, "failed to run the integration test commands of the pull request comment",
+				"comment.URL", event.GetComment().GetHTMLURL())
+		}
+	}
+	if err != nil {
+		http.Error(rw, err.Error(), statusCode)
+		return
+	}
+	rw.WriteHeader(statusCode)
+}
+
+// isRerunRequested returns true if the user asked to re-run the check run
+func isRerunRequested(event *ghapi.CheckRunEvent) bool {
+	switch event.GetAction() {
+	case rerequestedAction:
+		return true
+	case requestedActionAction:
+		return event.GetRequestedAction() != nil && event.GetRequestedAction().Identifier == github.RerunActionIdentifier
+	}
+	return false
+}
+
+// rerunCheckRun adds the re-run label for the scenario of the check run to the Snapshot the check run was reported for
+func (h *GitHubHandler) rerunCheckRun(ctx context.Context, event *ghapi.CheckRunEvent) (int, error) {
+	checkRun := event.GetCheckRun()
+	snapshot, scenarioName, err := h.findSnapshotForCheckRun(ctx, event.GetRepo().GetOwner().GetLogin(), event.GetRepo().GetName(),
+		checkRun.GetHeadSHA(), checkRun.GetExternalID())
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if snapshot == nil {
+		return http.StatusNotFound, fmt.Errorf("no Snapshot found for check run %s of commit %s", checkRun.GetExternalID(), checkRun.GetHeadSHA())
+	}
+
+	if err := gitops.AddIntegrationTestRerunLabel(ctx, h.client, snapshot, scenarioName); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	h.logger.Info("Requested re-run of the integration test from GitHub check run",
+		"snapshot.Namespace", snapshot.Namespace, "snapshot.Name", snapshot.Name, "scenario.Name", scenarioName,
+		"sender", event.GetSender().GetLogin())
+	return http.StatusAccepted, nil
+}
+
+// findSnapshotForCheckRun returns the latest Snapshot built from the given commit of the repository which reported
+// a scenario matching the external ID of the check run, along with the name of the scenario
+func (h *GitHubHandler) findSnapshotForCheckRun(ctx context.Context, owner, repo, sha, externalID string) (*applicationapiv1alpha1.Snapshot, string, error) {
+	if sha == "" || externalID == "" {
+		return nil, "", nil
+	}
+
+	snapshots := &applicationapiv1alpha1.SnapshotList{}
+	if err := h.client.List(ctx, snapshots, client.MatchingLabels{gitops.PipelineAsCodeSHALabel: sha}); err != nil {
+		return nil, "", fmt.Errorf("failed to list Snapshots of commit %s: %w", sha, err)
+	}
+
+	var latest *applicationapiv1alpha1.Snapshot
+	var latestScenarioName string
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if !isSnapshotOfRepository(snapshot, owner, repo) {
+			continue
+		}
+		scenarioName, ok := getCheckRunScenarioName(snapshot, externalID)
+		if !ok {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&snapshot.CreationTimestamp) {
+			latest = snapshot
+			latestScenarioName = scenarioName
+		}
+	}
+	return latest, latestScenarioName, nil
+}
+
+// runCommentCommands runs the integration test commands of the pull request comment on the Snapshots of the pull request
+func (h *GitHubHandler) runCommentCommands(ctx context.Context, event *ghapi.IssueCommentEvent) (int, error) {
+	commands := ParseCommands(event.GetComment().GetBody())
+	if len(commands) == 0 {
+		return http.StatusOK, nil
+	}
+
+	commentEvent := CommentEvent{
+		PullRequest: PullRequest{
+			GitProviders: []string{gitops.PipelineAsCodeGitHubProviderType},
+			Owner:        event.GetRepo().GetOwner().GetLogin(),
+			Repository:   event.GetRepo().GetName(),
+			Number:       event.GetIssue().GetNumber(),
+		},
+		User: event.GetComment().GetUser().GetLogin(),
+		Body: event.GetComment().GetBody(),
+		URL:  event.GetComment().GetHTMLURL(),
+	}
+	snapshots, err := getPullRequestSnapshots(ctx, h.client, commentEvent.PullRequest)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(snapshots) == 0 {
+		return http.StatusNotFound, fmt.Errorf("no Snapshot found for pull request %d of %s/%s", commentEvent.PullRequest.Number,
+			commentEvent.PullRequest.Owner, commentEvent.PullRequest.Repository)
+	}
+
+	return runCommands(ctx, h.client, h.logger, snapshots, commands, commentEvent, func(snapshot *applicationapiv1alpha1.Snapshot) (Permission, error) {
+		return h.getPermission(ctx, snapshot, commentEvent.PullRequest.Owner, commentEvent.PullRequest.Repository, commentEvent.User)
+	})
+}
+
+// getGitHubPermission returns the permission of the user on the repository, using the installation of the GitHub App
+// the Snapshot was reported with. Repository admins are the maintainers, GitHub doesn't tell apart the maintain role.
+func (h *GitHubHandler) getGitHubPermission(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, owner, repo, user string) (Permission, error) {
+	credentials, err := status.GetAppCredentials(ctx, h.client, snapshot)
+	if err != nil {
+		return PermissionNone, err
+	}
+
+	ghClient := github.NewClient(h.logger)
+	token, _, err := ghClient.CreateAppInstallationToken(ctx, credentials.AppID, credentials.InstallationID, credentials.PrivateKey)
+	if err != nil {
+		return PermissionNone, fmt.Errorf("failed to create the GitHub App installation token: %w", err)
+	}
+	ghClient.SetOAuthToken(ctx, token)
+
+	permission, _, err := ghClient.GetUserPermission(ctx, owner, repo, user)
+	if err != nil {
+		return PermissionNone, err
+	}
+	switch permission {
+	case "admin":
+		return PermissionMaintain, nil
+	case "write":
+		return PermissionWrite, nil
+	default:
+		return PermissionNone, nil
+	}
+}
+
+// isSnapshotOfRepository returns false if the Snapshot was built from a different repository than the given one
+func isSnapshotOfRepository(snapshot *applicationapiv1alpha1.Snapshot, owner, repo string) bool {
+	labels := snapshot.GetLabels()
+	if org, ok := labels[gitops.PipelineAsCodeURLOrgLabel]; ok && owner != "" && !strings.EqualFold(org, owner) {
+		return false
+	}
+	if repository, ok := labels[gitops.PipelineAsCodeURLRepositoryLabel]; ok && repo != "" && !strings.EqualFold(repository, repo) {
+		return false
+	}
+	return true
+}
+
+// getCheckRunScenarioName returns the name of the scenario tested for the Snapshot which is reported by the check run
+// with the given external ID. The external ID is the scenario name, suffixed by the component or PR group if any.
+func getCheckRunScenarioName(snapshot *applicationapiv1alpha1.Snapshot, externalID string) (string, bool) {
+	statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(snapshot)
+	if err != nil {
+		return "", false
+	}
+
+	candidates := []string{externalID}
+	for _, suffix := range []string{snapshot.GetLabels()[gitops.SnapshotComponentLabel], snapshot.GetAnnotations()[gitops.PRGroupAnnotation]} {
+		if suffix == "" {
+			continue
+		}
+		if scenarioName, found := strings.CutSuffix(externalID, "-"+suffix); found {
+			candidates = append(candidates, scenarioName)
+		}
+	}
+	for _, scenarioName := range candidates {
+		if _, ok := statuses.GetScenarioStatus(scenarioName); ok {
+			return scenarioName, true
+		}
+	}
+	return "", false
+}
+
+// getWebhookSecret returns the webhook secret of the GitHub App from the Pipelines as Code secret
+func (h *GitHubHandler) getWebhookSecret(ctx context.Context) ([]byte, error) {
+	integrationNS := os.Getenv("INTEGRATION_NS")
+	if integrationNS == "" {
+		integrationNS = "integration-service"
+	}
+	pacSecretName := os.Getenv("PAC_SECRET")
+	if pacSecretName == "" {
+		pacSecretName = "pipelines-as-code-secret"
+	}
+	webhookSecretKey := os.Getenv("GITHUBWEBHOOK_SECRET")
+	if webhookSecretKey == "" {
+		webhookSecretKey = "webhook.secret"
+	}
+
+	pacSecret := &v1.Secret{}
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: integrationNS, Name: pacSecretName}, pacSecret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", integrationNS, pacSecretName, err)
+	}
+	secret, ok := pacSecret.Data[webhookSecretKey]
+	if !ok || len(secret) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s key", integrationNS, pacSecretName, webhookSecretKey)
+	}
+	return secret, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/internal/gitevents"
+)
+
+const webhookSecret = "webhook-secret"
+
+var _ = Describe("GitHub events handler", func() {
+
+	var (
+		k8sClient client.Client
+		handler   *gitevents.GitHubHandler
+	)
+
+	newSnapshot := func(name, sha, component string, created time.Time) *applicationapiv1alpha1.Snapshot {
+		return &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					gitops.PipelineAsCodeSHALabel:              sha,
+					gitops.PipelineAsCodeURLOrgLabel:           "org",
+					gitops.PipelineAsCodeURLRepositoryLabel:    "repo",
+					gitops.SnapshotComponentLabel:              component,
+					gitops.PipelineAsCodePullRequestAnnotation: "1",
+				},
+				Annotations: map[string]string{
+					gitops.SnapshotTestsStatusAnnotation: `[{"scenario":"scenario-1","status":"TestFail","lastUpdateTime":"2026-10-01T10:00:00Z"}]`,
+				},
+			},
+		}
+	}
+
+	sendEvent := func(eventType, payload, secret string) *httptest.ResponseRecorder {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+		request := httptest.NewRequest(http.MethodPost, gitevents.GitHubEndpointPath, strings.NewReader(payload))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-GitHub-Event", eventType)
+		request.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	getOverrideRequests := func(name string) []gitops.TestOverride {
+		snapshot := &applicationapiv1alpha1.Snapshot{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, snapshot)).To(Succeed())
+		requests, err := gitops.GetTestOverrideRequests(snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		return requests
+	}
+
+	commentPayload := func(comment string) string {
+		return fmt.Sprintf(`{"action": "created", "issue": {"number": 1, "pull_request": {"url": "https://api.github.com/repos/org/repo/pulls/1"}},
+			"comment": {"body": %q, "html_url": "https://github.com/org/repo/pull/1#issuecomment-1", "user": {"login": "user-1"}},
+			"repository": {"name": "repo", "owner": {"login": "org"}}}`, comment)
+	}
+
+	getRerunLabel := func(name string) string {
+		snapshot := &applicationapiv1alpha1.Snapshot{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: name}, snapshot)).To(Succeed())
+		return snapshot.GetLabels()[gitops.SnapshotIntegrationTestRun]
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		now := time.Now()
+		otherCommitSnapshot := newSnapshot("snapshot-other-commit", "def456", "component-1", now)
+		otherCommitSnapshot.Labels[gitops.PipelineAsCodePullRequestAnnotation] = "2"
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelines-as-code-secret", Namespace: "integration-service"},
+				Data:       map[string][]byte{"webhook.secret": []byte(webhookSecret)},
+			},
+			newSnapshot("snapshot-old", "abc123", "component-1", now.Add(-time.Hour)),
+			newSnapshot("snapshot-new", "abc123", "component-1", now),
+			otherCommitSnapshot,
+		).Build()
+		handler = gitevents.NewGitHubHandler(logr.Discard(), k8sClient)
+	})
+
+	It("re-runs the scenario of the check run on the latest Snapshot when the re-run action is requested", func() {
+		payload := `{"action": "requested_action", "requested_action": {"identifier": "rerun"},
+			"check_run": {"head_sha": "abc123", "external_id": "scenario-1-component-1"},
+			"repository": {"name": "repo", "owner": {"login": "Org"}}}`
+		response := sendEvent("check_run", payload, webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRerunLabel("snapshot-new")).To(Equal("scenario-1"))
+		Expect(getRerunLabel("snapshot-old")).To(BeEmpty())
+		Expect(getRerunLabel("snapshot-other-commit")).To(BeEmpty())
+	})
+
+	It("re-runs the scenario of the check run when the check run is re-requested", func() {
+		payload := `{"action": "rerequested", "check_run": {"head_sha": "def456", "external_id": "scenario-1"},
+			"repository": {"name": "repo", "owner": {"login": "org"}}}`
+		response := sendEvent("check_run", payload, webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRerunLabel("snapshot-other-commit")).To(Equal("scenario-1"))
+	})
+
+	It("rejects events with an invalid signature", func() {
+		payload := `{"action": "rerequested", "check_run": {"head_sha": "abc123", "external_id": "scenario-1"}}`
+		response := sendEvent("check_run", payload, "wrong-secret")
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		Expect(getRerunLabel("snapshot-new")).To(BeEmpty())
+	})
+
+	It("ignores other events and actions", func() {
+		Expect(sendEvent("ping", `{"zen": "Keep it logically awesome."}`, webhookSecret).Code).To(Equal(http.StatusOK))
+
+		payload := `{"action": "requested_action", "requested_action": {"identifier": "other"},
+			"check_run": {"head_sha": "abc123", "external_id": "scenario-1"}}`
+		Expect(sendEvent("check_run", payload, webhookSecret).Code).To(Equal(http.StatusOK))
+		Expect(getRerunLabel("snapshot-new")).To(BeEmpty())
+	})
+
+	It("returns not found when no Snapshot matches the check run", func() {
+		for _, payload := range []string{
+			`{"action": "rerequested", "check_run": {"head_sha": "abc123", "external_id": "scenario-2"}}`,
+			`{"action": "rerequested", "check_run": {"head_sha": "abc123", "external_id": "scenario-1"},
+				"repository": {"name": "other-repo", "owner": {"login": "org"}}}`,
+		} {
+			Expect(sendEvent("check_run", payload, webhookSecret).Code).To(Equal(http.StatusNotFound))
+		}
+		Expect(getRerunLabel("snapshot-new")).To(BeEmpty())
+	})
+
+	It("re-runs the scenario of a /retest pull request comment on the Snapshots of the latest commit", func() {
+		handler.SetPermission(gitevents.PermissionWrite)
+		response := sendEvent("issue_comment", commentPayload("/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRerunLabel("snapshot-new")).To(Equal("scenario-1"))
+		Expect(getRerunLabel("snapshot-old")).To(Equal("scenario-1"))
+		Expect(getRerunLabel("snapshot-other-commit")).To(BeEmpty())
+	})
+
+	It("re-runs the failed scenarios of a /retest-failed pull request comment", func() {
+		handler.SetPermission(gitevents.PermissionWrite)
+		response := sendEvent("issue_comment", commentPayload("/retest-failed"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRerunLabel("snapshot-new")).To(Equal(gitops.SnapshotIntegrationTestRunFailed))
+	})
+
+	It("requests the override of a scenario when a maintainer comments /override", func() {
+		handler.SetPermission(gitevents.PermissionMaintain)
+		response := sendEvent("issue_comment", commentPayload("/override scenario-1 the test environment is down"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		requests := getOverrideRequests("snapshot-new")
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Scenario).To(Equal("scenario-1"))
+		Expect(requests[0].Action).To(Equal(gitops.TestOverrideActionPass))
+		Expect(requests[0].Reason).To(Equal("the test environment is down"))
+		Expect(requests[0].User).To(Equal("user-1"))
+		Expect(requests[0].Source).To(Equal("https://github.com/org/repo/pull/1#issuecomment-1"))
+	})
+
+	It("refuses the commands the user doesn't have the permission for", func() {
+		handler.SetPermission(gitevents.PermissionWrite)
+		response := sendEvent("issue_comment", commentPayload("/override scenario-1 the test environment is down"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusForbidden))
+		Expect(getOverrideRequests("snapshot-new")).To(BeEmpty())
+
+		handler.SetPermission(gitevents.PermissionNone)
+		response = sendEvent("issue_comment", commentPayload("/retest scenario-1"), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusForbidden))
+		Expect(getRerunLabel("snapshot-new")).To(BeEmpty())
+	})
+
+	It("ignores comments on issues and comments without commands", func() {
+		handler.SetPermission(gitevents.PermissionMaintain)
+		payload := `{"action": "created", "issue": {"number": 1}, "comment": {"body": "/retest scenario-1", "user": {"login": "user-1"}},
+			"repository": {"name": "repo", "owner": {"login": "org"}}}`
+		Expect(sendEvent("issue_comment", payload, webhookSecret).Code).To(Equal(http.StatusOK))
+		Expect(sendEvent("issue_comment", commentPayload("LGTM"), webhookSecret).Code).To(Equal(http.StatusOK))
+		Expect(getRerunLabel("snapshot-new")).To(BeEmpty())
+	})
+})
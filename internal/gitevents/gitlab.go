@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/pkg/common"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+// GitLabEndpointPath is the path the GitLab events endpoint is served at
+const GitLabEndpointPath = "/gitlab-events"
+
+// GitLabHandler handles the GitLab webhook events of merge request comments which run integration test commands
+type GitLabHandler struct {
+	logger logr.Logger
+	client client.Client
+	// getPermission returns the permission of the user on the project the Snapshot was built from
+	getPermission func(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, projectID, userID int64) (Permission, error)
+}
+
+// NewGitLabHandler returns a new GitLabHandler which finds and updates the Snapshots with the given client
+func NewGitLabHandler(logger logr.Logger, client client.Client) *GitLabHandler {
+	h := &GitLabHandler{
+		logger: logger,
+		client: client,
+	}
+	h.getPermission = h.getGitLabPermission
+	return h
+}
+
+// ServeHTTP runs the integration test commands of the merge request comment the GitLab webhook event is about,
+// once the event token is verified with the webhook secret of the Pipelines as Code Repository. Other events are
+// acknowledged and ignored.
+func (h *GitLabHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	eventType := gitlab.HookEventType(r)
+	if eventType != gitlab.EventTypeNote {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to read the event: %s", err), http.StatusBadRequest)
+		return
+	}
+	event, err := gitlab.ParseWebhook(eventType, payload)
+	if err != nil {
+		// notes on commits, issues and snippets are not supported by the client and are of no interest
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	commentEvent, ok := event.(*gitlab.MergeCommentEvent)
+	if !ok || (commentEvent.ObjectAttributes.Action != "" && commentEvent.ObjectAttributes.Action != gitlab.CommentEventActionCreate) {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	statusCode, err := h.runCommentCommands(r.Context(), gitlab.HookEventToken(r), commentEvent)
+	if err != nil {
+		// the commands dropped along with the accepted ones are logged when dropped
+		if statusCode != http.StatusAccepted {
+			h.logger.Error(err, "failed to run the integration test commands of the merge request comment",
+				"comment.URL", commentEvent.ObjectAttributes.URL)
+		}
+		http.Error(rw, err.Error(), statusCode)
+		return
+	}
+	rw.WriteHeader(statusCode)
+}
+
+// runCommentCommands runs the integration test commands of the merge request comment on the Snapshots of the merge request
+func (h *GitLabHandler) runCommentCommands(ctx context.Context, token string, event *gitlab.MergeCommentEvent) (int, error) {
+	commands := ParseCommands(event.ObjectAttributes.Note)
+	if len(commands) == 0 {
+		return http.StatusOK, nil
+	}
+	if event.User == nil {
+		return http.StatusBadRequest, fmt.Errorf("the event has no user")
+	}
+
+	commentEvent := CommentEvent{
+		PullRequest: PullRequest{
+			GitProviders: []string{gitops.PipelineAsCodeGitLabProviderType},
+			Repository:   event.Project.Name,
+			ProjectID:    strconv.FormatInt(event.ProjectID, 10),
+			Number:       int(event.MergeRequest.IID),
+		},
+		User: event.User.Username,
+		Body: event.ObjectAttributes.Note,
+		URL:  event.ObjectAttributes.URL,
+	}
+	snapshots, err := getPullRequestSnapshots(ctx, h.client, commentEvent.PullRequest)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// the token can only be verified with the webhook secrets of the Repositories the Snapshots were built from,
+	// the event is unauthorized when none of them validates it, whether Snapshots exist for the merge request or not
+	snapshots = getVerifiedSnapshots(ctx, h.client, h.logger, snapshots, func(secret []byte) bool {
+		return subtle.ConstantTimeCompare([]byte(token), secret) == 1
+	})
+	if len(snapshots) == 0 {
+		return http.StatusUnauthorized, fmt.Errorf("invalid token for the Snapshots of merge request %d of project %s", commentEvent.PullRequest.Number, commentEvent.PullRequest.ProjectID)
+	}
+
+	return runCommands(ctx, h.client, h.logger, snapshots, commands, commentEvent, func(snapshot *applicationapiv1alpha1.Snapshot) (Permission, error) {
+		return h.getPermission(ctx, snapshot, event.ProjectID, event.User.ID)
+	})
+}
+
+// getGitLabPermission returns the permission of the user on the project, using the token of the Pipelines as Code
+// Repository. Maintainers and owners are the maintainers, developers can push to the project.
+func (h *GitLabHandler) getGitLabPermission(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot, projectID, userID int64) (Permission, error) {
+	token, err := status.GetPACGitProviderToken(ctx, h.client, snapshot)
+	if err != nil {
+		return PermissionNone, err
+	}
+	repoURL, err := url.Parse(snapshot.GetAnnotations()[gitops.PipelineAsCodeRepoURLAnnotation])
+	if err != nil {
+		return PermissionNone, fmt.Errorf("failed to parse repo-url: %w", err)
+	}
+	apiURL := fmt.Sprintf("%s://%s", repoURL.Scheme, repoURL.Host)
+
+	glClient, err := gitlab.NewClient(token, gitlab.WithBaseURL(apiURL), gitlab.WithUserAgent(common.IntegrationServiceUserAgent))
+	if err != nil {
+		return PermissionNone, fmt.Errorf("failed to create gitlab client: %w", err)
+	}
+	member, _, err := glClient.ProjectMembers.GetInheritedProjectMember(projectID, userID, gitlab.WithContext(ctx))
+	if err != nil {
+		if gitlab.HasStatusCode(err, http.StatusNotFound) {
+			return PermissionNone, nil
+		}
+		return PermissionNone, err
+	}
+	switch {
+	case member.AccessLevel >= gitlab.MaintainerPermissions:
+		return PermissionMaintain, nil
+	case member.AccessLevel >= gitlab.DeveloperPermissions:
+		return PermissionWrite, nil
+	default:
+		return PermissionNone, nil
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/internal/gitevents"
+)
+
+var _ = Describe("GitLab events handler", func() {
+
+	const (
+		repoURL      = "https://gitlab.com/org/repo"
+		testStatuses = `[{"scenario":"scenario-1","status":"TestFail","lastUpdateTime":"2026-10-01T10:00:00Z"},` +
+			`{"scenario":"scenario-2","status":"TestFail","lastUpdateTime":"2026-10-01T10:00:00Z"}]`
+	)
+
+	var (
+		k8sClient client.Client
+		handler   *gitevents.GitLabHandler
+	)
+
+	sendEvent := func(eventType, payload, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, gitevents.GitLabEndpointPath, strings.NewReader(payload))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Gitlab-Event", eventType)
+		request.Header.Set("X-Gitlab-Token", token)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	commentPayload := func(comment string, projectID int) string {
+		return fmt.Sprintf(`{"object_kind": "note", "event_type": "note", "user": {"id": 1, "username": "user-1"},
+			"project_id": %d, "project": {"name": "repo", "path_with_namespace": "org/repo"},
+			"object_attributes": {"note": %q, "noteable_type": "MergeRequest", "action": "create",
+				"url": "https://gitlab.com/org/repo/-/merge_requests/1#note_1"},
+			"merge_request": {"iid": 1}}`, projectID, comment)
+	}
+
+	getSnapshot := func() *applicationapiv1alpha1.Snapshot {
+		snapshot := &applicationapiv1alpha1.Snapshot{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "snapshot-1"}, snapshot)).To(Succeed())
+		return snapshot
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(pacv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gitlab-secret", Namespace: "default"},
+				Data:       map[string][]byte{"webhook.secret": []byte(webhookSecret)},
+			},
+			&pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL: repoURL,
+					GitProvider: &pacv1alpha1.GitProvider{
+						Secret:        &pacv1alpha1.Secret{Name: "gitlab-secret", Key: "token"},
+						WebhookSecret: &pacv1alpha1.Secret{Name: "gitlab-secret"},
+					},
+				},
+			},
+			&applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "snapshot-1",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(time.Now()),
+					Labels: map[string]string{
+						gitops.PipelineAsCodeSHALabel:              "abc123",
+						gitops.PipelineAsCodePullRequestAnnotation: "1",
+						gitops.PipelineAsCodeGitProviderLabel:      gitops.PipelineAsCodeGitLabProviderType,
+					},
+					Annotations: map[string]string{
+						gitops.PipelineAsCodeRepoURLAnnotation:         repoURL,
+						gitops.PipelineAsCodeTargetProjectIDAnnotation: "42",
+						gitops.SnapshotTestsStatusAnnotation:           testStatuses,
+					},
+				},
+			},
+		).Build()
+		handler = gitevents.NewGitLabHandler(logr.Discard(), k8sClient)
+		handler.SetPermission(gitevents.PermissionMaintain)
+	})
+
+	It("runs the commands of a merge request comment", func() {
+		response := sendEvent("Note Hook", commentPayload("/retest scenario-1\n/override scenario-1 known flake", 42), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		snapshot := getSnapshot()
+		Expect(snapshot.GetLabels()[gitops.SnapshotIntegrationTestRun]).To(Equal("scenario-1"))
+		requests, err := gitops.GetTestOverrideRequests(snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].User).To(Equal("user-1"))
+		Expect(requests[0].Reason).To(Equal("known flake"))
+	})
+
+	It("drops the re-runs requested after the first one of a Snapshot and reports them", func() {
+		response := sendEvent("Note Hook", commentPayload("/retest scenario-1\n/retest scenario-2", 42), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(response.Body.String()).To(ContainSubstring("/retest scenario-2 on Snapshot default/snapshot-1"))
+		Expect(getSnapshot().GetLabels()[gitops.SnapshotIntegrationTestRun]).To(Equal("scenario-1"))
+	})
+
+	It("rejects events with an invalid token", func() {
+		response := sendEvent("Note Hook", commentPayload("/retest scenario-1", 42), "wrong-secret")
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		Expect(getSnapshot().GetLabels()).ToNot(HaveKey(gitops.SnapshotIntegrationTestRun))
+	})
+
+	It("rejects events on a merge request without Snapshots before looking for the tests", func() {
+		response := sendEvent("Note Hook", commentPayload("/retest scenario-1", 43), webhookSecret)
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("only runs the commands on the Snapshots whose Repository secret validates the event", func() {
+		other := getSnapshot().DeepCopy()
+		other.ObjectMeta = metav1.ObjectMeta{
+			Name:              "snapshot-1",
+			Namespace:         "other",
+			CreationTimestamp: other.CreationTimestamp,
+			Labels:            other.Labels,
+			Annotations:       other.Annotations,
+		}
+		Expect(k8sClient.Create(context.Background(), other)).To(Succeed())
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitlab-secret", Namespace: "other"},
+			Data:       map[string][]byte{"webhook.secret": []byte("other-secret")},
+		})).To(Succeed())
+		Expect(k8sClient.Create(context.Background(), &pacv1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "other"},
+			Spec: pacv1alpha1.RepositorySpec{
+				URL:         repoURL,
+				GitProvider: &pacv1alpha1.GitProvider{WebhookSecret: &pacv1alpha1.Secret{Name: "gitlab-secret"}},
+			},
+		})).To(Succeed())
+
+		response := sendEvent("Note Hook", commentPayload("/retest scenario-1", 42), "other-secret")
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getSnapshot().GetLabels()).ToNot(HaveKey(gitops.SnapshotIntegrationTestRun))
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "other", Name: "snapshot-1"}, other)).To(Succeed())
+		Expect(other.GetLabels()[gitops.SnapshotIntegrationTestRun]).To(Equal("scenario-1"))
+	})
+
+	It("ignores other events", func() {
+		Expect(sendEvent("Push Hook", `{"object_kind": "push"}`, webhookSecret).Code).To(Equal(http.StatusOK))
+		Expect(getSnapshot().GetLabels()).ToNot(HaveKey(gitops.SnapshotIntegrationTestRun))
+	})
+})
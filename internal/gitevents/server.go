@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultBindAddress is the address the git events server binds to by default
+	DefaultBindAddress = ":8090"
+
+	// readHeaderTimeout is the time allowed to git providers to send the headers of an event
+	readHeaderTimeout = 10 * time.Second
+
+	// shutdownTimeout is the time allowed to the events being handled to finish when the server stops
+	shutdownTimeout = 30 * time.Second
+)
+
+// Server serves the git provider events endpoints, separately from the admission webhook server so that only
+// these endpoints are exposed outside of the cluster
+type Server struct {
+	bindAddress string
+	logger      logr.Logger
+	mux         *http.ServeMux
+}
+
+// NewServer returns a new Server which serves the events endpoints of all git providers on the given address
+func NewServer(bindAddress string, logger logr.Logger, client client.Client) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(GitHubEndpointPath, NewGitHubHandler(logger.WithName("github-events"), client))
+	mux.Handle(GitLabEndpointPath, NewGitLabHandler(logger.WithName("gitlab-events"), client))
+	mux.Handle(ForgejoEndpointPath, NewForgejoHandler(logger.WithName("forgejo-events"), client))
+
+	return &Server{
+		bindAddress: bindAddress,
+		logger:      logger,
+		mux:         mux,
+	}
+}
+
+// ServeHTTP dispatches the event to the handler of its git provider
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Start serves the events endpoints until the context is canceled, it implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.bindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s for git events: %w", s.bindAddress, err)
+	}
+
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	idleConnsClosed := make(chan struct{})
+	go func() {
+		defer close(idleConnsClosed)
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error(err, "Failed to shut down the git events server")
+		}
+	}()
+
+	s.logger.Info("Serving git events", "address", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-idleConnsClosed
+	return nil
+}
+
+// NeedLeaderElection makes every replica serve the events, it implements manager.LeaderElectionRunnable.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
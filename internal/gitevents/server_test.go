@@ -0,0 +1,76 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitevents_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/internal/gitevents"
+)
+
+var _ = Describe("Git events server", func() {
+
+	var server *gitevents.Server
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(applicationapiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		server = gitevents.NewServer("127.0.0.1:0", logr.Discard(), fake.NewClientBuilder().WithScheme(scheme).Build())
+	})
+
+	It("dispatches the events to the handler of their git provider", func() {
+		payload := `{"object_kind": "note", "user": {"id": 1, "username": "user-1"}, "project_id": 42,
+			"object_attributes": {"note": "/retest scenario-1", "noteable_type": "MergeRequest"}, "merge_request": {"iid": 1}}`
+		request := httptest.NewRequest(http.MethodPost, gitevents.GitLabEndpointPath, strings.NewReader(payload))
+		request.Header.Set("X-Gitlab-Event", "Note Hook")
+		request.Header.Set("X-Gitlab-Token", "secret")
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("doesn't serve other paths", func() {
+		request := httptest.NewRequest(http.MethodPost, "/validate-appstudio-redhat-com-v1beta2-snapshot", strings.NewReader("{}"))
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("stops serving when the context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- server.Start(ctx)
+		}()
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("is run by every replica of the manager", func() {
+		Expect(server.NeedLeaderElection()).To(BeFalse())
+	})
+})
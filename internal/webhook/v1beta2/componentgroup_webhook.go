@@ -64,7 +64,7 @@ func (v *ComponentGroupCustomValidator) ValidateCreate(ctx context.Context, obj
 
 	componentgrouplog.Info("Validating created component")
 	if componentGroup.Spec.TestGraph != nil {
-		err := dag.ValidateTestGraph(componentGroup.Spec.TestGraph)
+		err := v.validateTestGraph(ctx, componentGroup)
 		if err != nil {
 			return nil, fmt.Errorf("error validating test graph: %v", err)
 		}
@@ -87,7 +87,7 @@ func (v *ComponentGroupCustomValidator) ValidateUpdate(ctx context.Context, oldO
 	componentgrouplog.Info("Validating updated component")
 	if !reflect.DeepEqual(oldComponentGroup.Spec.TestGraph, newComponentGroup.Spec.TestGraph) {
 		componentgrouplog.Info("Validating updated test graph")
-		err := dag.ValidateTestGraph(newComponentGroup.Spec.TestGraph)
+		err := v.validateTestGraph(ctx, newComponentGroup)
 		if err != nil {
 			return nil, fmt.Errorf("error validating test graph: %v", err)
 		}
@@ -96,6 +96,27 @@ func (v *ComponentGroupCustomValidator) ValidateUpdate(ctx context.Context, oldO
 	return nil, nil
 }
 
+// validateTestGraph checks that the TestGraph of the ComponentGroup, combined with the dependents declared
+// by the IntegrationTestScenarios of the ComponentGroup, doesn't contain any cycles.
+func (v *ComponentGroupCustomValidator) validateTestGraph(ctx context.Context, componentGroup *v1beta2.ComponentGroup) error {
+	if v.Client == nil {
+		return dag.ValidateTestGraph(componentGroup.Spec.TestGraph)
+	}
+
+	scenarioList := &v1beta2.IntegrationTestScenarioList{}
+	if err := v.Client.List(ctx, scenarioList, client.InNamespace(componentGroup.Namespace)); err != nil {
+		return err
+	}
+	var scenarios []v1beta2.IntegrationTestScenario
+	for _, scenario := range scenarioList.Items {
+		if scenario.Spec.ComponentGroup == componentGroup.Name {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+
+	return dag.ValidateTestGraph(dag.MergeScenarioDependents(componentGroup.Spec.TestGraph, scenarios...), scenarios...)
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (v *ComponentGroupCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
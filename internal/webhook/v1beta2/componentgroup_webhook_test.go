@@ -21,6 +21,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Describe("ComponentGroup webhook", Ordered, func() {
@@ -128,4 +130,38 @@ var _ = Describe("ComponentGroup webhook", Ordered, func() {
 			Expect(err.Error()).To(ContainSubstring("error validating test graph: invalid TestGraph - cycle detected"))
 		})
 	})
+	When("the scenarios of the component group declare dependents", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			_ = appstudiov1beta2.AddToScheme(scheme)
+			scenario := &appstudiov1beta2.IntegrationTestScenario{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "scenarioD",
+					Namespace: "default",
+				},
+				Spec: appstudiov1beta2.IntegrationTestScenarioSpec{
+					ComponentGroup: componentGroup.Name,
+					Dependents:     []string{"scenarioE"},
+				},
+			}
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenario).Build()
+		})
+
+		It("Returns an error for a test graph which forms a cycle with the dependents", func() {
+			// scenarioE -> scenarioB -> scenarioD in the test graph, scenarioD -> scenarioE as a dependent
+			componentGroup.Spec.TestGraph = validTestGraph
+			_, err := validator.ValidateCreate(ctx, componentGroup)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error validating test graph: invalid TestGraph - cycle detected"))
+		})
+
+		It("Does not return an error for a test graph which doesn't form a cycle with the dependents", func() {
+			componentGroup.Spec.TestGraph = map[string][]appstudiov1beta2.TestGraphNode{
+				"scenarioB": {{Name: "scenarioA"}},
+			}
+			warnings, err := validator.ValidateCreate(ctx, componentGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
 })
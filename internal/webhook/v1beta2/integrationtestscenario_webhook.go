@@ -19,6 +19,8 @@ package v1beta2
 import (
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/pkg/dag"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,6 +39,7 @@ import (
 	"fmt"
 	neturl "net/url"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -52,13 +55,14 @@ func SetupIntegrationTestScenarioWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1beta2.IntegrationTestScenario{}).
 		WithDefaulter(defaulter).
-		WithValidator(&IntegrationTestScenarioCustomValidator{}).
+		WithValidator(&IntegrationTestScenarioCustomValidator{Client: mgr.GetClient()}).
 		Complete()
 }
 
 // IntegrationTestScenarioCustomValidator is a webhook handler and does not need deepcopy methods.
 // +k8s:deepcopy-gen=false
 type IntegrationTestScenarioCustomValidator struct {
+	Client client.Client
 	// TODO(user): Add more fields as needed for validation
 }
 
@@ -155,6 +159,14 @@ func (v *IntegrationTestScenarioCustomValidator) ValidateCreate(ctx context.Cont
 
 	integrationtestscenariolog.Info("Validated params")
 
+	if err := validateWhenExpression(scenario); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateDependents(ctx, scenario); err != nil {
+		return nil, err
+	}
+
 	// Ensure the ownerReference was set by the mutating webhook
 	if ref := scenario.GetOwnerReferences(); len(ref) == 0 {
 		integrationtestscenariolog.Info("Owner reference not set for scenario", scenario.Name)
@@ -183,6 +195,14 @@ func validateOwnerField(scenario *v1beta2.IntegrationTestScenario) error {
 	return nil
 }
 
+// validateWhenExpression ensures that the optional when CEL expression of the scenario compiles to a boolean
+func validateWhenExpression(scenario *v1beta2.IntegrationTestScenario) error {
+	if err := gitops.ValidateIntegrationTestScenarioWhenExpression(scenario.Spec.When); err != nil {
+		return field.Invalid(field.NewPath("spec").Child("when"), scenario.Spec.When, err.Error())
+	}
+	return nil
+}
+
 // Returns an error if 'value' contains leading or trailing whitespace
 func validateNoWhitespace(key, value string) error {
 	r, _ := regexp.Compile(`(^\s+)|(\s+$)`)
@@ -229,9 +249,66 @@ func validateToken(token string) error {
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (v *IntegrationTestScenarioCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	oldScenario, ok := oldObj.(*v1beta2.IntegrationTestScenario)
+	if !ok {
+		return nil, fmt.Errorf("expected a IntegrationTestScenario for oldObj but got %T", oldObj)
+	}
+	newScenario, ok := newObj.(*v1beta2.IntegrationTestScenario)
+	if !ok {
+		return nil, fmt.Errorf("expected a IntegrationTestScenario for newObj but got %T", newObj)
+	}
+
+	if oldScenario.Spec.When != newScenario.Spec.When {
+		if err := validateWhenExpression(newScenario); err != nil {
+			return nil, err
+		}
+	}
+
+	if !slices.Equal(oldScenario.Spec.Dependents, newScenario.Spec.Dependents) {
+		integrationtestscenariolog.Info("Validating updated dependents", "name", newScenario.GetName())
+		if err := v.validateDependents(ctx, newScenario); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
 
+// validateDependents ensures that the dependents of the scenario don't introduce a cycle into the test graph
+// composed of the dependents of all scenarios of the same Application or ComponentGroup and the ComponentGroup's TestGraph.
+func (v *IntegrationTestScenarioCustomValidator) validateDependents(ctx context.Context, scenario *v1beta2.IntegrationTestScenario) error {
+	if len(scenario.Spec.Dependents) == 0 || v.Client == nil {
+		return nil
+	}
+
+	scenarioList := &v1beta2.IntegrationTestScenarioList{}
+	if err := v.Client.List(ctx, scenarioList, client.InNamespace(scenario.Namespace)); err != nil {
+		return err
+	}
+	scenarios := []v1beta2.IntegrationTestScenario{*scenario}
+	for _, item := range scenarioList.Items {
+		if item.Name != scenario.Name && item.Spec.Application == scenario.Spec.Application &&
+			item.Spec.ComponentGroup == scenario.Spec.ComponentGroup {
+			scenarios = append(scenarios, item)
+		}
+	}
+
+	var testGraph map[string][]v1beta2.TestGraphNode
+	if scenario.HasComponentGroup() {
+		componentGroup := &v1beta2.ComponentGroup{}
+		err := v.Client.Get(ctx, types.NamespacedName{Name: scenario.Spec.ComponentGroup, Namespace: scenario.Namespace}, componentGroup)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		testGraph = componentGroup.Spec.TestGraph
+	}
+
+	if err := dag.ValidateTestGraph(dag.MergeScenarioDependents(testGraph, scenarios...), scenarios...); err != nil {
+		return field.Invalid(field.NewPath("spec").Child("dependents"), scenario.Spec.Dependents, err.Error())
+	}
+	return nil
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (v *IntegrationTestScenarioCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
@@ -25,7 +25,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -403,6 +405,34 @@ var _ = Describe("IntegrationTestScenario webhook", Ordered, func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("should fail validation when the when expression is invalid", func() {
+		scenario := &v1beta2.IntegrationTestScenario{
+			Spec: v1beta2.IntegrationTestScenarioSpec{
+				Application: "test-app",
+				When:        `component == "component-x" &&`,
+			},
+		}
+		err := validateWhenExpression(scenario)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.when"))
+
+		updatedScenario := integrationTestScenario.DeepCopy()
+		updatedScenario.Spec.When = "component"
+		_, err = (&IntegrationTestScenarioCustomValidator{}).ValidateUpdate(ctx, integrationTestScenario, updatedScenario)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must evaluate to a boolean"))
+	})
+
+	It("should pass validation when the when expression is valid", func() {
+		scenario := &v1beta2.IntegrationTestScenario{
+			Spec: v1beta2.IntegrationTestScenarioSpec{
+				Application: "test-app",
+				When:        `component == "component-x" && targetBranch.startsWith("release-")`,
+			},
+		}
+		Expect(validateWhenExpression(scenario)).To(Succeed())
+	})
+
 	It("should create scenario with componentGroup successfully", func() {
 		cgScenario := &v1beta2.IntegrationTestScenario{
 			TypeMeta: metav1.TypeMeta{
@@ -459,4 +489,77 @@ var _ = Describe("IntegrationTestScenario webhook", Ordered, func() {
 		err := k8sClient.Delete(ctx, cgScenario)
 		Expect(err == nil || errors.IsNotFound(err)).To(BeTrue())
 	})
+	When("the scenario declares dependents", func() {
+		var (
+			validator        *IntegrationTestScenarioCustomValidator
+			existingScenario *v1beta2.IntegrationTestScenario
+		)
+
+		BeforeEach(func() {
+			existingScenario = &v1beta2.IntegrationTestScenario{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "existing-scenario",
+					Namespace: "default",
+				},
+				Spec: v1beta2.IntegrationTestScenarioSpec{
+					Application: "application-sample",
+					Dependents:  []string{integrationTestScenario.Name},
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = v1beta2.AddToScheme(scheme)
+			validator = &IntegrationTestScenarioCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingScenario).Build(),
+			}
+		})
+
+		It("should fail validation when the dependents form a cycle with another scenario", func() {
+			updatedScenario := integrationTestScenario.DeepCopy()
+			updatedScenario.Spec.Dependents = []string{existingScenario.Name}
+			_, err := validator.ValidateUpdate(ctx, integrationTestScenario, updatedScenario)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.dependents"))
+			Expect(err.Error()).To(ContainSubstring("cycle detected"))
+		})
+
+		It("should pass validation when the dependents don't form a cycle", func() {
+			updatedScenario := integrationTestScenario.DeepCopy()
+			updatedScenario.Spec.Dependents = []string{"another-scenario"}
+			_, err := validator.ValidateUpdate(ctx, integrationTestScenario, updatedScenario)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should ignore scenarios of other applications", func() {
+			otherScenario := integrationTestScenario.DeepCopy()
+			otherScenario.Spec.Application = "other-application"
+			otherScenario.Spec.Dependents = []string{existingScenario.Name}
+			_, err := validator.ValidateUpdate(ctx, integrationTestScenario, otherScenario)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail validation when the dependents form a cycle with the ComponentGroup's TestGraph", func() {
+			componentGroup := hasComponentGroup.DeepCopy()
+			componentGroup.Spec.TestGraph = map[string][]v1beta2.TestGraphNode{
+				"cg-scenario": {{Name: "cg-dependent"}},
+			}
+			scheme := runtime.NewScheme()
+			_ = v1beta2.AddToScheme(scheme)
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(componentGroup).Build()
+
+			cgScenario := &v1beta2.IntegrationTestScenario{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cg-scenario",
+					Namespace: "default",
+				},
+				Spec: v1beta2.IntegrationTestScenarioSpec{
+					ComponentGroup: componentGroup.Name,
+				},
+			}
+			updatedScenario := cgScenario.DeepCopy()
+			updatedScenario.Spec.Dependents = []string{"cg-dependent"}
+			_, err := validator.ValidateUpdate(ctx, cgScenario, updatedScenario)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cycle detected"))
+		})
+	})
 })
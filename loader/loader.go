@@ -50,6 +50,7 @@ type ObjectLoader interface {
 	GetComponentFromPipelineRun(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun) (*applicationapiv1alpha1.Component, error)
 	GetApplicationFromPipelineRun(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun) (*applicationapiv1alpha1.Application, error)
 	GetApplicationFromComponent(ctx context.Context, c client.Client, component *applicationapiv1alpha1.Component) (*applicationapiv1alpha1.Application, error)
+	GetApplicationFromScenario(ctx context.Context, c client.Client, scenario *v1beta2.IntegrationTestScenario) (*applicationapiv1alpha1.Application, error)
 	GetComponentGroupsForComponentVersion(ctx context.Context, c client.Client, component *applicationapiv1alpha1.Component, version string) (*[]v1beta2.ComponentGroup, error)
 	GetSnapshotFromPipelineRun(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun) (*applicationapiv1alpha1.Snapshot, error)
 	GetAllIntegrationTestScenariosForApplication(ctx context.Context, c client.Client, application *applicationapiv1alpha1.Application) (*[]v1beta2.IntegrationTestScenario, error)
@@ -83,6 +84,9 @@ type ObjectLoader interface {
 	GetPRComponentSnapshotsForComponentApplication(ctx context.Context, c client.Client, namespace, applicationName, componentName, prNumber string) (*[]applicationapiv1alpha1.Snapshot, error)
 	GetPRComponentSnapshotsForComponent(ctx context.Context, c client.Client, componentGroupNames []string, namespace, componentName, prNumber string) (*[]applicationapiv1alpha1.Snapshot, error)
 	GetPushComponentSnapshotsForComponent(ctx context.Context, c client.Client, snapshot *applicationapiv1alpha1.Snapshot) (*[]applicationapiv1alpha1.Snapshot, error)
+	GetNudgeConfig(ctx context.Context, c client.Client, namespace string) (*v1beta2.NudgeConfig, error)
+	GetAllComponentsInNamespace(ctx context.Context, c client.Client, namespace string) (*[]applicationapiv1alpha1.Component, error)
+	GetSnapshotCreatorTaskRuns(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun, componentGroup *v1beta2.ComponentGroup) (*[]tektonv1.TaskRun, error)
 }
 
 type loader struct{}
@@ -230,6 +234,22 @@ func (l *loader) GetApplicationFromComponent(ctx context.Context, c client.Clien
 	return application, nil
 }
 
+// GetApplicationFromScenario loads from the cluster the Application referenced in the given IntegrationTestScenario. If the
+// IntegrationTestScenario doesn't specify an Application or this is not found in the cluster, an error will be returned.
+func (l *loader) GetApplicationFromScenario(ctx context.Context, c client.Client, scenario *v1beta2.IntegrationTestScenario) (*applicationapiv1alpha1.Application, error) {
+	application := &applicationapiv1alpha1.Application{}
+	err := c.Get(ctx, types.NamespacedName{
+		Namespace: scenario.Namespace,
+		Name:      scenario.Spec.Application,
+	}, application)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return application, nil
+}
+
 // GetComponentGroupsForComponentVersion loads from the cluster a list of ComponentGroups that use the given ComponentVerison. If
 // the Component does not belong to any ComponentGroups then an empty list will be returned
 func (l *loader) GetComponentGroupsForComponentVersion(ctx context.Context, c client.Client, component *applicationapiv1alpha1.Component, version string) (*[]v1beta2.ComponentGroup, error) {
@@ -1067,3 +1087,42 @@ func (l *loader) GetPRComponentSnapshotsForComponentApplication(ctx context.Cont
 	}
 	return &snapshots.Items, nil
 }
+
+// GetNudgeConfig returns the singleton NudgeConfig of the given namespace
+func (l *loader) GetNudgeConfig(ctx context.Context, c client.Client, namespace string) (*v1beta2.NudgeConfig, error) {
+	nudgeConfig := &v1beta2.NudgeConfig{}
+	return nudgeConfig, toolkit.GetObject(v1beta2.NudgeConfigSingletonName, namespace, c, ctx, nudgeConfig)
+}
+
+// GetAllComponentsInNamespace loads from the cluster all Components in the given namespace.
+// In the case the List operation fails, an error will be returned.
+func (l *loader) GetAllComponentsInNamespace(ctx context.Context, c client.Client, namespace string) (*[]applicationapiv1alpha1.Component, error) {
+	components := &applicationapiv1alpha1.ComponentList{}
+	err := c.List(ctx, components, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.Items, nil
+}
+
+// GetSnapshotCreatorTaskRuns returns all snapshot creator TaskRuns created for the given build PipelineRun
+// and ComponentGroup. In the case the List operation fails, an error will be returned.
+func (l *loader) GetSnapshotCreatorTaskRuns(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun, componentGroup *v1beta2.ComponentGroup) (*[]tektonv1.TaskRun, error) {
+	taskRuns := &tektonv1.TaskRunList{}
+	opts := []client.ListOption{
+		client.InNamespace(componentGroup.Namespace),
+		client.MatchingLabels{
+			tektonconsts.PipelinesTypeLabel:        tektonconsts.TaskRunSnapshotCreatorType,
+			tektonconsts.ComponentGroupNameLabel:   componentGroup.Name,
+			tektonconsts.BuildPipelineRunNameLabel: pipelineRun.Name,
+		},
+	}
+
+	err := c.List(ctx, taskRuns, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskRuns.Items, nil
+}
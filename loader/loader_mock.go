@@ -75,6 +75,9 @@ const (
 	RequiredIntegrationTestScenariosForSnapshotContextKey
 	GetPushComponentSnapshotsForComponentContextKey
 	ComponentGroupComponentsContextKey
+	NudgeConfigContextKey
+	AllComponentsInNamespaceContextKey
+	SnapshotCreatorTaskRunsContextKey
 )
 
 func NewMockLoader() ObjectLoader {
@@ -149,6 +152,14 @@ func (l *mockLoader) GetApplicationFromComponent(ctx context.Context, c client.C
 	return toolkit.GetMockedResourceAndErrorFromContext(ctx, ApplicationContextKey, &applicationapiv1alpha1.Application{})
 }
 
+// GetApplicationFromScenario returns the resource and error passed as values of the context.
+func (l *mockLoader) GetApplicationFromScenario(ctx context.Context, c client.Client, scenario *v1beta2.IntegrationTestScenario) (*applicationapiv1alpha1.Application, error) {
+	if ctx.Value(ApplicationContextKey) == nil {
+		return l.loader.GetApplicationFromScenario(ctx, c, scenario)
+	}
+	return toolkit.GetMockedResourceAndErrorFromContext(ctx, ApplicationContextKey, &applicationapiv1alpha1.Application{})
+}
+
 // GetComponentGroupsForComponentVersion returns the r esource and error passed as values of the context
 func (l *mockLoader) GetComponentGroupsForComponentVersion(ctx context.Context, c client.Client, component *applicationapiv1alpha1.Component, version string) (*[]v1beta2.ComponentGroup, error) {
 	if ctx.Value(ComponentGroupsContextKey) == nil {
@@ -439,3 +450,29 @@ func (l *mockLoader) GetPushComponentSnapshotsForComponent(ctx context.Context,
 	snapshots, err := toolkit.GetMockedResourceAndErrorFromContext(ctx, GetPushComponentSnapshotsForComponentContextKey, []applicationapiv1alpha1.Snapshot{})
 	return &snapshots, err
 }
+
+// GetNudgeConfig returns the resource and error passed as values of the context.
+func (l *mockLoader) GetNudgeConfig(ctx context.Context, c client.Client, namespace string) (*v1beta2.NudgeConfig, error) {
+	if ctx.Value(NudgeConfigContextKey) == nil {
+		return l.loader.GetNudgeConfig(ctx, c, namespace)
+	}
+	return toolkit.GetMockedResourceAndErrorFromContext(ctx, NudgeConfigContextKey, &v1beta2.NudgeConfig{})
+}
+
+// GetAllComponentsInNamespace returns the resource and error passed as values of the context.
+func (l *mockLoader) GetAllComponentsInNamespace(ctx context.Context, c client.Client, namespace string) (*[]applicationapiv1alpha1.Component, error) {
+	if ctx.Value(AllComponentsInNamespaceContextKey) == nil {
+		return l.loader.GetAllComponentsInNamespace(ctx, c, namespace)
+	}
+	components, err := toolkit.GetMockedResourceAndErrorFromContext(ctx, AllComponentsInNamespaceContextKey, []applicationapiv1alpha1.Component{})
+	return &components, err
+}
+
+// GetSnapshotCreatorTaskRuns returns the resource and error passed as values of the context.
+func (l *mockLoader) GetSnapshotCreatorTaskRuns(ctx context.Context, c client.Client, pipelineRun *tektonv1.PipelineRun, componentGroup *v1beta2.ComponentGroup) (*[]tektonv1.TaskRun, error) {
+	if ctx.Value(SnapshotCreatorTaskRunsContextKey) == nil {
+		return l.loader.GetSnapshotCreatorTaskRuns(ctx, c, pipelineRun, componentGroup)
+	}
+	taskRuns, err := toolkit.GetMockedResourceAndErrorFromContext(ctx, SnapshotCreatorTaskRunsContextKey, []tektonv1.TaskRun{})
+	return &taskRuns, err
+}
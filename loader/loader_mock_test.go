@@ -172,6 +172,21 @@ var _ = Describe("Release Adapter", Ordered, func() {
 		})
 	})
 
+	Context("When calling GetApplicationFromScenario", func() {
+		It("returns resource and error from the context", func() {
+			application := &applicationapiv1alpha1.Application{}
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: ApplicationContextKey,
+					Resource:   application,
+				},
+			})
+			resource, err := loader.GetApplicationFromScenario(mockContext, nil, nil)
+			Expect(resource).To(Equal(application))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("When calling GetSnapshotFromPipelineRun", func() {
 		It("returns resource and error from the context", func() {
 			snapshot := &applicationapiv1alpha1.Snapshot{}
@@ -507,4 +522,48 @@ var _ = Describe("Release Adapter", Ordered, func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("When calling GetNudgeConfig", func() {
+		It("returns resource and error from the context", func() {
+			nudgeConfig := &v1beta2.NudgeConfig{}
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: NudgeConfigContextKey,
+					Resource:   nudgeConfig,
+				},
+			})
+			resource, err := loader.GetNudgeConfig(mockContext, nil, "")
+			Expect(resource).To(Equal(nudgeConfig))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("When calling GetAllComponentsInNamespace", func() {
+		It("returns resource and error from the context", func() {
+			components := []applicationapiv1alpha1.Component{}
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: AllComponentsInNamespaceContextKey,
+					Resource:   components,
+				},
+			})
+			resource, err := loader.GetAllComponentsInNamespace(mockContext, nil, "")
+			Expect(resource).To(Equal(&components))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+	Context("When calling GetSnapshotCreatorTaskRuns", func() {
+		It("returns resource and error from the context", func() {
+			taskRuns := []tektonv1.TaskRun{}
+			mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+				{
+					ContextKey: SnapshotCreatorTaskRunsContextKey,
+					Resource:   taskRuns,
+				},
+			})
+			resource, err := loader.GetSnapshotCreatorTaskRuns(mockContext, nil, nil, nil)
+			Expect(resource).To(Equal(&taskRuns))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
@@ -731,6 +731,13 @@ var _ = Describe("Loader", Ordered, func() {
 		Expect(app.ObjectMeta).To(Equal(hasApp.ObjectMeta))
 	})
 
+	It("ensures we can get the Application from an IntegrationTestScenario", func() {
+		app, err := loader.GetApplicationFromScenario(ctx, k8sClient, integrationTestScenario)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(app).NotTo(BeNil())
+		Expect(app.ObjectMeta).To(Equal(hasApp.ObjectMeta))
+	})
+
 	It("ensures we can get the Snapshot from a Pipeline Run", func() {
 		snapshot, err := loader.GetSnapshotFromPipelineRun(ctx, k8sClient, buildPipelineRun)
 		Expect(err).ToNot(HaveOccurred())
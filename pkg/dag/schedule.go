@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dag
+
+import (
+	"slices"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+// ScenarioState describes whether a scenario can be started according to the TestGraph
+type ScenarioState int
+
+const (
+	// ScenarioRunnable means that all parents of the scenario have finished (or it has no parents)
+	ScenarioRunnable ScenarioState = iota
+	// ScenarioBlocked means that at least one parent of the scenario hasn't finished yet
+	ScenarioBlocked
+	// ScenarioSkipped means that a failFast parent of the scenario didn't pass, so the scenario must not run
+	ScenarioSkipped
+)
+
+// EvaluateScenario determines whether the given scenario can be started based on the parents declared
+// for it in the testGraph and the current test statuses of the snapshot. Alongside the state it returns
+// the names of the parents which caused the scenario to be blocked or skipped.
+// Parents which have no status in the snapshot (e.g. they are not applicable to it) are ignored.
+func EvaluateScenario(testGraph map[string][]v1beta2.TestGraphNode, scenarioName string, testStatuses *intgteststat.SnapshotIntegrationTestStatuses) (ScenarioState, []string) {
+	var failedParents, unfinishedParents []string
+
+	for _, parent := range testGraph[scenarioName] {
+		parentStatus, ok := testStatuses.GetScenarioStatus(parent.Name)
+		if !ok {
+			continue
+		}
+		switch {
+		case !parentStatus.Status.IsFinal():
+			unfinishedParents = append(unfinishedParents, parent.Name)
+		case parent.FailFast && !parentStatus.Status.IsPassed():
+			failedParents = append(failedParents, parent.Name)
+		}
+	}
+
+	switch {
+	case len(failedParents) > 0:
+		return ScenarioSkipped, failedParents
+	case len(unfinishedParents) > 0:
+		return ScenarioBlocked, unfinishedParents
+	default:
+		return ScenarioRunnable, nil
+	}
+}
+
+// GetDependentScenarios returns the names of all scenarios which directly or transitively
+// depend on the given scenario within the testGraph, sorted by name.
+func GetDependentScenarios(testGraph map[string][]v1beta2.TestGraphNode, scenarioName string) []string {
+	// children maps each scenario to the scenarios listing it as a parent
+	children := make(map[string][]string)
+	for child, parentNodes := range testGraph {
+		for _, parentNode := range parentNodes {
+			children[parentNode.Name] = append(children[parentNode.Name], child)
+		}
+	}
+
+	visited := map[string]bool{scenarioName: true}
+	var dependents []string
+	queue := []string{scenarioName}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, child := range children[node] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			dependents = append(dependents, child)
+			queue = append(queue, child)
+		}
+	}
+
+	slices.Sort(dependents)
+	return dependents
+}
+
+// MergeScenarioDependents returns a copy of the testGraph extended with the dependents declared by the scenarios.
+// Every dependent gets the declaring scenario as a failFast parent, since a dependent may only run once the
+// scenario it depends on passed. The testGraph itself is left untouched.
+func MergeScenarioDependents(testGraph map[string][]v1beta2.TestGraphNode, scenarios ...v1beta2.IntegrationTestScenario) map[string][]v1beta2.TestGraphNode {
+	merged := make(map[string][]v1beta2.TestGraphNode, len(testGraph))
+	for name, parentNodes := range testGraph {
+		merged[name] = slices.Clone(parentNodes)
+	}
+
+	for _, scenario := range scenarios {
+		for _, dependent := range scenario.Spec.Dependents {
+			index := slices.IndexFunc(merged[dependent], func(node v1beta2.TestGraphNode) bool {
+				return node.Name == scenario.Name
+			})
+			if index >= 0 {
+				merged[dependent][index].FailFast = true
+				continue
+			}
+			merged[dependent] = append(merged[dependent], v1beta2.TestGraphNode{Name: scenario.Name, FailFast: true})
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dag
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+var _ = Describe("DAG scheduling unittests", func() {
+	var (
+		testGraph    map[string][]v1beta2.TestGraphNode
+		testStatuses *intgteststat.SnapshotIntegrationTestStatuses
+	)
+
+	BeforeEach(func() {
+		// Create testGraph
+		//    A   E
+		//   / \ /
+		//  B   C
+		//  |
+		//  D
+		// B depends on A with failFast, C depends on A and E without failFast
+		testGraph = make(map[string][]v1beta2.TestGraphNode)
+		testGraph["scenarioB"] = []v1beta2.TestGraphNode{{Name: "scenarioA", FailFast: true}}
+		testGraph["scenarioC"] = createTestGraphNodesForScenarios([]string{"scenarioA", "scenarioE"})
+		testGraph["scenarioD"] = []v1beta2.TestGraphNode{{Name: "scenarioB", FailFast: true}}
+
+		var err error
+		testStatuses, err = intgteststat.NewSnapshotIntegrationTestStatuses("")
+		Expect(err).NotTo(HaveOccurred())
+		for _, name := range []string{"scenarioA", "scenarioB", "scenarioC", "scenarioD", "scenarioE"} {
+			testStatuses.UpdateTestStatusIfChanged(name, intgteststat.IntegrationTestStatusPending, "")
+		}
+	})
+
+	Context("Evaluating scenarios", func() {
+		It("Marks scenarios without parents as runnable", func() {
+			state, parents := EvaluateScenario(testGraph, "scenarioA", testStatuses)
+			Expect(state).To(Equal(ScenarioRunnable))
+			Expect(parents).To(BeEmpty())
+		})
+
+		It("Blocks scenarios whose parents haven't finished", func() {
+			testStatuses.UpdateTestStatusIfChanged("scenarioA", intgteststat.IntegrationTestStatusInProgress, "")
+			testStatuses.UpdateTestStatusIfChanged("scenarioE", intgteststat.IntegrationTestStatusTestPassed, "")
+			state, parents := EvaluateScenario(testGraph, "scenarioC", testStatuses)
+			Expect(state).To(Equal(ScenarioBlocked))
+			Expect(parents).To(ConsistOf("scenarioA"))
+		})
+
+		It("Marks scenarios as runnable once all parents have passed", func() {
+			testStatuses.UpdateTestStatusIfChanged("scenarioA", intgteststat.IntegrationTestStatusTestPassed, "")
+			testStatuses.UpdateTestStatusIfChanged("scenarioE", intgteststat.IntegrationTestStatusTestWarning, "")
+			state, _ := EvaluateScenario(testGraph, "scenarioC", testStatuses)
+			Expect(state).To(Equal(ScenarioRunnable))
+		})
+
+		It("Runs scenarios after a failed parent without failFast", func() {
+			testStatuses.UpdateTestStatusIfChanged("scenarioA", intgteststat.IntegrationTestStatusTestFail, "")
+			testStatuses.UpdateTestStatusIfChanged("scenarioE", intgteststat.IntegrationTestStatusTestPassed, "")
+			state, _ := EvaluateScenario(testGraph, "scenarioC", testStatuses)
+			Expect(state).To(Equal(ScenarioRunnable))
+		})
+
+		It("Skips scenarios after a failed parent with failFast", func() {
+			testStatuses.UpdateTestStatusIfChanged("scenarioA", intgteststat.IntegrationTestStatusTestFail, "")
+			state, parents := EvaluateScenario(testGraph, "scenarioB", testStatuses)
+			Expect(state).To(Equal(ScenarioSkipped))
+			Expect(parents).To(ConsistOf("scenarioA"))
+		})
+
+		It("Skips scenarios after a skipped parent with failFast", func() {
+			testStatuses.UpdateTestStatusIfChanged("scenarioB", intgteststat.IntegrationTestStatusTestSkipped, "")
+			state, parents := EvaluateScenario(testGraph, "scenarioD", testStatuses)
+			Expect(state).To(Equal(ScenarioSkipped))
+			Expect(parents).To(ConsistOf("scenarioB"))
+		})
+
+		It("Ignores parents which have no status in the snapshot", func() {
+			testStatuses.DeleteStatus("scenarioA")
+			testStatuses.UpdateTestStatusIfChanged("scenarioE", intgteststat.IntegrationTestStatusTestPassed, "")
+			state, _ := EvaluateScenario(testGraph, "scenarioC", testStatuses)
+			Expect(state).To(Equal(ScenarioRunnable))
+		})
+	})
+
+	Context("Getting dependent scenarios", func() {
+		It("Returns all transitive dependents", func() {
+			Expect(GetDependentScenarios(testGraph, "scenarioA")).To(Equal([]string{"scenarioB", "scenarioC", "scenarioD"}))
+			Expect(GetDependentScenarios(testGraph, "scenarioE")).To(Equal([]string{"scenarioC"}))
+		})
+
+		It("Returns nothing for leaf scenarios", func() {
+			Expect(GetDependentScenarios(testGraph, "scenarioD")).To(BeEmpty())
+		})
+	})
+
+	Context("Merging scenario dependents", func() {
+		var scenarios []v1beta2.IntegrationTestScenario
+
+		BeforeEach(func() {
+			scenarios = []v1beta2.IntegrationTestScenario{
+				{ObjectMeta: metav1.ObjectMeta{Name: "scenarioC"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "scenarioE"}, Spec: v1beta2.IntegrationTestScenarioSpec{Dependents: []string{"scenarioC", "scenarioF"}}},
+			}
+		})
+
+		It("Adds dependents as failFast children of the declaring scenario", func() {
+			merged := MergeScenarioDependents(testGraph, scenarios...)
+			Expect(merged["scenarioF"]).To(Equal([]v1beta2.TestGraphNode{{Name: "scenarioE", FailFast: true}}))
+			Expect(merged["scenarioC"]).To(ConsistOf(
+				v1beta2.TestGraphNode{Name: "scenarioA"},
+				v1beta2.TestGraphNode{Name: "scenarioE", FailFast: true},
+			))
+			Expect(merged["scenarioB"]).To(Equal(testGraph["scenarioB"]))
+		})
+
+		It("Doesn't modify the original testGraph", func() {
+			MergeScenarioDependents(testGraph, scenarios...)
+			Expect(testGraph).NotTo(HaveKey("scenarioF"))
+			Expect(testGraph["scenarioC"]).To(ConsistOf(
+				v1beta2.TestGraphNode{Name: "scenarioA"},
+				v1beta2.TestGraphNode{Name: "scenarioE"},
+			))
+		})
+
+		It("Works without a testGraph", func() {
+			merged := MergeScenarioDependents(nil, scenarios...)
+			Expect(merged).To(HaveLen(2))
+			Expect(merged["scenarioC"]).To(Equal([]v1beta2.TestGraphNode{{Name: "scenarioE", FailFast: true}}))
+		})
+	})
+})
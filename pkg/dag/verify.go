@@ -125,3 +125,22 @@ func checkGraphForCycles(testGraph map[string][]v1beta2.TestGraphNode) error {
 		return nil
 	}
 }
+
+// ValidateNudgeGraph checks that the nudge relationships between components form a DAG,
+// i.e. no component ends up nudging itself through a chain of nudges.
+func ValidateNudgeGraph(nudges []v1beta2.NudgeRelationship) error {
+	// Reuse the scenario graph representation: each target component lists the
+	// components nudging it as its parents
+	nudgeGraph := make(map[string][]v1beta2.TestGraphNode)
+	for _, nudge := range nudges {
+		if _, ok := nudgeGraph[nudge.From]; !ok {
+			nudgeGraph[nudge.From] = []v1beta2.TestGraphNode{}
+		}
+		nudgeGraph[nudge.To] = append(nudgeGraph[nudge.To], v1beta2.TestGraphNode{Name: nudge.From})
+	}
+
+	if err := checkGraphForCycles(nudgeGraph); err != nil {
+		return fmt.Errorf("invalid nudge graph - cycle detected: %w", err)
+	}
+	return nil
+}
@@ -73,6 +73,33 @@ var _ = Describe("DAG library unittests", Ordered, func() {
 			})
 		})
 	})
+
+	Context("Checking nudge graph for cycles", func() {
+		It("Returns no error for a nudge graph without cycles", func() {
+			nudges := []v1beta2.NudgeRelationship{
+				{From: "component-a", To: "component-b"},
+				{From: "component-a", To: "component-c"},
+				{From: "component-b", To: "component-d"},
+				{From: "component-c", To: "component-d"},
+			}
+			Expect(ValidateNudgeGraph(nudges)).To(Succeed())
+		})
+
+		It("Returns an error for a nudge graph with cycles", func() {
+			nudges := []v1beta2.NudgeRelationship{
+				{From: "component-a", To: "component-b"},
+				{From: "component-b", To: "component-c"},
+				{From: "component-c", To: "component-a"},
+			}
+			err := ValidateNudgeGraph(nudges)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid nudge graph - cycle detected"))
+		})
+
+		It("Returns no error for an empty nudge graph", func() {
+			Expect(ValidateNudgeGraph(nil)).To(Succeed())
+		})
+	})
 })
 
 func createTestGraphNodesForScenarios(scenarioNames []string) (nodes []v1beta2.TestGraphNode) {
@@ -19,6 +19,7 @@ package integrationteststatus
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
@@ -57,9 +58,16 @@ const (
 	BuildPLRFailed // BuildPLRFailed
 	// Group snapshot creation failed
 	GroupSnapshotCreationFailed //GroupSnapshotCreationFailed
-
+	// Integration test was skipped because a parent scenario in the TestGraph didn't pass
+	IntegrationTestStatusTestSkipped // TestSkipped
+	// Integration test is waiting for the scenarios it depends on to finish
+	IntegrationTestStatusBlocked // Blocked
 )
 
+// MaxStoredTestCasesSize is the maximum size of the JSON of the test cases stored for all the scenarios of a Snapshot.
+// The test statuses are stored in a single annotation, and all the annotations of the Snapshot must fit in 256KiB.
+const MaxStoredTestCasesSize = 64 * 1024
+
 const integrationTestStatusesSchema = `{
 	"$schema": "http://json-schema.org/draft/2020-12/schema#",
 	"type":  "array",
@@ -86,6 +94,42 @@ const integrationTestStatusesSchema = `{
         },
         "testPipelineRunName": {
           "type": "string"
+        },
+        "attempt": {
+          "type": "integer"
+        },
+        "maxAttempts": {
+          "type": "integer"
+        },
+        "previousAttempts": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "attempt": {
+                "type": "integer"
+              },
+              "status": {
+                "type": "string"
+              },
+              "details": {
+                "type": "string"
+              },
+              "startTime": {
+                "type": "string"
+              },
+              "completionTime": {
+                "type": "string"
+              },
+              "testPipelineRunName": {
+                "type": "string"
+              }
+            },
+            "required": ["attempt", "status"]
+          }
+        },
+        "testCaseResults": {
+          "type": "object"
         }
       },
 	  "required": ["scenario", "status", "lastUpdateTime"]
@@ -110,6 +154,53 @@ type IntegrationTestStatusDetail struct {
 	TestPipelineRunName string `json:"testPipelineRunName,omitempty"`
 	// IsOptionalScenario defines if the scenario is optional, which means that test failure should not block promotion of snapshot
 	IsOptionalScenario bool `json:"isOptionalScenario,omitempty"`
+	// Attempt is the number of the current attempt of the scenario, 0 is handled as the first attempt
+	Attempt int `json:"attempt,omitempty"`
+	// MaxAttempts is the maximum number of attempts allowed by the retry policy of the scenario
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// PreviousAttempts records the outcome of the attempts which were retried
+	PreviousAttempts []IntegrationTestAttemptDetail `json:"previousAttempts,omitempty"`
+	// TestCaseResults are the test case outcomes published as JUnit results by the test pipelineRun
+	TestCaseResults *helpers.TestCaseResults `json:"testCaseResults,omitempty"`
+}
+
+// IntegrationTestAttemptDetail contains metadata about a previous attempt of the scenario which was retried
+type IntegrationTestAttemptDetail struct {
+	// Attempt is the number of the attempt
+	Attempt int `json:"attempt"`
+	// The status the attempt finished with
+	Status IntegrationTestStatus `json:"status"`
+	// The details of the attempt's status
+	Details string `json:"details,omitempty"`
+	// Startime when the attempt moved to inProgress
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// Completion time of the attempt
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// TestPipelineName name of the attempt's pipelineRun
+	TestPipelineRunName string `json:"testPipelineRunName,omitempty"`
+}
+
+// GetAttempt returns the number of the current attempt of the scenario, starting at 1
+func (d *IntegrationTestStatusDetail) GetAttempt() int {
+	if d.Attempt < 1 {
+		return 1
+	}
+	return d.Attempt
+}
+
+// HasAttemptsLeft returns true if the retry policy of the scenario allows another attempt
+func (d *IntegrationTestStatusDetail) HasAttemptsLeft() bool {
+	return d.GetAttempt() < d.MaxAttempts
+}
+
+// IsPreviousAttempt returns true if the given pipelineRun was created for an attempt of the scenario which was retried
+func (d *IntegrationTestStatusDetail) IsPreviousAttempt(pipelineRunName string) bool {
+	for _, attempt := range d.PreviousAttempts {
+		if attempt.TestPipelineRunName == pipelineRunName {
+			return true
+		}
+	}
+	return false
 }
 
 // SnapshotIntegrationTestStatuses type handles details about snapshot tests
@@ -130,12 +221,24 @@ func (sits *IntegrationTestStatus) IsFinal() bool {
 		IntegrationTestStatusTestFail,
 		IntegrationTestStatusTestPassed,
 		IntegrationTestStatusTestInvalid,
-		IntegrationTestStatusTestWarning:
+		IntegrationTestStatusTestWarning,
+		IntegrationTestStatusTestSkipped:
 		return true
 	}
 	return false
 }
 
+// IsPassed returns true if the status represents a successful test outcome
+func (sits *IntegrationTestStatus) IsPassed() bool {
+	return *sits == IntegrationTestStatusTestPassed || *sits == IntegrationTestStatusTestWarning
+}
+
+// IsFailed returns true if the status represents a test which finished without passing. Tests skipped because
+// of their parent scenarios are not considered failed, they are reset when their parents are re-run.
+func (sits *IntegrationTestStatus) IsFailed() bool {
+	return sits.IsFinal() && !sits.IsPassed() && *sits != IntegrationTestStatusTestSkipped
+}
+
 // IsDirty returns boolean if there are any changes
 func (sits *SnapshotIntegrationTestStatuses) IsDirty() bool {
 	return sits.dirty
@@ -152,7 +255,54 @@ func (sits *SnapshotIntegrationTestStatuses) ResetStatus(scenarioName string, is
 	detail := sits.statuses[scenarioName]
 	detail.TestPipelineRunName = ""
 	detail.IsOptionalScenario = isOptionalScenario
+	// a reset starts a new series of attempts
+	detail.Attempt = 0
+	detail.PreviousAttempts = nil
+	detail.TestCaseResults = nil
+	sits.dirty = true
+}
+
+// RetryTest records the current attempt of the scenario test as finished with the given status and details,
+// and resets the test back to Pending so that the next attempt is started
+// scenario must already exist in statuses
+func (sits *SnapshotIntegrationTestStatuses) RetryTest(scenarioName string, status IntegrationTestStatus, details string) error {
+	detail, ok := sits.GetScenarioStatus(scenarioName)
+	if !ok {
+		return fmt.Errorf("scenario name %s not found within the SnapshotIntegrationTestStatus, and cannot be retried", scenarioName)
+	}
+
+	completionTime := time.Now().UTC()
+	attempt := detail.GetAttempt()
+	detail.PreviousAttempts = append(detail.PreviousAttempts, IntegrationTestAttemptDetail{
+		Attempt:             attempt,
+		Status:              status,
+		Details:             details,
+		StartTime:           detail.StartTime,
+		CompletionTime:      &completionTime,
+		TestPipelineRunName: detail.TestPipelineRunName,
+	})
+	detail.Attempt = attempt + 1
+	detail.TestPipelineRunName = ""
+	detail.TestCaseResults = nil
+	sits.UpdateTestStatusIfChanged(scenarioName, IntegrationTestStatusPending,
+		fmt.Sprintf("Attempt %d/%d finished with status %s, starting attempt %d/%d: %s",
+			attempt, detail.MaxAttempts, status, detail.Attempt, detail.MaxAttempts, details))
 	sits.dirty = true
+
+	return nil
+}
+
+// SetTestMaxAttempts sets the maximum number of attempts allowed by the retry policy of the scenario
+// Scenarios which are attempted only once don't record the maximum number of attempts
+func (sits *SnapshotIntegrationTestStatuses) SetTestMaxAttempts(scenarioName string, maxAttempts int) {
+	if maxAttempts < 2 {
+		maxAttempts = 0
+	}
+	detail, ok := sits.statuses[scenarioName]
+	if ok && detail.MaxAttempts != maxAttempts {
+		detail.MaxAttempts = maxAttempts
+		sits.dirty = true
+	}
 }
 
 // UpdateTestStatusIfChanged updates status of scenario test when status or details changed
@@ -185,7 +335,7 @@ func (sits *SnapshotIntegrationTestStatuses) UpdateTestStatusIfChanged(scenarioN
 			detail.StartTime = &timestamp
 			// null CompletionTime because testing started again
 			detail.CompletionTime = nil
-		case IntegrationTestStatusPending, BuildPLRInProgress:
+		case IntegrationTestStatusPending, IntegrationTestStatusBlocked, BuildPLRInProgress:
 			// null all timestamps as test is not inProgress neither in final state
 			detail.StartTime = nil
 			detail.CompletionTime = nil
@@ -196,6 +346,7 @@ func (sits *SnapshotIntegrationTestStatuses) UpdateTestStatusIfChanged(scenarioN
 			IntegrationTestStatusTestPassed,
 			IntegrationTestStatusTestInvalid,
 			IntegrationTestStatusTestWarning,
+			IntegrationTestStatusTestSkipped,
 			SnapshotCreationFailed,
 			GroupSnapshotCreationFailed,
 			BuildPLRFailed:
@@ -237,6 +388,57 @@ func (sits *SnapshotIntegrationTestStatuses) UpdateTestPipelineRunName(scenarioN
 	return nil
 }
 
+// UpdateTestCaseResults updates the test case results of the scenario if changed
+// scenario must already exist in statuses
+// The test cases which don't fit in what is left of MaxStoredTestCasesSize by the other scenarios are omitted
+func (sits *SnapshotIntegrationTestStatuses) UpdateTestCaseResults(scenarioName string, testCaseResults *helpers.TestCaseResults) error {
+	detail, ok := sits.GetScenarioStatus(scenarioName)
+	if !ok {
+		return fmt.Errorf("scenario name %s not found within the SnapshotIntegrationTestStatus, and cannot be updated", scenarioName)
+	}
+
+	if testCaseResults != nil && len(testCaseResults.TestCases) > 0 {
+		storedSize := 0
+		for name, otherDetail := range sits.statuses {
+			if name != scenarioName && otherDetail.TestCaseResults != nil {
+				for _, testCase := range otherDetail.TestCaseResults.TestCases {
+					storedSize += getTestCaseSize(testCase)
+				}
+			}
+		}
+		stored := 0
+		for _, testCase := range testCaseResults.TestCases {
+			storedSize += getTestCaseSize(testCase)
+			if storedSize > MaxStoredTestCasesSize {
+				break
+			}
+			stored++
+		}
+		if stored < len(testCaseResults.TestCases) {
+			limitedResults := *testCaseResults
+			limitedResults.TestCases = testCaseResults.TestCases[:stored]
+			testCaseResults = &limitedResults
+		}
+	}
+
+	if !reflect.DeepEqual(detail.TestCaseResults, testCaseResults) {
+		detail.TestCaseResults = testCaseResults
+		sits.dirty = true
+	}
+
+	return nil
+}
+
+// getTestCaseSize returns the size of the test case in the JSON of the test statuses
+func getTestCaseSize(testCase helpers.TestCaseResult) int {
+	data, err := json.Marshal(testCase)
+	if err != nil {
+		return 0
+	}
+	// the separator of the test cases
+	return len(data) + 1
+}
+
 // InitStatuses creates initial representation all scenarios
 // This function also removes scenarios which are not defined in scenarios param
 func (sits *SnapshotIntegrationTestStatuses) InitStatuses(integrationTestScenarios *[]v1beta2.IntegrationTestScenario) {
@@ -19,6 +19,7 @@ package integrationteststatus_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/helpers"
 	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
 )
 
@@ -48,6 +50,9 @@ var _ = Describe("integrationteststatus library unittests", func() {
 			Entry("When status is TestPass", intgteststat.IntegrationTestStatusTestPassed, "TestPassed"),
 			Entry("When status is Deleted", intgteststat.IntegrationTestStatusDeleted, "Deleted"),
 			Entry("When status is Invalid", intgteststat.IntegrationTestStatusTestInvalid, "TestInvalid"),
+			Entry("When status is Warning", intgteststat.IntegrationTestStatusTestWarning, "TestWarning"),
+			Entry("When status is Skipped", intgteststat.IntegrationTestStatusTestSkipped, "TestSkipped"),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, "Blocked"),
 		)
 
 		DescribeTable("Status to JSON and vice versa",
@@ -69,6 +74,9 @@ var _ = Describe("integrationteststatus library unittests", func() {
 			Entry("When status is TestPass", intgteststat.IntegrationTestStatusTestPassed, "TestPassed"),
 			Entry("When status is Deleted", intgteststat.IntegrationTestStatusDeleted, "Deleted"),
 			Entry("When status is Invalid", intgteststat.IntegrationTestStatusTestInvalid, "TestInvalid"),
+			Entry("When status is Warning", intgteststat.IntegrationTestStatusTestWarning, "TestWarning"),
+			Entry("When status is Skipped", intgteststat.IntegrationTestStatusTestSkipped, "TestSkipped"),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, "Blocked"),
 		)
 
 		DescribeTable("Check IsFinal logic",
@@ -83,9 +91,39 @@ var _ = Describe("integrationteststatus library unittests", func() {
 			Entry("When status is TestPass", intgteststat.IntegrationTestStatusTestPassed, true),
 			Entry("When status is Invalid", intgteststat.IntegrationTestStatusTestInvalid, true),
 			Entry("When status is Warning", intgteststat.IntegrationTestStatusTestWarning, true),
+			Entry("When status is Skipped", intgteststat.IntegrationTestStatusTestSkipped, true),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, false),
 			Entry("When status is Other", intgteststat.IntegrationTestStatusPending, false),
 		)
 
+		DescribeTable("Check IsPassed logic",
+			func(st intgteststat.IntegrationTestStatus, isPassed bool) {
+				result := st.IsPassed()
+				Expect(result).To(Equal(isPassed))
+			},
+			Entry("When status is TestPass", intgteststat.IntegrationTestStatusTestPassed, true),
+			Entry("When status is Warning", intgteststat.IntegrationTestStatusTestWarning, true),
+			Entry("When status is TestFail", intgteststat.IntegrationTestStatusTestFail, false),
+			Entry("When status is Invalid", intgteststat.IntegrationTestStatusTestInvalid, false),
+			Entry("When status is Skipped", intgteststat.IntegrationTestStatusTestSkipped, false),
+			Entry("When status is InProgress", intgteststat.IntegrationTestStatusInProgress, false),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, false),
+		)
+
+		DescribeTable("Check IsFailed logic",
+			func(st intgteststat.IntegrationTestStatus, isFailed bool) {
+				result := st.IsFailed()
+				Expect(result).To(Equal(isFailed))
+			},
+			Entry("When status is TestFail", intgteststat.IntegrationTestStatusTestFail, true),
+			Entry("When status is Invalid", intgteststat.IntegrationTestStatusTestInvalid, true),
+			Entry("When status is Deleted", intgteststat.IntegrationTestStatusDeleted, true),
+			Entry("When status is TestPass", intgteststat.IntegrationTestStatusTestPassed, false),
+			Entry("When status is Warning", intgteststat.IntegrationTestStatusTestWarning, false),
+			Entry("When status is Skipped", intgteststat.IntegrationTestStatusTestSkipped, false),
+			Entry("When status is InProgress", intgteststat.IntegrationTestStatusInProgress, false),
+		)
+
 		It("Invalid status to type fails with error", func() {
 			_, err := intgteststat.IntegrationTestStatusString("Unknown")
 			Expect(err).To(HaveOccurred())
@@ -192,6 +230,7 @@ var _ = Describe("integrationteststatus library unittests", func() {
 				}
 			},
 			Entry("When status is Pending", intgteststat.IntegrationTestStatusPending, false),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, false),
 			Entry("When status is InProgress", intgteststat.IntegrationTestStatusInProgress, true),
 			Entry("When status is EnvironmentProvisionError", intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated, false),
 			Entry("When status is DeploymentError", intgteststat.IntegrationTestStatusDeploymentError_Deprecated, false),
@@ -213,6 +252,7 @@ var _ = Describe("integrationteststatus library unittests", func() {
 				}
 			},
 			Entry("When status is Pending", intgteststat.IntegrationTestStatusPending, false),
+			Entry("When status is Blocked", intgteststat.IntegrationTestStatusBlocked, false),
 			Entry("When status is InProgress", intgteststat.IntegrationTestStatusInProgress, false),
 			Entry("When status is EnvironmentProvisionError", intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated, true),
 			Entry("When status is DeploymentError", intgteststat.IntegrationTestStatusDeploymentError_Deprecated, true),
@@ -294,6 +334,131 @@ var _ = Describe("integrationteststatus library unittests", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("can update the test case results and marshal them", func() {
+			sits.UpdateTestStatusIfChanged(testScenarioName, intgteststat.IntegrationTestStatusTestFail, testDetails)
+			sits.ResetDirty()
+
+			testCaseResults := &helpers.TestCaseResults{
+				Total:  2,
+				Passed: 1,
+				Failed: 1,
+				TestCases: []helpers.TestCaseResult{
+					{Name: "TestLogin", Suite: "e2e", Status: helpers.TestCaseStatusFailed, Message: "expected 200, got 500"},
+				},
+			}
+			Expect(sits.UpdateTestCaseResults(testScenarioName, testCaseResults)).To(Succeed())
+			Expect(sits.IsDirty()).To(BeTrue())
+
+			sits.ResetDirty()
+			Expect(sits.UpdateTestCaseResults(testScenarioName, &helpers.TestCaseResults{
+				Total:     2,
+				Passed:    1,
+				Failed:    1,
+				TestCases: []helpers.TestCaseResult{testCaseResults.TestCases[0]},
+			})).To(Succeed())
+			Expect(sits.IsDirty()).To(BeFalse())
+
+			marshaled, err := json.Marshal(sits)
+			Expect(err).ToNot(HaveOccurred())
+			unmarshaled, err := intgteststat.NewSnapshotIntegrationTestStatuses(string(marshaled))
+			Expect(err).ToNot(HaveOccurred())
+			detail, ok := unmarshaled.GetScenarioStatus(testScenarioName)
+			Expect(ok).To(BeTrue())
+			Expect(detail.TestCaseResults).To(Equal(testCaseResults))
+
+			Expect(sits.UpdateTestCaseResults("unknown-scenario", testCaseResults)).NotTo(Succeed())
+		})
+
+		It("limits the total size of the test cases stored for all scenarios", func() {
+			newResults := func() *helpers.TestCaseResults {
+				results := &helpers.TestCaseResults{}
+				for i := 0; i < helpers.MaxStoredTestCases; i++ {
+					results.TestCases = append(results.TestCases, helpers.TestCaseResult{
+						Name:    fmt.Sprintf("TestCase%d", i),
+						Status:  helpers.TestCaseStatusFailed,
+						Message: strings.Repeat("x", 500),
+					})
+				}
+				results.Total, results.Failed = len(results.TestCases), len(results.TestCases)
+				return results
+			}
+
+			scenarios := 10
+			for i := 0; i < scenarios; i++ {
+				scenarioName := fmt.Sprintf("scenario-%d", i)
+				sits.UpdateTestStatusIfChanged(scenarioName, intgteststat.IntegrationTestStatusTestFail, testDetails)
+				Expect(sits.UpdateTestCaseResults(scenarioName, newResults())).To(Succeed())
+			}
+
+			marshaled, err := json.Marshal(sits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(marshaled)).To(BeNumerically("<", intgteststat.MaxStoredTestCasesSize+scenarios*1024))
+
+			first, ok := sits.GetScenarioStatus("scenario-0")
+			Expect(ok).To(BeTrue())
+			Expect(first.TestCaseResults.TestCases).To(HaveLen(helpers.MaxStoredTestCases))
+			last, ok := sits.GetScenarioStatus(fmt.Sprintf("scenario-%d", scenarios-1))
+			Expect(ok).To(BeTrue())
+			Expect(last.TestCaseResults.TestCases).To(BeEmpty())
+			Expect(last.TestCaseResults.Failed).To(Equal(helpers.MaxStoredTestCases))
+			Expect(last.TestCaseResults.GetOmittedTestCasesCount()).To(Equal(helpers.MaxStoredTestCases))
+		})
+
+		It("can record an attempt and reset the test for the next one", func() {
+			sits.UpdateTestStatusIfChanged(testScenarioName, intgteststat.IntegrationTestStatusInProgress, testDetails)
+			sits.SetTestMaxAttempts(testScenarioName, 3)
+			Expect(sits.UpdateTestPipelineRunName(testScenarioName, pipelineRunName)).To(Succeed())
+			sits.ResetDirty()
+
+			Expect(sits.RetryTest(testScenarioName, intgteststat.IntegrationTestStatusTestFail, "Integration test failed")).To(Succeed())
+			Expect(sits.IsDirty()).To(BeTrue())
+
+			detail, ok := sits.GetScenarioStatus(testScenarioName)
+			Expect(ok).To(BeTrue())
+			Expect(detail.Status).To(Equal(intgteststat.IntegrationTestStatusPending))
+			Expect(detail.Details).To(Equal("Attempt 1/3 finished with status TestFail, starting attempt 2/3: Integration test failed"))
+			Expect(detail.TestPipelineRunName).To(BeEmpty())
+			Expect(detail.GetAttempt()).To(Equal(2))
+			Expect(detail.HasAttemptsLeft()).To(BeTrue())
+			Expect(detail.PreviousAttempts).To(HaveLen(1))
+			Expect(detail.PreviousAttempts[0].Attempt).To(Equal(1))
+			Expect(detail.PreviousAttempts[0].Status).To(Equal(intgteststat.IntegrationTestStatusTestFail))
+			Expect(detail.PreviousAttempts[0].StartTime).NotTo(BeNil())
+			Expect(detail.PreviousAttempts[0].CompletionTime).NotTo(BeNil())
+			Expect(detail.IsPreviousAttempt(pipelineRunName)).To(BeTrue())
+
+			// the attempts survive a round trip through the snapshot annotation
+			jsonData, err := json.Marshal(sits)
+			Expect(err).ToNot(HaveOccurred())
+			statuses, err := intgteststat.NewSnapshotIntegrationTestStatuses(string(jsonData))
+			Expect(err).ToNot(HaveOccurred())
+			detail, ok = statuses.GetScenarioStatus(testScenarioName)
+			Expect(ok).To(BeTrue())
+			Expect(detail.GetAttempt()).To(Equal(2))
+			Expect(detail.MaxAttempts).To(Equal(3))
+			Expect(detail.IsPreviousAttempt(pipelineRunName)).To(BeTrue())
+
+			statuses.ResetStatus(testScenarioName, false)
+			Expect(detail.GetAttempt()).To(Equal(1))
+			Expect(detail.PreviousAttempts).To(BeEmpty())
+		})
+
+		It("has no attempts left when the scenario has no retry policy", func() {
+			sits.UpdateTestStatusIfChanged(testScenarioName, intgteststat.IntegrationTestStatusTestFail, testDetails)
+			sits.SetTestMaxAttempts(testScenarioName, 1)
+
+			detail, ok := sits.GetScenarioStatus(testScenarioName)
+			Expect(ok).To(BeTrue())
+			Expect(detail.MaxAttempts).To(BeZero())
+			Expect(detail.GetAttempt()).To(Equal(1))
+			Expect(detail.HasAttemptsLeft()).To(BeFalse())
+		})
+
+		It("fails to retry the test when testScenario doesn't exist", func() {
+			err := sits.RetryTest(testScenarioName, intgteststat.IntegrationTestStatusTestFail, testDetails)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("Can export valid JSON without start and completion time (Pending)", func() {
 			sits.UpdateTestStatusIfChanged(testScenarioName, intgteststat.IntegrationTestStatusPending, testDetails)
 			detail, ok := sits.GetScenarioStatus(testScenarioName)
@@ -5,11 +5,14 @@ package integrationteststatus
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
-const _IntegrationTestStatusName = "PendingInProgressDeletedEnvironmentProvisionErrorDeploymentErrorTestFailTestPassedTestInvalidBuildPLRInProgressSnapshotCreationFailedBuildPLRFailedGroupSnapshotCreationFailed"
+const _IntegrationTestStatusName = "PendingInProgressDeletedEnvironmentProvisionErrorDeploymentErrorTestFailTestPassedTestInvalidTestWarningBuildPLRInProgressSnapshotCreationFailedBuildPLRFailedGroupSnapshotCreationFailedTestSkippedBlocked"
 
-var _IntegrationTestStatusIndex = [...]uint8{0, 7, 17, 24, 49, 64, 72, 82, 93, 111, 133, 147, 174}
+var _IntegrationTestStatusIndex = [...]uint8{0, 7, 17, 24, 49, 64, 72, 82, 93, 104, 122, 144, 158, 185, 196, 203}
+
+const _IntegrationTestStatusLowerName = "pendinginprogressdeletedenvironmentprovisionerrordeploymenterrortestfailtestpassedtestinvalidtestwarningbuildplrinprogresssnapshotcreationfailedbuildplrfailedgroupsnapshotcreationfailedtestskippedblocked"
 
 func (i IntegrationTestStatus) String() string {
 	i -= 1
@@ -19,21 +22,78 @@ func (i IntegrationTestStatus) String() string {
 	return _IntegrationTestStatusName[_IntegrationTestStatusIndex[i]:_IntegrationTestStatusIndex[i+1]]
 }
 
-var _IntegrationTestStatusValues = []IntegrationTestStatus{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the stringer command to generate them again.
+func _IntegrationTestStatusNoOp() {
+	var x [1]struct{}
+	_ = x[IntegrationTestStatusPending-(1)]
+	_ = x[IntegrationTestStatusInProgress-(2)]
+	_ = x[IntegrationTestStatusDeleted-(3)]
+	_ = x[IntegrationTestStatusEnvironmentProvisionError_Deprecated-(4)]
+	_ = x[IntegrationTestStatusDeploymentError_Deprecated-(5)]
+	_ = x[IntegrationTestStatusTestFail-(6)]
+	_ = x[IntegrationTestStatusTestPassed-(7)]
+	_ = x[IntegrationTestStatusTestInvalid-(8)]
+	_ = x[IntegrationTestStatusTestWarning-(9)]
+	_ = x[BuildPLRInProgress-(10)]
+	_ = x[SnapshotCreationFailed-(11)]
+	_ = x[BuildPLRFailed-(12)]
+	_ = x[GroupSnapshotCreationFailed-(13)]
+	_ = x[IntegrationTestStatusTestSkipped-(14)]
+	_ = x[IntegrationTestStatusBlocked-(15)]
+}
+
+var _IntegrationTestStatusValues = []IntegrationTestStatus{IntegrationTestStatusPending, IntegrationTestStatusInProgress, IntegrationTestStatusDeleted, IntegrationTestStatusEnvironmentProvisionError_Deprecated, IntegrationTestStatusDeploymentError_Deprecated, IntegrationTestStatusTestFail, IntegrationTestStatusTestPassed, IntegrationTestStatusTestInvalid, IntegrationTestStatusTestWarning, BuildPLRInProgress, SnapshotCreationFailed, BuildPLRFailed, GroupSnapshotCreationFailed, IntegrationTestStatusTestSkipped, IntegrationTestStatusBlocked}
 
 var _IntegrationTestStatusNameToValueMap = map[string]IntegrationTestStatus{
-	_IntegrationTestStatusName[0:7]:     1,
-	_IntegrationTestStatusName[7:17]:    2,
-	_IntegrationTestStatusName[17:24]:   3,
-	_IntegrationTestStatusName[24:49]:   4,
-	_IntegrationTestStatusName[49:64]:   5,
-	_IntegrationTestStatusName[64:72]:   6,
-	_IntegrationTestStatusName[72:82]:   7,
-	_IntegrationTestStatusName[82:93]:   8,
-	_IntegrationTestStatusName[93:111]:  9,
-	_IntegrationTestStatusName[111:133]: 10,
-	_IntegrationTestStatusName[133:147]: 11,
-	_IntegrationTestStatusName[148:174]: 12,
+	_IntegrationTestStatusName[0:7]:          IntegrationTestStatusPending,
+	_IntegrationTestStatusLowerName[0:7]:     IntegrationTestStatusPending,
+	_IntegrationTestStatusName[7:17]:         IntegrationTestStatusInProgress,
+	_IntegrationTestStatusLowerName[7:17]:    IntegrationTestStatusInProgress,
+	_IntegrationTestStatusName[17:24]:        IntegrationTestStatusDeleted,
+	_IntegrationTestStatusLowerName[17:24]:   IntegrationTestStatusDeleted,
+	_IntegrationTestStatusName[24:49]:        IntegrationTestStatusEnvironmentProvisionError_Deprecated,
+	_IntegrationTestStatusLowerName[24:49]:   IntegrationTestStatusEnvironmentProvisionError_Deprecated,
+	_IntegrationTestStatusName[49:64]:        IntegrationTestStatusDeploymentError_Deprecated,
+	_IntegrationTestStatusLowerName[49:64]:   IntegrationTestStatusDeploymentError_Deprecated,
+	_IntegrationTestStatusName[64:72]:        IntegrationTestStatusTestFail,
+	_IntegrationTestStatusLowerName[64:72]:   IntegrationTestStatusTestFail,
+	_IntegrationTestStatusName[72:82]:        IntegrationTestStatusTestPassed,
+	_IntegrationTestStatusLowerName[72:82]:   IntegrationTestStatusTestPassed,
+	_IntegrationTestStatusName[82:93]:        IntegrationTestStatusTestInvalid,
+	_IntegrationTestStatusLowerName[82:93]:   IntegrationTestStatusTestInvalid,
+	_IntegrationTestStatusName[93:104]:       IntegrationTestStatusTestWarning,
+	_IntegrationTestStatusLowerName[93:104]:  IntegrationTestStatusTestWarning,
+	_IntegrationTestStatusName[104:122]:      BuildPLRInProgress,
+	_IntegrationTestStatusLowerName[104:122]: BuildPLRInProgress,
+	_IntegrationTestStatusName[122:144]:      SnapshotCreationFailed,
+	_IntegrationTestStatusLowerName[122:144]: SnapshotCreationFailed,
+	_IntegrationTestStatusName[144:158]:      BuildPLRFailed,
+	_IntegrationTestStatusLowerName[144:158]: BuildPLRFailed,
+	_IntegrationTestStatusName[158:185]:      GroupSnapshotCreationFailed,
+	_IntegrationTestStatusLowerName[158:185]: GroupSnapshotCreationFailed,
+	_IntegrationTestStatusName[185:196]:      IntegrationTestStatusTestSkipped,
+	_IntegrationTestStatusLowerName[185:196]: IntegrationTestStatusTestSkipped,
+	_IntegrationTestStatusName[196:203]:      IntegrationTestStatusBlocked,
+	_IntegrationTestStatusLowerName[196:203]: IntegrationTestStatusBlocked,
+}
+
+var _IntegrationTestStatusNames = []string{
+	_IntegrationTestStatusName[0:7],
+	_IntegrationTestStatusName[7:17],
+	_IntegrationTestStatusName[17:24],
+	_IntegrationTestStatusName[24:49],
+	_IntegrationTestStatusName[49:64],
+	_IntegrationTestStatusName[64:72],
+	_IntegrationTestStatusName[72:82],
+	_IntegrationTestStatusName[82:93],
+	_IntegrationTestStatusName[93:104],
+	_IntegrationTestStatusName[104:122],
+	_IntegrationTestStatusName[122:144],
+	_IntegrationTestStatusName[144:158],
+	_IntegrationTestStatusName[158:185],
+	_IntegrationTestStatusName[185:196],
+	_IntegrationTestStatusName[196:203],
 }
 
 // IntegrationTestStatusString retrieves an enum value from the enum constants string name.
@@ -42,6 +102,10 @@ func IntegrationTestStatusString(s string) (IntegrationTestStatus, error) {
 	if val, ok := _IntegrationTestStatusNameToValueMap[s]; ok {
 		return val, nil
 	}
+
+	if val, ok := _IntegrationTestStatusNameToValueMap[strings.ToLower(s)]; ok {
+		return val, nil
+	}
 	return 0, fmt.Errorf("%s does not belong to IntegrationTestStatus values", s)
 }
 
@@ -50,6 +114,13 @@ func IntegrationTestStatusValues() []IntegrationTestStatus {
 	return _IntegrationTestStatusValues
 }
 
+// IntegrationTestStatusStrings returns a slice of all String values of the enum
+func IntegrationTestStatusStrings() []string {
+	strs := make([]string, len(_IntegrationTestStatusNames))
+	copy(strs, _IntegrationTestStatusNames)
+	return strs
+}
+
 // IsAIntegrationTestStatus returns "true" if the value is listed in the enum definition. "false" otherwise
 func (i IntegrationTestStatus) IsAIntegrationTestStatus() bool {
 	for _, v := range _IntegrationTestStatusValues {
@@ -19,6 +19,7 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -74,8 +75,101 @@ var (
 			Buckets: []float64{0.5, 1, 2, 3, 4, 5, 6, 7, 10, 15, 30, 60, 120, 240, 300, 450, 600, 750, 900, 1050, 1200},
 		},
 	)
+
+	PendingGitReports = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "integration_svc_pending_git_reports",
+			Help: "Number of integration test reports which couldn't be delivered to the git provider or additional reporters yet, rebuilt from the Snapshot annotations as they are reconciled after a restart",
+		},
+		[]string{"reporter"},
+	)
+
+	PendingGitReportOldestAgeSeconds = &pendingGitReportAgeCollector{
+		desc: prometheus.NewDesc(
+			"integration_svc_pending_git_report_oldest_age_seconds",
+			"Time duration since the first failed delivery of the oldest integration test report still pending, rebuilt from the Snapshot annotations as they are reconciled after a restart",
+			[]string{"reporter"}, nil,
+		),
+	}
+
+	PendingGitReportDeliverySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "integration_svc_pending_git_report_delivery_seconds",
+			Help:    "Time duration from the first failed delivery of an integration test report till it is delivered",
+			Buckets: []float64{30, 60, 120, 300, 600, 1800, 3600, 7200, 14400, 28800, 43200, 86400},
+		},
+		[]string{"reporter"},
+	)
+
+	DroppedGitReportsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "integration_svc_dropped_git_reports_total",
+			Help: "Total number of integration test reports given up on after failed deliveries",
+		},
+		[]string{"reporter", "reason"},
+	)
+
+	SnapshotGCDeletedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "integration_svc_snapshot_gc_deleted_total",
+			Help: "Total number of Snapshots deleted by the snapshot garbage collection",
+		},
+		[]string{"type"},
+	)
+
+	SnapshotGCRetained = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "integration_svc_snapshot_gc_retained",
+			Help: "Number of Snapshots kept within the retention limits by the last snapshot garbage collection pass",
+		},
+		[]string{"type"},
+	)
+
+	SnapshotGCProtected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "integration_svc_snapshot_gc_protected",
+			Help: "Number of Snapshots kept regardless of the retention limits by the last snapshot garbage collection pass",
+		},
+		[]string{"reason"},
+	)
+)
+
+// pendingGitReports keeps the number of pending reports of each reporter by Snapshot, PendingGitReports is their sum.
+// oldestPendingGitReports keeps the first failed delivery time of the oldest pending report of each reporter by Snapshot.
+// Both start empty and are filled again as the Snapshots with pending reports are reconciled after a restart.
+var (
+	pendingGitReports       = map[string]map[string]int{}
+	oldestPendingGitReports = map[string]map[string]time.Time{}
+	pendingGitReportsMutex  sync.Mutex
 )
 
+// pendingGitReportAgeCollector reports the age of the oldest pending report of each reporter, computed when the
+// metrics are collected so that it keeps growing while the reports aren't delivered
+type pendingGitReportAgeCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c *pendingGitReportAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *pendingGitReportAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	pendingGitReportsMutex.Lock()
+	oldest := map[string]time.Time{}
+	for _, reports := range oldestPendingGitReports {
+		for reporter, since := range reports {
+			if current, ok := oldest[reporter]; !ok || since.Before(current) {
+				oldest[reporter] = since
+			}
+		}
+	}
+	pendingGitReportsMutex.Unlock()
+
+	for reporter, since := range oldest {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(since).Seconds(), reporter)
+	}
+}
+
 // IntegrationMetrics represents a collection of metrics to be registered on a
 // Prometheus metrics registry for a integration service.
 type IntegrationMetrics struct {
@@ -120,6 +214,53 @@ func RegisterReleaseLatency(startTime metav1.Time) {
 	ReleaseLatencySeconds.Observe(latency)
 }
 
+// RegisterPendingGitReports replaces the number of pending reports of each reporter for the given Snapshot and the
+// first failed delivery time of the oldest of them, the Snapshot is forgotten when it has no pending reports
+func RegisterPendingGitReports(snapshot string, reports map[string]int, oldestReports map[string]time.Time) {
+	pendingGitReportsMutex.Lock()
+	defer pendingGitReportsMutex.Unlock()
+
+	for reporter, count := range pendingGitReports[snapshot] {
+		PendingGitReports.WithLabelValues(reporter).Sub(float64(count))
+	}
+	for reporter, count := range reports {
+		PendingGitReports.WithLabelValues(reporter).Add(float64(count))
+	}
+	if len(reports) == 0 {
+		delete(pendingGitReports, snapshot)
+		delete(oldestPendingGitReports, snapshot)
+		return
+	}
+	pendingGitReports[snapshot] = reports
+	oldestPendingGitReports[snapshot] = oldestReports
+}
+
+func RegisterDeliveredPendingGitReport(reporter string, firstFailureTime time.Time) {
+	PendingGitReportDeliverySeconds.WithLabelValues(reporter).Observe(time.Since(firstFailureTime).Seconds())
+}
+
+func RegisterDroppedGitReport(reporter, reason string) {
+	DroppedGitReportsTotal.WithLabelValues(reporter, reason).Inc()
+}
+
+// RegisterDeletedSnapshots adds the number of snapshots of the given type deleted by the snapshot garbage collection
+func RegisterDeletedSnapshots(snapshotType string, count int) {
+	SnapshotGCDeletedTotal.WithLabelValues(snapshotType).Add(float64(count))
+}
+
+// RegisterSnapshotGCPass replaces the numbers of retained Snapshots by type and of protected Snapshots by reason
+// with the ones of the last snapshot garbage collection pass
+func RegisterSnapshotGCPass(retained, protected map[string]int) {
+	SnapshotGCRetained.Reset()
+	for snapshotType, count := range retained {
+		SnapshotGCRetained.WithLabelValues(snapshotType).Set(float64(count))
+	}
+	SnapshotGCProtected.Reset()
+	for reason, count := range protected {
+		SnapshotGCProtected.WithLabelValues(reason).Set(float64(count))
+	}
+}
+
 func (m *IntegrationMetrics) InitMetrics(registerer prometheus.Registerer) error {
 	registerer.MustRegister(
 		SnapshotCreatedToPipelineRunStartedSeconds,
@@ -128,6 +269,13 @@ func (m *IntegrationMetrics) InitMetrics(registerer prometheus.Registerer) error
 		SnapshotDurationSeconds,
 		SnapshotTotal,
 		ReleaseLatencySeconds,
+		PendingGitReports,
+		PendingGitReportOldestAgeSeconds,
+		PendingGitReportDeliverySeconds,
+		DroppedGitReportsTotal,
+		SnapshotGCDeletedTotal,
+		SnapshotGCRetained,
+		SnapshotGCProtected,
 	)
 	for _, probe := range m.probes {
 		if err := registerer.Register(probe.AvailabilityGauge()); err != nil {
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -213,4 +214,68 @@ var _ = Describe("Metrics Integration", Ordered, func() {
 			Expect(testutil.CollectAndCount(ReleaseLatencySeconds)).To(Equal(1))
 		})
 	})
+
+	Context("When the pending git reports are registered", func() {
+
+		It("sums the pending reports of the Snapshots by reporter", func() {
+			now := time.Now()
+			RegisterPendingGitReports("default/snapshot-a", map[string]int{"GithubReporter": 2, "WebhookReporter": 1},
+				map[string]time.Time{"GithubReporter": now, "WebhookReporter": now})
+			RegisterPendingGitReports("default/snapshot-b", map[string]int{"GithubReporter": 1},
+				map[string]time.Time{"GithubReporter": now})
+			Expect(testutil.ToFloat64(PendingGitReports.WithLabelValues("GithubReporter"))).To(Equal(3.0))
+			Expect(testutil.ToFloat64(PendingGitReports.WithLabelValues("WebhookReporter"))).To(Equal(1.0))
+
+			// the pending reports of a Snapshot are replaced, and forgotten once delivered
+			RegisterPendingGitReports("default/snapshot-a", map[string]int{"GithubReporter": 1},
+				map[string]time.Time{"GithubReporter": now})
+			RegisterPendingGitReports("default/snapshot-b", nil, nil)
+			Expect(testutil.ToFloat64(PendingGitReports.WithLabelValues("GithubReporter"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(PendingGitReports.WithLabelValues("WebhookReporter"))).To(Equal(0.0))
+
+			RegisterPendingGitReports("default/snapshot-a", nil, nil)
+			Expect(testutil.ToFloat64(PendingGitReports.WithLabelValues("GithubReporter"))).To(Equal(0.0))
+		})
+
+		It("reports the age of the oldest pending report of each reporter", func() {
+			now := time.Now()
+			RegisterPendingGitReports("default/snapshot-a", map[string]int{"GithubReporter": 1},
+				map[string]time.Time{"GithubReporter": now.Add(-10 * time.Minute)})
+			RegisterPendingGitReports("default/snapshot-b", map[string]int{"GithubReporter": 1, "WebhookReporter": 1},
+				map[string]time.Time{"GithubReporter": now.Add(-time.Hour), "WebhookReporter": now.Add(-time.Minute)})
+			Expect(testutil.CollectAndCount(PendingGitReportOldestAgeSeconds)).To(Equal(2))
+			Expect(collectPendingGitReportOldestAge("GithubReporter")).To(BeNumerically("~", time.Hour.Seconds(), 5))
+
+			// the age goes down once the oldest report is delivered and the reporter is forgotten once nothing is pending
+			RegisterPendingGitReports("default/snapshot-b", map[string]int{"WebhookReporter": 1},
+				map[string]time.Time{"WebhookReporter": now.Add(-time.Minute)})
+			Expect(collectPendingGitReportOldestAge("GithubReporter")).To(BeNumerically("~", (10 * time.Minute).Seconds(), 5))
+			RegisterPendingGitReports("default/snapshot-a", nil, nil)
+			RegisterPendingGitReports("default/snapshot-b", nil, nil)
+			Expect(testutil.CollectAndCount(PendingGitReportOldestAgeSeconds)).To(Equal(0))
+		})
+
+		It("observes the delivery time of the pending reports and counts the dropped ones", func() {
+			RegisterDeliveredPendingGitReport("GithubReporter", time.Now().Add(-10*time.Minute))
+			Expect(testutil.CollectAndCount(PendingGitReportDeliverySeconds)).To(Equal(1))
+
+			RegisterDroppedGitReport("GithubReporter", "expired")
+			Expect(testutil.ToFloat64(DroppedGitReportsTotal.WithLabelValues("GithubReporter", "expired"))).To(Equal(1.0))
+		})
+	})
 })
+
+// collectPendingGitReportOldestAge returns the age of the oldest pending report of the reporter, -1 when none is reported
+func collectPendingGitReportOldestAge(reporter string) float64 {
+	ch := make(chan prometheus.Metric, 10)
+	PendingGitReportOldestAgeSeconds.Collect(ch)
+	close(ch)
+	for metric := range ch {
+		m := &dto.Metric{}
+		Expect(metric.Write(m)).To(Succeed())
+		if m.GetLabel()[0].GetValue() == reporter {
+			return m.GetGauge().GetValue()
+		}
+	}
+	return -1
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshotgc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/pkg/metrics"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshots,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=releases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=componentgroups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshotretentionpolicies,verbs=get;list;watch
+
+const (
+	// DefaultCollectorInterval is the default delay between the end of a garbage collection pass and the start of the next one
+	DefaultCollectorInterval = 6 * time.Hour
+
+	// DefaultCollectorNamespacesPerSecond is the default number of namespaces garbage-collected per second
+	DefaultCollectorNamespacesPerSecond = 1
+)
+
+// CollectorOptions configures the garbage collection of the snapshots from within the manager
+type CollectorOptions struct {
+	Options
+
+	// Interval is the delay between the end of a garbage collection pass and the start of the next one
+	Interval time.Duration
+	// NamespacesPerSecond limits the rate at which the namespaces are garbage-collected during a pass
+	NamespacesPerSecond float64
+}
+
+// Collector garbage-collects the snapshots of the tenant namespaces from within the manager. Instead of
+// processing all namespaces at once on cron ticks, it goes through them one at a time with rate limiting,
+// and reads the namespaces, snapshots, releases, component groups and pipelineruns from the informer caches
+// of the manager.
+type Collector struct {
+	client  client.Client
+	logger  logr.Logger
+	options CollectorOptions
+	limiter flowcontrol.RateLimiter
+}
+
+// NewCollector creates a new Collector which reads through the given cache-backed client, the Releases of the
+// snapshots are looked up through its spec.snapshot field index.
+func NewCollector(cl client.Client, logger logr.Logger, options CollectorOptions) *Collector {
+	options.UseReleaseIndex = true
+	if options.Interval <= 0 {
+		options.Interval = DefaultCollectorInterval
+	}
+	if options.NamespacesPerSecond <= 0 {
+		options.NamespacesPerSecond = DefaultCollectorNamespacesPerSecond
+	}
+	return &Collector{
+		client:  cl,
+		logger:  logger,
+		options: options,
+		limiter: flowcontrol.NewTokenBucketRateLimiter(float32(options.NamespacesPerSecond), 1),
+	}
+}
+
+// Start runs the garbage collection passes until the context is canceled, it implements manager.Runnable.
+func (c *Collector) Start(ctx context.Context) error {
+	c.logger.Info("Starting snapshot garbage collection",
+		"interval", c.options.Interval.String(),
+		"namespacesPerSecond", c.options.NamespacesPerSecond,
+		"dryRun", c.options.DryRun,
+	)
+	wait.UntilWithContext(ctx, c.collect, c.options.Interval)
+	c.logger.Info("Stopped snapshot garbage collection")
+	return nil
+}
+
+// NeedLeaderElection makes only the leader garbage-collect the snapshots, it implements manager.LeaderElectionRunnable.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// collect runs a garbage collection pass over all tenant namespaces and registers its metrics
+func (c *Collector) collect(ctx context.Context) {
+	namespaces, err := getTenantNamespaces(c.client, c.logger)
+	if err != nil {
+		c.logger.Error(err, "Snapshot garbage collection pass failed, it will be retried on the next pass")
+		return
+	}
+
+	c.logger.V(1).Info("Snapshot garbage collection pass started", "namespaces", len(namespaces))
+	retained := map[string]int{}
+	protected := map[string]int{}
+	deleted := 0
+	for _, ns := range namespaces {
+		if err := c.limiter.Wait(ctx); err != nil {
+			// the context was canceled, the pass is abandoned without registering its partial numbers
+			return
+		}
+
+		result, err := GarbageCollectNamespace(c.client, c.logger, ns.Name, c.options.Options)
+		if err != nil {
+			continue
+		}
+		for snapshotType, count := range result.Deleted {
+			deleted += count
+			if !c.options.DryRun {
+				metrics.RegisterDeletedSnapshots(string(snapshotType), count)
+			}
+		}
+		for snapshotType, count := range result.Retained {
+			retained[string(snapshotType)] += count
+		}
+		for reason, count := range result.Protected {
+			protected[reason] += count
+		}
+	}
+	metrics.RegisterSnapshotGCPass(retained, protected)
+	c.logger.Info("Snapshot garbage collection pass finished",
+		"namespaces", len(namespaces),
+		"deleted", deleted,
+		"dryRun", c.options.DryRun,
+	)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshotgc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/pkg/metrics"
+)
+
+var _ = Describe("Snapshot garbage collection in the manager", func() {
+	var (
+		cl          client.Client
+		currentTime time.Time
+	)
+
+	// newSnapshot returns a component snapshot of the given event type created the given time ago
+	newSnapshot := func(name, namespace, eventType string, age time.Duration) applicationapiv1alpha1.Snapshot {
+		return applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"pac.test.appstudio.openshift.io/event-type": eventType,
+					"test.appstudio.openshift.io/type":           "component",
+					"appstudio.openshift.io/component":           "comp-a",
+				},
+				CreationTimestamp: metav1.NewTime(currentTime.Add(-age)),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		currentTime = time.Now()
+		metrics.SnapshotGCDeletedTotal.Reset()
+
+		namespaces := []core.Namespace{}
+		for _, name := range []string{"ns1", "ns2"} {
+			namespaces = append(namespaces, core.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"konflux-ci.dev/type": "tenant"},
+				},
+			})
+		}
+		cl = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithLists(
+				&core.NamespaceList{Items: namespaces},
+				&applicationapiv1alpha1.SnapshotList{
+					Items: []applicationapiv1alpha1.Snapshot{
+						newSnapshot("newer-pr", "ns1", "pull_request", time.Hour),
+						newSnapshot("older-pr", "ns1", "pull_request", 2*time.Hour),
+						newSnapshot("newer-push", "ns1", "push", time.Hour),
+						newSnapshot("older-push", "ns1", "push", 2*time.Hour),
+						newSnapshot("released-push", "ns1", "push", 3*time.Hour),
+						newSnapshot("pr", "ns2", "pull_request", time.Hour),
+					},
+				},
+				&releasev1alpha1.ReleaseList{
+					Items: []releasev1alpha1.Release{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ns1"},
+							Spec:       releasev1alpha1.ReleaseSpec{Snapshot: "released-push"},
+						},
+					},
+				},
+			).
+			WithIndex(&releasev1alpha1.Release{}, "spec.snapshot", func(obj client.Object) []string {
+				return []string{obj.(*releasev1alpha1.Release).Spec.Snapshot}
+			}).
+			Build()
+	})
+
+	It("counts the snapshots of a namespace by the outcome of their garbage collection", func() {
+		result, err := GarbageCollectNamespace(cl, logr.Discard(), "ns1", Options{
+			PrSnapshotsToKeep:              1,
+			NonPrSnapshotsToKeep:           3,
+			MinSnapshotsToKeepPerComponent: 1,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Deleted).To(Equal(map[v1beta2.SnapshotRetentionType]int{v1beta2.SnapshotRetentionTypePR: 1}))
+		Expect(result.Retained).To(Equal(map[v1beta2.SnapshotRetentionType]int{
+			v1beta2.SnapshotRetentionTypePR:   1,
+			v1beta2.SnapshotRetentionTypePush: 1,
+		}))
+		Expect(result.Protected).To(Equal(map[string]int{
+			protectedByRelease:          1,
+			protectedByComponentMinimum: 1,
+		}))
+	})
+
+	It("looks the releases of the snapshots up through the spec.snapshot field index", func() {
+		options := Options{
+			PrSnapshotsToKeep:              1,
+			NonPrSnapshotsToKeep:           3,
+			MinSnapshotsToKeepPerComponent: 1,
+			DryRun:                         true,
+		}
+		listed, err := GarbageCollectNamespace(cl, logr.Discard(), "ns1", options)
+		Expect(err).NotTo(HaveOccurred())
+
+		options.UseReleaseIndex = true
+		indexed, err := GarbageCollectNamespace(cl, logr.Discard(), "ns1", options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(Equal(listed))
+		Expect(indexed.Protected).To(HaveKeyWithValue(protectedByRelease, 1))
+	})
+
+	It("garbage collects the tenant namespaces and registers the metrics of the pass", func() {
+		collector := NewCollector(cl, logr.Discard(), CollectorOptions{
+			Options: Options{
+				PrSnapshotsToKeep:              1,
+				NonPrSnapshotsToKeep:           3,
+				MinSnapshotsToKeepPerComponent: 1,
+			},
+			NamespacesPerSecond: 100,
+		})
+		Expect(collector.NeedLeaderElection()).To(BeTrue())
+		collector.collect(context.Background())
+
+		snapshots := &applicationapiv1alpha1.SnapshotList{}
+		Expect(cl.List(context.Background(), snapshots)).To(Succeed())
+		var names []string
+		for _, snapshot := range snapshots.Items {
+			names = append(names, snapshot.Name)
+		}
+		Expect(names).To(ConsistOf("newer-pr", "newer-push", "older-push", "released-push", "pr"))
+
+		Expect(testutil.ToFloat64(metrics.SnapshotGCDeletedTotal.WithLabelValues("pr"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(metrics.SnapshotGCRetained.WithLabelValues("pr"))).To(Equal(float64(2)))
+		Expect(testutil.ToFloat64(metrics.SnapshotGCRetained.WithLabelValues("push"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(metrics.SnapshotGCProtected.WithLabelValues(protectedByRelease))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(metrics.SnapshotGCProtected.WithLabelValues(protectedByComponentMinimum))).To(Equal(float64(1)))
+	})
+
+	It("only reports the snapshots in dry run", func() {
+		collector := NewCollector(cl, logr.Discard(), CollectorOptions{
+			Options: Options{
+				PrSnapshotsToKeep:    1,
+				NonPrSnapshotsToKeep: 3,
+				DryRun:               true,
+			},
+			NamespacesPerSecond: 100,
+		})
+		collector.collect(context.Background())
+
+		snapshots := &applicationapiv1alpha1.SnapshotList{}
+		Expect(cl.List(context.Background(), snapshots)).To(Succeed())
+		Expect(snapshots.Items).To(HaveLen(6))
+		Expect(testutil.ToFloat64(metrics.SnapshotGCDeletedTotal.WithLabelValues("pr"))).To(BeZero())
+	})
+
+	It("stops when the context is canceled", func() {
+		collector := NewCollector(cl, logr.Discard(), CollectorOptions{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(collector.Start(ctx)).To(Succeed())
+	})
+})
@@ -0,0 +1,1141 @@
+package snapshotgc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Annotation that can be manually added by users to preven the deletion of a snapshot, they can also
+	// define the TTL of snapshot by providing time in following format: 10h28m9s
+	// in case the format is not correct the snapshot is considered to deletion
+	KeepSnapshotAnnotation = "test.appstudio.openshift.io/keep-snapshot"
+	// PRStatusAnnotation contains the status of the PR, it is marked as "merged" when the push build pipelinerun is triggered
+	PRStatusAnnotation = "test.appstudio.openshift.io/pr-status"
+	// PRStatusMerged indicates that the PR has been merged
+	PRStatusMerged = "merged"
+	// PRGroupCreationAnnotation is the annotation used to indicate whether the group snapshot has been created for the PR snapshot or not, or will be created
+	PRGroupCreationAnnotation = "test.appstudio.openshift.io/create-groupsnapshot-status"
+	// RetentionReasonAnnotation is set by the garbage collection on the snapshots it keeps, it tells why they were kept
+	RetentionReasonAnnotation = "test.appstudio.openshift.io/retention-reason"
+)
+
+// Default retention limits of the snapshot garbage collection
+const (
+	DefaultPrSnapshotsToKeep              = 512
+	DefaultNonPrSnapshotsToKeep           = 512
+	DefaultMinSnapshotsToKeepPerComponent = 5
+)
+
+// Reasons why snapshots are kept regardless of the retention limits
+const (
+	protectedByRelease          = "release"
+	protectedByKeepAnnotation   = "keep-annotation"
+	protectedByComponentMinimum = "component-minimum"
+	protectedByGCL              = "gcl"
+	protectedByOverride         = "override"
+	protectedByPipelineRun      = "in-flight-pipelinerun"
+)
+
+// Stores pointers to resources to which the snapshot is associated
+type snapshotData struct {
+	release  releasev1alpha1.Release
+	snapshot *applicationapiv1alpha1.Snapshot
+}
+
+// Stores a snapshot kept by the garbage collection and why it was kept, protection is the reason category
+// of the snapshots kept regardless of the retention limits and is empty for the other ones
+type snapshotRetention struct {
+	snapshot   applicationapiv1alpha1.Snapshot
+	protection string
+	reason     string
+}
+
+// Stores a snapshot selected for garbage collection and why it was selected
+type snapshotRemoval struct {
+	snapshot applicationapiv1alpha1.Snapshot
+	reason   string
+}
+
+// Options configures the garbage collection of the snapshots of the tenant namespaces
+type Options struct {
+	// PrSnapshotsToKeep is the number of PR snapshots to keep in each namespace
+	PrSnapshotsToKeep int
+	// NonPrSnapshotsToKeep is the number of non-PR snapshots to keep in each namespace
+	NonPrSnapshotsToKeep int
+	// MinSnapshotsToKeepPerComponent is the number of push snapshots to keep for each component
+	MinSnapshotsToKeepPerComponent int
+	// DryRun only reports the snapshots which would be garbage-collected, without deleting them
+	DryRun bool
+	// UseReleaseIndex looks the Releases of each snapshot up through the spec.snapshot field index of the client,
+	// which the manager cache provides, instead of listing all the Releases of the namespace
+	UseReleaseIndex bool
+}
+
+// Result counts the snapshots of a namespace by the outcome of their garbage collection
+type Result struct {
+	// Deleted counts the deleted snapshots by type, or the ones which would be deleted in dry run
+	Deleted map[v1beta2.SnapshotRetentionType]int
+	// Retained counts the snapshots kept within the retention limits by type
+	Retained map[v1beta2.SnapshotRetentionType]int
+	// Protected counts the snapshots kept regardless of the retention limits by reason
+	Protected map[string]int
+}
+
+// Iterates tenant namespaces and garbage-collect their snapshots, the snapshots are only
+// reported when dryRun is set in the options
+func GarbageCollectSnapshots(
+	cl client.Client,
+	logger logr.Logger,
+	options Options,
+) error {
+	namespaces, err := getTenantNamespaces(cl, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.V(1).Info("Snapshot garbage collection started...", "dryRun", options.DryRun)
+	for _, ns := range namespaces {
+		// the namespace failures are logged, they don't stop the garbage collection of the other namespaces
+		_, _ = GarbageCollectNamespace(cl, logger, ns.Name, options)
+	}
+	return nil
+}
+
+// Garbage-collect the snapshots of the namespace and count them by the outcome, the snapshots are only
+// reported when dryRun is set in the options
+func GarbageCollectNamespace(
+	cl client.Client,
+	logger logr.Logger,
+	namespace string,
+	options Options,
+) (*Result, error) {
+	logger.V(1).Info("Processing namespace", "namespace", namespace)
+
+	// Use local copies per namespace to prevent cumulative decrease across namespaces
+	localPrSnapshotsToKeep := options.PrSnapshotsToKeep
+	localNonPrSnapshotsToKeep := options.NonPrSnapshotsToKeep
+	localMinSnapShotsToKeepPerComponent := options.MinSnapshotsToKeepPerComponent
+
+	policy, err := getSnapshotRetentionPolicy(cl, namespace, logger)
+	if err != nil {
+		logger.Error(
+			err,
+			"Failed getting the snapshot retention policy. Skipping namespace",
+			"namespace", namespace,
+		)
+		return nil, err
+	}
+	if policy != nil && policy.Spec.MinSnapshotsToKeepPerComponent != nil {
+		// the preserved snapshots aren't subject to the limits, so the policy can lower the cluster-wide minimum but not raise it
+		localMinSnapShotsToKeepPerComponent = min(int(*policy.Spec.MinSnapshotsToKeepPerComponent), options.MinSnapshotsToKeepPerComponent)
+	}
+
+	snapToData := make(map[string]snapshotData)
+	if !options.UseReleaseIndex {
+		snapToData, err = getSnapshotsForNSReleases(cl, snapToData, namespace, logger)
+		if err != nil {
+			logger.Error(
+				err,
+				"Failed getting releases associated with snapshots. Skipping namespace",
+				"namespace",
+				namespace,
+			)
+			return nil, err
+		}
+	}
+
+	var candidates []applicationapiv1alpha1.Snapshot
+	candidates, err = getUnassociatedNSSnapshots(cl, snapToData, namespace, options.UseReleaseIndex, logger)
+	if err != nil {
+		logger.Error(
+			err,
+			"Failed getting unassociated snapshots. Skipping namespace",
+			"namespace", namespace,
+		)
+		return nil, err
+	}
+	logger.V(1).Info(
+		"Found unassociated snapshots not associated with releases",
+		"namespace", namespace,
+		"count of unassociated snapshots", len(candidates),
+	)
+
+	var retentions []snapshotRetention
+	for _, data := range snapToData {
+		if data.snapshot != nil {
+			retentions = append(retentions, snapshotRetention{
+				snapshot:   *data.snapshot,
+				protection: protectedByRelease,
+				reason:     fmt.Sprintf("associated with the Release %s", data.release.Name),
+			})
+		}
+	}
+
+	unfilteredCandidates := candidates
+	candidates, keptPrSnapshots, keptNonPrSnapshots := filterSnapshotsWithKeepSnapshotAnnotation(candidates, logger)
+	localPrSnapshotsToKeep -= keptPrSnapshots
+	// Both the Snapshots associated with Releases and ones that have been marked with
+	// the keep snapshot annotation count against the non-PR limit
+	localNonPrSnapshotsToKeep -= keptNonPrSnapshots
+	localNonPrSnapshotsToKeep -= len(snapToData)
+	unreserved := make(map[string]bool, len(candidates))
+	for _, snap := range candidates {
+		unreserved[snap.Name] = true
+	}
+	for _, snap := range unfilteredCandidates {
+		if !unreserved[snap.Name] {
+			retentions = append(retentions, snapshotRetention{
+				snapshot:   snap,
+				protection: protectedByKeepAnnotation,
+				reason:     fmt.Sprintf("annotated with %s", KeepSnapshotAnnotation),
+			})
+		}
+	}
+
+	// The snapshots which last promoted a GCL entry, the override snapshots and the snapshots with in-flight
+	// integration pipelineruns count against the limits as the ones with the keep snapshot annotation
+	protectedSnapshots, err := getProtectedSnapshots(cl, namespace, candidates, policy, logger)
+	if err != nil {
+		logger.Error(
+			err,
+			"Failed getting protected snapshots. Skipping namespace",
+			"namespace", namespace,
+		)
+		return nil, err
+	}
+	candidates, protectedPrSnapshots, protectedNonPrSnapshots := filterProtectedSnapshots(candidates, protectedSnapshots)
+	localPrSnapshotsToKeep -= protectedPrSnapshots
+	localNonPrSnapshotsToKeep -= protectedNonPrSnapshots
+	for _, retention := range protectedSnapshots {
+		retentions = append(retentions, retention)
+	}
+
+	result := &Result{
+		Deleted:   make(map[v1beta2.SnapshotRetentionType]int),
+		Retained:  make(map[v1beta2.SnapshotRetentionType]int),
+		Protected: make(map[string]int),
+	}
+	preservedPerComponent := getPreservedSnapshotsPerComponent(candidates, localMinSnapShotsToKeepPerComponent, logger)
+	allCandidates := slices.Clone(candidates)
+
+	// The snapshot types with a rule in the retention policy of the namespace are garbage collected
+	// by the rule, the other ones with the cluster-wide limits. The rules are clamped to the cluster-wide
+	// limits, and the snapshots they keep count against the limits of the other types.
+	policyCandidates, candidates := filterSnapshotsWithRetentionRule(candidates, policy)
+	removals, keptPolicyPrSnapshots, keptPolicyNonPrSnapshots := getSnapshotsForRemovalByPolicy(
+		policyCandidates, policy, localPrSnapshotsToKeep, localNonPrSnapshotsToKeep,
+		localMinSnapShotsToKeepPerComponent, time.Now(), logger,
+	)
+	localPrSnapshotsToKeep -= keptPolicyPrSnapshots
+	localNonPrSnapshotsToKeep -= keptPolicyNonPrSnapshots
+
+	cancelledOrMergedPRSnapshots := extractCancelledOrMergedPRSnapshots(candidates)
+	for _, snap := range getSnapshotsForRemoval(
+		cl, candidates, localPrSnapshotsToKeep, localNonPrSnapshotsToKeep, localMinSnapShotsToKeepPerComponent, logger,
+	) {
+		removals = append(removals, snapshotRemoval{
+			snapshot: snap,
+			reason: getRemovalReason(
+				snap, cancelledOrMergedPRSnapshots, localPrSnapshotsToKeep, localNonPrSnapshotsToKeep,
+			),
+		})
+	}
+
+	removed := make(map[string]bool, len(removals))
+	for _, removal := range removals {
+		removed[removal.snapshot.Name] = true
+	}
+	for _, snap := range allCandidates {
+		switch {
+		case removed[snap.Name]:
+			result.Deleted[getSnapshotRetentionType(snap)]++
+		case preservedPerComponent[snap.Name]:
+			retentions = append(retentions, snapshotRetention{
+				snapshot:   snap,
+				protection: protectedByComponentMinimum,
+				reason: fmt.Sprintf(
+					"one of the %d latest push snapshots of the component %s",
+					localMinSnapShotsToKeepPerComponent, snap.GetLabels()[gitops.SnapshotComponentLabel],
+				),
+			})
+		default:
+			retentions = append(retentions, snapshotRetention{
+				snapshot: snap,
+				reason:   "within the retention limits",
+			})
+		}
+	}
+	for _, retention := range retentions {
+		if retention.protection != "" {
+			result.Protected[retention.protection]++
+		} else {
+			result.Retained[getSnapshotRetentionType(retention.snapshot)]++
+		}
+	}
+
+	if options.DryRun {
+		reportSnapshotRemovals(namespace, removals, logger)
+		logger.V(1).Info("Finished processing namespace", "namespace", namespace)
+		return result, nil
+	}
+
+	logger.V(1).Info(
+		"Deleting snapshots",
+		"namespace", namespace,
+		"count of snapshots to delete", len(removals),
+	)
+	snapshotsToDelete := make([]applicationapiv1alpha1.Snapshot, 0, len(removals))
+	for _, removal := range removals {
+		logger.V(1).Info(
+			"Deleting snapshot",
+			"namespace", namespace,
+			"snapshot.name", removal.snapshot.Name,
+			"reason", removal.reason,
+		)
+		snapshotsToDelete = append(snapshotsToDelete, removal.snapshot)
+	}
+	deleteSnapshots(cl, snapshotsToDelete, logger)
+	annotateSnapshotRetentions(cl, retentions, logger)
+	logger.V(1).Info("Finished processing namespace", "namespace", namespace)
+	return result, nil
+}
+
+// Gets the snapshot retention policy of the namespace, nil is returned when the namespace has no
+// retention policy or the SnapshotRetentionPolicy CRD isn't installed
+func getSnapshotRetentionPolicy(
+	cl client.Client,
+	namespace string,
+	logger logr.Logger,
+) (*v1beta2.SnapshotRetentionPolicy, error) {
+	policy := &v1beta2.SnapshotRetentionPolicy{}
+	err := cl.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: namespace, Name: v1beta2.SnapshotRetentionPolicySingletonName},
+		policy,
+	)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to get snapshot retention policy", "namespace", namespace)
+		return nil, err
+	}
+	logger.V(1).Info(
+		"Found snapshot retention policy",
+		"namespace", namespace,
+		"rules", len(policy.Spec.Rules),
+	)
+	return policy, nil
+}
+
+// Gets all tenant namespaces
+func getTenantNamespaces(
+	cl client.Client, logger logr.Logger) ([]core.Namespace, error) {
+
+	// First get the toolchain-provisioned tenant namespaces
+	req, _ := labels.NewRequirement(
+		"toolchain.dev.openshift.com/type", selection.In, []string{"tenant"},
+	)
+	selector := labels.NewSelector().Add(*req)
+	toolChainNamespaceList := &core.NamespaceList{}
+	err := cl.List(
+		context.Background(),
+		toolChainNamespaceList,
+		&client.ListOptions{LabelSelector: selector},
+	)
+	if err != nil {
+		logger.Error(err, "Failed listing namespaces")
+		return nil, err
+	}
+
+	// Then get the Konflux user namespaces
+	req, _ = labels.NewRequirement(
+		"konflux.ci/type", selection.In, []string{"user"},
+	)
+	selector = labels.NewSelector().Add(*req)
+	konfluxUserNamespaceList := &core.NamespaceList{}
+	err = cl.List(
+		context.Background(),
+		konfluxUserNamespaceList,
+		&client.ListOptions{LabelSelector: selector},
+	)
+	if err != nil {
+		logger.Error(err, "Failed listing namespaces")
+		return nil, err
+	}
+
+	namespaces := append(toolChainNamespaceList.Items, konfluxUserNamespaceList.Items...)
+
+	// Finally get the new format Konflux tenant namespaces
+	req, _ = labels.NewRequirement(
+		"konflux-ci.dev/type", selection.In, []string{"tenant"},
+	)
+	selector = labels.NewSelector().Add(*req)
+	konfluxTenantNamespaceList := &core.NamespaceList{}
+	err = cl.List(
+		context.Background(),
+		konfluxTenantNamespaceList,
+		&client.ListOptions{LabelSelector: selector},
+	)
+	if err != nil {
+		logger.Error(err, "Failed listing namespaces")
+		return nil, err
+	}
+
+	namespaces = append(namespaces, konfluxTenantNamespaceList.Items...)
+
+	return namespaces, nil
+}
+
+// Gets a map to allow to tell with direct lookup if a snapshot is associated with
+// a release resource
+func getSnapshotsForNSReleases(
+	cl client.Client,
+	snapToData map[string]snapshotData,
+	namespace string,
+	logger logr.Logger,
+) (map[string]snapshotData, error) {
+	releases := &releasev1alpha1.ReleaseList{}
+	err := cl.List(
+		context.Background(),
+		releases,
+		&client.ListOptions{Namespace: namespace},
+	)
+	if err != nil {
+		logger.Error(err, "Failed to list releases")
+		return nil, err
+	}
+
+	for _, release := range releases.Items {
+		data, ok := snapToData[release.Spec.Snapshot]
+		if !ok {
+			data = snapshotData{}
+		}
+		data.release = release
+		snapToData[release.Spec.Snapshot] = data
+	}
+	return snapToData, nil
+}
+
+// Gets all namespace snapshots that aren't associated with a release. The releases are either already in snapToData,
+// or looked up for each snapshot through the spec.snapshot field index when useReleaseIndex is set
+func getUnassociatedNSSnapshots(
+	cl client.Client,
+	snapToData map[string]snapshotData,
+	namespace string,
+	useReleaseIndex bool,
+	logger logr.Logger,
+) ([]applicationapiv1alpha1.Snapshot, error) {
+	snaps := &applicationapiv1alpha1.SnapshotList{}
+	err := cl.List(
+		context.Background(),
+		snaps,
+		&client.ListOptions{Namespace: namespace},
+	)
+	if err != nil {
+		logger.Error(err, "Failed to list snapshots")
+		return nil, err
+	}
+
+	var unAssociatedSnaps []applicationapiv1alpha1.Snapshot
+
+	for _, snap := range snaps.Items {
+		if useReleaseIndex {
+			releases, err := loader.NewLoader().GetReleasesWithSnapshot(context.Background(), cl, &snap)
+			if err != nil {
+				logger.Error(err, "Failed to get the releases of snapshot", "snapshot.name", snap.Name)
+				return nil, err
+			}
+			if len(*releases) > 0 {
+				snapToData[snap.Name] = snapshotData{release: (*releases)[0]}
+			}
+		}
+		if data, found := snapToData[snap.Name]; found {
+			logger.V(1).Info(
+				"Skipping snapshot as it's associated with release",
+				"namespace", snap.Namespace,
+				"snapshot.name", snap.Name,
+			)
+			data.snapshot = &snap
+			snapToData[snap.Name] = data
+			continue
+		}
+		unAssociatedSnaps = append(unAssociatedSnaps, snap)
+	}
+
+	return unAssociatedSnaps, nil
+}
+
+// extractCancelledOrMergedPRSnapshots gets all namespace PR snapshots which were marked as cancelled or its PR is merged, and are not annotated with the keep snapshot annotation
+func extractCancelledOrMergedPRSnapshots(snapshots []applicationapiv1alpha1.Snapshot) []string {
+	var cancelledOrMergedPRSnapshots []string
+
+	for _, snap := range snapshots {
+		if !isNonPrSnapshot(snap) &&
+			(gitops.IsSnapshotMarkedAsCanceled(&snap) || metadata.HasAnnotationWithValue(&snap, PRStatusAnnotation, PRStatusMerged)) &&
+			!metadata.HasAnnotationWithValue(&snap, "test.appstudio.openshift.io/keep-snapshot", "true") {
+			cancelledOrMergedPRSnapshots = append(cancelledOrMergedPRSnapshots, snap.Name)
+		}
+	}
+
+	return cancelledOrMergedPRSnapshots
+}
+
+// Returns true if the snapshot's age is longer than specific (in seconds)
+func isLongerThanSpecificTime(snap applicationapiv1alpha1.Snapshot, specificTime int64) bool {
+	creationTime := snap.GetCreationTimestamp().Time
+	if creationTime.IsZero() {
+		return false
+	}
+
+	currentTime := metav1.Now().Time
+	elapsedTime := currentTime.Sub(creationTime).Seconds()
+
+	return int64(elapsedTime) > specificTime
+}
+
+// Returns true if snapshot is a push snapshot, override snapshot, or if the
+// event-type annnotation for the snapshot is not set.  Returns false otherwise
+func isNonPrSnapshot(
+	snapshot applicationapiv1alpha1.Snapshot,
+) bool {
+	label, found := snapshot.GetLabels()["pac.test.appstudio.openshift.io/event-type"]
+	isOverrideSnapshot := metadata.HasLabelWithValue(&snapshot, "test.appstudio.openshift.io/type", "override")
+	if !found || label == "push" || label == "Push" || isOverrideSnapshot {
+		return true
+	}
+	return false
+}
+
+// Returns the type of the snapshot which selects its rule in the snapshot retention policy
+func getSnapshotRetentionType(
+	snapshot applicationapiv1alpha1.Snapshot,
+) v1beta2.SnapshotRetentionType {
+	switch {
+	case gitops.IsOverrideSnapshot(&snapshot):
+		return v1beta2.SnapshotRetentionTypeOverride
+	case gitops.IsGroupSnapshot(&snapshot):
+		return v1beta2.SnapshotRetentionTypeGroup
+	case !isNonPrSnapshot(snapshot):
+		return v1beta2.SnapshotRetentionTypePR
+	default:
+		return v1beta2.SnapshotRetentionTypePush
+	}
+}
+
+// Returns true if the PR snapshot was created within one day and is waiting for other in-progress
+// pipelineruns of its PR group before the group snapshot can be created
+func isWaitingForGroupSnapshot(
+	snapshot applicationapiv1alpha1.Snapshot,
+) bool {
+	// the message "is still running, won't create group snapshot" comes from integration-service when it decides not to create group snapshot yet
+	return metadata.HasAnnotation(&snapshot, PRGroupCreationAnnotation) &&
+		strings.Contains(snapshot.GetAnnotations()[PRGroupCreationAnnotation], "is still running, won't create group snapshot") &&
+		!isLongerThanSpecificTime(snapshot, 1*24*60*60)
+}
+
+// Returns true if snapshots TTL has expired, for TTL snapshot annotation only
+// Returns false otherwise
+func isPastTTL(
+	snapshot applicationapiv1alpha1.Snapshot,
+	logger logr.Logger,
+) bool {
+	currentTime := metav1.Now()
+	creationTime := snapshot.GetCreationTimestamp().Time
+	if creationTime.IsZero() {
+		return false
+	}
+	label, found := snapshot.GetAnnotations()["test.appstudio.openshift.io/keep-snapshot"]
+	ttl, _ := time.ParseDuration(label)
+	if ttl.String() == "0s" {
+		logger.V(1).Info(
+			"Keep-snapshot annotation has invalid value, snapshot will be garbage collected if it is pull-request type.",
+			"namespace", snapshot.Namespace,
+			"snapshot.name", snapshot.Name,
+		)
+		return true
+	} else {
+		// calculate diff between creationTimestamp and currentTime()
+		diff := currentTime.Sub(creationTime)
+		if diff >= ttl && found {
+			return true
+		}
+	}
+	return false
+}
+
+// Removes snapshots with the KeepSnapshotAnnotation from the list of candidate
+// snapshots.  Returns a new slice without the reserved snapshots plus the
+// number of PR and non-pr snapshots that were reserved
+// also checks if the TTL format for keep-snapshot has been provided and remove snapshot
+// in case it lives past TTL
+func filterSnapshotsWithKeepSnapshotAnnotation(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	logger logr.Logger,
+) ([]applicationapiv1alpha1.Snapshot, int, int) {
+	nonReservedSnapshots := make([]applicationapiv1alpha1.Snapshot, 0, len(snapshots))
+	keptNonPrSnapshots := 0
+	keptPrSnapshots := 0
+	for _, snap := range snapshots {
+		if metadata.HasAnnotationWithValue(&snap, "test.appstudio.openshift.io/keep-snapshot", "true") || (metadata.HasAnnotation(&snap, "test.appstudio.openshift.io/keep-snapshot") && !isPastTTL(snap, logger)) {
+			if isNonPrSnapshot(snap) {
+				keptNonPrSnapshots++
+			} else {
+				keptPrSnapshots++
+			}
+		} else {
+			nonReservedSnapshots = append(nonReservedSnapshots, snap)
+		}
+	}
+	return nonReservedSnapshots, keptPrSnapshots, keptNonPrSnapshots
+}
+
+// Gets the candidate snapshots which are kept regardless of the retention limits by name: the snapshots
+// which last promoted a GCL entry of a ComponentGroup, the snapshots with in-flight integration pipelineruns
+// and the override snapshots, unless the retention policy of the namespace has a rule for the override snapshots
+func getProtectedSnapshots(
+	cl client.Client,
+	namespace string,
+	snapshots []applicationapiv1alpha1.Snapshot,
+	policy *v1beta2.SnapshotRetentionPolicy,
+	logger logr.Logger,
+) (map[string]snapshotRetention, error) {
+	protectedSnapshots := make(map[string]snapshotRetention)
+
+	gclReasons, err := getGCLSnapshotReasons(cl, namespace, snapshots, logger)
+	if err != nil {
+		return nil, err
+	}
+	pipelineRunReasons, err := getInFlightPipelineRunSnapshotReasons(cl, namespace, logger)
+	if err != nil {
+		return nil, err
+	}
+	protectOverrideSnapshots := policy == nil || policy.GetRule(v1beta2.SnapshotRetentionTypeOverride) == nil
+
+	for _, snap := range snapshots {
+		var protection, reason string
+		if gclReason, ok := gclReasons[snap.Name]; ok {
+			protection, reason = protectedByGCL, gclReason
+		} else if pipelineRunReason, ok := pipelineRunReasons[snap.Name]; ok {
+			protection, reason = protectedByPipelineRun, pipelineRunReason
+		} else if protectOverrideSnapshots && gitops.IsOverrideSnapshot(&snap) {
+			protection, reason = protectedByOverride, "override snapshot"
+		} else {
+			continue
+		}
+		logger.V(1).Info(
+			"Skipping protected snapshot",
+			"namespace", snap.Namespace,
+			"snapshot.name", snap.Name,
+			"reason", reason,
+		)
+		protectedSnapshots[snap.Name] = snapshotRetention{snapshot: snap, protection: protection, reason: reason}
+	}
+	return protectedSnapshots, nil
+}
+
+// Gets the names of the snapshots which last promoted a GCL entry of the ComponentGroups of the namespace
+// with the reason they are kept. The snapshot is the one recorded in the promotion history of the entry,
+// or the latest push snapshot of the component with the promoted image for the images promoted by their
+// build pipelinerun
+func getGCLSnapshotReasons(
+	cl client.Client,
+	namespace string,
+	snapshots []applicationapiv1alpha1.Snapshot,
+	logger logr.Logger,
+) (map[string]string, error) {
+	componentGroups := &v1beta2.ComponentGroupList{}
+	err := cl.List(
+		context.Background(),
+		componentGroups,
+		&client.ListOptions{Namespace: namespace},
+	)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list component groups")
+		return nil, err
+	}
+
+	reasons := make(map[string]string)
+	for _, componentGroup := range componentGroups.Items {
+		for _, entry := range componentGroup.Status.GlobalCandidateList {
+			if entry.LastPromotedImage == "" {
+				continue
+			}
+			reason := fmt.Sprintf(
+				"last promoted the component %s to the Global Candidate List of the ComponentGroup %s",
+				entry.Name, componentGroup.Name,
+			)
+			if record := getLastPromotionRecord(&componentGroup, entry); record != nil && record.Snapshot != "" {
+				reasons[record.Snapshot] = reason
+				continue
+			}
+			if snap := getLatestPushSnapshotWithImage(snapshots, entry.Name, entry.LastPromotedImage); snap != nil {
+				reasons[snap.Name] = reason
+			}
+		}
+	}
+	return reasons, nil
+}
+
+// Returns the latest promotion of the image of the GCL entry in the promotion history of the ComponentGroup,
+// or nil when it isn't recorded
+func getLastPromotionRecord(
+	componentGroup *v1beta2.ComponentGroup,
+	entry v1beta2.ComponentState,
+) *v1beta2.PromotionRecord {
+	for _, history := range componentGroup.Status.PromotionHistory {
+		if history.Name != entry.Name || history.Version != entry.Version {
+			continue
+		}
+		// the promotions are sorted from the most recent one
+		for i := range history.Promotions {
+			if history.Promotions[i].Image == entry.LastPromotedImage {
+				return &history.Promotions[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the latest push snapshot of the component which contains the given image of the component, or nil
+func getLatestPushSnapshotWithImage(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	componentName, image string,
+) *applicationapiv1alpha1.Snapshot {
+	var latest *applicationapiv1alpha1.Snapshot
+	for i, snap := range snapshots {
+		if getSnapshotRetentionType(snap) != v1beta2.SnapshotRetentionTypePush ||
+			snap.GetLabels()[gitops.SnapshotComponentLabel] != componentName {
+			continue
+		}
+		if !slices.ContainsFunc(snap.Spec.Components, func(component applicationapiv1alpha1.SnapshotComponent) bool {
+			return component.Name == componentName && component.ContainerImage == image
+		}) {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&snap.CreationTimestamp) {
+			latest = &snapshots[i]
+		}
+	}
+	return latest
+}
+
+// Gets the names of the snapshots with in-flight integration pipelineruns with the reason they are kept
+func getInFlightPipelineRunSnapshotReasons(
+	cl client.Client,
+	namespace string,
+	logger logr.Logger,
+) (map[string]string, error) {
+	pipelineRuns := &tektonv1.PipelineRunList{}
+	err := cl.List(
+		context.Background(),
+		pipelineRuns,
+		client.InNamespace(namespace),
+		client.MatchingLabels{tektonconsts.PipelinesTypeLabel: tektonconsts.PipelineTypeTest},
+	)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		logger.Error(err, "Failed to list integration pipelineruns")
+		return nil, err
+	}
+
+	reasons := make(map[string]string)
+	for _, pipelineRun := range pipelineRuns.Items {
+		snapshotName := pipelineRun.GetLabels()[tektonconsts.SnapshotNameLabel]
+		if snapshotName == "" || helpers.HasPipelineRunFinished(&pipelineRun) {
+			continue
+		}
+		reasons[snapshotName] = fmt.Sprintf("the integration pipelinerun %s is in progress", pipelineRun.Name)
+	}
+	return reasons, nil
+}
+
+// Removes the protected snapshots from the list of candidate snapshots. Returns a new slice
+// without the protected snapshots plus the number of PR and non-PR snapshots that were protected
+func filterProtectedSnapshots(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	protectedSnapshots map[string]snapshotRetention,
+) ([]applicationapiv1alpha1.Snapshot, int, int) {
+	unprotectedSnapshots := make([]applicationapiv1alpha1.Snapshot, 0, len(snapshots))
+	protectedPrSnapshots := 0
+	protectedNonPrSnapshots := 0
+	for _, snap := range snapshots {
+		if _, ok := protectedSnapshots[snap.Name]; !ok {
+			unprotectedSnapshots = append(unprotectedSnapshots, snap)
+		} else if isNonPrSnapshot(snap) {
+			protectedNonPrSnapshots++
+		} else {
+			protectedPrSnapshots++
+		}
+	}
+	return unprotectedSnapshots, protectedPrSnapshots, protectedNonPrSnapshots
+}
+
+// Sets the retention reason annotation on the kept snapshots protected by a GCL entry, an in-flight
+// integration pipelinerun or as override snapshots, and removes it from the other kept snapshots.
+// The snapshots whose annotation is up to date aren't patched.
+func annotateSnapshotRetentions(
+	cl client.Client,
+	retentions []snapshotRetention,
+	logger logr.Logger,
+) {
+	for _, retention := range retentions {
+		snap := retention.snapshot
+		annotated := retention.protection == protectedByGCL || retention.protection == protectedByOverride ||
+			retention.protection == protectedByPipelineRun
+		if annotated && metadata.HasAnnotationWithValue(&snap, RetentionReasonAnnotation, retention.reason) ||
+			!annotated && !metadata.HasAnnotation(&snap, RetentionReasonAnnotation) {
+			continue
+		}
+		patch := client.MergeFrom(snap.DeepCopy())
+		if annotated {
+			_ = metadata.SetAnnotation(&snap.ObjectMeta, RetentionReasonAnnotation, retention.reason)
+		} else {
+			delete(snap.Annotations, RetentionReasonAnnotation)
+		}
+		if err := cl.Patch(context.Background(), &snap, patch); err != nil {
+			logger.Error(err, "Failed to annotate snapshot with its retention reason.", "snapshot.name", snap.Name)
+		}
+	}
+}
+
+// Keep a certain amount of pr/non-pr snapshots
+func getSnapshotsForRemoval(
+	cl client.Client,
+	snapshots []applicationapiv1alpha1.Snapshot,
+	prSnapshotsToKeep int,
+	nonPrSnapshotsToKeep int,
+	minSnapShotsToKeepPerComponent int,
+	logger logr.Logger,
+) []applicationapiv1alpha1.Snapshot {
+	sort.Slice(snapshots, func(i, j int) bool {
+		// sorting in reverse order, so we keep the latest snapshots
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+
+	// preservedPerComponent is a map to track if a snapshot is preserved from garbage collection
+	preservedPerComponent := getPreservedSnapshotsPerComponent(snapshots, minSnapShotsToKeepPerComponent, logger)
+	shortList := []applicationapiv1alpha1.Snapshot{}
+	keptPrSnaps := 0
+	keptNonPrSnaps := 0
+
+	// First extract canceled PR Snapshots since these should be deleted first
+	// as they were superseded by newer Snapshots for that same PR
+	cancelledOrMergedPRSnapshots := extractCancelledOrMergedPRSnapshots(snapshots)
+
+	for _, snap := range snapshots {
+		snap := snap
+		if preservedPerComponent[snap.Name] {
+			logger.V(1).Info(
+				"Skipping snapshot (preserved per component minimum kept count)",
+				"namespace", snap.Namespace,
+				"snapshot.name", snap.Name,
+				"min-snapshots-to-keep-per-component", minSnapShotsToKeepPerComponent,
+			)
+
+			// count the snapshot towards the total number of snapshots to keep / global limits
+			if isNonPrSnapshot(snap) {
+				keptNonPrSnaps++
+			} else {
+				keptPrSnaps++
+			}
+			continue
+		}
+
+		// override snapshot does not have the event-type label, but we still want to add it to the cleanup list
+		if isNonPrSnapshot(snap) {
+			if keptNonPrSnaps < nonPrSnapshotsToKeep {
+				logger.V(1).Info(
+					"Skipping non-PR candidate snapshot",
+					"namespace", snap.Namespace,
+					"snapshot.name", snap.Name,
+					"non-pr-snapshot-kept", keptNonPrSnaps+1,
+					"non-pr-snapshots-to-keep", nonPrSnapshotsToKeep,
+				)
+				keptNonPrSnaps++
+			} else {
+				logger.V(1).Info(
+					"Adding non-PR candidate snapshot",
+					"namespace", snap.Namespace,
+					"snapshot.name", snap.Name,
+					"non-pr-snapshot-kept", keptNonPrSnaps,
+					"non-pr-snapshots-to-keep", nonPrSnapshotsToKeep,
+				)
+				shortList = append(shortList, snap)
+			}
+		} else {
+			if keptPrSnaps < prSnapshotsToKeep && !slices.Contains(cancelledOrMergedPRSnapshots, snap.Name) {
+				logger.V(1).Info(
+					"Skipping PR candidate snapshot",
+					"namespace", snap.Namespace,
+					"snapshot.name", snap.Name,
+					"pr-snapshot-kept", keptPrSnaps+1,
+					"pr-snapshots-to-keep", prSnapshotsToKeep,
+				)
+				keptPrSnaps++
+			} else if !slices.Contains(cancelledOrMergedPRSnapshots, snap.Name) && isWaitingForGroupSnapshot(snap) {
+				// when we have reached the number of nonPrSnapshotToKeep
+				// we still try to keep the component snapshot if it is created in one day, unmerged and expecting group snapshot creation but waiting for some other inprogress pipelineruns to finish
+				logger.V(1).Info(
+					"Skipping PR candidate snapshot as it is expecting group snapshot creation and is created within one day",
+					"namespace", snap.Namespace,
+					"snapshot.name", snap.Name,
+					"pr-snapshot-kept", keptPrSnaps+1,
+					"pr-snapshots-to-keep", prSnapshotsToKeep,
+				)
+				keptPrSnaps++
+			} else {
+				logger.V(1).Info(
+					"Adding PR candidate snapshot",
+					"namespace", snap.Namespace,
+					"snapshot.name", snap.Name,
+					"pr-snapshot-kept", keptPrSnaps,
+					"pr-snapshots-to-keep", prSnapshotsToKeep,
+				)
+				shortList = append(shortList, snap)
+			}
+		}
+	}
+	return shortList
+}
+
+// Returns the push component snapshots preserved from garbage collection as the latest
+// minSnapShotsToKeepPerComponent push snapshots of their component
+func getPreservedSnapshotsPerComponent(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	minSnapShotsToKeepPerComponent int,
+	logger logr.Logger,
+) map[string]bool {
+	preservedPerComponent := make(map[string]bool)
+
+	// Group push snapshots by component
+	componentToPushSnapshots := make(map[string][]applicationapiv1alpha1.Snapshot)
+	for _, snap := range snapshots {
+
+		if !isNonPrSnapshot(snap) {
+			continue
+		}
+
+		if !metadata.HasLabelWithValue(&snap, "test.appstudio.openshift.io/type", "component") {
+			continue
+		}
+
+		componentName := snap.GetLabels()["appstudio.openshift.io/component"]
+
+		if componentName == "" {
+			logger.V(1).Info(
+				"Skipping snapshot as component label is empty",
+				"namespace", snap.Namespace,
+				"snapshot.name", snap.Name,
+			)
+			continue
+		}
+
+		componentToPushSnapshots[componentName] = append(componentToPushSnapshots[componentName], snap)
+
+	}
+
+	// For each component, mark the latest N push snapshots as preserved
+	for componentName, compSnapshots := range componentToPushSnapshots {
+		// sorting in reverse order, so we keep the latest snapshots
+		sort.Slice(compSnapshots, func(i, j int) bool {
+			return compSnapshots[j].CreationTimestamp.Before(&compSnapshots[i].CreationTimestamp)
+		})
+
+		keepCount := minSnapShotsToKeepPerComponent
+		if len(compSnapshots) < keepCount {
+			keepCount = len(compSnapshots)
+		}
+
+		for i := 0; i < keepCount; i++ {
+			preservedPerComponent[compSnapshots[i].Name] = true
+			logger.V(1).Info(
+				"Preserving push snapshot per component minimum",
+				"component", componentName,
+				"snapshot.name", compSnapshots[i].Name,
+			)
+		}
+	}
+	return preservedPerComponent
+}
+
+// Returns why the snapshot was selected for garbage collection by the cluster-wide limits
+func getRemovalReason(
+	snapshot applicationapiv1alpha1.Snapshot,
+	cancelledOrMergedPRSnapshots []string,
+	prSnapshotsToKeep, nonPrSnapshotsToKeep int,
+) string {
+	if isNonPrSnapshot(snapshot) {
+		return fmt.Sprintf("exceeds the %d non-PR snapshots to keep", max(nonPrSnapshotsToKeep, 0))
+	}
+	if slices.Contains(cancelledOrMergedPRSnapshots, snapshot.Name) {
+		return "the PR snapshot was canceled or its PR was merged"
+	}
+	return fmt.Sprintf("exceeds the %d PR snapshots to keep", max(prSnapshotsToKeep, 0))
+}
+
+// Splits the snapshots into the ones whose type has a rule in the retention policy and the other ones
+func filterSnapshotsWithRetentionRule(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	policy *v1beta2.SnapshotRetentionPolicy,
+) ([]applicationapiv1alpha1.Snapshot, []applicationapiv1alpha1.Snapshot) {
+	if policy == nil {
+		return nil, snapshots
+	}
+	var withRule, withoutRule []applicationapiv1alpha1.Snapshot
+	for _, snap := range snapshots {
+		if policy.GetRule(getSnapshotRetentionType(snap)) != nil {
+			withRule = append(withRule, snap)
+		} else {
+			withoutRule = append(withoutRule, snap)
+		}
+	}
+	return withRule, withoutRule
+}
+
+// Selects the snapshots which exceed the count or the age of the rule of their type in the retention
+// policy. The latest push snapshots of each component are preserved regardless of the rules, and the
+// canceled or merged PR snapshots are removed first as with the cluster-wide limits. The rules are
+// clamped to the cluster-wide PR and non-PR limits, the numbers of PR and non-PR snapshots kept are
+// returned to count them against the limits of the snapshot types without a rule
+func getSnapshotsForRemovalByPolicy(
+	snapshots []applicationapiv1alpha1.Snapshot,
+	policy *v1beta2.SnapshotRetentionPolicy,
+	prSnapshotsToKeep, nonPrSnapshotsToKeep int,
+	minSnapShotsToKeepPerComponent int,
+	now time.Time,
+	logger logr.Logger,
+) ([]snapshotRemoval, int, int) {
+	if len(snapshots) == 0 {
+		return nil, 0, 0
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		// sorting in reverse order, so we keep the latest snapshots
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+
+	preservedPerComponent := getPreservedSnapshotsPerComponent(snapshots, minSnapShotsToKeepPerComponent, logger)
+	cancelledOrMergedPRSnapshots := extractCancelledOrMergedPRSnapshots(snapshots)
+	kept := make(map[v1beta2.SnapshotRetentionType]int)
+	keptPrSnaps, keptNonPrSnaps := 0, 0
+	var removals []snapshotRemoval
+
+	for _, snap := range snapshots {
+		snapshotType := getSnapshotRetentionType(snap)
+		rule := policy.GetRule(snapshotType)
+		creationTime := snap.GetCreationTimestamp().Time
+		isNonPr := isNonPrSnapshot(snap)
+
+		var reason string
+		switch {
+		case preservedPerComponent[snap.Name]:
+			// count the snapshot towards the number of snapshots of its type to keep
+		case snapshotType == v1beta2.SnapshotRetentionTypePR && slices.Contains(cancelledOrMergedPRSnapshots, snap.Name):
+			reason = "the PR snapshot was canceled or its PR was merged"
+		case rule.MaxAge != nil && !creationTime.IsZero() && now.Sub(creationTime) > rule.MaxAge.Duration:
+			reason = fmt.Sprintf("older than the %s max age of %s snapshots in the retention policy", rule.MaxAge.Duration, snapshotType)
+		case snapshotType == v1beta2.SnapshotRetentionTypePR && isWaitingForGroupSnapshot(snap):
+			// kept beyond the limits as with the cluster-wide limits
+		case rule.MaxCount != nil && kept[snapshotType] >= int(*rule.MaxCount):
+			reason = fmt.Sprintf("exceeds the %d %s snapshots to keep in the retention policy", *rule.MaxCount, snapshotType)
+		case isNonPr && keptNonPrSnaps >= nonPrSnapshotsToKeep:
+			reason = fmt.Sprintf("exceeds the %d non-PR snapshots to keep, the retention policy can't exceed it", max(nonPrSnapshotsToKeep, 0))
+		case !isNonPr && keptPrSnaps >= prSnapshotsToKeep:
+			reason = fmt.Sprintf("exceeds the %d PR snapshots to keep, the retention policy can't exceed it", max(prSnapshotsToKeep, 0))
+		}
+
+		if reason == "" {
+			logger.V(1).Info(
+				"Skipping candidate snapshot kept by the retention policy",
+				"namespace", snap.Namespace,
+				"snapshot.name", snap.Name,
+				"snapshot.type", snapshotType,
+				"snapshots-kept", kept[snapshotType]+1,
+			)
+			kept[snapshotType]++
+			if isNonPr {
+				keptNonPrSnaps++
+			} else {
+				keptPrSnaps++
+			}
+			continue
+		}
+		logger.V(1).Info(
+			"Adding candidate snapshot by the retention policy",
+			"namespace", snap.Namespace,
+			"snapshot.name", snap.Name,
+			"snapshot.type", snapshotType,
+			"reason", reason,
+		)
+		removals = append(removals, snapshotRemoval{snapshot: snap, reason: reason})
+	}
+	return removals, keptPrSnaps, keptNonPrSnaps
+}
+
+// Reports the snapshots which would be garbage-collected in the namespace and why, without deleting them
+func reportSnapshotRemovals(
+	namespace string,
+	removals []snapshotRemoval,
+	logger logr.Logger,
+) {
+	for _, removal := range removals {
+		logger.Info(
+			"Dry run: snapshot would be deleted",
+			"namespace", namespace,
+			"snapshot.name", removal.snapshot.Name,
+			"snapshot.type", getSnapshotRetentionType(removal.snapshot),
+			"snapshot.creationTimestamp", removal.snapshot.CreationTimestamp.UTC().Format(time.RFC3339),
+			"reason", removal.reason,
+		)
+	}
+	logger.Info(
+		"Dry run: finished processing namespace",
+		"namespace", namespace,
+		"count of snapshots which would be deleted", len(removals),
+	)
+}
+
+// Delete snapshots determined to be garbage-collected
+func deleteSnapshots(
+	cl client.Client,
+	snapshots []applicationapiv1alpha1.Snapshot,
+	logger logr.Logger,
+) {
+
+	for _, snap := range snapshots {
+		snap := snap
+		err := cl.Delete(context.Background(), &snap)
+		if err != nil {
+			logger.Error(err, "Failed to delete snapshot.", "snapshot.name", snap.Name)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package snapshotgc
 
 import (
 	"bytes"
@@ -10,16 +10,21 @@ import (
 	gomonkey "github.com/agiledragon/gomonkey/v2"
 	"github.com/go-logr/logr"
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/gitops"
 	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tonglil/buflogr"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var _ = Describe("Test garbage collection for snapshots", func() {
@@ -138,7 +143,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 						Items: []applicationapiv1alpha1.Snapshot{},
 					}).Build()
 			snapToData := make(map[string]snapshotData)
-			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", logger)
+			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", false, logger)
 
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(output).To(BeEmpty())
@@ -167,7 +172,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 			snapToData := make(map[string]snapshotData)
 			snapToData["associated-snapshot"] = snapshotData{}
 			snapToData["another-associated-snapshot"] = snapshotData{}
-			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", logger)
+			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", false, logger)
 
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(output).To(BeEmpty())
@@ -204,7 +209,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 			snapToData := make(map[string]snapshotData)
 			snapToData["associated-snapshot"] = snapshotData{}
 			snapToData["another-associated-snapshot"] = snapshotData{}
-			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", logger)
+			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", false, logger)
 
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(output).To(HaveLen(1))
@@ -232,7 +237,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 						Items: []applicationapiv1alpha1.Snapshot{*snap1, *snap2},
 					}).Build()
 			snapToData := make(map[string]snapshotData)
-			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", logger)
+			output, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", false, logger)
 
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(output).To(HaveLen(2))
@@ -242,7 +247,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 
 			cl := fake.NewClientBuilder().Build()
 			snapToData := make(map[string]snapshotData)
-			_, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", logger)
+			_, err := getUnassociatedNSSnapshots(cl, snapToData, "ns1", false, logger)
 
 			Expect(err).Should(HaveOccurred())
 			Expect(err).To(MatchError(ContainSubstring(
@@ -864,7 +869,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 		})
 	})
 
-	Describe("Test garbageCollectSnapshots", func() {
+	Describe("Test GarbageCollectSnapshots", func() {
 		It("Garbage collect snapshots from multiple namespaces", func() {
 
 			ns1 := &core.Namespace{
@@ -1128,7 +1133,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(snapsBefore.Items).To(HaveLen(5))
 
-			err = garbageCollectSnapshots(cl, logger, 1, 1, 0)
+			err = GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 1})
 			Expect(err).ShouldNot(HaveOccurred())
 
 			snapsAfter := &applicationapiv1alpha1.SnapshotList{}
@@ -1156,7 +1161,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 
 		It("Fails if cannot list namespaces", func() {
 			cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
-			err := garbageCollectSnapshots(cl, logger, 1, 1, 0)
+			err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 1})
 			Expect(err).Should(HaveOccurred())
 			Expect(err).To(MatchError(ContainSubstring(
 				"no kind is registered for the type v1.NamespaceList in scheme",
@@ -1177,7 +1182,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 				&core.NamespaceList{Items: []core.Namespace{*ns1}},
 			).Build()
 
-			err := garbageCollectSnapshots(cl, logger, 1, 1, 0)
+			err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 1})
 			Expect(err).ShouldNot(HaveOccurred())
 			logLines := strings.Split(buf.String(), "\n")
 			Expect(logLines[len(logLines)-2]).Should(ContainSubstring(
@@ -1200,6 +1205,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 				getUnassociatedNSSnapshots, func(cl client.Client,
 					snapToData map[string]snapshotData,
 					namespace string,
+					useReleaseIndex bool,
 					logger logr.Logger) ([]applicationapiv1alpha1.Snapshot, error) {
 					return nil, errors.New("")
 				})
@@ -1208,7 +1214,7 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 				&core.NamespaceList{Items: []core.Namespace{*ns1}},
 			).Build()
 
-			err := garbageCollectSnapshots(cl, logger, 1, 1, 0)
+			err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 1})
 			Expect(err).ShouldNot(HaveOccurred())
 			logLines := strings.Split(buf.String(), "\n")
 			Expect(logLines[len(logLines)-2]).Should(ContainSubstring(
@@ -1704,4 +1710,472 @@ var _ = Describe("Test garbage collection for snapshots", func() {
 			Expect(output).To(HaveLen(3))
 		})
 	})
+
+	Describe("Test snapshot retention policy", func() {
+		var (
+			currentTime time.Time
+			policy      *v1beta2.SnapshotRetentionPolicy
+		)
+
+		// newSnapshot returns a snapshot of the given type and component created the given time ago
+		newSnapshot := func(name, eventType, snapshotType, component string, age time.Duration) *applicationapiv1alpha1.Snapshot {
+			return &applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "ns1",
+					Labels: map[string]string{
+						"pac.test.appstudio.openshift.io/event-type": eventType,
+						"test.appstudio.openshift.io/type":           snapshotType,
+						"appstudio.openshift.io/component":           component,
+					},
+					CreationTimestamp: metav1.NewTime(currentTime.Add(-age)),
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			currentTime = time.Now()
+			maxCount := int32(1)
+			policy = &v1beta2.SnapshotRetentionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      v1beta2.SnapshotRetentionPolicySingletonName,
+					Namespace: "ns1",
+				},
+				Spec: v1beta2.SnapshotRetentionPolicySpec{
+					Rules: []v1beta2.SnapshotRetentionRule{
+						{
+							SnapshotType: v1beta2.SnapshotRetentionTypePR,
+							MaxCount:     &maxCount,
+						},
+						{
+							SnapshotType: v1beta2.SnapshotRetentionTypePush,
+							MaxAge:       &metav1.Duration{Duration: 24 * time.Hour},
+						},
+					},
+				},
+			}
+		})
+
+		It("Gets the retention policy of the namespace", func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).Build()
+
+			output, err := getSnapshotRetentionPolicy(cl, "ns1", logger)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(output).NotTo(BeNil())
+			Expect(output.GetRule(v1beta2.SnapshotRetentionTypePR)).NotTo(BeNil())
+			Expect(output.GetRule(v1beta2.SnapshotRetentionTypeGroup)).To(BeNil())
+
+			output, err = getSnapshotRetentionPolicy(cl, "ns2", logger)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(output).To(BeNil())
+		})
+
+		It("Gets the type of the snapshots", func() {
+			Expect(getSnapshotRetentionType(*newSnapshot("pr", "pull_request", "component", "comp-a", 0))).
+				To(Equal(v1beta2.SnapshotRetentionTypePR))
+			Expect(getSnapshotRetentionType(*newSnapshot("push", "push", "component", "comp-a", 0))).
+				To(Equal(v1beta2.SnapshotRetentionTypePush))
+			Expect(getSnapshotRetentionType(*newSnapshot("group", "pull_request", "group", "", 0))).
+				To(Equal(v1beta2.SnapshotRetentionTypeGroup))
+			Expect(getSnapshotRetentionType(*newSnapshot("override", "", "override", "", 0))).
+				To(Equal(v1beta2.SnapshotRetentionTypeOverride))
+		})
+
+		It("Splits the snapshots by the rules of the retention policy", func() {
+			prSnap := newSnapshot("pr", "pull_request", "component", "comp-a", 0)
+			groupSnap := newSnapshot("group", "pull_request", "group", "", 0)
+
+			withRule, withoutRule := filterSnapshotsWithRetentionRule(
+				[]applicationapiv1alpha1.Snapshot{*prSnap, *groupSnap}, policy,
+			)
+			Expect(withRule).To(ConsistOf(*prSnap))
+			Expect(withoutRule).To(ConsistOf(*groupSnap))
+
+			withRule, withoutRule = filterSnapshotsWithRetentionRule(
+				[]applicationapiv1alpha1.Snapshot{*prSnap, *groupSnap}, nil,
+			)
+			Expect(withRule).To(BeEmpty())
+			Expect(withoutRule).To(HaveLen(2))
+		})
+
+		It("Removes the snapshots exceeding the count or age of their rule", func() {
+			newerPRSnap := newSnapshot("newer-pr", "pull_request", "component", "comp-a", time.Hour)
+			olderPRSnap := newSnapshot("older-pr", "pull_request", "component", "comp-a", 2*time.Hour)
+			newPushSnap := newSnapshot("new-push", "push", "component", "comp-a", time.Hour)
+			oldPushSnap := newSnapshot("old-push", "push", "component", "comp-a", 48*time.Hour)
+			olderPushSnap := newSnapshot("older-push", "push", "component", "comp-a", 72*time.Hour)
+
+			removals, _, _ := getSnapshotsForRemovalByPolicy(
+				[]applicationapiv1alpha1.Snapshot{*olderPRSnap, *oldPushSnap, *newerPRSnap, *newPushSnap, *olderPushSnap},
+				policy, 512, 512, 2, currentTime, logger,
+			)
+			Expect(removals).To(HaveLen(2))
+			// the two latest push snapshots of the component are preserved regardless of their age
+			Expect(removals[0].snapshot.Name).To(Equal("older-pr"))
+			Expect(removals[0].reason).To(Equal("exceeds the 1 pr snapshots to keep in the retention policy"))
+			Expect(removals[1].snapshot.Name).To(Equal("older-push"))
+			Expect(removals[1].reason).To(Equal("older than the 24h0m0s max age of push snapshots in the retention policy"))
+		})
+
+		It("Removes the canceled PR snapshots first", func() {
+			canceledPRSnap := newSnapshot("canceled-pr", "pull_request", "component", "comp-a", time.Hour)
+			canceledPRSnap.Annotations = map[string]string{PRStatusAnnotation: PRStatusMerged}
+			olderPRSnap := newSnapshot("older-pr", "pull_request", "component", "comp-a", 2*time.Hour)
+
+			removals, _, _ := getSnapshotsForRemovalByPolicy(
+				[]applicationapiv1alpha1.Snapshot{*canceledPRSnap, *olderPRSnap}, policy, 512, 512, 0, currentTime, logger,
+			)
+			Expect(removals).To(HaveLen(1))
+			Expect(removals[0].snapshot.Name).To(Equal("canceled-pr"))
+			Expect(removals[0].reason).To(Equal("the PR snapshot was canceled or its PR was merged"))
+		})
+
+		It("Clamps the rules asking for more snapshots than the cluster-wide limits", func() {
+			maxCount := int32(500)
+			policy.Spec.Rules = []v1beta2.SnapshotRetentionRule{
+				{SnapshotType: v1beta2.SnapshotRetentionTypePR, MaxCount: &maxCount},
+				{SnapshotType: v1beta2.SnapshotRetentionTypePush, MaxCount: &maxCount},
+				{SnapshotType: v1beta2.SnapshotRetentionTypeGroup, MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			}
+			newerPRSnap := newSnapshot("newer-pr", "pull_request", "component", "comp-a", time.Hour)
+			olderPRSnap := newSnapshot("older-pr", "pull_request", "component", "comp-a", 2*time.Hour)
+			pushSnap := newSnapshot("push", "push", "component", "comp-a", time.Hour)
+			groupSnap := newSnapshot("group", "push", "group", "", 2*time.Hour)
+
+			removals, keptPrSnapshots, keptNonPrSnapshots := getSnapshotsForRemovalByPolicy(
+				[]applicationapiv1alpha1.Snapshot{*olderPRSnap, *groupSnap, *newerPRSnap, *pushSnap},
+				policy, 1, 1, 0, currentTime, logger,
+			)
+			Expect(removals).To(HaveLen(2))
+			Expect(removals[0].snapshot.Name).To(Equal("older-pr"))
+			Expect(removals[0].reason).To(Equal("exceeds the 1 PR snapshots to keep, the retention policy can't exceed it"))
+			// the push and group snapshots share the non-PR limit, the group rule without a max count is limited too
+			Expect(removals[1].snapshot.Name).To(Equal("group"))
+			Expect(removals[1].reason).To(Equal("exceeds the 1 non-PR snapshots to keep, the retention policy can't exceed it"))
+			Expect(keptPrSnapshots).To(Equal(1))
+			Expect(keptNonPrSnapshots).To(Equal(1))
+		})
+
+		It("Doesn't let the retention policy raise the cluster-wide minimum snapshots per component", func() {
+			minSnapshots := int32(10)
+			policy.Spec.MinSnapshotsToKeepPerComponent = &minSnapshots
+			policy.Spec.Rules = []v1beta2.SnapshotRetentionRule{
+				{SnapshotType: v1beta2.SnapshotRetentionTypePush, MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			}
+			ns1 := &core.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "ns1",
+					Labels: map[string]string{"toolchain.dev.openshift.com/type": "tenant"},
+				},
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(policy).
+				WithLists(
+					&core.NamespaceList{Items: []core.Namespace{*ns1}},
+					&applicationapiv1alpha1.SnapshotList{
+						Items: []applicationapiv1alpha1.Snapshot{
+							*newSnapshot("old-push", "push", "component", "comp-a", 48*time.Hour),
+							*newSnapshot("older-push", "push", "component", "comp-a", 72*time.Hour),
+						},
+					},
+				).Build()
+
+			result, err := GarbageCollectNamespace(cl, logger, "ns1", Options{
+				PrSnapshotsToKeep:              512,
+				NonPrSnapshotsToKeep:           512,
+				MinSnapshotsToKeepPerComponent: 1,
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.Deleted).To(Equal(map[v1beta2.SnapshotRetentionType]int{v1beta2.SnapshotRetentionTypePush: 1}))
+			Expect(result.Protected).To(Equal(map[string]int{protectedByComponentMinimum: 1}))
+		})
+
+		When("The namespace has a retention policy", func() {
+			var cl client.Client
+
+			BeforeEach(func() {
+				ns1 := &core.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "ns1",
+						Labels: map[string]string{
+							"toolchain.dev.openshift.com/type": "tenant",
+						},
+					},
+				}
+				cl = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(policy).
+					WithLists(
+						&core.NamespaceList{Items: []core.Namespace{*ns1}},
+						&applicationapiv1alpha1.SnapshotList{
+							Items: []applicationapiv1alpha1.Snapshot{
+								*newSnapshot("newer-pr", "pull_request", "component", "comp-a", time.Hour),
+								*newSnapshot("older-pr", "pull_request", "component", "comp-a", 2*time.Hour),
+								*newSnapshot("new-push", "push", "component", "comp-a", time.Hour),
+								*newSnapshot("old-push", "push", "component", "comp-a", 48*time.Hour),
+								*newSnapshot("newer-group", "pull_request", "group", "", time.Hour),
+								*newSnapshot("older-group", "pull_request", "group", "", 2*time.Hour),
+							},
+						},
+					).Build()
+			})
+
+			It("Garbage collects the snapshots with the policy and the cluster-wide limits", func() {
+				err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 2, NonPrSnapshotsToKeep: 100})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				snapsAfter := &applicationapiv1alpha1.SnapshotList{}
+				Expect(cl.List(context.Background(), snapsAfter, &client.ListOptions{Namespace: "ns1"})).To(Succeed())
+				var names []string
+				for _, snap := range snapsAfter.Items {
+					names = append(names, snap.Name)
+				}
+				// the group snapshots have no rule, they count against the cluster-wide PR limit
+				// left by the PR snapshot the policy keeps
+				Expect(names).To(ConsistOf("newer-pr", "new-push", "newer-group"))
+			})
+
+			It("Only reports the snapshots which would be deleted in dry run", func() {
+				err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 2, NonPrSnapshotsToKeep: 100, DryRun: true})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				snapsAfter := &applicationapiv1alpha1.SnapshotList{}
+				Expect(cl.List(context.Background(), snapsAfter, &client.ListOptions{Namespace: "ns1"})).To(Succeed())
+				Expect(snapsAfter.Items).To(HaveLen(6))
+
+				Expect(buf.String()).To(ContainSubstring("Dry run: snapshot would be deleted"))
+				Expect(buf.String()).To(ContainSubstring("exceeds the 1 pr snapshots to keep in the retention policy"))
+				Expect(buf.String()).To(ContainSubstring("older than the 24h0m0s max age of push snapshots in the retention policy"))
+				Expect(buf.String()).To(ContainSubstring("exceeds the 1 PR snapshots to keep"))
+				Expect(buf.String()).To(ContainSubstring("count of snapshots which would be deleted 3"))
+			})
+		})
+	})
+
+	Describe("Test protected snapshots", func() {
+		var (
+			currentTime    time.Time
+			componentGroup *v1beta2.ComponentGroup
+			pipelineRuns   *tektonv1.PipelineRunList
+			snapshots      []applicationapiv1alpha1.Snapshot
+		)
+
+		// newSnapshot returns a snapshot of the given type with the image of comp-a created the given time ago
+		newSnapshot := func(name, eventType, snapshotType, image string, age time.Duration) applicationapiv1alpha1.Snapshot {
+			return applicationapiv1alpha1.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "ns1",
+					Labels: map[string]string{
+						"pac.test.appstudio.openshift.io/event-type": eventType,
+						"test.appstudio.openshift.io/type":           snapshotType,
+						"appstudio.openshift.io/component":           "comp-a",
+					},
+					CreationTimestamp: metav1.NewTime(currentTime.Add(-age)),
+				},
+				Spec: applicationapiv1alpha1.SnapshotSpec{
+					Components: []applicationapiv1alpha1.SnapshotComponent{
+						{Name: "comp-a", ContainerImage: image},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			currentTime = time.Now()
+			componentGroup = &v1beta2.ComponentGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cg",
+					Namespace: "ns1",
+				},
+				Status: v1beta2.ComponentGroupStatus{
+					GlobalCandidateList: []v1beta2.ComponentState{
+						{Name: "comp-a", LastPromotedImage: "quay.io/org/comp-a@sha256:bbb"},
+					},
+				},
+			}
+			pipelineRuns = &tektonv1.PipelineRunList{
+				Items: []tektonv1.PipelineRun{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "in-flight-plr",
+							Namespace: "ns1",
+							Labels: map[string]string{
+								"pipelines.appstudio.openshift.io/type": "test",
+								"appstudio.openshift.io/snapshot":       "tested-pr",
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "finished-plr",
+							Namespace: "ns1",
+							Labels: map[string]string{
+								"pipelines.appstudio.openshift.io/type": "test",
+								"appstudio.openshift.io/snapshot":       "older-pr",
+							},
+						},
+						Status: tektonv1.PipelineRunStatus{
+							Status: duckv1.Status{
+								Conditions: duckv1.Conditions{
+									{Type: apis.ConditionSucceeded, Status: core.ConditionTrue},
+								},
+							},
+						},
+					},
+				},
+			}
+			snapshots = []applicationapiv1alpha1.Snapshot{
+				newSnapshot("tested-pr", "pull_request", "component", "quay.io/org/comp-a@sha256:ccc", 3*time.Hour),
+				newSnapshot("older-pr", "pull_request", "component", "quay.io/org/comp-a@sha256:ccc", 4*time.Hour),
+				newSnapshot("newer-push", "push", "component", "quay.io/org/comp-a@sha256:ccc", time.Hour),
+				newSnapshot("promoted-push", "push", "component", "quay.io/org/comp-a@sha256:bbb", 5*time.Hour),
+				newSnapshot("older-promoted-push", "push", "component", "quay.io/org/comp-a@sha256:bbb", 6*time.Hour),
+				newSnapshot("override", "", "override", "quay.io/org/comp-a@sha256:aaa", 7*time.Hour),
+			}
+		})
+
+		It("Protects the GCL, override and tested snapshots", func() {
+			cl := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(componentGroup).WithLists(pipelineRuns).Build()
+
+			protectedSnapshots, err := getProtectedSnapshots(cl, "ns1", snapshots, nil, logger)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(protectedSnapshots).To(HaveLen(3))
+			// the image promoted by its build pipelinerun is kept in the latest push snapshot with the image
+			Expect(protectedSnapshots["promoted-push"].protection).To(Equal(protectedByGCL))
+			Expect(protectedSnapshots["promoted-push"].reason).To(Equal(
+				"last promoted the component comp-a to the Global Candidate List of the ComponentGroup cg",
+			))
+			Expect(protectedSnapshots["tested-pr"].protection).To(Equal(protectedByPipelineRun))
+			Expect(protectedSnapshots["tested-pr"].reason).To(Equal("the integration pipelinerun in-flight-plr is in progress"))
+			Expect(protectedSnapshots["override"].protection).To(Equal(protectedByOverride))
+
+			remaining, protectedPr, protectedNonPr := filterProtectedSnapshots(snapshots, protectedSnapshots)
+			Expect(remaining).To(HaveLen(3))
+			Expect(protectedPr).To(Equal(1))
+			Expect(protectedNonPr).To(Equal(2))
+		})
+
+		It("Protects the snapshot recorded in the promotion history of the GCL entry", func() {
+			componentGroup.Status.PromotionHistory = []v1beta2.ComponentPromotionHistory{
+				{
+					Name: "comp-a",
+					Promotions: []v1beta2.PromotionRecord{
+						{Image: "quay.io/org/comp-a@sha256:bbb", Snapshot: "older-promoted-push"},
+						{Image: "quay.io/org/comp-a@sha256:aaa", Snapshot: "override"},
+					},
+				},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(componentGroup).Build()
+
+			reasons, err := getGCLSnapshotReasons(cl, "ns1", snapshots, logger)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(reasons).To(HaveKey("older-promoted-push"))
+			Expect(reasons).NotTo(HaveKey("promoted-push"))
+		})
+
+		It("Doesn't protect the override snapshots with a rule in the retention policy", func() {
+			maxCount := int32(0)
+			policy := &v1beta2.SnapshotRetentionPolicy{
+				Spec: v1beta2.SnapshotRetentionPolicySpec{
+					Rules: []v1beta2.SnapshotRetentionRule{
+						{SnapshotType: v1beta2.SnapshotRetentionTypeOverride, MaxCount: &maxCount},
+					},
+				},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			protectedSnapshots, err := getProtectedSnapshots(cl, "ns1", snapshots, policy, logger)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(protectedSnapshots).To(BeEmpty())
+		})
+
+		When("The namespace is garbage collected", func() {
+			var cl client.Client
+
+			BeforeEach(func() {
+				ns1 := &core.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "ns1",
+						Labels: map[string]string{"konflux-ci.dev/type": "tenant"},
+					},
+				}
+				cl = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(componentGroup).
+					WithLists(
+						&core.NamespaceList{Items: []core.Namespace{*ns1}},
+						&applicationapiv1alpha1.SnapshotList{Items: snapshots},
+						pipelineRuns,
+					).Build()
+			})
+
+			It("Keeps the protected snapshots and annotates the kept snapshots with the reason", func() {
+				err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 3})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				snapsAfter := &applicationapiv1alpha1.SnapshotList{}
+				Expect(cl.List(context.Background(), snapsAfter, &client.ListOptions{Namespace: "ns1"})).To(Succeed())
+				reasons := map[string]string{}
+				for _, snap := range snapsAfter.Items {
+					reasons[snap.Name] = snap.GetAnnotations()[RetentionReasonAnnotation]
+				}
+				// the tested PR snapshot counts against the PR limit, and the protected push and override
+				// snapshots against the non-PR limit
+				Expect(reasons).To(Equal(map[string]string{
+					"tested-pr":     "the integration pipelinerun in-flight-plr is in progress",
+					"newer-push":    "",
+					"promoted-push": "last promoted the component comp-a to the Global Candidate List of the ComponentGroup cg",
+					"override":      "override snapshot",
+				}))
+			})
+
+			It("Only patches the snapshots whose retention reason annotation changes", func() {
+				annotated := func(snap applicationapiv1alpha1.Snapshot, reason string) applicationapiv1alpha1.Snapshot {
+					snap.Annotations = map[string]string{RetentionReasonAnnotation: reason}
+					return snap
+				}
+				retentions := []snapshotRetention{
+					{
+						snapshot:   annotated(snapshots[0], "override snapshot"),
+						protection: protectedByOverride,
+						reason:     "override snapshot",
+					},
+					{snapshot: snapshots[1], protection: protectedByPipelineRun, reason: "the integration pipelinerun in-flight-plr is in progress"},
+					{snapshot: snapshots[2], reason: "within the retention limits"},
+					{snapshot: annotated(snapshots[3], "override snapshot"), reason: "within the retention limits"},
+				}
+				patched := []string{}
+				cl = interceptor.NewClient(cl.(client.WithWatch), interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						patched = append(patched, obj.GetName())
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				})
+
+				annotateSnapshotRetentions(cl, retentions, logger)
+				Expect(patched).To(Equal([]string{snapshots[1].Name, snapshots[3].Name}))
+
+				snap := &applicationapiv1alpha1.Snapshot{}
+				Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(&snapshots[1]), snap)).To(Succeed())
+				Expect(snap.GetAnnotations()).To(HaveKeyWithValue(RetentionReasonAnnotation, "the integration pipelinerun in-flight-plr is in progress"))
+				Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(&snapshots[3]), snap)).To(Succeed())
+				Expect(snap.GetAnnotations()).NotTo(HaveKey(RetentionReasonAnnotation))
+			})
+
+			It("Doesn't annotate the snapshots in dry run", func() {
+				err := GarbageCollectSnapshots(cl, logger, Options{PrSnapshotsToKeep: 1, NonPrSnapshotsToKeep: 3, DryRun: true})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				snapsAfter := &applicationapiv1alpha1.SnapshotList{}
+				Expect(cl.List(context.Background(), snapsAfter, &client.ListOptions{Namespace: "ns1"})).To(Succeed())
+				Expect(snapsAfter.Items).To(HaveLen(6))
+				for _, snap := range snapsAfter.Items {
+					Expect(snap.GetAnnotations()).NotTo(HaveKey(RetentionReasonAnnotation))
+				}
+			})
+		})
+	})
 })
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -29,6 +30,7 @@ import (
 	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/gitops"
 	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/pkg/snapshotgc"
 	"github.com/konflux-ci/integration-service/tekton"
 	"github.com/konflux-ci/operator-toolkit/metadata"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -39,6 +41,31 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// parentSnapshotStateAnnotations are the test.appstudio.openshift.io annotations which hold the testing, reporting or
+// processing state of a Snapshot or are set for each Snapshot, they aren't copied to the Snapshots of dependent ComponentGroups
+var parentSnapshotStateAnnotations = []string{
+	gitops.SnapshotTestsStatusAnnotation,
+	gitops.SnapshotTestOverrideRequestsAnnotation,
+	gitops.SnapshotTestOverridesAnnotation,
+	gitops.SnapshotPRLastUpdate,
+	gitops.SnapshotStatusReportAnnotation,
+	gitops.SnapshotPendingReportsAnnotation,
+	gitops.PRGroupCreationAnnotation,
+	gitops.DependentSnapshotsCreationAnnotation,
+	gitops.GitReportingFailureAnnotation,
+	gitops.GroupSnapshotInfoAnnotation,
+	gitops.PRStatusAnnotation,
+	gitops.AddedToGlobalCandidateListAnnotation,
+	gitops.SnapshotNudgesAnnotation,
+	helpers.CreateSnapshotAnnotationName,
+	helpers.SnapshotCreationReportAnnotation,
+	v1beta2.MissingComponentVersionsAnnotation,
+	v1beta2.GCLRollbackAnnotation,
+	v1beta2.GCLRollbackReasonAnnotation,
+	snapshotgc.KeepSnapshotAnnotation,
+	snapshotgc.RetentionReasonAnnotation,
+}
+
 // PrepareSnapshotForPipelineRun prepares the Snapshot for a given PipelineRun,
 // component and application. In case the Snapshot can't be created, an error will be returned.
 func PrepareSnapshotForPipelineRun(ctx context.Context, adapterClient client.Client, pipelineRun *tektonv1.PipelineRun, componentName string, componentGroup *v1beta2.ComponentGroup) (*applicationapiv1alpha1.Snapshot, error) {
@@ -150,6 +177,136 @@ func CreateSnapshotWithCollisionHandling(ctx context.Context, client client.Clie
 	return fmt.Errorf("failed to create snapshot after %d attempts", maxRetries)
 }
 
+// PrepareDependentSnapshot prepares the Snapshot for a ComponentGroup which is a dependent of the ComponentGroup of the parent Snapshot.
+// The Snapshot is composed of the Global Candidate List of the dependent ComponentGroup and the component built for the parent Snapshot,
+// and it's linked to the parent Snapshot and to the origin Snapshot of the chain. In case the Snapshot can't be created, an error will be returned.
+func PrepareDependentSnapshot(ctx context.Context, adapterClient client.Client, dependentComponentGroup *v1beta2.ComponentGroup, parentSnapshot *applicationapiv1alpha1.Snapshot) (*applicationapiv1alpha1.Snapshot, error) {
+	log := log.FromContext(ctx)
+
+	componentName := parentSnapshot.Labels[gitops.SnapshotComponentLabel]
+	newSnapshotComponent := gitops.FindMatchingSnapshotComponent(parentSnapshot, componentName)
+	if newSnapshotComponent.Name == "" {
+		return nil, fmt.Errorf("component %s is not found in the components of snapshot %s", componentName, parentSnapshot.Name)
+	}
+
+	snapshot, err := PrepareSnapshot(ctx, adapterClient, dependentComponentGroup, newSnapshotComponent, log)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := []string{gitops.PipelinesAsCodePrefix, gitops.BuildPipelineRunPrefix, gitops.CustomLabelPrefix, gitops.ReleaseLabelPrefix}
+	gitops.CopySnapshotLabelsAndAnnotations(&dependentComponentGroup.ObjectMeta, snapshot, componentName, &parentSnapshot.ObjectMeta, prefixes, false)
+
+	if finishTime, ok := parentSnapshot.Labels[gitops.BuildPipelineRunFinishTimeLabel]; ok {
+		snapshot.Labels[gitops.BuildPipelineRunFinishTimeLabel] = finishTime
+	}
+	// the test.appstudio.openshift.io annotations are copied except the ones holding the state of the parent Snapshot
+	for key, value := range parentSnapshot.Annotations {
+		if strings.HasPrefix(key, gitops.TestLabelPrefix+"/") && !slices.Contains(parentSnapshotStateAnnotations, key) {
+			if _, ok := snapshot.Annotations[key]; !ok {
+				snapshot.Annotations[key] = value
+			}
+		}
+	}
+
+	originSnapshot := parentSnapshot.Name
+	if origin, ok := parentSnapshot.Annotations[v1beta2.OriginSnapshotAnnotation]; ok && origin != "" {
+		originSnapshot = origin
+	}
+	snapshot.Annotations[v1beta2.ParentSnapshotAnnotation] = parentSnapshot.Name
+	snapshot.Annotations[v1beta2.OriginSnapshotAnnotation] = originSnapshot
+	chain := append(gitops.GetSnapshotComponentGroupChain(parentSnapshot), dependentComponentGroup.Name)
+	snapshot.Annotations[v1beta2.ComponentGroupChainAnnotation] = strings.Join(chain, ",")
+
+	// name the Snapshot after the build of the parent Snapshot so that it's the same across reconciliations
+	timestampMillis := time.Now().UnixMilli()
+	if startTime, err := strconv.ParseInt(parentSnapshot.Annotations[gitops.BuildPipelineRunStartTime], 10, 64); err == nil {
+		timestampMillis = startTime
+	}
+	snapshot.Annotations[gitops.BuildPipelineRunStartTime] = strconv.FormatInt(timestampMillis, 10)
+	snapshot.Name = gitops.GenerateSnapshotNameWithTimestamp(dependentComponentGroup.Name, timestampMillis)
+
+	return snapshot, nil
+}
+
+// CreateDependentSnapshot creates the Snapshot prepared for a dependent ComponentGroup. An existing Snapshot which was created
+// for the same parent Snapshot and ComponentGroup is reused, either found by its labels or by its name, otherwise a colliding
+// name is retried with a random suffix. The returned boolean is true when a new Snapshot was created.
+func CreateDependentSnapshot(ctx context.Context, adapterClient client.Client, snapshot *applicationapiv1alpha1.Snapshot, logger helpers.IntegrationLogger) (bool, error) {
+	existingSnapshot, err := getExistingDependentSnapshot(ctx, adapterClient, snapshot)
+	if err != nil {
+		return false, err
+	}
+	if existingSnapshot != nil {
+		logger.Info("Snapshot for the dependent ComponentGroup already exists", "snapshot.Name", existingSnapshot.Name)
+		existingSnapshot.DeepCopyInto(snapshot)
+		return false, nil
+	}
+
+	originalName := snapshot.Name
+	maxRetries := 5
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err := adapterClient.Create(ctx, snapshot)
+		if err == nil {
+			return true, nil
+		}
+		if !k8sErrors.IsAlreadyExists(err) {
+			return false, err
+		}
+
+		existingSnapshot := &applicationapiv1alpha1.Snapshot{}
+		getErr := adapterClient.Get(ctx, client.ObjectKeyFromObject(snapshot), existingSnapshot)
+		if getErr != nil {
+			return false, getErr
+		}
+		if existingSnapshot.Annotations[v1beta2.ParentSnapshotAnnotation] == snapshot.Annotations[v1beta2.ParentSnapshotAnnotation] {
+			logger.Info("Snapshot for the dependent ComponentGroup already exists", "snapshot.Name", existingSnapshot.Name)
+			return false, nil
+		}
+
+		suffix, suffixErr := generateRandomSuffix()
+		if suffixErr != nil {
+			logger.Error(suffixErr, "Failed to generate random suffix for snapshot name collision")
+			return false, err
+		}
+		timestampMillis, _ := strconv.ParseInt(snapshot.Annotations[gitops.BuildPipelineRunStartTime], 10, 64)
+		snapshot.Name = gitops.GenerateSnapshotNameWithTimestamp(snapshot.Spec.ComponentGroup, timestampMillis, suffix)
+		logger.Info("Snapshot name collision detected, retrying with suffix",
+			"originalName", originalName,
+			"newName", snapshot.Name,
+			"attempt", attempt+1,
+			"maxRetries", maxRetries)
+	}
+
+	return false, fmt.Errorf("failed to create snapshot after %d attempts", maxRetries)
+}
+
+// getExistingDependentSnapshot returns the Snapshot already created for the same parent Snapshot, ComponentGroup and component
+// as the given dependent Snapshot, or nil if there is none. The Snapshots are listed by labels since the name of the existing
+// Snapshot may have got a random suffix.
+func getExistingDependentSnapshot(ctx context.Context, adapterClient client.Client, snapshot *applicationapiv1alpha1.Snapshot) (*applicationapiv1alpha1.Snapshot, error) {
+	snapshots := &applicationapiv1alpha1.SnapshotList{}
+	opts := []client.ListOption{
+		client.InNamespace(snapshot.Namespace),
+		client.MatchingLabels{
+			gitops.ComponentGroupNameLabel: snapshot.Spec.ComponentGroup,
+			gitops.SnapshotComponentLabel:  snapshot.Labels[gitops.SnapshotComponentLabel],
+		},
+	}
+	if err := adapterClient.List(ctx, snapshots, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list the snapshots of the dependent ComponentGroup %s: %w", snapshot.Spec.ComponentGroup, err)
+	}
+
+	for i, existingSnapshot := range snapshots.Items {
+		if existingSnapshot.Spec.ComponentGroup == snapshot.Spec.ComponentGroup &&
+			existingSnapshot.Annotations[v1beta2.ParentSnapshotAnnotation] == snapshot.Annotations[v1beta2.ParentSnapshotAnnotation] {
+			return &snapshots.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
 // PrepareSnapshot prepares the Snapshot for a given componentGroup, components and the updated component (if any).
 // In case the Snapshot can't be created, an error will be returned.
 func PrepareSnapshot(ctx context.Context, adapterClient client.Client, componentGroup *v1beta2.ComponentGroup, newSnapshotComponent applicationapiv1alpha1.SnapshotComponent, log logr.Logger) (*applicationapiv1alpha1.Snapshot, error) {
@@ -39,6 +39,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	v1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -623,6 +624,161 @@ var _ = Describe("Snapshot creation functions", Ordered, func() {
 		})
 	})
 
+	Context("Testing PrepareDependentSnapshot()", func() {
+		var (
+			parentSnapshot *applicationapiv1alpha1.Snapshot
+			dependentGroup *v1beta2.ComponentGroup
+		)
+		const dependentComponentName = "dependent-component-sample"
+
+		BeforeEach(func() {
+			var err error
+			parentSnapshot, err = PrepareSnapshotForPipelineRun(ctx, k8sClient, buildPipelineRun, componentName, hasCompGroup)
+			Expect(err).ToNot(HaveOccurred())
+			parentSnapshot.Annotations[gitops.SnapshotTestsStatusAnnotation] = "[]"
+
+			dependentGroup = &v1beta2.ComponentGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependent-group-sample",
+					Namespace: "default",
+				},
+				Spec: v1beta2.ComponentGroupSpec{
+					Components: []v1beta2.ComponentReference{
+						{
+							Name: dependentComponentName,
+							ComponentVersion: v1beta2.ComponentVersionReference{
+								Name:     "v1",
+								Revision: "main",
+							},
+						},
+					},
+				},
+				Status: v1beta2.ComponentGroupStatus{
+					GlobalCandidateList: []v1beta2.ComponentState{
+						{
+							Name:               dependentComponentName,
+							Version:            "v1",
+							URL:                SampleRepoLink,
+							LastPromotedImage:  fmt.Sprintf("%s@%s", SampleImageWithoutDigest, SampleDigest),
+							LastPromotedCommit: SampleCommit,
+						},
+					},
+				},
+			}
+		})
+
+		It("ensures the snapshot contains the dependent GCL and the built component", func() {
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Spec.ComponentGroup).To(Equal(dependentGroup.Name))
+			Expect(snapshot.Spec.Components).To(HaveLen(2))
+			Expect(snapshot.Spec.Components[0].Name).To(Equal(dependentComponentName))
+			Expect(snapshot.Spec.Components[1]).To(Equal(gitops.FindMatchingSnapshotComponent(parentSnapshot, componentName)))
+			Expect(snapshot.Name).To(HavePrefix(dependentGroup.Name + "-"))
+		})
+
+		It("ensures the snapshot is linked to its parent and origin and copies the build metadata", func() {
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Annotations[v1beta2.ParentSnapshotAnnotation]).To(Equal(parentSnapshot.Name))
+			Expect(snapshot.Annotations[v1beta2.OriginSnapshotAnnotation]).To(Equal(parentSnapshot.Name))
+			Expect(snapshot.Annotations[v1beta2.ComponentGroupChainAnnotation]).To(Equal("component-group-sample,dependent-group-sample"))
+			Expect(snapshot.Labels[gitops.SnapshotTypeLabel]).To(Equal(gitops.SnapshotComponentType))
+			Expect(snapshot.Labels[gitops.SnapshotComponentLabel]).To(Equal(componentName))
+			Expect(snapshot.Labels[gitops.ComponentGroupNameLabel]).To(Equal(dependentGroup.Name))
+			Expect(snapshot.Labels["pac.test.appstudio.openshift.io/event-type"]).To(Equal("pull_request"))
+			Expect(snapshot.Labels[customLabel]).To(Equal("custom-label"))
+			Expect(snapshot.Annotations[gitops.BuildPipelineRunStartTime]).To(Equal(parentSnapshot.Annotations[gitops.BuildPipelineRunStartTime]))
+			Expect(snapshot.Annotations[gitops.IntegrationWorkflowAnnotation]).To(Equal(gitops.IntegrationWorkflowPullRequestValue))
+			Expect(snapshot.Labels).NotTo(HaveKey(gitops.BuildPipelineRunNameLabel))
+			Expect(snapshot.Annotations).NotTo(HaveKey(gitops.SnapshotTestsStatusAnnotation))
+		})
+
+		It("ensures the test annotations of the parent snapshot are copied except the ones holding its state", func() {
+			parentSnapshot.Annotations[gitops.SnapshotReportersAnnotation] = "kubernetes-events"
+			parentSnapshot.Annotations[gitops.SnapshotGitSourceRepoURLAnnotation] = SampleRepoLink
+			parentSnapshot.Annotations[gitops.SnapshotStatusReportAnnotation] = "{}"
+			parentSnapshot.Annotations[gitops.SnapshotPendingReportsAnnotation] = "{}"
+			parentSnapshot.Annotations[gitops.DependentSnapshotsCreationAnnotation] = "snapshot is created"
+
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Annotations[gitops.SnapshotReportersAnnotation]).To(Equal("kubernetes-events"))
+			Expect(snapshot.Annotations[gitops.SnapshotGitSourceRepoURLAnnotation]).To(Equal(SampleRepoLink))
+			Expect(snapshot.Annotations).NotTo(HaveKey(gitops.SnapshotTestsStatusAnnotation))
+			Expect(snapshot.Annotations).NotTo(HaveKey(gitops.SnapshotStatusReportAnnotation))
+			Expect(snapshot.Annotations).NotTo(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+			Expect(snapshot.Annotations).NotTo(HaveKey(gitops.DependentSnapshotsCreationAnnotation))
+		})
+
+		It("ensures the origin and the chain of a dependent parent snapshot are kept", func() {
+			parentSnapshot.Annotations[v1beta2.OriginSnapshotAnnotation] = "origin-snapshot"
+			parentSnapshot.Annotations[v1beta2.ComponentGroupChainAnnotation] = "origin-group,component-group-sample"
+
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Annotations[v1beta2.ParentSnapshotAnnotation]).To(Equal(parentSnapshot.Name))
+			Expect(snapshot.Annotations[v1beta2.OriginSnapshotAnnotation]).To(Equal("origin-snapshot"))
+			Expect(snapshot.Annotations[v1beta2.ComponentGroupChainAnnotation]).To(Equal("origin-group,component-group-sample,dependent-group-sample"))
+		})
+
+		It("ensures an error is returned when the built component is missing from the parent snapshot", func() {
+			parentSnapshot.Spec.Components = []applicationapiv1alpha1.SnapshotComponent{}
+
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).To(HaveOccurred())
+			Expect(snapshot).To(BeNil())
+		})
+
+		It("ensures an existing snapshot of the same parent is reused and a colliding one gets a suffix", func() {
+			buf := bytes.Buffer{}
+			integrationLogger := helpers.IntegrationLogger{Logger: buflogr.NewWithBuffer(&buf)}
+			dependentStatus := dependentGroup.Status
+			Expect(k8sClient.Create(ctx, dependentGroup)).Should(Succeed())
+			dependentGroup.Status = dependentStatus
+
+			snapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			created, err := CreateDependentSnapshot(ctx, k8sClient, snapshot, integrationLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			sameParentSnapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, parentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			created, err = CreateDependentSnapshot(ctx, k8sClient, sameParentSnapshot, integrationLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(sameParentSnapshot.Name).To(Equal(snapshot.Name))
+
+			otherParentSnapshot := parentSnapshot.DeepCopy()
+			otherParentSnapshot.Name = "other-parent-snapshot"
+			collidingSnapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, otherParentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			created, err = CreateDependentSnapshot(ctx, k8sClient, collidingSnapshot, integrationLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(collidingSnapshot.Name).NotTo(Equal(snapshot.Name))
+
+			// a retry finds the snapshot created with a suffix instead of creating another one
+			Eventually(func() int {
+				snapshots := &applicationapiv1alpha1.SnapshotList{}
+				Expect(k8sClient.List(ctx, snapshots, client.InNamespace(dependentGroup.Namespace),
+					client.MatchingLabels{gitops.ComponentGroupNameLabel: dependentGroup.Name})).To(Succeed())
+				return len(snapshots.Items)
+			}).Should(Equal(2))
+			retriedSnapshot, err := PrepareDependentSnapshot(ctx, k8sClient, dependentGroup, otherParentSnapshot)
+			Expect(err).ToNot(HaveOccurred())
+			created, err = CreateDependentSnapshot(ctx, k8sClient, retriedSnapshot, integrationLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(retriedSnapshot.Name).To(Equal(collidingSnapshot.Name))
+
+			Expect(k8sClient.Delete(ctx, snapshot)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, collidingSnapshot)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, dependentGroup)).To(Succeed())
+		})
+	})
+
 	It("ensures NewSnapshot truncates application name if longer than 43 characters", func() {
 		longGroupName := "this-is-a-very-long-application-name-that-exceeds-43-chars"
 		longGroup := &v1beta2.ComponentGroup{
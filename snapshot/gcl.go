@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/integration-service/api/v1beta2"
@@ -28,26 +29,31 @@ import (
 	"github.com/konflux-ci/integration-service/loader"
 	"github.com/konflux-ci/integration-service/tekton"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// updateGCLForBuildPLR updates global candidate list for component snapshots
-func UpdateGCLForBuildPLR(ctx context.Context, client client.Client, objectLoader loader.ObjectLoader, componentGroups *[]v1beta2.ComponentGroup, pipelineRun *tektonv1.PipelineRun, componentName string) error {
+// PromotionHistoryLimit is the number of promotions kept in the promotion history of each Component version
+const PromotionHistoryLimit = 10
+
+// updateGCLForBuildPLR updates global candidate list for component snapshots, it returns true when the promotion was
+// queued by at least one ComponentGroup because its Global Candidate List is frozen
+func UpdateGCLForBuildPLR(ctx context.Context, client client.Client, objectLoader loader.ObjectLoader, componentGroups *[]v1beta2.ComponentGroup, pipelineRun *tektonv1.PipelineRun, componentName string) (bool, error) {
 	containerImage, err := tekton.GetImagePullSpecFromPipelineRun(pipelineRun)
 	if err != nil {
-		return nil
+		return false, nil
 	}
 
 	componentSource, err := tekton.GetComponentSourceFromPipelineRun(pipelineRun)
 	if err != nil {
-		return nil
+		return false, nil
 	}
 
 	componentVersion, err := tekton.GetComponentVersionFromPipelineRun(pipelineRun)
 	if err != nil {
-		return nil
+		return false, nil
 	}
 
 	buildTime := pipelineRun.Status.StartTime
@@ -61,63 +67,84 @@ func UpdateGCLForBuildPLR(ctx context.Context, client client.Client, objectLoade
 		LastPromotedBuildTime: buildTime,
 	}
 
+	anyQueued := false
 	for _, componentGroup := range *componentGroups {
 		retryError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 			cg, err := objectLoader.GetComponentGroup(ctx, client, componentGroup.Name, componentGroup.Namespace)
 			if err != nil {
 				return err
 			}
-			err = UpdateGCLEntry(ctx, client, cg, entry)
+			queued, err := UpdateGCLEntry(ctx, client, cg, entry, pipelineRun.Name)
+			anyQueued = anyQueued || queued
 			return err
 		})
 		err = errors.Join(err, retryError)
 	}
-	return err
+	return anyQueued, err
 }
 
-// Updates a single GCL entry for a given componentGroup
-func UpdateGCLEntry(ctx context.Context, adapterClient client.Client, componentGroup *v1beta2.ComponentGroup, newEntry v1beta2.ComponentState) error {
+// Updates a single GCL entry for a given componentGroup, the promotion by the given build pipelineRun is recorded in the promotion history.
+// The promotion is queued instead while the Component is frozen, it returns true when the promotion was queued.
+func UpdateGCLEntry(ctx context.Context, adapterClient client.Client, componentGroup *v1beta2.ComponentGroup, newEntry v1beta2.ComponentState, buildPipelineRunName string) (bool, error) {
 	log := log.FromContext(ctx)
 	patch := client.MergeFromWithOptions(componentGroup.DeepCopy(), client.MergeFromWithOptimisticLock{})
 
 	// TODO: add mutating webhook for ComponentGroups that adds blank GCL item when component is added to components list and removes existing GCL entry when component is removed from components list
 	// Find matching GCL entry for ComponentVersion
-	replaced := false
-	for i, entry := range componentGroup.Status.GlobalCandidateList {
-		if entry.Name == newEntry.Name && entry.Version == newEntry.Version {
-			if entry == newEntry {
-				// Adapter may successfully update GCL on one ComponentGroup but have to requeue reconciliation because another failed.
-				// This check prevents us from promoting the same image over and over
-				log.Info("Refusing to update GCL with identical ComponentState", "name", entry.Name, "version", entry.Version, "url", entry.URL, "lastPromotedCommit", entry.LastPromotedCommit, "lastPromotedImage", entry.LastPromotedImage, "lastPromotedBuildTime", entry.LastPromotedBuildTime)
-				return nil
-			}
-			if newEntry.LastPromotedBuildTime.Before(entry.LastPromotedBuildTime) {
-				log.Info("Refusing to update the Global Candidate List for ComponentGroup and ComponentVersion. Existing GCL entry was built after new entry", "ComponentGroup", componentGroup.Name, "Component.Name", entry.Name, "Component.Version", entry.Version, "oldEntry.LastPromotedBuildTime", entry.LastPromotedBuildTime, "newEntry.LastPromotedBuildTime", newEntry.LastPromotedBuildTime)
-				return nil
-			}
-			componentGroup.Status.GlobalCandidateList = slices.Replace(componentGroup.Status.GlobalCandidateList, i, i+1, newEntry)
-			replaced = true
-			break
-		}
+	entryIndex := slices.IndexFunc(componentGroup.Status.GlobalCandidateList, func(entry v1beta2.ComponentState) bool {
+		return entry.Name == newEntry.Name && entry.Version == newEntry.Version
+	})
+	if entryIndex < 0 {
+		return false, fmt.Errorf("could not find ComponentVersion '%s',%s' in ComponentGroup '%s'", newEntry.Name, newEntry.Version, componentGroup.Name)
+	}
+	entry := componentGroup.Status.GlobalCandidateList[entryIndex]
+	if entry == newEntry {
+		// Adapter may successfully update GCL on one ComponentGroup but have to requeue reconciliation because another failed.
+		// This check prevents us from promoting the same image over and over
+		log.Info("Refusing to update GCL with identical ComponentState", "name", entry.Name, "version", entry.Version, "url", entry.URL, "lastPromotedCommit", entry.LastPromotedCommit, "lastPromotedImage", entry.LastPromotedImage, "lastPromotedBuildTime", entry.LastPromotedBuildTime)
+		return false, nil
+	}
+	if newEntry.LastPromotedBuildTime.Before(entry.LastPromotedBuildTime) {
+		log.Info("Refusing to update the Global Candidate List for ComponentGroup and ComponentVersion. Existing GCL entry was built after new entry", "ComponentGroup", componentGroup.Name, "Component.Name", entry.Name, "Component.Version", entry.Version, "oldEntry.LastPromotedBuildTime", entry.LastPromotedBuildTime, "newEntry.LastPromotedBuildTime", newEntry.LastPromotedBuildTime)
+		return false, nil
+	}
+
+	promotion := v1beta2.QueuedPromotion{
+		Entry:            newEntry,
+		BuildPipelineRun: buildPipelineRunName,
 	}
-	if !replaced {
-		return fmt.Errorf("could not find ComponentVersion '%s',%s' in ComponentGroup '%s'", newEntry.Name, newEntry.Version, componentGroup.Name)
+	if IsGCLFrozen(componentGroup, newEntry.Name, time.Now()) {
+		if !queuePromotion(componentGroup, promotion) {
+			log.Info("Refusing to queue the promotion for ComponentGroup and ComponentVersion. The promotion is already queued or a later build is queued", "ComponentGroup", componentGroup.Name, "Component.Name", newEntry.Name, "Component.Version", newEntry.Version)
+			return true, nil
+		}
+		err := adapterClient.Status().Patch(ctx, componentGroup, patch)
+		if err != nil {
+			log.Error(err, "Failed to queue the promotion for ComponentVersion", "componentGroup", componentGroup.Name, "component.Name", newEntry.Name, "component.Version", newEntry.Version)
+			return false, err
+		}
+		log.Info("Queued the promotion for the ComponentVersion because its Global Candidate List entry is frozen", "ComponentGroup", componentGroup.Name, "Component.Name", newEntry.Name, "Component.Version", newEntry.Version, "LastPromotedCommit", newEntry.LastPromotedCommit, "LastPromotedImage", newEntry.LastPromotedImage, "LastPromotedBuildTime", newEntry.LastPromotedBuildTime)
+		return true, nil
 	}
+	applyPromotion(componentGroup, entryIndex, promotion)
 
 	// TODO: support optimistic locking
 	err := adapterClient.Status().Patch(ctx, componentGroup, patch)
 	if err != nil {
 		log.Error(err, "Failed to updated GCL entry for ComponentVersion", "componentGroup", componentGroup.Name, "component.Name", newEntry.Name, "component.Version", newEntry.Version)
-		return err
+		return false, err
 	}
 
 	log.Info("Updated Global Candidate List entry for the ComponentVersion", "ComponentGroup", componentGroup.Name, "Component.Name", newEntry.Name, "Component.Version", newEntry.Version, "URL", newEntry.URL, "LastPromotedCommit", newEntry.LastPromotedCommit, "LastPromotedImage", newEntry.LastPromotedImage, "LastPromotedBuildTime", newEntry.LastPromotedBuildTime)
-	return nil
+	return false, nil
 }
 
-// Updates the GCL for a componentGroup with a list of entries
-func UpdateMultipleGCLEntries(ctx context.Context, adapterClient client.Client, componentGroup *v1beta2.ComponentGroup, componentsToUpdate map[string]v1beta2.ComponentState, logger helpers.IntegrationLogger) error {
+// Updates the GCL for a componentGroup with a list of entries, the promotions by the given snapshot are recorded in the promotion history.
+// The promotions of the frozen Components are queued instead, it returns true when at least one promotion was queued.
+func UpdateMultipleGCLEntries(ctx context.Context, adapterClient client.Client, componentGroup *v1beta2.ComponentGroup, componentsToUpdate map[string]v1beta2.ComponentState, snapshotName string, logger helpers.IntegrationLogger) (bool, error) {
 	patch := client.MergeFromWithOptions(componentGroup.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	now := time.Now()
+	queued := false
 	for i, entry := range componentGroup.Status.GlobalCandidateList {
 		entryKey := helpers.GetComponentVersionString(entry.Name, entry.Version)
 		newEntry, ok := componentsToUpdate[entryKey]
@@ -125,21 +152,35 @@ func UpdateMultipleGCLEntries(ctx context.Context, adapterClient client.Client,
 			// if key doesn't exist then we want to retain the original GCL entry
 			continue
 		}
-		newEntry.LastPromotedBuildTime = entry.LastPromotedBuildTime
 
-		componentGroup.Status.GlobalCandidateList = slices.Replace(componentGroup.Status.GlobalCandidateList, i, i+1, newEntry)
+		promotion := v1beta2.QueuedPromotion{
+			Entry:    newEntry,
+			Snapshot: snapshotName,
+		}
+		if IsGCLFrozen(componentGroup, entry.Name, now) {
+			if newEntry.LastPromotedImage == entry.LastPromotedImage && newEntry.LastPromotedCommit == entry.LastPromotedCommit {
+				// the snapshot keeps the current image, the promotions queued before it are outdated
+				dequeuePromotion(componentGroup, entry.Name, entry.Version)
+				continue
+			}
+			queuePromotion(componentGroup, promotion)
+			queued = true
+			continue
+		}
+		applyPromotion(componentGroup, i, promotion)
 	}
 
 	err := adapterClient.Status().Patch(ctx, componentGroup, patch)
 	if err != nil {
 		logger.Error(err, "Failed to updated GCL entry for ComponentVersion with components", "componentGroup", componentGroup.Name, "snapshotComponents", componentsToUpdate)
-		return err
+		return false, err
 	}
-	return nil
+	return queued, nil
 }
 
-// Update GCL for all Components in snapshot
-func UpdateGCLForOverrideSnapshot(ctx context.Context, adapterClient client.Client, objectLoader loader.ObjectLoader, componentGroup *v1beta2.ComponentGroup, snapshot *applicationapiv1alpha1.Snapshot, logger helpers.IntegrationLogger) error {
+// Update GCL for all Components in snapshot, it returns true when the promotion of at least one Component was queued
+// because the Global Candidate List is frozen
+func UpdateGCLForOverrideSnapshot(ctx context.Context, adapterClient client.Client, objectLoader loader.ObjectLoader, componentGroup *v1beta2.ComponentGroup, snapshot *applicationapiv1alpha1.Snapshot, logger helpers.IntegrationLogger) (bool, error) {
 	componentsToAdd := map[string]v1beta2.ComponentState{}
 	for _, component := range snapshot.Spec.Components {
 		component := component //G601
@@ -149,19 +190,202 @@ func UpdateGCLForOverrideSnapshot(ctx context.Context, adapterClient client.Clie
 		componentsToAdd[key] = snapshotComponentToComponentState(component)
 	}
 
+	queued := false
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		cg, err := objectLoader.GetComponentGroup(ctx, adapterClient, componentGroup.Name, componentGroup.Namespace)
 		if err != nil {
 			return err
 		}
-		err = UpdateMultipleGCLEntries(ctx, adapterClient, cg, componentsToAdd, logger)
+		queued, err = UpdateMultipleGCLEntries(ctx, adapterClient, cg, componentsToAdd, snapshot.Name, logger)
 		if err == nil {
-			logger.Info("Updated Global Candidate List with override snapshot", "componentGroup.Name", componentGroup.Name, "componentGroup.Namespace", componentGroup.Namespace, "snapshot.Name", snapshot.Name)
+			logger.Info("Updated Global Candidate List with override snapshot", "componentGroup.Name", componentGroup.Name, "componentGroup.Namespace", componentGroup.Namespace, "snapshot.Name", snapshot.Name, "queued", queued)
 		}
 		return err
 	})
 
-	return err
+	return queued, err
+}
+
+// IsGCLFrozen returns true when the Global Candidate List entries of the given Component are frozen at the given time
+func IsGCLFrozen(componentGroup *v1beta2.ComponentGroup, componentName string, now time.Time) bool {
+	freeze := componentGroup.Spec.Freeze
+	if freeze == nil || (freeze.Until != nil && !now.Before(freeze.Until.Time)) {
+		return false
+	}
+	return len(freeze.Components) == 0 || slices.Contains(freeze.Components, componentName)
+}
+
+// ProcessQueuedPromotions applies, or discards when the freeze requests it, the queued promotions of the Components which
+// aren't frozen anymore at the given time. The promotions of Component versions which left the Global Candidate List and
+// the build promotions older than the current entry are discarded. Only the in-memory componentGroup is updated, it returns
+// the applied and discarded promotions.
+func ProcessQueuedPromotions(componentGroup *v1beta2.ComponentGroup, now time.Time) (applied, discarded []v1beta2.QueuedPromotion) {
+	discard := componentGroup.Spec.Freeze != nil && componentGroup.Spec.Freeze.OnLift == v1beta2.GCLFreezeLiftDiscard
+	var remaining []v1beta2.QueuedPromotion
+	for _, promotion := range componentGroup.Status.QueuedPromotions {
+		if IsGCLFrozen(componentGroup, promotion.Entry.Name, now) {
+			remaining = append(remaining, promotion)
+			continue
+		}
+		entryIndex := slices.IndexFunc(componentGroup.Status.GlobalCandidateList, func(entry v1beta2.ComponentState) bool {
+			return entry.Name == promotion.Entry.Name && entry.Version == promotion.Entry.Version
+		})
+		if discard || entryIndex < 0 ||
+			(promotion.Snapshot == "" && promotion.Entry.LastPromotedBuildTime.Before(componentGroup.Status.GlobalCandidateList[entryIndex].LastPromotedBuildTime)) {
+			discarded = append(discarded, promotion)
+			continue
+		}
+		applyPromotion(componentGroup, entryIndex, promotion)
+		applied = append(applied, promotion)
+	}
+	componentGroup.Status.QueuedPromotions = remaining
+	return applied, discarded
+}
+
+// applyPromotion replaces the GCL entry at the given index with the entry of the promotion and records the promotion in the
+// promotion history, the promotion queued for the Component version is outdated and dropped. The promotions by a Snapshot keep
+// the build time of the replaced entry and aren't recorded when they keep its image.
+func applyPromotion(componentGroup *v1beta2.ComponentGroup, entryIndex int, promotion v1beta2.QueuedPromotion) {
+	entry := componentGroup.Status.GlobalCandidateList[entryIndex]
+	newEntry := promotion.Entry
+	if promotion.Snapshot != "" {
+		newEntry.LastPromotedBuildTime = entry.LastPromotedBuildTime
+	}
+
+	// components of the snapshot which keep their image aren't promoted again
+	if promotion.Snapshot == "" || newEntry.LastPromotedImage != entry.LastPromotedImage || newEntry.LastPromotedCommit != entry.LastPromotedCommit {
+		record := newPromotionRecord(newEntry)
+		record.Snapshot = promotion.Snapshot
+		record.BuildPipelineRun = promotion.BuildPipelineRun
+		recordPromotion(componentGroup, entry, record)
+	}
+	componentGroup.Status.GlobalCandidateList = slices.Replace(componentGroup.Status.GlobalCandidateList, entryIndex, entryIndex+1, newEntry)
+	dequeuePromotion(componentGroup, newEntry.Name, newEntry.Version)
+}
+
+// queuePromotion queues the given promotion in place of the promotion already queued for its Component version. A build
+// promotion doesn't replace the queued promotion of a later build, it returns false when the queue wasn't changed.
+func queuePromotion(componentGroup *v1beta2.ComponentGroup, promotion v1beta2.QueuedPromotion) bool {
+	promotion.QueuedTime = metav1.Now()
+	queuedIndex := slices.IndexFunc(componentGroup.Status.QueuedPromotions, func(queued v1beta2.QueuedPromotion) bool {
+		return queued.Entry.Name == promotion.Entry.Name && queued.Entry.Version == promotion.Entry.Version
+	})
+	if queuedIndex < 0 {
+		componentGroup.Status.QueuedPromotions = append(componentGroup.Status.QueuedPromotions, promotion)
+		return true
+	}
+
+	queued := componentGroup.Status.QueuedPromotions[queuedIndex]
+	if queued.Snapshot == promotion.Snapshot && queued.BuildPipelineRun == promotion.BuildPipelineRun &&
+		queued.Entry.LastPromotedImage == promotion.Entry.LastPromotedImage && queued.Entry.LastPromotedCommit == promotion.Entry.LastPromotedCommit {
+		return false
+	}
+	if promotion.Snapshot == "" && queued.Snapshot == "" && promotion.Entry.LastPromotedBuildTime.Before(queued.Entry.LastPromotedBuildTime) {
+		return false
+	}
+	componentGroup.Status.QueuedPromotions[queuedIndex] = promotion
+	return true
+}
+
+// dequeuePromotion drops the promotion queued for the given Component version
+func dequeuePromotion(componentGroup *v1beta2.ComponentGroup, name, version string) {
+	componentGroup.Status.QueuedPromotions = slices.DeleteFunc(componentGroup.Status.QueuedPromotions, func(queued v1beta2.QueuedPromotion) bool {
+		return queued.Entry.Name == name && queued.Entry.Version == version
+	})
+}
+
+// RollbackGCLEntry rolls the GCL entry of the given Component version back to its latest previous promotion which wasn't
+// rolled back itself, and records the rollback with its reason in the promotion history. Only the in-memory componentGroup
+// is updated, it returns the restored promotion.
+func RollbackGCLEntry(componentGroup *v1beta2.ComponentGroup, name, version, reason string) (*v1beta2.PromotionRecord, error) {
+	entryIndex := slices.IndexFunc(componentGroup.Status.GlobalCandidateList, func(entry v1beta2.ComponentState) bool {
+		return entry.Name == name && entry.Version == version
+	})
+	if entryIndex < 0 {
+		return nil, fmt.Errorf("could not find ComponentVersion '%s' in the Global Candidate List of ComponentGroup '%s'",
+			helpers.GetComponentVersionString(name, version), componentGroup.Name)
+	}
+	entry := componentGroup.Status.GlobalCandidateList[entryIndex]
+
+	history := getPromotionHistory(componentGroup, name, version)
+	if history == nil {
+		return nil, fmt.Errorf("ComponentVersion '%s' of ComponentGroup '%s' has no promotion history to roll back to",
+			helpers.GetComponentVersionString(name, version), componentGroup.Name)
+	}
+	targetIndex := slices.IndexFunc(history.Promotions, func(record v1beta2.PromotionRecord) bool {
+		return !record.Superseded && record.Image != "" && record.Image != entry.LastPromotedImage
+	})
+	if targetIndex < 0 {
+		return nil, fmt.Errorf("ComponentVersion '%s' of ComponentGroup '%s' has no previous promotion to roll back to",
+			helpers.GetComponentVersionString(name, version), componentGroup.Name)
+	}
+	target := history.Promotions[targetIndex]
+
+	// the promotions of the rolled back image are skipped by the following rollbacks
+	for i := range history.Promotions[:targetIndex] {
+		if history.Promotions[i].Image == entry.LastPromotedImage {
+			history.Promotions[i].Superseded = true
+		}
+	}
+
+	entry.LastPromotedImage = target.Image
+	entry.LastPromotedCommit = target.Commit
+	entry.LastPromotedBuildTime = target.BuildTime
+	componentGroup.Status.GlobalCandidateList[entryIndex] = entry
+
+	restored := target
+	restored.PromotionTime = metav1.Now()
+	restored.RolledBack = true
+	restored.Reason = reason
+	restored.Superseded = false
+	recordPromotion(componentGroup, entry, restored)
+	return &restored, nil
+}
+
+// newPromotionRecord returns the promotion record of the given GCL entry
+func newPromotionRecord(entry v1beta2.ComponentState) v1beta2.PromotionRecord {
+	return v1beta2.PromotionRecord{
+		Image:         entry.LastPromotedImage,
+		Commit:        entry.LastPromotedCommit,
+		BuildTime:     entry.LastPromotedBuildTime,
+		PromotionTime: metav1.Now(),
+	}
+}
+
+// recordPromotion adds the promotion of the Component version of the given GCL entry to the head of its promotion history,
+// the oldest promotions are dropped past PromotionHistoryLimit. The image promoted before the history was kept is recorded
+// first, so that the promotion can be rolled back to it.
+func recordPromotion(componentGroup *v1beta2.ComponentGroup, previousEntry v1beta2.ComponentState, record v1beta2.PromotionRecord) {
+	history := getPromotionHistory(componentGroup, previousEntry.Name, previousEntry.Version)
+	if history == nil {
+		componentGroup.Status.PromotionHistory = append(componentGroup.Status.PromotionHistory, v1beta2.ComponentPromotionHistory{
+			Name:    previousEntry.Name,
+			Version: previousEntry.Version,
+		})
+		history = &componentGroup.Status.PromotionHistory[len(componentGroup.Status.PromotionHistory)-1]
+		if previousEntry.LastPromotedImage != "" {
+			previousRecord := newPromotionRecord(previousEntry)
+			if previousEntry.LastPromotedBuildTime != nil {
+				previousRecord.PromotionTime = *previousEntry.LastPromotedBuildTime
+			}
+			history.Promotions = []v1beta2.PromotionRecord{previousRecord}
+		}
+	}
+
+	history.Promotions = append([]v1beta2.PromotionRecord{record}, history.Promotions...)
+	if len(history.Promotions) > PromotionHistoryLimit {
+		history.Promotions = history.Promotions[:PromotionHistoryLimit]
+	}
+}
+
+// getPromotionHistory returns the promotion history of the given Component version, or nil when it has none
+func getPromotionHistory(componentGroup *v1beta2.ComponentGroup, name, version string) *v1beta2.ComponentPromotionHistory {
+	for i := range componentGroup.Status.PromotionHistory {
+		if componentGroup.Status.PromotionHistory[i].Name == name && componentGroup.Status.PromotionHistory[i].Version == version {
+			return &componentGroup.Status.PromotionHistory[i]
+		}
+	}
+	return nil
 }
 
 func FetchSnapshotComponentFromGCL(componentName string, snapshotComponentsFromGCL []applicationapiv1alpha1.SnapshotComponent, invalidComponents []v1beta2.ComponentState) (*applicationapiv1alpha1.SnapshotComponent, error) {
@@ -227,8 +227,9 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 					LastPromotedImage:     newImageWithDigest,
 					LastPromotedBuildTime: &metav1.Time{Time: time.Now()},
 				}
-				err := UpdateGCLEntry(ctx, k8sClient, updatedComponentGroup, newEntry)
+				queued, err := UpdateGCLEntry(ctx, k8sClient, updatedComponentGroup, newEntry, buildPipelineRun.Name)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(queued).To(BeFalse())
 
 				Eventually(func() error {
 					err := k8sClient.Get(ctx, types.NamespacedName{
@@ -258,6 +259,19 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 				}
 				Expect(found).To(BeTrue(), "[ERROR] The ComponentVersion was not found in the GCL")
 			})
+
+			It("records the promotion in the promotion history", func() {
+				Expect(updatedComponentGroup.Status.PromotionHistory).To(HaveLen(1))
+				history := updatedComponentGroup.Status.PromotionHistory[0]
+				Expect(history.Name).To(Equal(componentName))
+				Expect(history.Version).To(Equal(componentVersion))
+				// the image promoted before the history was kept is recorded too, so that it can be rolled back to
+				Expect(history.Promotions).To(HaveLen(2))
+				Expect(history.Promotions[0].Image).To(Equal(newImageWithDigest))
+				Expect(history.Promotions[0].Commit).To(Equal(newCommit))
+				Expect(history.Promotions[0].BuildPipelineRun).To(Equal(buildPipelineRun.Name))
+				Expect(history.Promotions[1].Image).To(Equal(oldEntry.LastPromotedImage))
+			})
 		})
 
 		When("an entry with no matching componentVersion is added", func() {
@@ -278,7 +292,7 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 					GlobalCandidateList: []v1beta2.ComponentState{},
 				}
 
-				err := UpdateGCLEntry(ctx, k8sClient, compGroupNoGCL, newEntry)
+				_, err := UpdateGCLEntry(ctx, k8sClient, compGroupNoGCL, newEntry, buildPipelineRun.Name)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("could not find ComponentVersion"))
 			})
@@ -289,8 +303,9 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 				newEntryOldBuild := newEntry.DeepCopy()
 				newEntryOldBuild.LastPromotedBuildTime = &metav1.Time{Time: time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)}
 
-				err := UpdateGCLEntry(ctx, k8sClient, hasCompGroup, *newEntryOldBuild)
+				queued, err := UpdateGCLEntry(ctx, k8sClient, hasCompGroup, *newEntryOldBuild, buildPipelineRun.Name)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(queued).To(BeFalse())
 				for _, component := range hasCompGroup.Status.GlobalCandidateList {
 					if component.Name == newEntry.Name && component.Version == newEntry.Version {
 						Expect(component.URL).To(Equal(oldEntry.URL))
@@ -422,8 +437,9 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 						Resource:   hasCompGroup,
 					},
 				})
-				err := UpdateGCLForOverrideSnapshot(mockContext, k8sClient, loader.NewLoader(), hasCompGroup, overrideSnapshot, log)
+				queued, err := UpdateGCLForOverrideSnapshot(mockContext, k8sClient, loader.NewLoader(), hasCompGroup, overrideSnapshot, log)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(queued).To(BeFalse())
 				time.Sleep(3 * time.Second)
 				Expect(k8sClient.Get(ctx, types.NamespacedName{
 					Name:      hasCompGroup.Name,
@@ -450,6 +466,19 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 				Expect(updatedComponent.LastPromotedImage).To(Equal(overrideSnapshotImage))
 			})
 
+			It("Should record the promotions by the snapshot in the promotion history", func() {
+				var promotions []v1beta2.PromotionRecord
+				for _, history := range hasCompGroup.Status.PromotionHistory {
+					Expect(history.Name).NotTo(Equal("another-component-sample"))
+					if history.Name == componentName && history.Version == componentVersion {
+						promotions = history.Promotions
+					}
+				}
+				Expect(promotions).NotTo(BeEmpty())
+				Expect(promotions[0].Image).To(Equal(overrideSnapshotImage))
+				Expect(promotions[0].Snapshot).To(Equal(overrideSnapshot.Name))
+			})
+
 			It("Should not have updated the GCL for a component not in the snapshot", func() {
 				var secondComponent *v1beta2.ComponentState
 				for i := range hasCompGroup.Status.GlobalCandidateList {
@@ -468,4 +497,205 @@ var _ = Describe("GCL manipulation functions", Ordered, func() {
 		})
 
 	})
+
+	Context("testing GCL rollback", func() {
+		var componentGroup *v1beta2.ComponentGroup
+
+		BeforeEach(func() {
+			componentGroup = &v1beta2.ComponentGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "component-group-rollback",
+					Namespace: "default",
+				},
+				Status: v1beta2.ComponentGroupStatus{
+					GlobalCandidateList: []v1beta2.ComponentState{
+						{Name: componentName, Version: componentVersion, URL: componentURL},
+					},
+				},
+			}
+			// promote three images one after another
+			for i, image := range []string{"quay.io/org/image@sha256:aaa", "quay.io/org/image@sha256:bbb", "quay.io/org/image@sha256:ccc"} {
+				entry := componentGroup.Status.GlobalCandidateList[0]
+				newEntry := entry
+				newEntry.LastPromotedImage = image
+				newEntry.LastPromotedCommit = fmt.Sprintf("commit-%d", i)
+				newEntry.LastPromotedBuildTime = &metav1.Time{Time: time.Date(2026, 1, 1, i, 0, 0, 0, time.UTC)}
+				record := newPromotionRecord(newEntry)
+				record.Snapshot = fmt.Sprintf("snapshot-%d", i)
+				recordPromotion(componentGroup, entry, record)
+				componentGroup.Status.GlobalCandidateList[0] = newEntry
+			}
+		})
+
+		It("keeps a bounded promotion history", func() {
+			Expect(componentGroup.Status.PromotionHistory[0].Promotions).To(HaveLen(3))
+			entry := componentGroup.Status.GlobalCandidateList[0]
+			for i := 0; i < PromotionHistoryLimit+5; i++ {
+				recordPromotion(componentGroup, entry, newPromotionRecord(entry))
+			}
+			Expect(componentGroup.Status.PromotionHistory[0].Promotions).To(HaveLen(PromotionHistoryLimit))
+		})
+
+		It("rolls back to the previous promotion and records why", func() {
+			restored, err := RollbackGCLEntry(componentGroup, componentName, componentVersion, "broken image")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.Image).To(Equal("quay.io/org/image@sha256:bbb"))
+			Expect(restored.Snapshot).To(Equal("snapshot-1"))
+
+			entry := componentGroup.Status.GlobalCandidateList[0]
+			Expect(entry.LastPromotedImage).To(Equal("quay.io/org/image@sha256:bbb"))
+			Expect(entry.LastPromotedCommit).To(Equal("commit-1"))
+			Expect(entry.LastPromotedBuildTime.Time).To(Equal(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)))
+			Expect(entry.URL).To(Equal(componentURL))
+
+			promotions := componentGroup.Status.PromotionHistory[0].Promotions
+			Expect(promotions).To(HaveLen(4))
+			Expect(promotions[0].RolledBack).To(BeTrue())
+			Expect(promotions[0].Reason).To(Equal("broken image"))
+			Expect(promotions[1].Superseded).To(BeTrue())
+
+			// a second rollback skips the rolled back promotion
+			restored, err = RollbackGCLEntry(componentGroup, componentName, componentVersion, "also broken")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.Image).To(Equal("quay.io/org/image@sha256:aaa"))
+			Expect(componentGroup.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal("quay.io/org/image@sha256:aaa"))
+
+			// there is nothing left to roll back to
+			_, err = RollbackGCLEntry(componentGroup, componentName, componentVersion, "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no previous promotion"))
+		})
+
+		It("fails for an unknown ComponentVersion", func() {
+			_, err := RollbackGCLEntry(componentGroup, componentName, "v2", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("could not find ComponentVersion"))
+		})
+	})
+
+	Context("testing GCL freeze", func() {
+		var (
+			componentGroup *v1beta2.ComponentGroup
+			now            time.Time
+		)
+
+		// newPromotion returns the promotion of the given image of the Component version built at the given hour
+		newPromotion := func(image string, hour int) v1beta2.QueuedPromotion {
+			return v1beta2.QueuedPromotion{
+				Entry: v1beta2.ComponentState{
+					Name:                  componentName,
+					Version:               componentVersion,
+					URL:                   componentURL,
+					LastPromotedImage:     image,
+					LastPromotedCommit:    newCommit,
+					LastPromotedBuildTime: &metav1.Time{Time: time.Date(2026, 1, 1, hour, 0, 0, 0, time.UTC)},
+				},
+				BuildPipelineRun: fmt.Sprintf("build-%d", hour),
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+			componentGroup = &v1beta2.ComponentGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "component-group-freeze",
+					Namespace: "default",
+				},
+				Spec: v1beta2.ComponentGroupSpec{
+					Freeze: &v1beta2.GCLFreeze{
+						Until:  &metav1.Time{Time: now.Add(time.Hour)},
+						Reason: "release stabilization",
+					},
+				},
+				Status: v1beta2.ComponentGroupStatus{
+					GlobalCandidateList: []v1beta2.ComponentState{
+						{
+							Name:                  componentName,
+							Version:               componentVersion,
+							URL:                   componentURL,
+							LastPromotedImage:     "quay.io/org/image@sha256:aaa",
+							LastPromotedBuildTime: &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+						},
+						{Name: "another-component-sample", Version: componentVersion},
+					},
+				},
+			}
+		})
+
+		It("freezes the whole ComponentGroup or the listed Components until the freeze ends", func() {
+			Expect(IsGCLFrozen(componentGroup, componentName, now)).To(BeTrue())
+			Expect(IsGCLFrozen(componentGroup, "another-component-sample", now)).To(BeTrue())
+			Expect(IsGCLFrozen(componentGroup, componentName, now.Add(time.Hour))).To(BeFalse())
+
+			componentGroup.Spec.Freeze.Components = []string{componentName}
+			Expect(IsGCLFrozen(componentGroup, componentName, now)).To(BeTrue())
+			Expect(IsGCLFrozen(componentGroup, "another-component-sample", now)).To(BeFalse())
+
+			componentGroup.Spec.Freeze = nil
+			Expect(IsGCLFrozen(componentGroup, componentName, now)).To(BeFalse())
+		})
+
+		It("queues only the latest promotion of each Component version", func() {
+			Expect(queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))).To(BeTrue())
+			// the same promotion isn't queued twice
+			Expect(queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))).To(BeFalse())
+			// an earlier build doesn't replace a later one
+			Expect(queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:bbb", 2))).To(BeFalse())
+			Expect(componentGroup.Status.QueuedPromotions).To(HaveLen(1))
+			Expect(componentGroup.Status.QueuedPromotions[0].Entry.LastPromotedImage).To(Equal("quay.io/org/image@sha256:ccc"))
+
+			// a snapshot replaces the queued build
+			snapshotPromotion := newPromotion("quay.io/org/image@sha256:bbb", 2)
+			snapshotPromotion.BuildPipelineRun = ""
+			snapshotPromotion.Snapshot = "snapshot-sample"
+			Expect(queuePromotion(componentGroup, snapshotPromotion)).To(BeTrue())
+			Expect(componentGroup.Status.QueuedPromotions).To(HaveLen(1))
+			Expect(componentGroup.Status.QueuedPromotions[0].Snapshot).To(Equal("snapshot-sample"))
+		})
+
+		It("keeps the queued promotions while the freeze is active and applies them when it lifts", func() {
+			queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))
+
+			applied, discarded := ProcessQueuedPromotions(componentGroup, now)
+			Expect(applied).To(BeEmpty())
+			Expect(discarded).To(BeEmpty())
+			Expect(componentGroup.Status.QueuedPromotions).To(HaveLen(1))
+			Expect(componentGroup.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal("quay.io/org/image@sha256:aaa"))
+
+			applied, discarded = ProcessQueuedPromotions(componentGroup, now.Add(2*time.Hour))
+			Expect(applied).To(HaveLen(1))
+			Expect(discarded).To(BeEmpty())
+			Expect(componentGroup.Status.QueuedPromotions).To(BeEmpty())
+			Expect(componentGroup.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal("quay.io/org/image@sha256:ccc"))
+			Expect(componentGroup.Status.PromotionHistory[0].Promotions[0].BuildPipelineRun).To(Equal("build-3"))
+		})
+
+		It("discards the queued promotions when the freeze requests it", func() {
+			componentGroup.Spec.Freeze.OnLift = v1beta2.GCLFreezeLiftDiscard
+			queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))
+
+			applied, discarded := ProcessQueuedPromotions(componentGroup, now.Add(2*time.Hour))
+			Expect(applied).To(BeEmpty())
+			Expect(discarded).To(HaveLen(1))
+			Expect(componentGroup.Status.QueuedPromotions).To(BeEmpty())
+			Expect(componentGroup.Status.GlobalCandidateList[0].LastPromotedImage).To(Equal("quay.io/org/image@sha256:aaa"))
+			Expect(componentGroup.Status.PromotionHistory).To(BeEmpty())
+		})
+
+		It("discards the queued builds which are older than the current entry", func() {
+			queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))
+			componentGroup.Status.GlobalCandidateList[0].LastPromotedBuildTime = &metav1.Time{Time: time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)}
+			componentGroup.Spec.Freeze = nil
+
+			applied, discarded := ProcessQueuedPromotions(componentGroup, now)
+			Expect(applied).To(BeEmpty())
+			Expect(discarded).To(HaveLen(1))
+		})
+
+		It("drops the queued promotion once the Component version is promoted", func() {
+			queuePromotion(componentGroup, newPromotion("quay.io/org/image@sha256:ccc", 3))
+			applyPromotion(componentGroup, 0, newPromotion("quay.io/org/image@sha256:ddd", 4))
+			Expect(componentGroup.Status.QueuedPromotions).To(BeEmpty())
+		})
+	})
 })
@@ -62,3 +62,39 @@ func ValidateOverrideSnapshotComponents(ctx context.Context, snapshot *applicati
 
 	return errsForSnapshot
 }
+
+// ValidateCreatedSnapshotComponents checks the Snapshot spec emitted by the snapshot creator Task of a
+// ComponentGroup. Unlike override snapshots, the spec may contain components which aren't members of the
+// ComponentGroup, but it must contain at least one component, no component may be listed twice and every
+// component must have a valid image digest and git source fields defined. All validation failures are
+// collected and returned as a single joined error.
+func ValidateCreatedSnapshotComponents(ctx context.Context, snapshotSpec *applicationapiv1alpha1.SnapshotSpec) error {
+	log := log.FromContext(ctx)
+
+	if len(snapshotSpec.Components) == 0 {
+		return fmt.Errorf("snapshot spec doesn't contain any components")
+	}
+
+	var errsForSnapshot error
+	seenComponents := make(map[string]bool, len(snapshotSpec.Components))
+	for _, snapshotComponent := range snapshotSpec.Components {
+		snapshotComponent := snapshotComponent //G601
+
+		key := helpers.GetComponentVersionString(snapshotComponent.Name, snapshotComponent.Version)
+		if seenComponents[key] {
+			errsForSnapshot = errors.Join(errsForSnapshot, fmt.Errorf("snapshotComponent %s is defined more than once", helpers.GetComponentVersionLogString(snapshotComponent.Name, snapshotComponent.Version)))
+		}
+		seenComponents[key] = true
+
+		if err := gitops.ValidateImageDigest(snapshotComponent.ContainerImage); err != nil {
+			log.Error(err, "containerImage in snapshotComponent has invalid digest", "snapshotComponent.Name", snapshotComponent.Name, "snapshotComponent.ContainerImage", snapshotComponent.ContainerImage)
+			errsForSnapshot = errors.Join(errsForSnapshot, err)
+		}
+
+		if !gitops.HaveGitSource(snapshotComponent) {
+			errsForSnapshot = errors.Join(errsForSnapshot, fmt.Errorf("snapshotComponent %s/%s has no git url/revision fields defined", snapshotComponent.Name, snapshotComponent.Version))
+		}
+	}
+
+	return errsForSnapshot
+}
@@ -172,5 +172,26 @@ var _ = Describe("Snapshot validation function", Ordered, func() {
 			Expect(err.Error()).To(ContainSubstring("doesn't exist in componentGroup"))
 		})
 	})
+	When("A snapshot spec is emitted by a snapshot creator", func() {
+		It("Does not return an error for a valid spec, including components outside of the ComponentGroup", func() {
+			snapshotSpec := hasSnapshot.Spec.DeepCopy()
+			Expect(ValidateCreatedSnapshotComponents(ctx, snapshotSpec)).To(Succeed())
+		})
+
+		It("Returns an error for a spec without components", func() {
+			err := ValidateCreatedSnapshotComponents(ctx, &applicationapiv1alpha1.SnapshotSpec{ComponentGroup: hasCompGroup.Name})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("doesn't contain any components"))
+		})
 
+		It("Returns an error for duplicated components and invalid images", func() {
+			snapshotSpec := hasSnapshot.Spec.DeepCopy()
+			snapshotSpec.Components = append(snapshotSpec.Components, snapshotSpec.Components[0])
+			snapshotSpec.Components[1].ContainerImage = builtImageWithoutDigest
+			err := ValidateCreatedSnapshotComponents(ctx, snapshotSpec)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is defined more than once"))
+			Expect(err.Error()).To(ContainSubstring(builtImageWithoutDigest))
+		})
+	})
 })
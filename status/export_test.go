@@ -16,7 +16,12 @@ limitations under the License.
 
 package status
 
-import "k8s.io/apimachinery/pkg/util/wait"
+import (
+	"net/http"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
 
 // SetReporterRetryBackoff overrides the retry backoff for testing.
 func SetReporterRetryBackoff(b wait.Backoff) { reporterRetryBackoff = b }
@@ -26,3 +31,9 @@ func GetReporterRetryBackoff() wait.Backoff { return reporterRetryBackoff }
 
 // SetUpdater sets the StatusUpdater on a GitHubReporter for testing.
 func (r *GitHubReporter) SetUpdater(u StatusUpdater) { r.updater = u }
+
+// AllowInternalWebhooks makes the reporter accept the http webhooks and the webhooks of the cluster network, e.g. test servers.
+func (r *WebhookReporter) AllowInternalWebhooks() {
+	r.httpClient = &http.Client{Timeout: webhookRequestTimeout}
+	r.parseWebhookURL = url.Parse
+}
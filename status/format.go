@@ -19,13 +19,16 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"os"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/konflux-ci/integration-service/gitops"
 	"github.com/konflux-ci/integration-service/helpers"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
 	"knative.dev/pkg/apis"
 )
 
@@ -50,6 +53,21 @@ const summaryTemplate = `
 {{- range $tr := .TaskRuns }}
 | <a href="{{ formatTaskLogURL $tr $pipelineRunName $namespace $logger }}">{{ formatTaskName $tr }}</a> | {{ $tr.GetDuration.String }} | {{ formatNamespace $tr }} | {{ formatStatus $tr }} | {{ formatDetails $tr }} | {{ formatNote $tr }} |
 {{- end }}
+{{- with .TestCaseResults }}
+
+<b>Test cases</b>: {{ .Total }} total, {{ .Passed }} passed, {{ .Failed }} failed, {{ .Errored }} errored, {{ .Skipped }} skipped
+{{ if .TestCases }}
+| Test case | Status | Message |
+| --- | --- | --- |
+{{- range $tc := .TestCases }}
+| {{ formatTestCaseName $tc }} | {{ formatTestCaseStatus $tc }} | {{ formatTableCell $tc.Message }} |
+{{- end }}
+{{- if gt .GetOmittedTestCasesCount 0 }}
+
+{{ .GetOmittedTestCasesCount }} more unsuccessful test case(s) not shown
+{{- end }}
+{{ end }}
+{{- end }}
 
 {{ if .ComponentSnapshotInfos}}
 The group snapshot is generated for pr group {{ .PRGroup }} and the component snasphots as below:
@@ -76,9 +94,62 @@ The group snapshot is generated for pr group {{ .PRGroup }} and the component sn
 {{- end }}
 {{end}}`
 
+// findingsTemplate is a template used to generate a markdown code quality section for the line-level findings of a test.
+const findingsTemplate = `
+<details>
+<summary><b>Code quality</b>: {{ .Failures }} failure(s), {{ .Warnings }} warning(s), {{ .Notices }} notice(s)</summary>
+
+| Severity | Location | Finding |
+| --- | --- | --- |
+{{- range $f := .Findings }}
+| {{ formatFindingLevel $f }} | <code>{{ formatFindingLocation $f }}</code> | {{ formatFinding $f }} |
+{{- end }}
+{{- if gt .Omitted 0 }}
+
+{{ .Omitted }} more finding(s) not shown
+{{- end }}
+</details>
+`
+
+// pullRequestSummaryTemplate is a template used to generate the markdown summary comment of a pull request, holding the
+// statuses of all integration test scenarios of its Snapshots, with the ones of superseded Snapshots collapsed.
+const pullRequestSummaryTemplate = `### {{ .Title }}
+
+| Component | Snapshot | Scenario | Status | Duration | Logs |
+| --- | --- | --- | --- | --- | --- |
+{{- range $e := .Entries }}
+| {{ formatTableCell $e.ComponentNameOrPrGroup }} | {{ $e.SnapshotName }} | {{ formatSummaryScenario $e }} | {{ formatSummaryStatus $e }} | {{ formatSummaryDuration $e }} | {{ formatSummaryLogs $e }} |
+{{- end }}
+{{- if .SupersededEntries }}
+
+<details>
+<summary>{{ len .SupersededEntries }} result(s) of superseded snapshots</summary>
+
+| Component | Snapshot | Scenario | Status | Duration | Logs |
+| --- | --- | --- | --- | --- | --- |
+{{- range $e := .SupersededEntries }}
+| {{ formatTableCell $e.ComponentNameOrPrGroup }} | {{ $e.SnapshotName }} | {{ formatSummaryScenario $e }} | {{ formatSummaryStatus $e }} | {{ formatSummaryDuration $e }} | {{ formatSummaryLogs $e }} |
+{{- end }}
+</details>
+{{- end }}
+`
+
+// maxCommentFindings is the maximum number of findings listed in a comment
+const maxCommentFindings = 50
+
+// FindingsTemplateData holds the data necessary to construct the code quality section of a comment.
+type FindingsTemplateData struct {
+	Findings []helpers.Finding
+	Failures int
+	Warnings int
+	Notices  int
+	Omitted  int
+}
+
 // SummaryTemplateData holds the data necessary to construct a PipelineRun summary.
 type SummaryTemplateData struct {
 	TaskRuns               []*helpers.TaskRun
+	TestCaseResults        *helpers.TestCaseResults
 	PipelineRunName        string
 	Namespace              string
 	PRGroup                string
@@ -86,27 +157,36 @@ type SummaryTemplateData struct {
 	Logger                 logr.Logger
 }
 
+// PullRequestSummaryTemplateData holds the data necessary to construct the summary comment of a pull request.
+type PullRequestSummaryTemplateData struct {
+	Title string
+	*PullRequestSummary
+}
+
 // CommentTemplateData holds the data necessary to construct a PipelineRun comment.
 type CommentTemplateData struct {
 	Title   string
 	Summary string
 }
 
-// FormatTestsSummary builds a markdown summary for a list of integration TaskRuns.
-func FormatTestsSummary(taskRuns []*helpers.TaskRun, pipelineRunName string, namespace string, componentSnapshotInfos []*gitops.ComponentSnapshotInfo, pr_group string, logger logr.Logger) (string, error) {
+// FormatTestsSummary builds a markdown summary for a list of integration TaskRuns and the unsuccessful test cases, if any.
+func FormatTestsSummary(taskRuns []*helpers.TaskRun, testCaseResults *helpers.TestCaseResults, pipelineRunName string, namespace string, componentSnapshotInfos []*gitops.ComponentSnapshotInfo, pr_group string, logger logr.Logger) (string, error) {
 	funcMap := template.FuncMap{
 		"formatTaskName":       FormatTaskName,
 		"formatNamespace":      FormatNamespace,
 		"formatStatus":         FormatStatus,
 		"formatDetails":        FormatDetails,
 		"formatNote":           FormatNote,
+		"formatTestCaseName":   FormatTestCaseName,
+		"formatTestCaseStatus": FormatTestCaseStatus,
+		"formatTableCell":      FormatTableCell,
 		"formatPipelineURL":    FormatPipelineURL,
 		"formatTaskLogURL":     FormatTaskLogURL,
 		"formatPullRequestURL": FormatPullRequestURL,
 		"formatRepoURL":        FormatRepoURL,
 	}
 	buf := bytes.Buffer{}
-	data := SummaryTemplateData{TaskRuns: taskRuns, PipelineRunName: pipelineRunName, Namespace: namespace, PRGroup: pr_group, ComponentSnapshotInfos: componentSnapshotInfos, Logger: logger}
+	data := SummaryTemplateData{TaskRuns: taskRuns, TestCaseResults: testCaseResults, PipelineRunName: pipelineRunName, Namespace: namespace, PRGroup: pr_group, ComponentSnapshotInfos: componentSnapshotInfos, Logger: logger}
 	t := template.Must(template.New("").Funcs(funcMap).Parse(summaryTemplate))
 	if err := t.Execute(&buf, data); err != nil {
 		return "", err
@@ -133,6 +213,65 @@ func FormatShortTestsSummary(pipelineRunName string, namespace string, component
 	return buf.String(), nil
 }
 
+// FormatFindingsSummary builds a markdown code quality section for the line-level findings of a test, the
+// failures are listed first. An empty string is returned when there are no findings.
+func FormatFindingsSummary(findings []helpers.Finding) (string, error) {
+	if len(findings) == 0 {
+		return "", nil
+	}
+	data := FindingsTemplateData{}
+	for _, level := range []string{helpers.FindingLevelFailure, helpers.FindingLevelWarning, helpers.FindingLevelNotice} {
+		for _, finding := range findings {
+			if finding.Level != level {
+				continue
+			}
+			switch level {
+			case helpers.FindingLevelFailure:
+				data.Failures++
+			case helpers.FindingLevelWarning:
+				data.Warnings++
+			default:
+				data.Notices++
+			}
+			if len(data.Findings) < maxCommentFindings {
+				data.Findings = append(data.Findings, finding)
+			} else {
+				data.Omitted++
+			}
+		}
+	}
+
+	funcMap := template.FuncMap{
+		"formatFindingLevel":    FormatFindingLevel,
+		"formatFindingLocation": FormatFindingLocation,
+		"formatFinding":         FormatFinding,
+	}
+	buf := bytes.Buffer{}
+	t := template.Must(template.New("").Funcs(funcMap).Parse(findingsTemplate))
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// FormatPullRequestSummary builds the markdown summary comment of a pull request from the integration test statuses of its Snapshots.
+func FormatPullRequestSummary(summary *PullRequestSummary) (string, error) {
+	funcMap := template.FuncMap{
+		"formatTableCell":       FormatTableCell,
+		"formatSummaryScenario": FormatSummaryScenario,
+		"formatSummaryStatus":   FormatSummaryStatus,
+		"formatSummaryDuration": FormatSummaryDuration,
+		"formatSummaryLogs":     FormatSummaryLogs,
+	}
+	buf := bytes.Buffer{}
+	data := PullRequestSummaryTemplateData{Title: SummaryCommentTitle, PullRequestSummary: summary}
+	t := template.Must(template.New("").Funcs(funcMap).Parse(pullRequestSummaryTemplate))
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // FormatComment build a markdown comment with the details in text for unsuccessful tests.
 func FormatComment(title, text string) (string, error) {
 	buf := bytes.Buffer{}
@@ -144,6 +283,15 @@ func FormatComment(title, text string) (string, error) {
 	return buf.String(), nil
 }
 
+// FormatTestReportComment builds the markdown comment of a test report, the comment rendered with the report templates of the
+// namespace is used if any. The summary is kept in a hidden marker of a custom comment so that it can still be found and updated.
+func FormatTestReportComment(report TestReport) (string, error) {
+	if report.Comment != "" {
+		return fmt.Sprintf("%s\n\n<!-- %s -->", report.Comment, report.Summary), nil
+	}
+	return FormatComment(report.Summary, report.Text)
+}
+
 // FormatCommentForSuccessfulTest build a markdown comment with the details in text for successful tests.
 func FormatCommentForSuccessfulTest(title, text string) (string, error) {
 	buf := bytes.Buffer{}
@@ -267,6 +415,114 @@ func FormatNote(taskRun *helpers.TaskRun) (string, error) {
 	return result.TestOutput.Note, nil
 }
 
+// FormatTestCaseName accepts a test case result and returns a Markdown friendly representation of its qualified name.
+func FormatTestCaseName(testCase helpers.TestCaseResult) string {
+	name := testCase.Name
+	if testCase.ClassName != "" {
+		name = testCase.ClassName + "." + name
+	}
+	if testCase.Suite != "" {
+		name = testCase.Suite + " / " + name
+	}
+	return FormatTableCell(name)
+}
+
+// FormatTestCaseStatus accepts a test case result and returns a Markdown friendly representation of its status.
+func FormatTestCaseStatus(testCase helpers.TestCaseResult) string {
+	switch testCase.Status {
+	case helpers.TestCaseStatusFailed:
+		return ":x: " + testCase.Status
+	case helpers.TestCaseStatusError:
+		return ":heavy_exclamation_mark: " + testCase.Status
+	case helpers.TestCaseStatusSkipped:
+		return ":white_check_mark: " + testCase.Status
+	case helpers.TestCaseStatusPassed:
+		return ":heavy_check_mark: " + testCase.Status
+	default:
+		return ":question: " + testCase.Status
+	}
+}
+
+// FormatSummaryScenario accepts a summary entry and returns the name of its scenario, marking the optional ones.
+func FormatSummaryScenario(entry SummaryEntry) string {
+	if entry.IsOptionalScenario {
+		return FormatTableCell(entry.ScenarioName) + " (optional)"
+	}
+	return FormatTableCell(entry.ScenarioName)
+}
+
+// FormatSummaryStatus accepts a summary entry and returns a Markdown friendly representation of its status.
+func FormatSummaryStatus(entry SummaryEntry) string {
+	switch {
+	case entry.Status == intgteststat.IntegrationTestStatusTestPassed:
+		return ":heavy_check_mark: " + entry.Status.String()
+	case entry.Status == intgteststat.IntegrationTestStatusTestWarning:
+		return ":warning: " + entry.Status.String()
+	case entry.Status == intgteststat.IntegrationTestStatusTestSkipped:
+		return ":white_check_mark: " + entry.Status.String()
+	case entry.Status.IsFailed(),
+		entry.Status == intgteststat.SnapshotCreationFailed,
+		entry.Status == intgteststat.BuildPLRFailed,
+		entry.Status == intgteststat.GroupSnapshotCreationFailed:
+		return ":x: " + entry.Status.String()
+	default:
+		return ":hourglass_flowing_sand: " + entry.Status.String()
+	}
+}
+
+// FormatSummaryDuration accepts a summary entry and returns the duration of its test, if it has completed.
+func FormatSummaryDuration(entry SummaryEntry) string {
+	if entry.StartTime == nil || entry.CompletionTime == nil {
+		return "-"
+	}
+	return entry.CompletionTime.Sub(*entry.StartTime).Round(time.Second).String()
+}
+
+// FormatSummaryLogs accepts a summary entry and returns a link to the logs of its test PipelineRun, if any.
+func FormatSummaryLogs(entry SummaryEntry) string {
+	if entry.TestPipelineRunName == "" {
+		return "-"
+	}
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", FormatPipelineURL(entry.TestPipelineRunName, entry.Namespace, logr.Discard()), entry.TestPipelineRunName)
+}
+
+// FormatTableCell accepts text reported by a test and escapes it so that it can be safely rendered in a Markdown table cell.
+func FormatTableCell(text string) string {
+	text = html.EscapeString(strings.TrimSpace(text))
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\n", "<br>")
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// FormatFindingLevel accepts a finding and returns a Markdown friendly representation of its level.
+func FormatFindingLevel(finding helpers.Finding) string {
+	switch finding.Level {
+	case helpers.FindingLevelFailure:
+		return ":x: " + finding.Level
+	case helpers.FindingLevelWarning:
+		return ":warning: " + finding.Level
+	default:
+		return ":information_source: " + finding.Level
+	}
+}
+
+// FormatFindingLocation accepts a finding and returns the file and lines it points at.
+func FormatFindingLocation(finding helpers.Finding) string {
+	location := fmt.Sprintf("%s:%d", finding.Path, finding.StartLine)
+	if finding.EndLine > finding.StartLine {
+		location = fmt.Sprintf("%s-%d", location, finding.EndLine)
+	}
+	return FormatTableCell(location)
+}
+
+// FormatFinding accepts a finding and returns a Markdown friendly representation of its title and message.
+func FormatFinding(finding helpers.Finding) string {
+	if finding.Title == "" || finding.Title == finding.Message {
+		return FormatTableCell(finding.Message)
+	}
+	return fmt.Sprintf("<b>%s</b>: %s", FormatTableCell(finding.Title), FormatTableCell(finding.Message))
+}
+
 // Console URL env vars (CONSOLE_URL, CONSOLE_URL_TASKLOG) use literal placeholder substitution only.
 // Operator-controlled values are not evaluated as Go templates (avoids CWE-94 / server-side template injection).
 //
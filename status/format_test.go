@@ -235,7 +235,7 @@ var _ = Describe("Formatters", func() {
 
 	It("CONSOLE_URL env var not set", func() {
 		os.Setenv("CONSOLE_URL", "")
-		text, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		text, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).To(Succeed())
 		Expect(text).To(ContainSubstring("https://CONSOLE_URL_NOT_AVAILABLE"))
 	})
@@ -247,7 +247,7 @@ var _ = Describe("Formatters", func() {
 	})
 
 	It("can construct a comment", func() {
-		text, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		text, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).To(Succeed())
 		comment, err := status.FormatComment("example-title", text)
 		Expect(err).ToNot(HaveOccurred())
@@ -256,7 +256,7 @@ var _ = Describe("Formatters", func() {
 	})
 
 	It("can construct a comment", func() {
-		text, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		text, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).To(Succeed())
 		comment, err := status.FormatCommentForSuccessfulTest("example-title", text)
 		Expect(err).ToNot(HaveOccurred())
@@ -274,7 +274,7 @@ var _ = Describe("Formatters", func() {
 		os.Setenv("CONSOLE_URL_TASKLOG", "https://example.com/ns/{{NAMESPACE}}/pipelinerun/{{PIPELINE_RUN_NAME}}/logs/{{TASK_NAME}}")
 		taskLogURL := status.FormatTaskLogURL(taskRuns[0], pipelineRun.Name, pipelineRun.Namespace, logr.Discard())
 		Expect(taskLogURL).To(Equal("https://example.com/ns/default/pipelinerun/pipelinerun-component-sample/logs/example-task-1"))
-		summary, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		summary, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(summary).To(ContainSubstring(`href="https://example.com/ns/default/pipelinerun/pipelinerun-component-sample"`))
 	})
@@ -284,16 +284,58 @@ var _ = Describe("Formatters", func() {
 		os.Setenv("CONSOLE_URL_TASKLOG", "https://example.com/ns/{{.Namespace}}/pipelinerun/{{.PipelineRunName}}/logs/{{.TaskName}}")
 		taskLogURL := status.FormatTaskLogURL(taskRuns[0], pipelineRun.Name, pipelineRun.Namespace, logr.Discard())
 		Expect(taskLogURL).To(Equal("https://example.com/ns/default/pipelinerun/pipelinerun-component-sample/logs/example-task-1"))
-		summary, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		summary, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(summary).To(ContainSubstring(`href="https://example.com/ns/default/pipelinerun/pipelinerun-component-sample"`))
 	})
 
 	It("can construct a summary", func() {
-		summary, err := status.FormatTestsSummary(taskRuns, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		summary, err := status.FormatTestsSummary(taskRuns, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 		Expect(err).ToNot(HaveOccurred())
 		Expect(summary).To(Equal(expectedSummary))
 	})
+
+	It("can construct a code quality summary of the findings", func() {
+		summary, err := status.FormatFindingsSummary(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(BeEmpty())
+
+		findings := []helpers.Finding{
+			{Path: "README.md", StartLine: 3, EndLine: 3, Level: helpers.FindingLevelNotice, Message: "Line too long"},
+			{Path: "main.go", StartLine: 10, EndLine: 12, Level: helpers.FindingLevelFailure, Title: "gosec: G101", Message: "Potential hardcoded credentials"},
+		}
+		for i := 0; i < 50; i++ {
+			findings = append(findings, helpers.Finding{Path: "pkg/util.go", StartLine: i + 1, EndLine: i + 1, Level: helpers.FindingLevelWarning, Message: "unused | variable"})
+		}
+		summary, err = status.FormatFindingsSummary(findings)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(ContainSubstring("<b>Code quality</b>: 1 failure(s), 50 warning(s), 1 notice(s)"))
+		Expect(summary).To(ContainSubstring("| Severity | Location | Finding |\n| --- | --- | --- |\n| :x: failure | <code>main.go:10-12</code> | <b>gosec: G101</b>: Potential hardcoded credentials |"))
+		Expect(summary).To(ContainSubstring("| :warning: warning | <code>pkg/util.go:1</code> | unused \\| variable |"))
+		Expect(summary).NotTo(ContainSubstring("README.md"))
+		Expect(summary).To(ContainSubstring("2 more finding(s) not shown"))
+	})
+
+	It("can construct a summary with the unsuccessful test cases", func() {
+		testCaseResults := &helpers.TestCaseResults{
+			Total:   25,
+			Passed:  2,
+			Failed:  21,
+			Errored: 1,
+			Skipped: 1,
+			TestCases: []helpers.TestCaseResult{
+				{Name: "TestLogin", ClassName: "auth", Suite: "e2e", Status: helpers.TestCaseStatusFailed, Message: "expected <200>\nactual | 500"},
+				{Name: "TestSetup", Status: helpers.TestCaseStatusError, Message: "timeout"},
+			},
+		}
+		summary, err := status.FormatTestsSummary(taskRuns, testCaseResults, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary).To(ContainSubstring("<b>Test cases</b>: 25 total, 2 passed, 21 failed, 1 errored, 1 skipped"))
+		Expect(summary).To(ContainSubstring("| Test case | Status | Message |"))
+		Expect(summary).To(ContainSubstring("| e2e / auth.TestLogin | :x: failed | expected &lt;200&gt;<br>actual \\| 500 |"))
+		Expect(summary).To(ContainSubstring("| TestSetup | :heavy_exclamation_mark: error | timeout |"))
+		Expect(summary).To(ContainSubstring("20 more unsuccessful test case(s) not shown"))
+	})
 	//when TEST_OUTPUT == "" is also invalid
 	When("task TEST_OUTPUT is invalid", func() {
 
@@ -318,7 +360,7 @@ var _ = Describe("Formatters", func() {
 		})
 
 		It("won't fail when summary is generated from invalid result", func() {
-			_, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+			_, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 			Expect(err).To(Succeed())
 		})
 	})
@@ -340,7 +382,7 @@ var _ = Describe("Formatters", func() {
 		})
 
 		It("won't fail when summary is generated from taskrun without TEST_OUTPUT", func() {
-			_, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+			_, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 			Expect(err).To(Succeed())
 		})
 	})
@@ -384,7 +426,7 @@ var _ = Describe("Formatters", func() {
 		}
 		PRGroup = "feature-1"
 		It("component snapshot info is generated", func() {
-			text, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
+			text, err := status.FormatTestsSummary([]*helpers.TaskRun{taskRun}, nil, pipelineRun.Name, pipelineRun.Namespace, componentSnapshotInfos, PRGroup, logr.Discard())
 			Expect(text).To(ContainSubstring("The group snapshot is generated for pr group feature-1 and the component snasphots as below:"))
 			Expect(text).To(ContainSubstring("| com3 | snapshot3 | <a href=\"https://definetly.not.prod/preview/application-pipeline/ns/default/pipelinerun/buildPLR3\">buildPLR3</a> | <a href=\"https://github.com/example/pull/1\">example</a> |"))
 			Expect(err).To(Succeed())
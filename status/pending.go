@@ -0,0 +1,221 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+)
+
+const (
+	// PendingReportRetryTimeout is how long the delivery of a pending report is retried after its first failure
+	PendingReportRetryTimeout = 24 * time.Hour
+
+	// pendingReportMinBackoff and pendingReportMaxBackoff bound the delay between the delivery attempts of a pending report
+	pendingReportMinBackoff = time.Minute
+	pendingReportMaxBackoff = 30 * time.Minute
+
+	// maxPendingReportErrorLength limits the size of the last error kept in the Snapshot annotation
+	maxPendingReportErrorLength = 512
+)
+
+// PendingReport keeps the delivery state of the reports a reporter owes to a destination Snapshot
+type PendingReport struct {
+	// Reporter is the name of the reporter which failed to deliver the reports
+	Reporter string `json:"reporter"`
+	// DestinationSnapshot is the name of the component Snapshot the reports are delivered to
+	DestinationSnapshot string `json:"destinationSnapshot"`
+	// Since is the time of the first failed delivery
+	Since time.Time `json:"since"`
+	// LastAttemptTime is the time of the last failed delivery
+	LastAttemptTime time.Time `json:"lastAttemptTime"`
+	// Attempts is the number of failed deliveries
+	Attempts int `json:"attempts"`
+	// LastError is the error of the last failed delivery
+	LastError string `json:"lastError,omitempty"`
+}
+
+// IsExpired returns true when the delivery of the report has been failing for longer than PendingReportRetryTimeout
+func (p *PendingReport) IsExpired(now time.Time) bool {
+	return now.Sub(p.Since) > PendingReportRetryTimeout
+}
+
+// NextAttemptTime returns the time of the next delivery attempt, the delay doubles with each failed attempt
+func (p *PendingReport) NextAttemptTime() time.Time {
+	backoff := pendingReportMinBackoff
+	for i := 1; i < p.Attempts && backoff < pendingReportMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > pendingReportMaxBackoff {
+		backoff = pendingReportMaxBackoff
+	}
+	return p.LastAttemptTime.Add(backoff)
+}
+
+// PendingReports keeps the reports of a Snapshot which couldn't be delivered yet, so that they are retried
+// across reconciliations and restarts until they are delivered or expire
+type PendingReports struct {
+	Reports map[string]*PendingReport `json:"reports"`
+	dirty   bool
+}
+
+// NewPendingReports creates new object
+func NewPendingReports(jsondata string) (*PendingReports, error) {
+	pr := PendingReports{
+		Reports: map[string]*PendingReport{},
+	}
+	if jsondata != "" {
+		if err := json.Unmarshal([]byte(jsondata), &pr); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal json '%s': %w", jsondata, err)
+		}
+		if pr.Reports == nil {
+			pr.Reports = map[string]*PendingReport{}
+		}
+	}
+	return &pr, nil
+}
+
+// NewPendingReportsFromSnapshot creates new PendingReports struct from snapshot annotation
+func NewPendingReportsFromSnapshot(s *applicationapiv1alpha1.Snapshot) (*PendingReports, error) {
+	pr, err := NewPendingReports(s.GetAnnotations()[gitops.SnapshotPendingReportsAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending reports from snapshot %s (annotation: %s): %w", s.Name, gitops.SnapshotPendingReportsAnnotation, err)
+	}
+	return pr, nil
+}
+
+// RecordFailure records a failed delivery of the reports of the reporter to the destination Snapshot and returns its pending report
+func (pr *PendingReports) RecordFailure(reporterName, destinationSnapshotName string, reportErr error, now time.Time) *PendingReport {
+	pr.dirty = true
+	key := pendingReportKey(reporterName, destinationSnapshotName)
+	pendingReport, ok := pr.Reports[key]
+	if !ok {
+		pendingReport = &PendingReport{
+			Reporter:            reporterName,
+			DestinationSnapshot: destinationSnapshotName,
+			Since:               now,
+		}
+		pr.Reports[key] = pendingReport
+	}
+	pendingReport.LastAttemptTime = now
+	pendingReport.Attempts++
+	pendingReport.LastError = ""
+	if reportErr != nil {
+		pendingReport.LastError = reportErr.Error()
+		if len(pendingReport.LastError) > maxPendingReportErrorLength {
+			pendingReport.LastError = pendingReport.LastError[:maxPendingReportErrorLength-3] + "..."
+		}
+	}
+	return pendingReport
+}
+
+// Remove forgets the pending report of the reporter for the destination Snapshot, it returns the removed pending report
+// or nil when the reports weren't pending
+func (pr *PendingReports) Remove(reporterName, destinationSnapshotName string) *PendingReport {
+	key := pendingReportKey(reporterName, destinationSnapshotName)
+	pendingReport, ok := pr.Reports[key]
+	if !ok {
+		return nil
+	}
+	pr.dirty = true
+	delete(pr.Reports, key)
+	return pendingReport
+}
+
+// NextRetryDelay returns the delay until the next pending report should be retried, false is returned
+// when there are no pending reports
+func (pr *PendingReports) NextRetryDelay(now time.Time) (time.Duration, bool) {
+	if len(pr.Reports) == 0 {
+		return 0, false
+	}
+	var next time.Time
+	for _, pendingReport := range pr.Reports {
+		if attemptTime := pendingReport.NextAttemptTime(); next.IsZero() || attemptTime.Before(next) {
+			next = attemptTime
+		}
+	}
+	// retry right away, without going through the rate limiter of the controller, when the attempt is already due
+	if delay := next.Sub(now); delay > time.Second {
+		return delay, true
+	}
+	return time.Second, true
+}
+
+// CountByReporter returns the number of pending reports of each reporter
+func (pr *PendingReports) CountByReporter() map[string]int {
+	counts := map[string]int{}
+	for _, pendingReport := range pr.Reports {
+		counts[pendingReport.Reporter]++
+	}
+	return counts
+}
+
+// OldestByReporter returns the first failed delivery time of the oldest pending report of each reporter
+func (pr *PendingReports) OldestByReporter() map[string]time.Time {
+	oldest := map[string]time.Time{}
+	for _, pendingReport := range pr.Reports {
+		if since, ok := oldest[pendingReport.Reporter]; !ok || pendingReport.Since.Before(since) {
+			oldest[pendingReport.Reporter] = pendingReport.Since
+		}
+	}
+	return oldest
+}
+
+// IsDirty returns true if there are new changes to be written
+func (pr *PendingReports) IsDirty() bool {
+	return pr.dirty
+}
+
+// WritePendingReports writes the pending reports to the snapshot annotation, the annotation is removed
+// once no reports are pending
+func WritePendingReports(ctx context.Context, c client.Client, s *applicationapiv1alpha1.Snapshot, pr *PendingReports) error {
+	if !pr.IsDirty() {
+		return nil // nothing to update
+	}
+	patch := client.MergeFrom(s.DeepCopy())
+
+	if len(pr.Reports) == 0 {
+		delete(s.Annotations, gitops.SnapshotPendingReportsAnnotation)
+	} else {
+		value, err := json.Marshal(pr)
+		if err != nil {
+			return fmt.Errorf("failed to marshal pending reports into JSON: %w", err)
+		}
+		if err := metadata.SetAnnotation(&s.ObjectMeta, gitops.SnapshotPendingReportsAnnotation, string(value)); err != nil {
+			return fmt.Errorf("failed to add annotations: %w", err)
+		}
+	}
+
+	if err := c.Patch(ctx, s, patch); err != nil {
+		return fmt.Errorf("failed to write pending reports of snapshot %s/%s: %w", s.Namespace, s.Name, err)
+	}
+	pr.dirty = false
+	return nil
+}
+
+// pendingReportKey returns the key of the pending report of the reporter for the destination Snapshot
+func pendingReportKey(reporterName, destinationSnapshotName string) string {
+	return reporterName + "/" + destinationSnapshotName
+}
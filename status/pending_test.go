@@ -0,0 +1,139 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+var _ = Describe("Pending reports", func() {
+	var (
+		snapshot       *applicationapiv1alpha1.Snapshot
+		pendingReports *status.PendingReports
+		now            time.Time
+	)
+
+	BeforeEach(func() {
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "snapshot-sample",
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			},
+		}
+		var err error
+		pendingReports, err = status.NewPendingReportsFromSnapshot(snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		now = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	})
+
+	It("records the failed deliveries and backs off between the attempts", func() {
+		_, ok := pendingReports.NextRetryDelay(now)
+		Expect(ok).To(BeFalse())
+
+		pendingReport := pendingReports.RecordFailure("GithubReporter", "snapshot-sample", errors.New("service unavailable"), now)
+		Expect(pendingReport.Attempts).To(Equal(1))
+		Expect(pendingReport.LastError).To(Equal("service unavailable"))
+		delay, ok := pendingReports.NextRetryDelay(now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(time.Minute))
+
+		pendingReport = pendingReports.RecordFailure("GithubReporter", "snapshot-sample", errors.New(strings.Repeat("x", 1000)), now.Add(time.Minute))
+		Expect(pendingReport.Attempts).To(Equal(2))
+		Expect(pendingReport.Since).To(Equal(now))
+		Expect(pendingReport.LastError).To(HaveLen(512))
+		Expect(pendingReport.NextAttemptTime()).To(Equal(now.Add(3 * time.Minute)))
+
+		// the delay is capped
+		pendingReport.Attempts = 20
+		Expect(pendingReport.NextAttemptTime()).To(Equal(now.Add(31 * time.Minute)))
+
+		// the attempts which are already due are retried right away
+		delay, _ = pendingReports.NextRetryDelay(now.Add(time.Hour))
+		Expect(delay).To(Equal(time.Second))
+	})
+
+	It("expires the reports which have been failing for too long", func() {
+		pendingReport := pendingReports.RecordFailure("GithubReporter", "snapshot-sample", errors.New("service unavailable"), now)
+		Expect(pendingReport.IsExpired(now.Add(time.Hour))).To(BeFalse())
+		Expect(pendingReport.IsExpired(now.Add(status.PendingReportRetryTimeout + time.Minute))).To(BeTrue())
+	})
+
+	It("counts the pending reports by reporter and forgets the delivered ones", func() {
+		pendingReports.RecordFailure("GithubReporter", "snapshot-a", errors.New("service unavailable"), now)
+		pendingReports.RecordFailure("GithubReporter", "snapshot-b", errors.New("service unavailable"), now)
+		pendingReports.RecordFailure("WebhookReporter", "snapshot-a", errors.New("connection refused"), now)
+		Expect(pendingReports.CountByReporter()).To(Equal(map[string]int{"GithubReporter": 2, "WebhookReporter": 1}))
+
+		Expect(pendingReports.Remove("GithubReporter", "snapshot-a")).ToNot(BeNil())
+		Expect(pendingReports.Remove("GithubReporter", "snapshot-a")).To(BeNil())
+		Expect(pendingReports.CountByReporter()).To(Equal(map[string]int{"GithubReporter": 1, "WebhookReporter": 1}))
+	})
+
+	It("returns the oldest pending report of each reporter", func() {
+		pendingReports.RecordFailure("GithubReporter", "snapshot-a", errors.New("service unavailable"), now.Add(-time.Hour))
+		pendingReports.RecordFailure("GithubReporter", "snapshot-b", errors.New("service unavailable"), now)
+		pendingReports.RecordFailure("WebhookReporter", "snapshot-a", errors.New("connection refused"), now)
+		Expect(pendingReports.OldestByReporter()).To(Equal(map[string]time.Time{
+			"GithubReporter":  now.Add(-time.Hour),
+			"WebhookReporter": now,
+		}))
+	})
+
+	It("writes the pending reports to the snapshot and removes the annotation once they are delivered", func() {
+		mockK8sClient := &MockK8sClient{}
+		Expect(status.WritePendingReports(context.Background(), mockK8sClient, snapshot, pendingReports)).To(Succeed())
+		Expect(snapshot.Annotations).ToNot(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+
+		pendingReports.RecordFailure("GithubReporter", "snapshot-sample", errors.New("service unavailable"), now)
+		Expect(status.WritePendingReports(context.Background(), mockK8sClient, snapshot, pendingReports)).To(Succeed())
+		Expect(pendingReports.IsDirty()).To(BeFalse())
+		Expect(snapshot.Annotations).To(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+
+		// the pending reports are kept across reconciliations
+		readPendingReports, err := status.NewPendingReportsFromSnapshot(snapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readPendingReports.Reports).To(HaveLen(1))
+		for _, pendingReport := range readPendingReports.Reports {
+			Expect(pendingReport.Reporter).To(Equal("GithubReporter"))
+			Expect(pendingReport.DestinationSnapshot).To(Equal("snapshot-sample"))
+			Expect(pendingReport.Since.Equal(now)).To(BeTrue())
+			Expect(pendingReport.Attempts).To(Equal(1))
+		}
+
+		readPendingReports.Remove("GithubReporter", "snapshot-sample")
+		Expect(status.WritePendingReports(context.Background(), mockK8sClient, snapshot, readPendingReports)).To(Succeed())
+		Expect(snapshot.Annotations).ToNot(HaveKey(gitops.SnapshotPendingReportsAnnotation))
+	})
+
+	It("fails for an invalid annotation", func() {
+		snapshot.Annotations[gitops.SnapshotPendingReportsAnnotation] = "{"
+		_, err := status.NewPendingReportsFromSnapshot(snapshot)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -68,6 +68,32 @@ type TestReport struct {
 	CompletionTime *time.Time
 	// pipelineRun Name
 	TestPipelineRunName string
+	// line-level findings published by the tasks of the pipelineRun
+	Findings []helpers.Finding
+	// CheckRunText is the check run text rendered with the report templates of the namespace, Text is used when empty
+	CheckRunText string
+	// Description is the commit status description rendered with the report templates of the namespace, Summary is used when empty
+	Description string
+	// Comment is the comment of the test rendered with the report templates of the namespace, the default comment is used when empty
+	Comment string
+	// SkipComment is set when the status is reported in the summary comment of the pull request instead of a comment of its own
+	SkipComment bool
+}
+
+// GetCheckRunText returns the text of the check run of the test
+func (r TestReport) GetCheckRunText() string {
+	if r.CheckRunText != "" {
+		return r.CheckRunText
+	}
+	return r.Text
+}
+
+// GetDescription returns the description of the commit status of the test
+func (r TestReport) GetDescription() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	return r.Summary
 }
 
 type ReporterInterface interface {
@@ -81,12 +107,34 @@ type ReporterInterface interface {
 	ReportStatus(context.Context, TestReport) (int, error)
 	// Is the return code a recoverable error
 	ReturnCodeIsUnrecoverable(statusCode int) bool
-	// Update status comment in the gitlab merge request, implemented and used in reporter_gitlab.go
+	// Update status comment in the pull/merge request, used for the comment of each component and for the summary comment
 	UpdateStatusInComment(string, string, bool) (int, error)
 }
 
 // GetPACGitProviderToken lookup for configured repo and fetch token from namespace
 func GetPACGitProviderToken(ctx context.Context, k8sClient client.Client, snapshot *applicationapiv1alpha1.Snapshot) (string, error) {
+	token, err := getPACGitProviderSecretValue(ctx, k8sClient, snapshot, func(gitProvider *pacv1alpha1.GitProvider) *pacv1alpha1.Secret {
+		return gitProvider.Secret
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+// GetPACGitProviderWebhookSecret lookup for configured repo and fetch the secret of its webhook from namespace
+func GetPACGitProviderWebhookSecret(ctx context.Context, k8sClient client.Client, snapshot *applicationapiv1alpha1.Snapshot) ([]byte, error) {
+	return getPACGitProviderSecretValue(ctx, k8sClient, snapshot, func(gitProvider *pacv1alpha1.GitProvider) *pacv1alpha1.Secret {
+		if gitProvider.WebhookSecret != nil && gitProvider.WebhookSecret.Key == "" {
+			// same default key as Pipelines as Code
+			return &pacv1alpha1.Secret{Name: gitProvider.WebhookSecret.Name, Key: "webhook.secret"}
+		}
+		return gitProvider.WebhookSecret
+	})
+}
+
+// getPACGitProviderSecretValue lookup for configured repo and fetch the value of the given git provider secret from namespace
+func getPACGitProviderSecretValue(ctx context.Context, k8sClient client.Client, snapshot *applicationapiv1alpha1.Snapshot, getSecret func(*pacv1alpha1.GitProvider) *pacv1alpha1.Secret) ([]byte, error) {
 	log := log.FromContext(ctx)
 	var err, unRecoverableError error
 
@@ -94,7 +142,7 @@ func GetPACGitProviderToken(ctx context.Context, k8sClient client.Client, snapsh
 	repos := pacv1alpha1.RepositoryList{}
 	if err = k8sClient.List(ctx, &repos, &client.ListOptions{Namespace: snapshot.Namespace}); err != nil {
 		log.Error(err, fmt.Sprintf("failed to get repo from namespace %s", snapshot.Namespace))
-		return "", err
+		return nil, err
 	}
 
 	// Get the full repo URL
@@ -102,14 +150,14 @@ func GetPACGitProviderToken(ctx context.Context, k8sClient client.Client, snapsh
 	if !found {
 		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("object annotation not found %q", gitops.PipelineAsCodeRepoURLAnnotation))
 		log.Error(unRecoverableError, fmt.Sprintf("object annotation not found %q", gitops.PipelineAsCodeRepoURLAnnotation))
-		return "", unRecoverableError
+		return nil, unRecoverableError
 	}
 
 	// Find a Repository CR with a matching URL and get its secret details
 	var repoSecret *pacv1alpha1.Secret
 	for _, repo := range repos.Items {
-		if url == repo.Spec.URL {
-			repoSecret = repo.Spec.GitProvider.Secret
+		if url == repo.Spec.URL && repo.Spec.GitProvider != nil {
+			repoSecret = getSecret(repo.Spec.GitProvider)
 			break
 		}
 	}
@@ -117,7 +165,7 @@ func GetPACGitProviderToken(ctx context.Context, k8sClient client.Client, snapsh
 	if repoSecret == nil {
 		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to find a Repository matching URL: %q", url))
 		log.Error(unRecoverableError, fmt.Sprintf("failed to find a Repository matching URL: %q", url))
-		return "", unRecoverableError
+		return nil, unRecoverableError
 	}
 
 	// Get the pipelines as code secret from the PipelineRun's namespace
@@ -125,16 +173,16 @@ func GetPACGitProviderToken(ctx context.Context, k8sClient client.Client, snapsh
 	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: snapshot.Namespace, Name: repoSecret.Name}, &pacSecret)
 	if err != nil {
 		log.Error(err, fmt.Sprintf("failed to get secret %s/%s", snapshot.Namespace, repoSecret.Name))
-		return "", err
+		return nil, err
 	}
 
-	// Get the personal access token from the secret
-	token, found := pacSecret.Data[repoSecret.Key]
+	// Get the value from the secret
+	value, found := pacSecret.Data[repoSecret.Key]
 	if !found {
 		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to find %s secret key", repoSecret.Key))
 		log.Error(unRecoverableError, fmt.Sprintf("failed to find %s secret key", repoSecret.Key))
-		return "", unRecoverableError
+		return nil, unRecoverableError
 	}
 
-	return string(token), nil
+	return value, nil
 }
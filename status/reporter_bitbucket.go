@@ -0,0 +1,447 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/git/bitbucket"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/loader"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+// bitbucketBuildStatusDescriptionLimit is the maximum length of a Bitbucket build status description
+const bitbucketBuildStatusDescriptionLimit = 255
+
+type BitbucketReporter struct {
+	logger      *logr.Logger
+	k8sClient   client.Client
+	client      *bitbucket.Client
+	sha         string
+	projectKey  string
+	repoSlug    string
+	repoUrl     string
+	pullRequest int
+	snapshot    *applicationapiv1alpha1.Snapshot
+}
+
+func NewBitbucketReporter(logger logr.Logger, k8sClient client.Client) *BitbucketReporter {
+	return &BitbucketReporter{
+		logger:    &logger,
+		k8sClient: k8sClient,
+	}
+}
+
+var BitbucketProvider = "BitbucketReporter"
+
+// check if interface has been correctly implemented
+var _ ReporterInterface = (*BitbucketReporter)(nil)
+
+// Detect if snapshot has been created from Bitbucket Data Center or Bitbucket Server provider
+func (r *BitbucketReporter) Detect(snapshot *applicationapiv1alpha1.Snapshot) bool {
+	return metadata.HasAnnotationWithValue(snapshot, gitops.PipelineAsCodeGitProviderLabel, gitops.PipelineAsCodeBitbucketDataCenterProviderType) ||
+		metadata.HasLabelWithValue(snapshot, gitops.PipelineAsCodeGitProviderAnnotation, gitops.PipelineAsCodeBitbucketDataCenterProviderType) ||
+		metadata.HasAnnotationWithValue(snapshot, gitops.PipelineAsCodeGitProviderLabel, gitops.PipelineAsCodeBitbucketServerProviderType) ||
+		metadata.HasLabelWithValue(snapshot, gitops.PipelineAsCodeGitProviderAnnotation, gitops.PipelineAsCodeBitbucketServerProviderType)
+}
+
+// GetReporterName returns the reporter name
+func (r *BitbucketReporter) GetReporterName() string {
+	return BitbucketProvider
+}
+
+// Initialize initializes bitbucket reporter
+func (r *BitbucketReporter) Initialize(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot) (int, error) {
+	var unRecoverableError error
+	token, err := GetPACGitProviderToken(ctx, r.k8sClient, snapshot)
+	if err != nil {
+		r.logger.Error(err, "failed to get PAC token from snapshot",
+			"snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, err
+	}
+
+	annotations := snapshot.GetAnnotations()
+	repoUrl, ok := annotations[gitops.PipelineAsCodeRepoURLAnnotation]
+	if !ok {
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to get value of %s annotation from the snapshot %s", gitops.PipelineAsCodeRepoURLAnnotation, snapshot.Name))
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+
+	apiURL, projectKey, repoSlug, err := ParseBitbucketRepoURL(repoUrl)
+	if err != nil {
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(err.Error())
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+	r.projectKey = projectKey
+	r.repoSlug = repoSlug
+	r.repoUrl = repoUrl
+
+	r.client, err = bitbucket.NewClient(apiURL, token)
+	if err != nil {
+		r.logger.Error(err, "failed to create bitbucket client", "apiURL", apiURL, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, err
+	}
+
+	labels := snapshot.GetLabels()
+	sha, found := labels[gitops.PipelineAsCodeSHALabel]
+	if !found {
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("sha label not found %q", gitops.PipelineAsCodeSHALabel))
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+	r.sha = sha
+
+	pullRequestStr, found := annotations[gitops.PipelineAsCodePullRequestAnnotation]
+	if !found && !gitops.IsSnapshotCreatedByPACPushEvent(snapshot) {
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("pull-request annotation not found %q", gitops.PipelineAsCodePullRequestAnnotation))
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+
+	if found {
+		r.pullRequest, err = strconv.Atoi(pullRequestStr)
+		if err != nil && !gitops.IsSnapshotCreatedByPACPushEvent(snapshot) {
+			unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to convert pull request number '%s' to integer: %s", pullRequestStr, err.Error()))
+			r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+			return 0, unRecoverableError
+		}
+	}
+
+	r.snapshot = snapshot
+	return http.StatusOK, nil
+}
+
+// ParseBitbucketRepoURL extracts the REST API base URL, the project key and the repository slug from a Bitbucket repository URL.
+// Both the browse (https://host/projects/KEY/repos/slug) and clone (https://host/scm/key/slug.git) URL formats
+// are supported, and the context path of the Bitbucket instance is kept in the base URL.
+func ParseBitbucketRepoURL(repoUrl string) (string, string, string, error) {
+	burl, err := url.Parse(repoUrl)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse repo-url %s: %s", repoUrl, err.Error())
+	}
+
+	pathParts := strings.Split(strings.Trim(burl.Path, "/"), "/")
+	for i := range pathParts {
+		var projectKey, repoSlug string
+		switch {
+		case pathParts[i] == "projects" && i+3 < len(pathParts) && pathParts[i+2] == "repos":
+			projectKey, repoSlug = pathParts[i+1], pathParts[i+3]
+		case pathParts[i] == "scm" && i+2 < len(pathParts):
+			projectKey, repoSlug = pathParts[i+1], strings.TrimSuffix(pathParts[i+2], ".git")
+		default:
+			continue
+		}
+		if projectKey == "" || repoSlug == "" {
+			break
+		}
+		apiURL := fmt.Sprintf("%s://%s", burl.Scheme, burl.Host)
+		if i > 0 {
+			apiURL = apiURL + "/" + strings.Join(pathParts[:i], "/")
+		}
+		return apiURL, projectKey, repoSlug, nil
+	}
+
+	return "", "", "", fmt.Errorf("failed to extract project key and repository slug from URL path %s", burl.Path)
+}
+
+// IsPullRequestOpen returns whether the snapshot's pull request is still open.
+// Used by status.IsPRMRInSnapshotOpened. For push snapshots (no PR) returns false.
+func (r *BitbucketReporter) IsPullRequestOpen(ctx context.Context) (bool, int, error) {
+	if r.client == nil {
+		return false, 0, fmt.Errorf("bitbucket reporter not initialized")
+	}
+	if r.pullRequest == 0 {
+		return false, 0, nil
+	}
+	pr, statusCode, err := r.client.GetPullRequest(ctx, r.projectKey, r.repoSlug, r.pullRequest)
+	if statusCode == http.StatusNotFound {
+		r.logger.Info("pull request not found, it may have been deleted",
+			"projectKey", r.projectKey, "repoSlug", r.repoSlug, "pullRequest", r.pullRequest)
+		return false, statusCode, nil
+	}
+	if err != nil {
+		return false, statusCode, err
+	}
+	return pr.State == bitbucket.PullRequestStateOpen, statusCode, nil
+}
+
+// setBuildStatus sets the build status of the scenario to be shown on the commit in bitbucket view
+func (r *BitbucketReporter) setBuildStatus(ctx context.Context, report TestReport) (int, error) {
+	var statusCode = 0
+	l := loader.NewLoader()
+	scenario, err := l.GetScenario(ctx, r.k8sClient, report.ScenarioName, r.snapshot.Namespace)
+
+	if err != nil {
+		r.logger.Error(err, fmt.Sprintf("could not determine whether scenario %s was optional", report.ScenarioName))
+		return statusCode, fmt.Errorf("could not determine whether scenario %s is optional: %w", report.ScenarioName, err)
+	}
+	optional := helpers.IsIntegrationTestScenarioOptional(scenario)
+	// quarantined scenarios are reported like optional ones since they don't block the Snapshot either
+	quarantined := helpers.IsIntegrationTestScenarioQuarantined(scenario)
+	bbState, err := GenerateBitbucketBuildState(report.Status, optional || quarantined)
+	if err != nil {
+		return statusCode, fmt.Errorf("failed to generate bitbucket state: %w", err)
+	}
+
+	description := report.GetDescription()
+	if quarantined {
+		description = GenerateQuarantinedSummary(description, scenario)
+	}
+	if len(description) > bitbucketBuildStatusDescriptionLimit {
+		description = description[:bitbucketBuildStatusDescriptionLimit-3] + "..."
+	}
+
+	// Bitbucket requires an URL for every build status, fall back to the repository when there is no PipelineRun yet
+	buildStatus := bitbucket.BuildStatus{
+		State:       bbState,
+		Key:         report.FullName,
+		Name:        report.FullName,
+		URL:         r.repoUrl,
+		Description: description,
+	}
+
+	if report.TestPipelineRunName != "" {
+		buildStatus.URL = FormatPipelineURL(report.TestPipelineRunName, r.snapshot.Namespace, *r.logger)
+	}
+
+	// Fetch existing build statuses only if necessary
+	if bbState == bitbucket.BuildStateInProgress {
+		buildStatuses, statusCode, err := r.client.GetBuildStatuses(ctx, r.sha)
+		if err != nil {
+			return statusCode, fmt.Errorf("error while getting all build statuses for sha %s: %w", r.sha, err)
+		}
+		existingStatus := r.GetExistingBuildStatus(buildStatuses, report.FullName)
+
+		// special case, we want to skip updating build status if the status is unchanged while in progress
+		if existingStatus != nil && existingStatus.State == bbState && existingStatus.Description == buildStatus.Description {
+			r.logger.Info("Skipping build status update",
+				"scenario.name", report.ScenarioName,
+				"current_status", existingStatus.State,
+				"new_status", bbState)
+			return statusCode, nil
+		}
+	}
+
+	r.logger.Info("creating build status for scenario test status of snapshot",
+		"scenarioName", report.ScenarioName)
+
+	statusCode, err = r.client.SetBuildStatus(ctx, r.sha, buildStatus)
+	if err != nil {
+		return statusCode, fmt.Errorf("failed to set build status to %s: %w", bbState, err)
+	}
+
+	r.logger.Info("Created bitbucket build status", "scenario.name", report.ScenarioName, "URL", buildStatus.URL)
+	return statusCode, nil
+}
+
+// UpdateStatusInComment searches and updates existing comments or creates a new comment in the PR which creates snapshot
+// retry to get comment if no existing comment is found for final status to avoid the duplicate comments are created
+func (r *BitbucketReporter) UpdateStatusInComment(commentPrefix, comment string, isFinalStatus bool) (int, error) {
+	var statusCode = 0
+	ctx := context.Background()
+
+	var existingComments []bitbucket.Comment
+	var err error
+	err = retry.OnError(reporterRetryBackoff, func(err error) bool {
+		// statusCode 0 means no HTTP response was received (network/timeout error), always retry
+		retryable := !r.ReturnCodeIsUnrecoverable(statusCode)
+		if retryable {
+			r.logger.Info("failed to find existing comment for final integration test status, retrying")
+		}
+		return retryable
+	}, func() error {
+		var allComments []bitbucket.Comment
+		// get all existing integration test comments according to commentPrefix
+		allComments, statusCode, err = r.client.ListPullRequestComments(ctx, r.projectKey, r.repoSlug, r.pullRequest)
+		if err != nil {
+			r.logger.Error(err, "error while getting all comments for pull-request", "pullRequest", r.pullRequest, "report.SnapshotName", r.snapshot.Name)
+			return err
+		}
+
+		existingComments = r.GetExistingComments(allComments, commentPrefix)
+		if len(existingComments) == 0 && isFinalStatus {
+			r.logger.Info("no existing comment found for final status, retrying to find comment since it might be created with delay")
+			return fmt.Errorf("no existing comment found for final status with commentPrefix %s", commentPrefix)
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.logger.Error(err, "failed to get existing comment for final status after retries, will create a new comment without updating existing comments, please refer to the comment created on the PR", "commentPrefix", commentPrefix)
+	}
+
+	if len(existingComments) > 0 {
+		// update the first existing comment but delete others because sometimes there might be multiple existing comments for the same component due to previous intermittent errors
+		commentToBeUpdated := existingComments[0]
+
+		if len(existingComments) > 1 {
+			r.logger.Info("found multiple existing comments for the same component, updating the first one but delete others", "commentPrefix", commentPrefix, "count", len(existingComments))
+			statusCode, err = r.DeleteExistingComments(existingComments[1:])
+			if err != nil {
+				return statusCode, fmt.Errorf("error while deleting existing comments for pull-request %d: %w", r.pullRequest, err)
+			}
+		}
+
+		// update the first existing comment, the version of the comment is required by bitbucket to avoid overwriting concurrent edits
+		commentToBeUpdated.Text = comment
+		_, statusCode, err = r.client.UpdatePullRequestComment(ctx, r.projectKey, r.repoSlug, r.pullRequest, commentToBeUpdated)
+		if err != nil {
+			return statusCode, fmt.Errorf("error while updating comment %d for pull-request %d: %w", commentToBeUpdated.ID, r.pullRequest, err)
+		}
+		r.logger.Info("updated existing comment with matching commentPrefix", "commentID", commentToBeUpdated.ID, "commentPrefix", commentPrefix)
+	} else {
+		// create a new comment
+		r.logger.Info("no existing comments found with matching commentPrefix, creating a new comment", "commentPrefix", commentPrefix)
+		_, statusCode, err = r.client.CreatePullRequestComment(ctx, r.projectKey, r.repoSlug, r.pullRequest, comment)
+		if err != nil {
+			return statusCode, fmt.Errorf("error while creating comment for pull-request %d: %w", r.pullRequest, err)
+		}
+	}
+
+	return statusCode, nil
+}
+
+// GetExistingBuildStatus returns existing Bitbucket build status that matches the status key
+func (r *BitbucketReporter) GetExistingBuildStatus(buildStatuses []bitbucket.BuildStatus, statusKey string) *bitbucket.BuildStatus {
+	for i := range buildStatuses {
+		if buildStatuses[i].Key == statusKey {
+			r.logger.Info("found matching existing build status",
+				"buildStatus.Key", buildStatuses[i].Key)
+			return &buildStatuses[i]
+		}
+	}
+	r.logger.Info("found no matching existing build status", "statusKey", statusKey)
+	return nil
+}
+
+// GetExistingComments returns the comments that contain the commentPrefix
+func (r *BitbucketReporter) GetExistingComments(comments []bitbucket.Comment, commentPrefix string) []bitbucket.Comment {
+	var matchingComments []bitbucket.Comment
+	for _, comment := range comments {
+		// get existing comment by searching commentPrefix in comment text
+		if strings.Contains(comment.Text, commentPrefix) {
+			r.logger.Info("found comment ID with a matching commentPrefix", "commentPrefix", commentPrefix, "commentID", comment.ID)
+			matchingComments = append(matchingComments, comment)
+		}
+	}
+
+	if len(matchingComments) == 0 {
+		r.logger.Info("found no comment with a matching commentPrefix", "commentPrefix", commentPrefix)
+	}
+	return matchingComments
+}
+
+// DeleteExistingComments deletes the given existing Bitbucket comments
+func (r *BitbucketReporter) DeleteExistingComments(comments []bitbucket.Comment) (int, error) {
+	var lastStatusCode = 0
+	var errs []error // collect errors during deletion
+
+	for _, comment := range comments {
+		statusCode, err := r.client.DeletePullRequestComment(context.Background(), r.projectKey, r.repoSlug, r.pullRequest, comment)
+		lastStatusCode = statusCode
+		if err != nil {
+			r.logger.Error(err, "failed to delete comment", "commentID", comment.ID)
+			errs = append(errs, fmt.Errorf("commentID %d: %w", comment.ID, err))
+			continue // continue to delete next comment
+		}
+		r.logger.Info("existing comment deleted", "commentID", comment.ID)
+	}
+
+	if len(errs) > 0 {
+		return lastStatusCode, fmt.Errorf("errors occurred during deletion existing comments on pull request %d: %v", r.pullRequest, errs)
+	}
+
+	return lastStatusCode, nil
+}
+
+// ReportStatus reports test result to bitbucket
+func (r *BitbucketReporter) ReportStatus(ctx context.Context, report TestReport) (int, error) {
+	var statusCode = 0
+	if r.client == nil {
+		return statusCode, fmt.Errorf("bitbucket reporter is not initialized")
+	}
+
+	var err error
+	err = retry.OnError(reporterRetryBackoff, func(err error) bool {
+		// statusCode 0 means no HTTP response was received (network/timeout error), always retry
+		retryable := statusCode == 0 || !r.ReturnCodeIsUnrecoverable(statusCode)
+		if retryable {
+			r.logger.Info("retrying to set bitbucket build status after transient error",
+				"scenario.name", report.ScenarioName, "statusCode", statusCode, "error", err.Error())
+		}
+		return retryable
+	}, func() error {
+		statusCode = 0 // reset before each attempt to avoid stale values
+		statusCode, err = r.setBuildStatus(ctx, report)
+		return err
+	})
+
+	if err != nil {
+		r.logger.Error(err, "failed to set bitbucket build status after all retries, please refer to the comment created on the PR",
+			"scenario.name", report.ScenarioName, "statusCode", statusCode)
+		return statusCode, err
+	}
+	return statusCode, nil
+}
+
+func (r *BitbucketReporter) ReturnCodeIsUnrecoverable(statusCode int) bool {
+	return statusCode == http.StatusForbidden || statusCode == http.StatusUnauthorized || statusCode == http.StatusBadRequest || statusCode == http.StatusNotFound
+}
+
+// GenerateBitbucketBuildState transforms internal integration test state into Bitbucket build state
+func GenerateBitbucketBuildState(state intgteststat.IntegrationTestStatus, optional bool) (string, error) {
+	bbState := bitbucket.BuildStateFailed
+
+	switch state {
+	case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusBlocked, intgteststat.BuildPLRInProgress,
+		intgteststat.IntegrationTestStatusInProgress:
+		bbState = bitbucket.BuildStateInProgress
+	case intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated,
+		intgteststat.IntegrationTestStatusDeploymentError_Deprecated,
+		intgteststat.IntegrationTestStatusTestInvalid, intgteststat.IntegrationTestStatusTestSkipped,
+		intgteststat.IntegrationTestStatusTestFail:
+		if optional {
+			bbState = bitbucket.BuildStateSuccessful // Bitbucket has no "skipped" or "warning" state; optional tests are not a failure
+			break
+		}
+		bbState = bitbucket.BuildStateFailed
+	case intgteststat.IntegrationTestStatusDeleted,
+		intgteststat.BuildPLRFailed, intgteststat.SnapshotCreationFailed, intgteststat.GroupSnapshotCreationFailed:
+		bbState = bitbucket.BuildStateFailed
+	case intgteststat.IntegrationTestStatusTestPassed, intgteststat.IntegrationTestStatusTestWarning:
+		bbState = bitbucket.BuildStateSuccessful
+	default:
+		return bbState, fmt.Errorf("unknown status %s", state)
+	}
+
+	return bbState, nil
+}
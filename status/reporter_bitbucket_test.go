@@ -0,0 +1,435 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/tonglil/buflogr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/git/bitbucket"
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+var _ = Describe("BitbucketReporter", func() {
+
+	const (
+		repoUrl     = "https://bitbucket.example.com/projects/PROJ/repos/example"
+		digest      = "12a4a35ccd08194595179815e4646c3a6c08bb77"
+		pullRequest = "45"
+		projectKey  = "PROJ"
+		repoSlug    = "example"
+		contextPath = "/bitbucket"
+	)
+
+	var (
+		hasSnapshot   *applicationapiv1alpha1.Snapshot
+		mockK8sClient *MockK8sClient
+		buf           bytes.Buffer
+		log           logr.Logger
+	)
+
+	BeforeEach(func() {
+		log = buflogr.NewWithBuffer(&buf)
+
+		hasSnapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot-sample",
+				Namespace: "default",
+				Labels: map[string]string{
+					"test.appstudio.openshift.io/type":           "component",
+					"appstudio.openshift.io/component":           "component-sample",
+					"pac.test.appstudio.openshift.io/sha":        digest,
+					"pac.test.appstudio.openshift.io/event-type": "pull_request",
+				},
+				Annotations: map[string]string{
+					"build.appstudio.redhat.com/commit_sha":        digest,
+					"pac.test.appstudio.openshift.io/git-provider": "bitbucket-datacenter",
+					"pac.test.appstudio.openshift.io/repo-url":     repoUrl,
+					"pac.test.appstudio.openshift.io/pull-request": pullRequest,
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{
+						Name:           "component-sample",
+						ContainerImage: "sample_image",
+					},
+				},
+			},
+		}
+	})
+
+	It("Reporter can return name uninitialized", func() {
+		reporter := status.NewBitbucketReporter(log, mockK8sClient)
+		Expect(reporter.GetReporterName()).To(Equal("BitbucketReporter"))
+	})
+
+	It("can detect if bitbucket reporter should be used", func() {
+		reporter := status.NewBitbucketReporter(log, mockK8sClient)
+		Expect(reporter.Detect(hasSnapshot)).To(BeTrue())
+
+		hasSnapshot.Annotations["pac.test.appstudio.openshift.io/git-provider"] = "bitbucket-server"
+		Expect(reporter.Detect(hasSnapshot)).To(BeTrue())
+
+		hasSnapshot.Annotations["pac.test.appstudio.openshift.io/git-provider"] = "bitbucket-cloud"
+		Expect(reporter.Detect(hasSnapshot)).To(BeFalse())
+
+		hasSnapshot.Labels["pac.test.appstudio.openshift.io/git-provider"] = "bitbucket-datacenter"
+		Expect(reporter.Detect(hasSnapshot)).To(BeTrue())
+	})
+
+	DescribeTable("parses the Bitbucket repository URL",
+		func(repoUrl, expectedAPIURL, expectedProjectKey, expectedRepoSlug string) {
+			apiURL, projectKey, repoSlug, err := status.ParseBitbucketRepoURL(repoUrl)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiURL).To(Equal(expectedAPIURL))
+			Expect(projectKey).To(Equal(expectedProjectKey))
+			Expect(repoSlug).To(Equal(expectedRepoSlug))
+		},
+		Entry("browse URL", "https://bitbucket.example.com/projects/PROJ/repos/example", "https://bitbucket.example.com", "PROJ", "example"),
+		Entry("browse URL with trailing path", "https://bitbucket.example.com/projects/PROJ/repos/example/browse", "https://bitbucket.example.com", "PROJ", "example"),
+		Entry("browse URL with context path", "https://example.com/bitbucket/projects/PROJ/repos/example", "https://example.com/bitbucket", "PROJ", "example"),
+		Entry("clone URL", "https://bitbucket.example.com/scm/proj/example.git", "https://bitbucket.example.com", "proj", "example"),
+		Entry("clone URL with context path", "https://example.com/bitbucket/scm/proj/example", "https://example.com/bitbucket", "proj", "example"),
+	)
+
+	It("fails to parse URLs without project key and repository slug", func() {
+		_, _, _, err := status.ParseBitbucketRepoURL("https://bitbucket.example.com/PROJ/example")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when provided Bitbucket credentials", func() {
+		var (
+			repoCR       pacv1alpha1.Repository
+			reporter     *status.BitbucketReporter
+			mux          *http.ServeMux
+			server       *httptest.Server
+			savedBackoff wait.Backoff
+			prPath       = fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%s", contextPath, projectKey, repoSlug, pullRequest)
+			statusPath   = fmt.Sprintf("%s/rest/build-status/1.0/commits/%s", contextPath, digest)
+		)
+
+		BeforeEach(func() {
+			buf.Reset()
+
+			savedBackoff = status.GetReporterRetryBackoff()
+			status.SetReporterRetryBackoff(wait.Backoff{
+				Steps:    5,
+				Duration: 1 * time.Millisecond,
+				Factor:   1.0,
+				Jitter:   0.0,
+			})
+
+			mux = http.NewServeMux()
+			server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer example-personal-access-token" {
+					rw.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				mux.ServeHTTP(rw, r)
+			}))
+
+			mockRepoURL := fmt.Sprintf("%s%s/projects/%s/repos/%s", server.URL, contextPath, projectKey, repoSlug)
+			hasSnapshot.Annotations[gitops.PipelineAsCodeRepoURLAnnotation] = mockRepoURL
+
+			repoCR = pacv1alpha1.Repository{
+				Spec: pacv1alpha1.RepositorySpec{
+					URL: mockRepoURL,
+					GitProvider: &pacv1alpha1.GitProvider{
+						Secret: &pacv1alpha1.Secret{
+							Name: "example-secret-name",
+							Key:  "example-token",
+						},
+					},
+				},
+			}
+
+			mockK8sClient = &MockK8sClient{
+				getInterceptor: func(key client.ObjectKey, obj client.Object) {
+					if secret, ok := obj.(*v1.Secret); ok {
+						secret.Data = map[string][]byte{
+							"example-token": []byte("example-personal-access-token"),
+						}
+					}
+				},
+				listInterceptor: func(list client.ObjectList) {
+					if repoList, ok := list.(*pacv1alpha1.RepositoryList); ok {
+						repoList.Items = []pacv1alpha1.Repository{repoCR}
+					}
+				},
+			}
+
+			reporter = status.NewBitbucketReporter(log, mockK8sClient)
+			statusCode, err := reporter.Initialize(context.TODO(), hasSnapshot)
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusOK))
+		})
+
+		AfterEach(func() {
+			server.Close()
+			status.SetReporterRetryBackoff(savedBackoff)
+		})
+
+		DescribeTable("test handling of missing labels/annotations", func(missingKey string, isLabel bool) {
+			testSnapshot := hasSnapshot.DeepCopy()
+			if isLabel {
+				delete(testSnapshot.Labels, missingKey)
+			} else {
+				delete(testSnapshot.Annotations, missingKey)
+			}
+			testReporter := status.NewBitbucketReporter(log, mockK8sClient)
+			statusCode, err := testReporter.Initialize(context.TODO(), testSnapshot)
+			Expect(err).ToNot(Succeed())
+			Expect(statusCode).To(Equal(0))
+		},
+			Entry("Missing repo_url", gitops.PipelineAsCodeRepoURLAnnotation, false),
+			Entry("Missing SHA", gitops.PipelineAsCodeSHALabel, true),
+		)
+
+		It("sends valid build status payload to the API", func() {
+			summary := "Integration test for component component-sample snapshot snapshot-sample and scenario scenario1 passed"
+			mux.HandleFunc(statusPath, func(rw http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPost))
+				buildStatus := bitbucket.BuildStatus{}
+				Expect(json.NewDecoder(r.Body).Decode(&buildStatus)).To(Succeed())
+				Expect(buildStatus.State).To(Equal(bitbucket.BuildStateSuccessful))
+				Expect(buildStatus.Key).To(Equal("fullname/scenario1"))
+				Expect(buildStatus.Description).To(Equal(summary))
+				Expect(buildStatus.URL).To(Equal(hasSnapshot.Annotations[gitops.PipelineAsCodeRepoURLAnnotation]))
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			statusCode, err := reporter.ReportStatus(context.TODO(), status.TestReport{
+				FullName:     "fullname/scenario1",
+				ScenarioName: "scenario1",
+				Status:       integrationteststatus.IntegrationTestStatusTestPassed,
+				Summary:      summary,
+			})
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusNoContent))
+		})
+
+		It("does not update a build status which is already in progress with the same description", func() {
+			var posted int32
+			mux.HandleFunc(statusPath, func(rw http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					atomic.AddInt32(&posted, 1)
+					rw.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(rw, `{"values":[{"state":"INPROGRESS","key":"fullname/scenario1","description":"pending"}],"isLastPage":true}`)
+			})
+
+			report := status.TestReport{
+				FullName:     "fullname/scenario1",
+				ScenarioName: "scenario1",
+				Status:       integrationteststatus.IntegrationTestStatusPending,
+				Summary:      "pending",
+			}
+			statusCode, err := reporter.ReportStatus(context.TODO(), report)
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(atomic.LoadInt32(&posted)).To(BeZero())
+
+			report.Summary = "in progress"
+			_, err = reporter.ReportStatus(context.TODO(), report)
+			Expect(err).To(Succeed())
+			Expect(atomic.LoadInt32(&posted)).To(BeNumerically("==", 1))
+		})
+
+		It("updates the first matching comment and deletes the others", func() {
+			commentPrefix := status.GenerateTestSummaryPrefixForComponent("component-sample")
+			var deleted, updated int32
+			mux.HandleFunc(prPath+"/activities", func(rw http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(rw, `{"values":[
+					{"action":"COMMENTED","comment":{"id":1,"version":2,"text":%q}},
+					{"action":"COMMENTED","comment":{"id":2,"version":0,"text":"unrelated"}},
+					{"action":"COMMENTED","comment":{"id":3,"version":1,"text":%q}}
+				],"isLastPage":true}`, commentPrefix+" old", commentPrefix+" older")
+			})
+			mux.HandleFunc(prPath+"/comments/1", func(rw http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPut))
+				comment := bitbucket.Comment{}
+				Expect(json.NewDecoder(r.Body).Decode(&comment)).To(Succeed())
+				Expect(comment.Version).To(Equal(2))
+				Expect(comment.Text).To(Equal(commentPrefix + " new"))
+				atomic.AddInt32(&updated, 1)
+				fmt.Fprint(rw, `{"id":1,"version":3}`)
+			})
+			mux.HandleFunc(prPath+"/comments/3", func(rw http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodDelete))
+				Expect(r.URL.Query().Get("version")).To(Equal("1"))
+				atomic.AddInt32(&deleted, 1)
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			statusCode, err := reporter.UpdateStatusInComment(commentPrefix, commentPrefix+" new", true)
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(atomic.LoadInt32(&updated)).To(BeNumerically("==", 1))
+			Expect(atomic.LoadInt32(&deleted)).To(BeNumerically("==", 1))
+		})
+
+		It("creates a new comment when there is no matching comment", func() {
+			commentPrefix := status.GenerateTestSummaryPrefixForComponent("component-sample")
+			commentText, err := status.GenerateSummaryForAllScenarios(integrationteststatus.SnapshotCreationFailed, "component-sample")
+			Expect(err).To(Succeed())
+			mux.HandleFunc(prPath+"/activities", func(rw http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(rw, `{"values":[],"isLastPage":true}`)
+			})
+			mux.HandleFunc(prPath+"/comments", func(rw http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPost))
+				comment := bitbucket.Comment{}
+				Expect(json.NewDecoder(r.Body).Decode(&comment)).To(Succeed())
+				Expect(comment.Text).To(Equal(commentText))
+				rw.WriteHeader(http.StatusCreated)
+				fmt.Fprint(rw, `{"id":4,"version":0}`)
+			})
+
+			statusCode, err := reporter.UpdateStatusInComment(commentPrefix, commentText, false)
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusCreated))
+		})
+
+		It("checks whether the pull request is open", func() {
+			state := "OPEN"
+			mux.HandleFunc(prPath, func(rw http.ResponseWriter, r *http.Request) {
+				if state == "" {
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(rw, `{"id":45,"state":%q}`, state)
+			})
+
+			isOpen, statusCode, err := reporter.IsPullRequestOpen(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(isOpen).To(BeTrue())
+
+			state = "MERGED"
+			isOpen, _, err = reporter.IsPullRequestOpen(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isOpen).To(BeFalse())
+
+			state = ""
+			isOpen, statusCode, err = reporter.IsPullRequestOpen(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusNotFound))
+			Expect(isOpen).To(BeFalse())
+		})
+
+		It("retries on transient 500 error and succeeds on retry", func() {
+			var callCount int32
+			mux.HandleFunc(statusPath, func(rw http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&callCount, 1) == 1 {
+					rw.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				rw.WriteHeader(http.StatusNoContent)
+			})
+
+			statusCode, err := reporter.ReportStatus(context.TODO(), status.TestReport{
+				FullName:     "fullname/scenario1",
+				ScenarioName: "scenario1",
+				Status:       integrationteststatus.IntegrationTestStatusTestFail,
+				Summary:      "test failed",
+			})
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusNoContent))
+			Expect(atomic.LoadInt32(&callCount)).To(BeNumerically("==", 2))
+			Expect(buf.String()).To(ContainSubstring("retrying to set bitbucket build status after transient error"))
+		})
+
+		It("does not retry on 401 Unauthorized", func() {
+			secretlessReporter := status.NewBitbucketReporter(log, &MockK8sClient{
+				getInterceptor: func(key client.ObjectKey, obj client.Object) {
+					if secret, ok := obj.(*v1.Secret); ok {
+						secret.Data = map[string][]byte{"example-token": []byte("wrong-token")}
+					}
+				},
+				listInterceptor: mockK8sClient.listInterceptor,
+			})
+			_, err := secretlessReporter.Initialize(context.TODO(), hasSnapshot)
+			Expect(err).To(Succeed())
+
+			statusCode, err := secretlessReporter.ReportStatus(context.TODO(), status.TestReport{
+				FullName:     "fullname/scenario1",
+				ScenarioName: "scenario1",
+				Status:       integrationteststatus.IntegrationTestStatusTestPassed,
+				Summary:      "test passed",
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusUnauthorized))
+			Expect(buf.String()).NotTo(ContainSubstring("retrying to set bitbucket build status"))
+		})
+	})
+
+	Describe("Test helper functions", func() {
+		DescribeTable(
+			"reports correct bitbucket build states from test statuses",
+			func(teststatus integrationteststatus.IntegrationTestStatus, bbState string) {
+				state, err := status.GenerateBitbucketBuildState(teststatus, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(bbState))
+			},
+			Entry("Deleted", integrationteststatus.IntegrationTestStatusDeleted, bitbucket.BuildStateFailed),
+			Entry("Success", integrationteststatus.IntegrationTestStatusTestPassed, bitbucket.BuildStateSuccessful),
+			Entry("Test failure", integrationteststatus.IntegrationTestStatusTestFail, bitbucket.BuildStateFailed),
+			Entry("In progress", integrationteststatus.IntegrationTestStatusInProgress, bitbucket.BuildStateInProgress),
+			Entry("Pending", integrationteststatus.IntegrationTestStatusPending, bitbucket.BuildStateInProgress),
+			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, bitbucket.BuildStateFailed),
+			Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, bitbucket.BuildStateSuccessful),
+			Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, bitbucket.BuildStateFailed),
+			Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, bitbucket.BuildStateInProgress),
+			Entry("BuildPLRFailed", integrationteststatus.BuildPLRFailed, bitbucket.BuildStateFailed),
+			Entry("SnapshotCreationFailed", integrationteststatus.SnapshotCreationFailed, bitbucket.BuildStateFailed),
+		)
+
+		It("check if all integration tests statuses are supported", func() {
+			for _, teststatus := range integrationteststatus.IntegrationTestStatusValues() {
+				_, err := status.GenerateBitbucketBuildState(teststatus, false)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("check if optional integration test failures don't fail the build status", func() {
+			state, err := status.GenerateBitbucketBuildState(integrationteststatus.IntegrationTestStatusTestFail, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal(bitbucket.BuildStateSuccessful))
+		})
+	})
+})
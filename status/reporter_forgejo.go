@@ -187,15 +187,22 @@ func (r *ForgejoReporter) setCommitStatus(report TestReport) (int, error) {
 		return statusCode, fmt.Errorf("could not determine whether scenario %s is optional: %w", report.ScenarioName, err)
 	}
 	optional := helpers.IsIntegrationTestScenarioOptional(scenario)
-	fjState, err := GenerateForgejoCommitState(report.Status, optional)
+	// quarantined scenarios are reported like optional ones since they don't block the Snapshot either
+	quarantined := helpers.IsIntegrationTestScenarioQuarantined(scenario)
+	fjState, err := GenerateForgejoCommitState(report.Status, optional || quarantined)
 	if err != nil {
 		return statusCode, fmt.Errorf("failed to generate forgejo state: %w", err)
 	}
 
+	description := report.GetDescription()
+	if quarantined {
+		description = GenerateQuarantinedSummary(description, scenario)
+	}
+
 	statusOpt := forgejo.CreateStatusOption{
 		State:       forgejo.StatusState(fjState),
 		TargetURL:   "",
-		Description: report.Summary,
+		Description: description,
 		Context:     report.FullName,
 	}
 
@@ -424,13 +431,13 @@ func GenerateForgejoCommitState(state intgteststat.IntegrationTestStatus, option
 	fjState := "error"
 
 	switch state {
-	case intgteststat.IntegrationTestStatusPending, intgteststat.BuildPLRInProgress:
+	case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusBlocked, intgteststat.BuildPLRInProgress:
 		fjState = "pending"
 	case intgteststat.IntegrationTestStatusInProgress:
 		fjState = "pending" // Forgejo uses "pending" for in-progress statuses
 	case intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated,
 		intgteststat.IntegrationTestStatusDeploymentError_Deprecated,
-		intgteststat.IntegrationTestStatusTestInvalid:
+		intgteststat.IntegrationTestStatusTestInvalid, intgteststat.IntegrationTestStatusTestSkipped:
 		if optional {
 			fjState = "success" // Forgejo doesn't have "skipped"; optional/skipped tests are not a failure
 			break
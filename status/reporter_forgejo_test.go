@@ -569,6 +569,8 @@ var _ = Describe("ForgejoReporter", func() {
 			Entry("Pending", integrationteststatus.IntegrationTestStatusPending, "pending"),
 			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, "error"),
 			Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, "warning"),
+			Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, "error"),
+			Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, "pending"),
 			Entry("BuildPLRInProgress", integrationteststatus.BuildPLRInProgress, "pending"),
 			Entry("BuildPLRFailed", integrationteststatus.BuildPLRFailed, "error"),
 			Entry("SnapshotCreationFailed", integrationteststatus.SnapshotCreationFailed, "error"),
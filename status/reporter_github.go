@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ghapi "github.com/google/go-github/v45/github"
@@ -195,8 +196,10 @@ func (cru *CheckRunStatusUpdater) createCheckRunAdapterForSnapshot(report TestRe
 		return nil, fmt.Errorf("could not determine whether scenario %s is optional", report.ScenarioName)
 	}
 	optional := helpers.IsIntegrationTestScenarioOptional(scenario)
+	// quarantined scenarios are reported like optional ones since they don't block the Snapshot either
+	quarantined := helpers.IsIntegrationTestScenarioQuarantined(scenario)
 
-	conclusion, err := GenerateCheckRunConclusion(report.Status, optional)
+	conclusion, err := GenerateCheckRunConclusion(report.Status, optional || quarantined)
 	if err != nil {
 		cru.logger.Error(err, fmt.Sprintf("failed to generate conclusion for integrationTestScenario %s and snapshot %s/%s", report.ScenarioName, snapshot.Namespace, snapshot.Name))
 		return nil, fmt.Errorf("unknown status %s for integrationTestScenario %s and snapshot %s/%s", report.Status, report.ScenarioName, snapshot.Namespace, snapshot.Name)
@@ -208,6 +211,12 @@ func (cru *CheckRunStatusUpdater) createCheckRunAdapterForSnapshot(report TestRe
 		return nil, fmt.Errorf("failed to generate title for integrationTestScenario %s and snapshot %s/%s", report.ScenarioName, snapshot.Namespace, snapshot.Name)
 	}
 
+	summary := report.Summary
+	if quarantined {
+		title = fmt.Sprintf("%s (quarantined)", title)
+		summary = GenerateQuarantinedSummary(summary, scenario)
+	}
+
 	externalID := report.ScenarioName
 	if report.ComponentName != "" {
 		externalID = fmt.Sprintf("%s-%s", report.ScenarioName, report.ComponentName)
@@ -220,16 +229,17 @@ func (cru *CheckRunStatusUpdater) createCheckRunAdapterForSnapshot(report TestRe
 	}
 
 	cra := &github.CheckRunAdapter{
-		Owner:      cru.owner,
-		Repository: cru.repo,
-		Name:       report.FullName,
-		SHA:        cru.sha,
-		ExternalID: externalID,
-		Conclusion: conclusion,
-		Title:      title,
-		Summary:    report.Summary,
-		Text:       report.Text,
-		DetailsURL: detailsURL,
+		Owner:       cru.owner,
+		Repository:  cru.repo,
+		Name:        report.FullName,
+		SHA:         cru.sha,
+		ExternalID:  externalID,
+		Conclusion:  conclusion,
+		Title:       title,
+		Summary:     summary,
+		Text:        report.GetCheckRunText(),
+		DetailsURL:  detailsURL,
+		Annotations: generateCheckRunAnnotations(report.Findings),
 	}
 
 	if start := report.StartTime; start != nil {
@@ -240,9 +250,43 @@ func (cru *CheckRunStatusUpdater) createCheckRunAdapterForSnapshot(report TestRe
 		cra.CompletionTime = *complete
 	}
 
+	// finished tests can be re-run from the CheckRun, the requested action is handled by the GitHub events endpoint
+	if report.Status.IsFinal() {
+		cra.Actions = []*ghapi.CheckRunAction{
+			{
+				Label:       "Re-run",
+				Description: "Re-run this integration test",
+				Identifier:  github.RerunActionIdentifier,
+			},
+		}
+	}
+
 	return cra, nil
 }
 
+// generateCheckRunAnnotations converts the findings of the test into CheckRun annotations
+// https://docs.github.com/en/rest/checks/runs?apiVersion=2022-11-28#update-a-check-run
+func generateCheckRunAnnotations(findings []helpers.Finding) []*ghapi.CheckRunAnnotation {
+	if len(findings) == 0 {
+		return nil
+	}
+	annotations := make([]*ghapi.CheckRunAnnotation, 0, len(findings))
+	for _, finding := range findings {
+		annotation := &ghapi.CheckRunAnnotation{
+			Path:            ghapi.String(finding.Path),
+			StartLine:       ghapi.Int(finding.StartLine),
+			EndLine:         ghapi.Int(finding.EndLine),
+			AnnotationLevel: ghapi.String(finding.Level),
+			Message:         ghapi.String(finding.Message),
+		}
+		if finding.Title != "" {
+			annotation.Title = ghapi.String(finding.Title)
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations
+}
+
 // UpdateStatus updates CheckRun status of PR
 func (cru *CheckRunStatusUpdater) UpdateStatus(ctx context.Context, report TestReport) (int, error) {
 	if cru.creds == nil {
@@ -292,6 +336,8 @@ func (cru *CheckRunStatusUpdater) UpdateStatus(ctx context.Context, report TestR
 		return statusCode, err
 	}
 
+	// a previous update may have already delivered some of the annotations, e.g. before failing on a later batch
+	checkRunAdapter.DeliveredAnnotations = existingCheckrun.GetOutput().GetAnnotationsCount()
 	statusCode, err = cru.ghClient.UpdateCheckRun(ctx, *existingCheckrun.ID, checkRunAdapter)
 	if err != nil {
 		cru.logger.Error(err, "failed to update checkrun",
@@ -383,7 +429,7 @@ func (csu *CommitStatusUpdater) createCommitStatusAdapterForSnapshot(report Test
 		Repository:  csu.repo,
 		SHA:         csu.sha,
 		State:       state,
-		Description: report.Summary,
+		Description: report.GetDescription(),
 		Context:     report.FullName,
 		TargetURL:   targetURL,
 	}, nil
@@ -406,7 +452,7 @@ func (csu *CommitStatusUpdater) updateStatusInComment(ctx context.Context, repor
 		return 0, unRecoverableError
 	}
 
-	comment, err := FormatComment(report.Summary, report.Text)
+	comment, err := FormatTestReportComment(report)
 	if err != nil {
 		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to format comment for pull-request %d: %s", issueNumber, err.Error()))
 		csu.logger.Error(unRecoverableError, "snapshot.Name", report.SnapshotName)
@@ -482,7 +528,7 @@ func (csu *CommitStatusUpdater) UpdateStatus(ctx context.Context, report TestRep
 	}
 	// Create a comment when integration test is neither pending nor inprogress since comment for pending/inprogress is less meaningful and there is commitStatus for all statuses
 	_, isPullRequest := csu.snapshot.GetAnnotations()[gitops.PipelineAsCodePullRequestAnnotation]
-	if isPullRequest {
+	if isPullRequest && !report.SkipComment {
 		statusCode, err := csu.updateStatusInComment(ctx, report)
 		if err != nil {
 			csu.logger.Error(err, "failed to update comment", "snapshot.NameSpace", csu.snapshot.Namespace, "snapshot.Name", csu.snapshot.Name, "scenarioName", report.ScenarioName)
@@ -499,6 +545,9 @@ type GitHubReporter struct {
 	k8sClient client.Client
 	client    github.ClientInterface
 	updater   StatusUpdater
+	owner     string
+	repo      string
+	snapshot  *applicationapiv1alpha1.Snapshot
 }
 
 // check if interface has been correctly implemented
@@ -553,6 +602,10 @@ func generateCheckRunTitle(state intgteststat.IntegrationTestStatus) (string, er
 		title = "Succeeded"
 	case intgteststat.IntegrationTestStatusTestWarning:
 		title = "Warning"
+	case intgteststat.IntegrationTestStatusTestSkipped:
+		title = "Skipped"
+	case intgteststat.IntegrationTestStatusBlocked:
+		title = "Blocked"
 	case intgteststat.IntegrationTestStatusTestFail,
 		intgteststat.SnapshotCreationFailed,
 		intgteststat.BuildPLRFailed,
@@ -583,12 +636,19 @@ func GenerateCheckRunConclusion(state intgteststat.IntegrationTestStatus, option
 	case intgteststat.IntegrationTestStatusTestPassed:
 		conclusion = gitops.IntegrationTestStatusSuccessGithub
 	case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusInProgress,
-		intgteststat.BuildPLRInProgress:
+		intgteststat.IntegrationTestStatusBlocked, intgteststat.BuildPLRInProgress:
 		conclusion = ""
 	case intgteststat.SnapshotCreationFailed, intgteststat.BuildPLRFailed, intgteststat.GroupSnapshotCreationFailed:
 		conclusion = gitops.IntegrationTestStatusCancelledGithub
 	case intgteststat.IntegrationTestStatusTestWarning:
 		conclusion = gitops.IntegrationTestStatusNeutralGithub
+	case intgteststat.IntegrationTestStatusTestSkipped:
+		if optional {
+			conclusion = gitops.IntegrationTestStatusSkippedGithub
+			break
+		}
+		// a skipped required test can't be considered as passed
+		conclusion = gitops.IntegrationTestStatusFailureGithub
 	default:
 		return conclusion, fmt.Errorf("unknown status")
 	}
@@ -607,12 +667,13 @@ func generateGithubCommitState(state intgteststat.IntegrationTestStatus) (string
 		intgteststat.BuildPLRFailed, intgteststat.GroupSnapshotCreationFailed:
 		commitState = gitops.IntegrationTestStatusFailureGithub
 	case intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated, intgteststat.IntegrationTestStatusDeploymentError_Deprecated,
-		intgteststat.IntegrationTestStatusDeleted, intgteststat.IntegrationTestStatusTestInvalid:
+		intgteststat.IntegrationTestStatusDeleted, intgteststat.IntegrationTestStatusTestInvalid,
+		intgteststat.IntegrationTestStatusTestSkipped:
 		commitState = gitops.IntegrationTestStatusErrorGithub
 	case intgteststat.IntegrationTestStatusTestPassed:
 		commitState = gitops.IntegrationTestStatusSuccessGithub
 	case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusInProgress,
-		intgteststat.BuildPLRInProgress:
+		intgteststat.IntegrationTestStatusBlocked, intgteststat.BuildPLRInProgress:
 		commitState = gitops.IntegrationTestStatusPendingGithub
 	case intgteststat.IntegrationTestStatusTestWarning:
 		commitState = gitops.IntegrationTestStatusSuccessGithub
@@ -629,9 +690,50 @@ func (r *GitHubReporter) Detect(snapshot *applicationapiv1alpha1.Snapshot) bool
 		metadata.HasLabelWithValue(snapshot, gitops.PipelineAsCodeGitProviderLabel, gitops.PipelineAsCodeGitHubProviderType)
 }
 
-// blank implementation to satisfy ReporterInterface
-func (r *GitHubReporter) UpdateStatusInComment(arg0, arg1 string, arg2 bool) (int, error) {
-	return http.StatusCreated, nil
+// UpdateStatusInComment creates the comment with the given text in the pull request the snapshot was built from,
+// or edits the existing comment containing the given prefix in place
+func (r *GitHubReporter) UpdateStatusInComment(commentPrefix, comment string, isFinalStatus bool) (int, error) {
+	ctx := context.Background()
+	if r.snapshot == nil {
+		r.logger.Error(nil, "reporter is not initialized")
+		return 0, fmt.Errorf("reporter is not initialized")
+	}
+
+	issueNumberStr, found := r.snapshot.GetAnnotations()[gitops.PipelineAsCodePullRequestAnnotation]
+	if !found {
+		unRecoverableError := helpers.NewUnrecoverableMetadataError(fmt.Sprintf("pull-request annotation not found %q", gitops.PipelineAsCodePullRequestAnnotation))
+		r.logger.Error(unRecoverableError, "snapshot.Name", r.snapshot.Name)
+		return 0, unRecoverableError
+	}
+	issueNumber, err := strconv.Atoi(issueNumberStr)
+	if err != nil {
+		unRecoverableError := helpers.NewUnrecoverableMetadataError(fmt.Sprintf("failed to convert string issueNumberStr %s to int：%s", issueNumberStr, err.Error()))
+		r.logger.Error(unRecoverableError, "snapshot.Name", r.snapshot.Name)
+		return 0, unRecoverableError
+	}
+
+	allComments, statusCode, err := r.client.GetAllCommentsForPR(ctx, r.owner, r.repo, issueNumber)
+	if err != nil {
+		r.logger.Error(err, fmt.Sprintf("error while getting all comments for pull-request %s", issueNumberStr))
+		return statusCode, fmt.Errorf("error while getting all comments for pull-request %s: %w", issueNumberStr, err)
+	}
+	for _, existingComment := range allComments {
+		if existingComment.Body != nil && existingComment.ID != nil && strings.Contains(*existingComment.Body, commentPrefix) {
+			_, statusCode, err = r.client.EditComment(ctx, r.owner, r.repo, *existingComment.ID, comment)
+			if err != nil {
+				r.logger.Error(err, fmt.Sprintf("error while updating comment for pull-request %s", issueNumberStr))
+				return statusCode, fmt.Errorf("error while updating comment for pull-request %s: %w", issueNumberStr, err)
+			}
+			return statusCode, nil
+		}
+	}
+
+	_, statusCode, err = r.client.CreateComment(ctx, r.owner, r.repo, issueNumber, comment)
+	if err != nil {
+		r.logger.Error(err, fmt.Sprintf("error while creating comment for pull-request %s", issueNumberStr))
+		return statusCode, fmt.Errorf("error while creating comment for pull-request %s: %w", issueNumberStr, err)
+	}
+	return statusCode, nil
 }
 
 // Initialize github reporter. Must be called before updating status
@@ -660,6 +762,10 @@ func (r *GitHubReporter) Initialize(ctx context.Context, snapshot *applicationap
 		return 0, unRecoverableError
 	}
 
+	r.owner = owner
+	r.repo = repo
+	r.snapshot = snapshot
+
 	// Existence of the Pipelines as Code installation ID annotation signals configuration using GitHub App integration.
 	// If it doesn't exist, GitHub webhook integration is configured.
 	if metadata.HasAnnotation(snapshot, gitops.PipelineAsCodeInstallationIDAnnotation) {
@@ -37,8 +37,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/konflux-ci/integration-service/api/v1beta2"
 	"github.com/konflux-ci/integration-service/git/github"
 	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/helpers"
 	"github.com/konflux-ci/integration-service/pkg/integrationteststatus"
 	"github.com/konflux-ci/integration-service/status"
 )
@@ -99,6 +101,7 @@ type MockGitHubClient struct {
 	CreateCommentResult
 	CreateCommitStatusResult
 	EditCommentResult
+	AllComments []*ghapi.IssueComment
 }
 
 func (c *MockGitHubClient) CreateAppInstallationToken(ctx context.Context, appID int64, installationID int64, privateKey []byte) (string, int, error) {
@@ -151,6 +154,9 @@ func (c *MockGitHubClient) EditComment(ctx context.Context, owner string, repo s
 }
 
 func (c *MockGitHubClient) GetAllCommentsForPR(ctx context.Context, owner string, repo string, pr int) ([]*ghapi.IssueComment, int, error) {
+	if c.AllComments != nil {
+		return c.AllComments, 200, nil
+	}
 	var id int64 = 20
 	comments := []*ghapi.IssueComment{{ID: &id}}
 	return comments, 200, nil
@@ -197,6 +203,10 @@ func (c *MockGitHubClient) GetPullRequest(ctx context.Context, owner string, rep
 	return pullRequest, 200, nil
 }
 
+func (c *MockGitHubClient) GetUserPermission(ctx context.Context, owner string, repo string, user string) (string, int, error) {
+	return "write", 200, nil
+}
+
 // MockStatusUpdater implements StatusUpdater for testing retry behavior.
 // statusCodes and errors define the return values for each call.
 // If callCount exceeds the slice length, the last element is reused.
@@ -386,6 +396,8 @@ var _ = Describe("GitHubReporter", func() {
 			Entry("Pending", integrationteststatus.IntegrationTestStatusPending, "Pending", ""),
 			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, "Errored", gitops.IntegrationTestStatusFailureGithub),
 			Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, "Warning", gitops.IntegrationTestStatusNeutralGithub),
+			Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, "Skipped", gitops.IntegrationTestStatusFailureGithub),
+			Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, "Blocked", ""),
 			Entry("BuildPLRInProgress", integrationteststatus.IntegrationTestStatusPending, "Pending", ""),
 			Entry("BuildPLRFailed", integrationteststatus.IntegrationTestStatusTestFail, "Failed", gitops.IntegrationTestStatusFailureGithub),
 			Entry("SnapshotCreationFailed", integrationteststatus.IntegrationTestStatusTestFail, "Failed", gitops.IntegrationTestStatusFailureGithub),
@@ -434,6 +446,75 @@ var _ = Describe("GitHubReporter", func() {
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.Name).To(Equal("test-name"))
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.StartTime.IsZero()).To(BeFalse())
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.CompletionTime.IsZero()).To(BeFalse())
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Actions).To(HaveLen(1))
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Actions[0].Identifier).To(Equal(github.RerunActionIdentifier))
+		})
+
+		It("reports the findings of the test as CheckRun annotations", func() {
+			now := time.Now()
+
+			statusCode, err := reporter.ReportStatus(
+				context.TODO(),
+				status.TestReport{
+					FullName:       "test-name",
+					ScenarioName:   "scenario1",
+					SnapshotName:   "snapshot-sample",
+					ComponentName:  "component-sample",
+					Status:         integrationteststatus.IntegrationTestStatusTestFail,
+					Summary:        "Integration test for snapshot snapshot-sample and scenario scenario1 has failed",
+					StartTime:      &now,
+					CompletionTime: &now,
+					Findings: []helpers.Finding{
+						{Path: "main.go", StartLine: 10, EndLine: 12, Level: helpers.FindingLevelFailure, Title: "gosec: G101", Message: "Potential hardcoded credentials"},
+						{Path: "README.md", StartLine: 1, EndLine: 1, Level: helpers.FindingLevelNotice, Message: "Line too long"},
+					},
+				})
+
+			Expect(err).To(Succeed(), "ReportStatus should succeed")
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(mockGitHubClient.CreateCheckRunResult.cra).NotTo(BeNil())
+			annotations := mockGitHubClient.CreateCheckRunResult.cra.Annotations
+			Expect(annotations).To(HaveLen(2))
+			Expect(annotations[0].GetPath()).To(Equal("main.go"))
+			Expect(annotations[0].GetStartLine()).To(Equal(10))
+			Expect(annotations[0].GetEndLine()).To(Equal(12))
+			Expect(annotations[0].GetAnnotationLevel()).To(Equal("failure"))
+			Expect(annotations[0].GetTitle()).To(Equal("gosec: G101"))
+			Expect(annotations[0].GetMessage()).To(Equal("Potential hardcoded credentials"))
+			Expect(annotations[1].Title).To(BeNil())
+			Expect(annotations[1].GetAnnotationLevel()).To(Equal("notice"))
+		})
+
+		It("reports the status of a quarantined scenario as non-blocking via CheckRuns", func() {
+			expiresAt := time.Now().Add(time.Hour)
+			mockK8sClient.getInterceptor = func(key client.ObjectKey, obj client.Object) {
+				if scenario, ok := obj.(*v1beta2.IntegrationTestScenario); ok {
+					scenario.Spec.Quarantine = &v1beta2.Quarantine{
+						Reason:    "flaky test",
+						ExpiresAt: metav1.NewTime(expiresAt),
+					}
+				}
+			}
+
+			statusCode, err := reporter.ReportStatus(
+				context.TODO(),
+				status.TestReport{
+					FullName:      "test-name",
+					ScenarioName:  "scenario1",
+					SnapshotName:  "snapshot-sample",
+					ComponentName: "component-sample",
+					Status:        integrationteststatus.IntegrationTestStatusTestFail,
+					Summary:       "Integration test for snapshot snapshot-sample and scenario scenario1 has failed",
+				})
+
+			Expect(err).To(Succeed(), "ReportStatus should succeed")
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(mockGitHubClient.CreateCheckRunResult.cra).NotTo(BeNil())
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Conclusion).To(Equal(gitops.IntegrationTestStatusNeutralGithub))
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Title).To(Equal("Failed (quarantined)"))
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Summary).To(Equal(fmt.Sprintf(
+				"Integration test for snapshot snapshot-sample and scenario scenario1 has failed, the scenario is quarantined until %s: flaky test",
+				expiresAt.UTC().Format(time.RFC3339))))
 		})
 
 		It("reports all details of snapshot tests status via CheckRuns for a Snapshot without a component", func() {
@@ -535,6 +616,7 @@ var _ = Describe("GitHubReporter", func() {
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.Conclusion).To(Equal(""))
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.ExternalID).To(Equal("scenario1-component-sample"))
 			Expect(mockGitHubClient.CreateCheckRunResult.cra.CompletionTime.IsZero()).To(BeTrue())
+			Expect(mockGitHubClient.CreateCheckRunResult.cra.Actions).To(BeEmpty())
 		})
 	})
 
@@ -609,6 +691,47 @@ var _ = Describe("GitHubReporter", func() {
 			Expect(mockGitHubClient.CreateCommentResult.body).To(Equal("### Integration test for snapshot snapshot-sample and scenario scenario1 failed\n\ndetailed text here"))
 		})
 
+		It("creates a commit status but does not create a comment when the status is reported in the summary comment", func() {
+			statusCode, err := reporter.ReportStatus(
+				context.TODO(),
+				status.TestReport{
+					FullName:      "fullname/scenario1",
+					ScenarioName:  "scenario1",
+					SnapshotName:  "snapshot-sample",
+					ComponentName: "component-sample",
+					Status:        integrationteststatus.IntegrationTestStatusTestFail,
+					Summary:       "Integration test for snapshot snapshot-sample and scenario scenario1 failed",
+					Text:          "detailed text here",
+					SkipComment:   true,
+				})
+
+			Expect(err).To(Succeed())
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(mockGitHubClient.CreateCommitStatusResult.state).To(Equal(gitops.IntegrationTestStatusFailureGithub))
+			Expect(mockGitHubClient.CreateCommentResult.body).To(BeEmpty())
+		})
+
+		It("creates the summary comment in the pull request", func() {
+			_, err := reporter.UpdateStatusInComment(status.SummaryCommentTitle, "### Integration test summary\n\nsummary", true)
+			Expect(err).To(Succeed())
+			Expect(mockGitHubClient.CreateCommentResult.issueNumber).To(Equal(999))
+			Expect(mockGitHubClient.CreateCommentResult.body).To(Equal("### Integration test summary\n\nsummary"))
+			Expect(mockGitHubClient.EditCommentResult.body).To(BeEmpty())
+		})
+
+		It("edits the existing summary comment in place", func() {
+			var otherID, summaryID int64 = 20, 21
+			otherBody := "### Integration test for component component-sample"
+			summaryBody := "### Integration test summary\n\nold summary"
+			mockGitHubClient.AllComments = []*ghapi.IssueComment{{ID: &otherID, Body: &otherBody}, {ID: &summaryID, Body: &summaryBody}}
+
+			_, err := reporter.UpdateStatusInComment(status.SummaryCommentTitle, "### Integration test summary\n\nnew summary", true)
+			Expect(err).To(Succeed())
+			Expect(mockGitHubClient.EditCommentResult.ID).To(Equal(summaryID))
+			Expect(mockGitHubClient.EditCommentResult.body).To(Equal("### Integration test summary\n\nnew summary"))
+			Expect(mockGitHubClient.CreateCommentResult.body).To(BeEmpty())
+		})
+
 		It("creates a commit status for snapshot with correct textual data, but does not create a comment for push event", func() {
 			delete(hasSnapshot.Annotations, "pac.test.appstudio.openshift.io/pull-request")
 			hasSnapshot.Labels["pac.test.appstudio.openshift.io/event-type"] = "push"
@@ -655,6 +778,8 @@ var _ = Describe("GitHubReporter", func() {
 			Entry("Pending", integrationteststatus.IntegrationTestStatusPending, gitops.IntegrationTestStatusPendingGithub),
 			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, gitops.IntegrationTestStatusErrorGithub),
 			Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, gitops.IntegrationTestStatusSuccessGithub),
+			Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, gitops.IntegrationTestStatusErrorGithub),
+			Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, gitops.IntegrationTestStatusPendingGithub),
 			Entry("BuildPLRInProgress", integrationteststatus.BuildPLRInProgress, gitops.IntegrationTestStatusPendingGithub),
 			Entry("BuildPLRFailed", integrationteststatus.BuildPLRFailed, gitops.IntegrationTestStatusFailureGithub),
 			Entry("SnapshotCreationFailed", integrationteststatus.SnapshotCreationFailed, gitops.IntegrationTestStatusFailureGithub),
@@ -853,5 +978,17 @@ var _ = Describe("GitHubReporter", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(conclusion).To(Equal("failure"))
 		})
+
+		It("Returns 'skipped' when optional tests are skipped", func() {
+			conclusion, err := status.GenerateCheckRunConclusion(integrationteststatus.IntegrationTestStatusTestSkipped, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conclusion).To(Equal("skipped"))
+		})
+
+		It("Returns 'failure' when required tests are skipped", func() {
+			conclusion, err := status.GenerateCheckRunConclusion(integrationteststatus.IntegrationTestStatusTestSkipped, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conclusion).To(Equal("failure"))
+		})
 	})
 })
@@ -174,15 +174,22 @@ func (r *GitLabReporter) setCommitStatus(report TestReport) (int, error) {
 		return 0, fmt.Errorf("could not determine whether scenario %s is optional, %w", report.ScenarioName, err)
 	}
 	optional := helpers.IsIntegrationTestScenarioOptional(scenario)
-	glState, err := GenerateGitlabCommitState(report.Status, optional)
+	// quarantined scenarios are reported like optional ones since they don't block the Snapshot either
+	quarantined := helpers.IsIntegrationTestScenarioQuarantined(scenario)
+	glState, err := GenerateGitlabCommitState(report.Status, optional || quarantined)
 	if err != nil {
 		return 0, fmt.Errorf("failed to generate gitlab state: %w", err)
 	}
 
+	description := report.GetDescription()
+	if quarantined {
+		description = GenerateQuarantinedSummary(description, scenario)
+	}
+
 	opt := gitlab.SetCommitStatusOptions{
 		State:       gitlab.BuildStateValue(glState),
 		Name:        gitlab.Ptr(report.FullName),
-		Description: gitlab.Ptr(report.Summary),
+		Description: gitlab.Ptr(description),
 	}
 
 	if report.TestPipelineRunName == "" {
@@ -518,10 +525,12 @@ func GenerateGitlabCommitState(state intgteststat.IntegrationTestStatus, optiona
 
 	if optional {
 		switch state {
-		case intgteststat.IntegrationTestStatusPending, intgteststat.BuildPLRInProgress, intgteststat.IntegrationTestStatusInProgress:
+		case intgteststat.IntegrationTestStatusPending, intgteststat.BuildPLRInProgress, intgteststat.IntegrationTestStatusInProgress,
+			intgteststat.IntegrationTestStatusBlocked:
 			glState = gitlab.Pending
 		case intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated, intgteststat.IntegrationTestStatusDeploymentError_Deprecated,
-			intgteststat.IntegrationTestStatusTestInvalid, intgteststat.IntegrationTestStatusTestFail:
+			intgteststat.IntegrationTestStatusTestInvalid, intgteststat.IntegrationTestStatusTestFail,
+			intgteststat.IntegrationTestStatusTestSkipped:
 			glState = gitlab.Skipped
 		case intgteststat.IntegrationTestStatusDeleted, intgteststat.BuildPLRFailed,
 			intgteststat.SnapshotCreationFailed, intgteststat.GroupSnapshotCreationFailed:
@@ -535,7 +544,7 @@ func GenerateGitlabCommitState(state intgteststat.IntegrationTestStatus, optiona
 		}
 	} else {
 		switch state {
-		case intgteststat.IntegrationTestStatusPending, intgteststat.BuildPLRInProgress:
+		case intgteststat.IntegrationTestStatusPending, intgteststat.IntegrationTestStatusBlocked, intgteststat.BuildPLRInProgress:
 			glState = gitlab.Pending
 		case intgteststat.IntegrationTestStatusInProgress:
 			glState = gitlab.Running
@@ -551,6 +560,9 @@ func GenerateGitlabCommitState(state intgteststat.IntegrationTestStatus, optiona
 			glState = gitlab.Success
 		case intgteststat.IntegrationTestStatusTestWarning:
 			glState = gitlab.Success
+		case intgteststat.IntegrationTestStatusTestSkipped:
+			// a skipped required test can't be considered as passed
+			glState = gitlab.Failed
 		default:
 			return glState, fmt.Errorf("unknown status %s", state)
 		}
@@ -620,6 +620,8 @@ var _ = Describe("GitLabReporter", func() {
 			Entry("Pending", integrationteststatus.IntegrationTestStatusPending, gitlab.Pending),
 			Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, gitlab.Success),
 			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, gitlab.Failed),
+			Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, gitlab.Failed),
+			Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, gitlab.Pending),
 			Entry("BuildPLRInProgress", integrationteststatus.BuildPLRInProgress, gitlab.Pending),
 			Entry("BuildPLRFailed", integrationteststatus.BuildPLRFailed, gitlab.Canceled),
 			Entry("SnapshotCreationFailed", integrationteststatus.SnapshotCreationFailed, gitlab.Canceled),
@@ -647,6 +649,8 @@ var _ = Describe("GitLabReporter", func() {
 			Entry("Deployment error (optional)", integrationteststatus.IntegrationTestStatusDeploymentError_Deprecated, gitlab.Skipped),
 			Entry("Test failure (optional)", integrationteststatus.IntegrationTestStatusTestFail, gitlab.Skipped),
 			Entry("Invalid (optional)", integrationteststatus.IntegrationTestStatusTestInvalid, gitlab.Skipped),
+			Entry("Skipped (optional)", integrationteststatus.IntegrationTestStatusTestSkipped, gitlab.Skipped),
+			Entry("Blocked (optional)", integrationteststatus.IntegrationTestStatusBlocked, gitlab.Pending),
 			Entry("Deleted (optional)", integrationteststatus.IntegrationTestStatusDeleted, gitlab.Canceled),
 			Entry("BuildPLRFailed (optional)", integrationteststatus.BuildPLRFailed, gitlab.Canceled),
 			Entry("SnapshotCreationFailed (optional)", integrationteststatus.SnapshotCreationFailed, gitlab.Canceled),
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+const (
+	// KubernetesEventsReporterType is the value of the reporters annotation enabling the Kubernetes Events reporter
+	KubernetesEventsReporterType = "kubernetes-events"
+
+	// IntegrationTestStatusEventReason is the reason of the Kubernetes Events created for integration test statuses
+	IntegrationTestStatusEventReason = "IntegrationTestStatus"
+
+	// integrationServiceEventSource is the component reported as the source of the Kubernetes Events
+	integrationServiceEventSource = "integration-service"
+
+	// eventMessageLimit is the maximum length of the message of a Kubernetes Event
+	eventMessageLimit = 1024
+)
+
+// KubernetesEventsReporter reports the integration test statuses as Kubernetes Events on the Snapshot
+type KubernetesEventsReporter struct {
+	logger    *logr.Logger
+	k8sClient client.Client
+	snapshot  *applicationapiv1alpha1.Snapshot
+}
+
+func NewKubernetesEventsReporter(logger logr.Logger, k8sClient client.Client) *KubernetesEventsReporter {
+	return &KubernetesEventsReporter{
+		logger:    &logger,
+		k8sClient: k8sClient,
+	}
+}
+
+var KubernetesEventsProvider = "KubernetesEventsReporter"
+
+// check if interface has been correctly implemented
+var _ ReporterInterface = (*KubernetesEventsReporter)(nil)
+
+// Detect if the Kubernetes Events reporter is requested for the snapshot
+func (r *KubernetesEventsReporter) Detect(snapshot *applicationapiv1alpha1.Snapshot) bool {
+	return isSinkReporterRequested(snapshot, KubernetesEventsReporterType)
+}
+
+// GetReporterName returns the reporter name
+func (r *KubernetesEventsReporter) GetReporterName() string {
+	return KubernetesEventsProvider
+}
+
+// Initialize initializes the Kubernetes Events reporter
+func (r *KubernetesEventsReporter) Initialize(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot) (int, error) {
+	r.snapshot = snapshot
+	return 0, nil
+}
+
+// ReportStatus creates a Kubernetes Event for the integration test status on the snapshot
+func (r *KubernetesEventsReporter) ReportStatus(ctx context.Context, report TestReport) (int, error) {
+	if r.snapshot == nil {
+		return 0, fmt.Errorf("kubernetes events reporter is not initialized")
+	}
+
+	message := fmt.Sprintf("%s: %s", report.FullName, report.Summary)
+	if len(message) > eventMessageLimit {
+		message = message[:eventMessageLimit-3] + "..."
+	}
+
+	now := metav1.NewTime(time.Now())
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v.%x", r.snapshot.Name, now.UnixNano()),
+			Namespace: r.snapshot.Namespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:            "Snapshot",
+			APIVersion:      applicationapiv1alpha1.GroupVersion.String(),
+			Name:            r.snapshot.Name,
+			Namespace:       r.snapshot.Namespace,
+			UID:             r.snapshot.UID,
+			ResourceVersion: r.snapshot.ResourceVersion,
+		},
+		Reason:         IntegrationTestStatusEventReason,
+		Message:        message,
+		Type:           GenerateKubernetesEventType(report.Status),
+		Source:         v1.EventSource{Component: integrationServiceEventSource},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	if err := r.k8sClient.Create(ctx, event); err != nil {
+		r.logger.Error(err, "failed to create kubernetes event for integration test status",
+			"snapshot.Namespace", r.snapshot.Namespace, "snapshot.Name", r.snapshot.Name, "scenario.name", report.ScenarioName)
+		return kubernetesErrorStatusCode(err), fmt.Errorf("failed to create kubernetes event for scenario %s: %w", report.ScenarioName, err)
+	}
+
+	r.logger.Info("Created kubernetes event for integration test status",
+		"snapshot.Name", r.snapshot.Name, "scenario.name", report.ScenarioName, "event.Name", event.Name)
+	return http.StatusCreated, nil
+}
+
+// UpdateStatusInComment is a no-op since Kubernetes Events have no comments
+func (r *KubernetesEventsReporter) UpdateStatusInComment(commentPrefix, comment string, isFinalStatus bool) (int, error) {
+	return 0, nil
+}
+
+func (r *KubernetesEventsReporter) ReturnCodeIsUnrecoverable(statusCode int) bool {
+	return statusCode == http.StatusForbidden || statusCode == http.StatusUnauthorized || statusCode == http.StatusBadRequest ||
+		statusCode == http.StatusUnprocessableEntity
+}
+
+// GenerateKubernetesEventType returns the type of the Kubernetes Event reported for the integration test status
+func GenerateKubernetesEventType(state intgteststat.IntegrationTestStatus) string {
+	switch state {
+	case intgteststat.IntegrationTestStatusEnvironmentProvisionError_Deprecated,
+		intgteststat.IntegrationTestStatusDeploymentError_Deprecated,
+		intgteststat.IntegrationTestStatusDeleted,
+		intgteststat.IntegrationTestStatusTestFail,
+		intgteststat.IntegrationTestStatusTestInvalid,
+		intgteststat.BuildPLRFailed,
+		intgteststat.SnapshotCreationFailed,
+		intgteststat.GroupSnapshotCreationFailed:
+		return v1.EventTypeWarning
+	default:
+		return v1.EventTypeNormal
+	}
+}
+
+// kubernetesErrorStatusCode returns the HTTP status code of the given Kubernetes API error, 0 if there is none
+func kubernetesErrorStatusCode(err error) int {
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		return int(apiStatus.Status().Code)
+	}
+	return 0
+}
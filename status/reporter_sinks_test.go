@@ -0,0 +1,266 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+var _ = Describe("Sink reporters", func() {
+	var (
+		sinkSnapshot *applicationapiv1alpha1.Snapshot
+		testReport   status.TestReport
+	)
+
+	BeforeEach(func() {
+		sinkSnapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot-sample",
+				Namespace: "default",
+				UID:       "snapshot-uid",
+				Annotations: map[string]string{
+					gitops.SnapshotReportersAnnotation: "kubernetes-events,webhook",
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+			},
+		}
+		testReport = status.TestReport{
+			FullName:      "Konflux / scenario1 / component-sample",
+			ScenarioName:  "scenario1",
+			ComponentName: "component-sample",
+			Status:        integrationteststatus.IntegrationTestStatusTestFail,
+			Summary:       "Integration test for component component-sample snapshot snapshot-sample and scenario scenario1 has failed",
+			Text:          "detailed text here",
+		}
+	})
+
+	Context("KubernetesEventsReporter", func() {
+		It("creates a warning event on the snapshot for failed tests", func() {
+			var createdEvent *v1.Event
+			reporter := status.NewKubernetesEventsReporter(logr.Discard(), &MockK8sClient{
+				genericInterceptor: func(obj client.Object) {
+					if event, ok := obj.(*v1.Event); ok {
+						createdEvent = event
+					}
+				},
+			})
+			Expect(reporter.Detect(sinkSnapshot)).To(BeTrue())
+			Expect(reporter.GetReporterName()).To(Equal(status.KubernetesEventsProvider))
+
+			_, err := reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).To(HaveOccurred())
+
+			_, err = reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+			statusCode, err := reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusCreated))
+
+			Expect(createdEvent).NotTo(BeNil())
+			Expect(createdEvent.Namespace).To(Equal(sinkSnapshot.Namespace))
+			Expect(createdEvent.InvolvedObject.Kind).To(Equal("Snapshot"))
+			Expect(createdEvent.InvolvedObject.Name).To(Equal(sinkSnapshot.Name))
+			Expect(createdEvent.InvolvedObject.UID).To(Equal(sinkSnapshot.UID))
+			Expect(createdEvent.Type).To(Equal(v1.EventTypeWarning))
+			Expect(createdEvent.Reason).To(Equal(status.IntegrationTestStatusEventReason))
+			Expect(createdEvent.Message).To(ContainSubstring(testReport.Summary))
+		})
+
+		It("returns the status code of the failed event creation", func() {
+			reporter := status.NewKubernetesEventsReporter(logr.Discard(), &failingCreateK8sClient{
+				MockK8sClient: &MockK8sClient{},
+				err:           apierrors.NewForbidden(schema.GroupResource{Resource: "events"}, "event", fmt.Errorf("forbidden")),
+			})
+			_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			statusCode, err := reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).To(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusForbidden))
+			Expect(reporter.ReturnCodeIsUnrecoverable(statusCode)).To(BeTrue())
+		})
+
+		DescribeTable("reports the event type of the test status",
+			func(teststatus integrationteststatus.IntegrationTestStatus, eventType string) {
+				Expect(status.GenerateKubernetesEventType(teststatus)).To(Equal(eventType))
+			},
+			Entry("Success", integrationteststatus.IntegrationTestStatusTestPassed, v1.EventTypeNormal),
+			Entry("In progress", integrationteststatus.IntegrationTestStatusInProgress, v1.EventTypeNormal),
+			Entry("Test failure", integrationteststatus.IntegrationTestStatusTestFail, v1.EventTypeWarning),
+			Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, v1.EventTypeWarning),
+			Entry("Deleted", integrationteststatus.IntegrationTestStatusDeleted, v1.EventTypeWarning),
+		)
+	})
+
+	Context("WebhookReporter", func() {
+		var (
+			server       *httptest.Server
+			received     []status.WebhookReport
+			responseCode int32
+			savedBackoff wait.Backoff
+			webhooks     string
+			mockClient   *MockK8sClient
+		)
+
+		BeforeEach(func() {
+			received = nil
+			responseCode = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				report := status.WebhookReport{}
+				Expect(json.NewDecoder(r.Body).Decode(&report)).To(Succeed())
+				received = append(received, report)
+				rw.WriteHeader(int(atomic.LoadInt32(&responseCode)))
+			}))
+			webhooks = "# test server\n" + server.URL + "/hook\n"
+			mockClient = &MockK8sClient{
+				getInterceptor: func(key client.ObjectKey, obj client.Object) {
+					if configMap, ok := obj.(*v1.ConfigMap); ok && key.Name == status.WebhooksConfigMapName && key.Namespace == sinkSnapshot.Namespace {
+						configMap.Data = map[string]string{status.WebhooksConfigMapKey: webhooks}
+					}
+				},
+			}
+
+			savedBackoff = status.GetReporterRetryBackoff()
+			status.SetReporterRetryBackoff(wait.Backoff{
+				Steps:    3,
+				Duration: 1 * time.Millisecond,
+				Factor:   1.0,
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+			status.SetReporterRetryBackoff(savedBackoff)
+		})
+
+		It("sends the integration test status to the webhook", func() {
+			reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+			reporter.AllowInternalWebhooks()
+			Expect(reporter.Detect(sinkSnapshot)).To(BeTrue())
+			statusCode, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusOK))
+
+			statusCode, err = reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusOK))
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].Snapshot).To(Equal(sinkSnapshot.Name))
+			Expect(received[0].Application).To(Equal("application-sample"))
+			Expect(received[0].Scenario).To(Equal("scenario1"))
+			Expect(received[0].Status).To(Equal(integrationteststatus.IntegrationTestStatusTestFail))
+			Expect(received[0].Details).To(Equal("detailed text here"))
+		})
+
+		It("retries transient errors but not unrecoverable ones", func() {
+			reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+			reporter.AllowInternalWebhooks()
+			_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			atomic.StoreInt32(&responseCode, http.StatusServiceUnavailable)
+			statusCode, err := reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).To(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusServiceUnavailable))
+			Expect(received).To(HaveLen(3))
+
+			received = nil
+			atomic.StoreInt32(&responseCode, http.StatusNotFound)
+			statusCode, err = reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).To(HaveOccurred())
+			Expect(statusCode).To(Equal(http.StatusNotFound))
+			Expect(received).To(HaveLen(1))
+		})
+
+		It("ignores the webhook URLs of the snapshot metadata", func() {
+			sinkSnapshot.Annotations["test.appstudio.openshift.io/report-webhook-url"] = "http://169.254.169.254/latest"
+			reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+			reporter.AllowInternalWebhooks()
+			_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reporter.ReportStatus(context.Background(), testReport)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(HaveLen(1))
+		})
+
+		It("fails to initialize when the namespace has no webhooks ConfigMap", func() {
+			mockClient.err = apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, status.WebhooksConfigMapName)
+			reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+			_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(helpers.IsUnrecoverableMetadataError(err)).To(BeTrue())
+		})
+
+		It("returns transient errors to get the webhooks ConfigMap", func() {
+			mockClient.err = fmt.Errorf("connection refused")
+			reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+			_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+			Expect(err).To(HaveOccurred())
+			Expect(helpers.IsUnrecoverableMetadataError(err)).To(BeFalse())
+		})
+
+		DescribeTable("fails to initialize without a valid webhook URL",
+			func(configuredWebhooks string) {
+				webhooks = configuredWebhooks
+				reporter := status.NewWebhookReporter(logr.Discard(), mockClient)
+				_, err := reporter.Initialize(context.Background(), sinkSnapshot)
+				Expect(helpers.IsUnrecoverableMetadataError(err)).To(BeTrue())
+			},
+			Entry("missing URL", ""),
+			Entry("relative URL", "/hook"),
+			Entry("unsupported scheme", "ftp://example.com/hook"),
+			Entry("http URL", "http://example.com/hook"),
+			Entry("loopback address", "https://127.0.0.1:8443/hook"),
+			Entry("link-local address", "https://169.254.169.254/latest"),
+			Entry("private address", "https://10.0.0.1/hook\nhttps://[fd00::1]/hook"),
+		)
+	})
+})
+
+// failingCreateK8sClient is a MockK8sClient which fails to create objects with the given error
+type failingCreateK8sClient struct {
+	*MockK8sClient
+	err error
+}
+
+func (c *failingCreateK8sClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.err
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/helpers"
+	"github.com/konflux-ci/integration-service/pkg/common"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+const (
+	// WebhookReporterType is the value of the reporters annotation enabling the webhook reporter
+	WebhookReporterType = "webhook"
+
+	// WebhooksConfigMapName is the name of the ConfigMap configuring the webhooks of a namespace. The webhooks are only
+	// read from this namespace-owned ConfigMap, never from the Snapshot metadata which may come from a pull request.
+	WebhooksConfigMapName = "integration-service-report-webhooks"
+
+	// WebhooksConfigMapKey is the key of the webhooks ConfigMap holding the webhook URLs, one per line
+	WebhooksConfigMapKey = "webhooks"
+
+	// webhookRequestTimeout is the timeout of a single request sent by the webhook reporter
+	webhookRequestTimeout = 10 * time.Second
+)
+
+// WebhookReport is the JSON payload sent by the webhook reporter for each integration test status
+type WebhookReport struct {
+	Namespace           string                             `json:"namespace"`
+	Snapshot            string                             `json:"snapshot"`
+	Application         string                             `json:"application,omitempty"`
+	Component           string                             `json:"component,omitempty"`
+	Scenario            string                             `json:"scenario"`
+	FullName            string                             `json:"fullName"`
+	Status              intgteststat.IntegrationTestStatus `json:"status"`
+	Summary             string                             `json:"summary"`
+	Details             string                             `json:"details,omitempty"`
+	TestPipelineRunName string                             `json:"testPipelineRunName,omitempty"`
+	StartTime           *time.Time                         `json:"startTime,omitempty"`
+	CompletionTime      *time.Time                         `json:"completionTime,omitempty"`
+}
+
+// WebhookReporter sends the integration test statuses to a generic HTTP webhook
+type WebhookReporter struct {
+	logger      *logr.Logger
+	k8sClient   client.Client
+	httpClient  *http.Client
+	webhookURLs []string
+	snapshot    *applicationapiv1alpha1.Snapshot
+	// parseWebhookURL parses a configured webhook URL, rejecting the ones which aren't allowed
+	parseWebhookURL func(rawURL string) (*url.URL, error)
+}
+
+func NewWebhookReporter(logger logr.Logger, k8sClient client.Client) *WebhookReporter {
+	return &WebhookReporter{
+		logger:          &logger,
+		k8sClient:       k8sClient,
+		httpClient:      common.NewSinkHTTPClient(webhookRequestTimeout),
+		parseWebhookURL: common.ParseSinkURL,
+	}
+}
+
+var WebhookProvider = "WebhookReporter"
+
+// check if interface has been correctly implemented
+var _ ReporterInterface = (*WebhookReporter)(nil)
+
+// Detect if the webhook reporter is requested for the snapshot
+func (r *WebhookReporter) Detect(snapshot *applicationapiv1alpha1.Snapshot) bool {
+	return isSinkReporterRequested(snapshot, WebhookReporterType)
+}
+
+// GetReporterName returns the reporter name
+func (r *WebhookReporter) GetReporterName() string {
+	return WebhookProvider
+}
+
+// Initialize initializes the webhook reporter with the webhooks configured for the namespace of the snapshot.
+// The webhooks must be https URLs, and the ones whose host is an internal address of the cluster network are ignored.
+func (r *WebhookReporter) Initialize(ctx context.Context, snapshot *applicationapiv1alpha1.Snapshot) (int, error) {
+	var unRecoverableError error
+	configMap := &v1.ConfigMap{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: snapshot.Namespace, Name: WebhooksConfigMapName}, configMap)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to get the %s ConfigMap: %w", WebhooksConfigMapName, err)
+		}
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("the webhook reporter is requested for the snapshot %s but the namespace has no %s ConfigMap", snapshot.Name, WebhooksConfigMapName))
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+
+	webhookURLs := []string{}
+	for _, line := range strings.Split(configMap.Data[WebhooksConfigMapKey], "\n") {
+		webhookURL := strings.TrimSpace(line)
+		if webhookURL == "" || strings.HasPrefix(webhookURL, "#") {
+			continue
+		}
+		parsedURL, err := r.parseWebhookURL(webhookURL)
+		if err != nil {
+			r.logger.Info("Ignoring invalid webhook",
+				"namespace", snapshot.Namespace, "configMap.Name", WebhooksConfigMapName, "webhook", webhookURL, "error", err.Error())
+			continue
+		}
+		webhookURLs = append(webhookURLs, parsedURL.String())
+	}
+	if len(webhookURLs) == 0 {
+		unRecoverableError = helpers.NewUnrecoverableMetadataError(fmt.Sprintf("no valid webhook URL in the %s key of the %s ConfigMap, absolute https URLs to external hosts are expected", WebhooksConfigMapKey, WebhooksConfigMapName))
+		r.logger.Error(unRecoverableError, "snapshot.NameSpace", snapshot.Namespace, "snapshot.Name", snapshot.Name)
+		return 0, unRecoverableError
+	}
+
+	r.webhookURLs = webhookURLs
+	r.snapshot = snapshot
+	return http.StatusOK, nil
+}
+
+// ReportStatus sends the integration test status to the webhooks of the namespace
+func (r *WebhookReporter) ReportStatus(ctx context.Context, report TestReport) (int, error) {
+	var statusCode = 0
+	if r.snapshot == nil {
+		return statusCode, fmt.Errorf("webhook reporter is not initialized")
+	}
+
+	payload, err := json.Marshal(WebhookReport{
+		Namespace:           r.snapshot.Namespace,
+		Snapshot:            r.snapshot.Name,
+		Application:         r.snapshot.Spec.Application,
+		Component:           report.ComponentName,
+		Scenario:            report.ScenarioName,
+		FullName:            report.FullName,
+		Status:              report.Status,
+		Summary:             report.Summary,
+		Details:             report.Text,
+		TestPipelineRunName: report.TestPipelineRunName,
+		StartTime:           report.StartTime,
+		CompletionTime:      report.CompletionTime,
+	})
+	if err != nil {
+		return statusCode, fmt.Errorf("failed to marshal webhook report: %w", err)
+	}
+
+	// the report is sent to every webhook, the status code and error of the last failed webhook are returned
+	var reportErr error
+	for _, webhookURL := range r.webhookURLs {
+		webhookStatusCode, err := r.sendWithRetry(ctx, webhookURL, payload, report.ScenarioName)
+		if err != nil {
+			statusCode, reportErr = webhookStatusCode, err
+			continue
+		}
+		if reportErr == nil {
+			statusCode = webhookStatusCode
+		}
+	}
+	return statusCode, reportErr
+}
+
+// sendWithRetry sends the payload to the webhook, retrying with backoff on transient errors
+func (r *WebhookReporter) sendWithRetry(ctx context.Context, webhookURL string, payload []byte, scenarioName string) (int, error) {
+	var statusCode int
+	err := retry.OnError(reporterRetryBackoff, func(err error) bool {
+		// statusCode 0 means no HTTP response was received (network/timeout error), always retry
+		retryable := statusCode == 0 || !r.ReturnCodeIsUnrecoverable(statusCode)
+		if retryable {
+			r.logger.Info("retrying to send integration test status to webhook after transient error",
+				"scenario.name", scenarioName, "webhook", webhookURL, "statusCode", statusCode, "error", err.Error())
+		}
+		return retryable
+	}, func() error {
+		var err error
+		statusCode = 0 // reset before each attempt to avoid stale values
+		statusCode, err = r.send(ctx, webhookURL, payload)
+		return err
+	})
+
+	if err != nil {
+		r.logger.Error(err, "failed to send integration test status to webhook after all retries",
+			"scenario.name", scenarioName, "webhook", webhookURL, "statusCode", statusCode)
+		return statusCode, err
+	}
+
+	r.logger.Info("Sent integration test status to webhook", "scenario.name", scenarioName, "webhook", webhookURL, "statusCode", statusCode)
+	return statusCode, nil
+}
+
+// send posts the payload to the webhook and returns the HTTP status code of the response
+func (r *WebhookReporter) send(ctx context.Context, webhookURL string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", common.IntegrationServiceUserAgent)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// UpdateStatusInComment is a no-op since webhooks have no comments
+func (r *WebhookReporter) UpdateStatusInComment(commentPrefix, comment string, isFinalStatus bool) (int, error) {
+	return 0, nil
+}
+
+func (r *WebhookReporter) ReturnCodeIsUnrecoverable(statusCode int) bool {
+	return statusCode == http.StatusForbidden || statusCode == http.StatusUnauthorized || statusCode == http.StatusBadRequest || statusCode == http.StatusNotFound
+}
@@ -49,31 +49,52 @@ type ScenarioReportStatus struct {
 	LastUpdateTime *time.Time `json:"lastUpdateTime"`
 }
 
-// SnapshotReportStatus keep report status of git provider for the snapshot
+// SnapshotReportStatus keep report status of git provider and additional reporters for the snapshot
 type SnapshotReportStatus struct {
 	Scenarios map[string]*ScenarioReportStatus `json:"scenarios"`
+	// Reporters keeps the report status of the additional reporters by reporter name,
+	// the git provider reporter keeps using Scenarios
+	Reporters map[string]map[string]*ScenarioReportStatus `json:"reporters,omitempty"`
 	dirty     bool
 }
 
 // SetLastUpdateTime updates the last udpate time of the given scenario and snapshot to the given time
 func (srs *SnapshotReportStatus) SetLastUpdateTime(scenarioName string, snapshotName string, t time.Time) {
+	srs.setLastUpdateTime(srs.Scenarios, scenarioName, snapshotName, t)
+}
+
+// SetReporterLastUpdateTime updates the last update time of the given scenario and snapshot for the given reporter
+func (srs *SnapshotReportStatus) SetReporterLastUpdateTime(reporterName string, scenarioName string, snapshotName string, t time.Time) {
+	srs.setLastUpdateTime(srs.reporterScenarios(reporterName), scenarioName, snapshotName, t)
+}
+
+func (srs *SnapshotReportStatus) setLastUpdateTime(scenarios map[string]*ScenarioReportStatus, scenarioName string, snapshotName string, t time.Time) {
 	srs.dirty = true
 	//use scenarioName and snapshotName as the key to support group snapshot status report
 	keyName := scenarioName + "-" + snapshotName
-	if scenario, ok := srs.Scenarios[keyName]; ok {
+	if scenario, ok := scenarios[keyName]; ok {
 		scenario.LastUpdateTime = &t
 		return
 	}
 
-	srs.Scenarios[keyName] = &ScenarioReportStatus{
+	scenarios[keyName] = &ScenarioReportStatus{
 		LastUpdateTime: &t,
 	}
 }
 
 // IsNewer returns true if given scenario and snapshot has newer time than the last updated
 func (srs *SnapshotReportStatus) IsNewer(scenarioName string, snapshotName string, t time.Time) bool {
+	return isNewer(srs.Scenarios, scenarioName, snapshotName, t)
+}
+
+// IsNewerForReporter returns true if given scenario and snapshot has newer time than the last update of the given reporter
+func (srs *SnapshotReportStatus) IsNewerForReporter(reporterName string, scenarioName string, snapshotName string, t time.Time) bool {
+	return isNewer(srs.reporterScenarios(reporterName), scenarioName, snapshotName, t)
+}
+
+func isNewer(scenarios map[string]*ScenarioReportStatus, scenarioName string, snapshotName string, t time.Time) bool {
 	key := scenarioName + "-" + snapshotName
-	if scenario, ok := srs.Scenarios[key]; ok {
+	if scenario, ok := scenarios[key]; ok {
 		return scenario.LastUpdateTime.Before(t)
 	}
 
@@ -81,6 +102,21 @@ func (srs *SnapshotReportStatus) IsNewer(scenarioName string, snapshotName strin
 	return true
 }
 
+// reporterScenarios returns the report status of the scenarios kept for the given reporter,
+// the git provider reporters share the Scenarios so their existing report status is kept
+func (srs *SnapshotReportStatus) reporterScenarios(reporterName string) map[string]*ScenarioReportStatus {
+	if !IsSinkReporter(reporterName) {
+		return srs.Scenarios
+	}
+	if srs.Reporters == nil {
+		srs.Reporters = map[string]map[string]*ScenarioReportStatus{}
+	}
+	if _, ok := srs.Reporters[reporterName]; !ok {
+		srs.Reporters[reporterName] = map[string]*ScenarioReportStatus{}
+	}
+	return srs.Reporters[reporterName]
+}
+
 // ToAnnotationString exports data in format for annotation
 func (srs *SnapshotReportStatus) ToAnnotationString() (string, error) {
 	byteVar, err := json.Marshal(srs)
@@ -232,13 +268,56 @@ func (s *Status) GetReporter(snapshot *applicationapiv1alpha1.Snapshot) Reporter
 		return forgejoReporter
 	}
 
+	bitbucketReporter := NewBitbucketReporter(s.logger, s.client)
+	if bitbucketReporter.Detect(snapshot) {
+		return bitbucketReporter
+	}
+
 	return nil
 }
 
+// GetSinkReporters returns the additional reporters which the snapshot is reported to besides its git provider,
+// they are selected by the test.appstudio.openshift.io/reporters annotation of the snapshot
+func GetSinkReporters(logger logr.Logger, client client.Client, snapshot *applicationapiv1alpha1.Snapshot) []ReporterInterface {
+	var reporters []ReporterInterface
+
+	kubernetesEventsReporter := NewKubernetesEventsReporter(logger, client)
+	if kubernetesEventsReporter.Detect(snapshot) {
+		reporters = append(reporters, kubernetesEventsReporter)
+	}
+
+	webhookReporter := NewWebhookReporter(logger, client)
+	if webhookReporter.Detect(snapshot) {
+		reporters = append(reporters, webhookReporter)
+	}
+
+	return reporters
+}
+
+// IsSinkReporter returns true if the reporter with the given name is one of the additional reporters
+// which keep their own report status for the snapshot
+func IsSinkReporter(reporterName string) bool {
+	return reporterName == KubernetesEventsProvider || reporterName == WebhookProvider
+}
+
+// isSinkReporterRequested returns true if the given reporter type is listed in the reporters annotation of the snapshot
+func isSinkReporterRequested(snapshot *applicationapiv1alpha1.Snapshot, reporterType string) bool {
+	reportersValue, ok := snapshot.GetAnnotations()[gitops.SnapshotReportersAnnotation]
+	if !ok {
+		return false
+	}
+	for _, requestedReporter := range strings.Split(reportersValue, ",") {
+		if strings.TrimSpace(requestedReporter) == reporterType {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateTestReport generates TestReport to be used by all reporters
 func GenerateTestReport(ctx context.Context, client client.Client, detail intgteststat.IntegrationTestStatusDetail, testedSnapshot *applicationapiv1alpha1.Snapshot, componentName string) (*TestReport, error) {
 	var err error
-	text, err := generateText(ctx, client, detail, testedSnapshot)
+	text, taskRuns, findings, err := generateText(ctx, client, detail, testedSnapshot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate text message: %w", err)
 	}
@@ -252,6 +331,10 @@ func GenerateTestReport(ctx context.Context, client client.Client, detail intgte
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate summary message: %w", err)
 	}
+	// scenarios with a retry policy report which attempt the status belongs to
+	if detail.MaxAttempts > 1 {
+		summary = fmt.Sprintf("%s (attempt %d/%d)", summary, detail.GetAttempt(), detail.MaxAttempts)
+	}
 
 	consoleName := getConsoleName()
 
@@ -272,12 +355,23 @@ func GenerateTestReport(ctx context.Context, client client.Client, detail intgte
 		StartTime:           detail.StartTime,
 		CompletionTime:      detail.CompletionTime,
 		TestPipelineRunName: detail.TestPipelineRunName,
+		Findings:            findings,
+	}
+
+	// the report templates of the namespace are optional, the default texts are used when they can't be applied
+	logger := log.FromContext(ctx)
+	templates, err := GetReportTemplates(ctx, client, testedSnapshot.Namespace, logger)
+	if err != nil {
+		logger.Error(err, "Failed to get the report templates, the default ones are used instead", "namespace", testedSnapshot.Namespace)
+	} else if templates != nil {
+		templates.Apply(&report, NewReportTemplateData(&report, detail, testedSnapshot, taskRuns, logger))
 	}
 	return &report, nil
 }
 
-// generateText generates a text with details for the given state
-func generateText(ctx context.Context, client client.Client, integrationTestStatusDetail intgteststat.IntegrationTestStatusDetail, snapshot *applicationapiv1alpha1.Snapshot) (string, error) {
+// generateText generates a text with details for the given state, along with the tasks of the finished integration
+// PipelineRun and the line-level findings they published
+func generateText(ctx context.Context, client client.Client, integrationTestStatusDetail intgteststat.IntegrationTestStatusDetail, snapshot *applicationapiv1alpha1.Snapshot) (string, []*helpers.TaskRun, []helpers.Finding, error) {
 	log := log.FromContext(ctx)
 
 	var componentSnapshotInfos []*gitops.ComponentSnapshotInfo
@@ -285,7 +379,7 @@ func generateText(ctx context.Context, client client.Client, integrationTestStat
 	if componentSnapshotInfoString, ok := snapshot.Annotations[gitops.GroupSnapshotInfoAnnotation]; ok {
 		componentSnapshotInfos, err = gitops.UnmarshalJSON([]byte(componentSnapshotInfoString))
 		if err != nil {
-			return "", fmt.Errorf("failed to unmarshal JSON string: %w", err)
+			return "", nil, nil, fmt.Errorf("failed to unmarshal JSON string: %w", err)
 		}
 	}
 
@@ -303,24 +397,29 @@ func generateText(ctx context.Context, client client.Client, integrationTestStat
 			if apierrors.IsNotFound(err) {
 				log.Error(err, "Failed to fetch pipelineRun", "pipelineRun.Name", pipelineRunName)
 				text := fmt.Sprintf("%s\n\n\n(Failed to fetch test result details because pipelineRun %s/%s can not be found.)", integrationTestStatusDetail.Details, snapshot.Namespace, pipelineRunName)
-				return text, nil
+				return text, nil, nil, nil
 			}
 
-			return "", fmt.Errorf("error while getting the pipelineRun %s: %w", pipelineRunName, err)
+			return "", nil, nil, fmt.Errorf("error while getting the pipelineRun %s: %w", pipelineRunName, err)
 		}
 
 		taskRuns, err := helpers.GetAllChildTaskRunsForPipelineRun(ctx, client, pipelineRun)
 		if err != nil {
-			return "", fmt.Errorf("error while getting all child taskRuns from pipelineRun %s: %w", pipelineRunName, err)
+			return "", nil, nil, fmt.Errorf("error while getting all child taskRuns from pipelineRun %s: %w", pipelineRunName, err)
 		}
-		text, err := FormatTestsSummary(taskRuns, pipelineRunName, snapshot.Namespace, componentSnapshotInfos, pr_group, log)
+		text, err := FormatTestsSummary(taskRuns, integrationTestStatusDetail.TestCaseResults, pipelineRunName, snapshot.Namespace, componentSnapshotInfos, pr_group, log)
 		if err != nil {
-			return "", err
+			return "", nil, nil, err
 		}
-		return text, nil
+		// findings are informative, the report is sent with the ones which could be parsed
+		findings, err := helpers.GetFindingsFromTaskRuns(taskRuns)
+		if err != nil {
+			log.Error(err, "Failed to parse some of the findings of the pipelineRun", "pipelineRun.Name", pipelineRunName)
+		}
+		return text, taskRuns, findings, nil
 	} else {
 		text := integrationTestStatusDetail.Details
-		return text, nil
+		return text, nil, nil, nil
 	}
 }
 
@@ -380,6 +479,10 @@ func GenerateSummary(state intgteststat.IntegrationTestStatus, snapshotName, sce
 		statusDesc = "has not run and is considered as failed because the build pipelinerun failed and snapshot was not created"
 	case intgteststat.GroupSnapshotCreationFailed:
 		statusDesc = "has not run and is considered as failed because group snapshot was not created"
+	case intgteststat.IntegrationTestStatusTestSkipped:
+		statusDesc = "was skipped because a scenario it depends on did not pass"
+	case intgteststat.IntegrationTestStatusBlocked:
+		statusDesc = "is blocked until the scenario(s) it depends on pass"
 	default:
 		return summary, fmt.Errorf("unknown status")
 	}
@@ -401,6 +504,12 @@ func GenerateSummary(state intgteststat.IntegrationTestStatus, snapshotName, sce
 	return summary, nil
 }
 
+// GenerateQuarantinedSummary returns the summary extended with the reason and expiry of the quarantine of the given IntegrationTestScenario
+func GenerateQuarantinedSummary(summary string, scenario *v1beta2.IntegrationTestScenario) string {
+	return fmt.Sprintf("%s, the scenario is quarantined until %s: %s", summary,
+		scenario.Spec.Quarantine.ExpiresAt.UTC().Format(time.RFC3339), scenario.Spec.Quarantine.Reason)
+}
+
 // GenerateSummaryForAllScenarios returns summary for the given state and componentName or PR group when all ITS have the same status because snapshot have not been created due to various reasons
 func GenerateSummaryForAllScenarios(state intgteststat.IntegrationTestStatus, componentNameOrPrGroup string) (string, error) {
 	var summary string
@@ -488,7 +597,16 @@ func (s Status) IsPRMRInSnapshotOpened(ctx context.Context, snapshot *applicatio
 		return forgejoReporter.IsPullRequestOpen(ctx)
 	}
 
-	return false, 0, fmt.Errorf("invalid git provider, valid git provider must be one of github, gitlab, forgejo and gitea")
+	bitbucketReporter := NewBitbucketReporter(s.logger, s.client)
+	if bitbucketReporter.Detect(snapshot) {
+		statusCode, err := bitbucketReporter.Initialize(ctx, snapshot)
+		if err != nil {
+			return false, statusCode, err
+		}
+		return bitbucketReporter.IsPullRequestOpen(ctx)
+	}
+
+	return false, 0, fmt.Errorf("invalid git provider, valid git provider must be one of github, gitlab, forgejo, gitea, bitbucket-datacenter and bitbucket-server")
 }
 
 // IsMRInSnapshotOpened check if the gitlab merge request triggering snapshot is opened
@@ -712,6 +830,9 @@ func IterateIntegrationTestScenarioWithSameStatus(ctx context.Context, client cl
 	log := log.FromContext(ctx)
 
 	var statusCode = 0
+	// the summary comment of the pull request only lists the statuses of the created Snapshots, the statuses reported
+	// before a Snapshot is created are only reported as commit statuses or check runs
+	isSummaryComment := gitops.IsSummaryCommentEnabled(component)
 	for _, integrationTestScenario := range *integrationTestScenarios {
 		integrationTestScenario := integrationTestScenario //G601
 		// set test details' scenarioName and optional for each its
@@ -722,17 +843,19 @@ func IterateIntegrationTestScenarioWithSameStatus(ctx context.Context, client cl
 		if reportErr != nil {
 			return statusCode, fmt.Errorf("failed to generate test report: %w", reportErr)
 		}
+		testReport.SkipComment = isSummaryComment
 		if statusCode, reportStatusErr := reporter.ReportStatus(ctx, *testReport); reportStatusErr != nil {
 			return statusCode, fmt.Errorf("failed to report status to git provider, error: %w", reportStatusErr)
 		}
 	}
 
-	// if git provider is gitlab or forgejo, and comment is neither disabled for component nor pac repository,
+	// if git provider is gitlab, forgejo or bitbucket, and comment is neither disabled for component nor pac repository,
 	// delete existing comment and post one new comment with the latest test report summary of all ITS to the merge/pull request for each component
 	if reporter.GetReporterName() == GitLabProvider ||
-		reporter.GetReporterName() == ForgejoProvider {
+		reporter.GetReporterName() == ForgejoProvider ||
+		reporter.GetReporterName() == BitbucketProvider {
 		_, isMergeRequest := snapshot.GetAnnotations()[gitops.PipelineAsCodePullRequestAnnotation]
-		if isMergeRequest {
+		if isMergeRequest && !isSummaryComment {
 			// get the destination snapshot's component to check if comment is disabled for all comments for pac repository or integration test
 			isCommentDisabled, err := gitops.IsCommentDisabled(ctx, client, component)
 			if err != nil {
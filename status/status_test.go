@@ -687,6 +687,39 @@ var _ = Describe("Status Adapter", func() {
 		Expect(ok).To(BeTrue())
 	})
 
+	It("returns BitbucketReporter when snapshot has bitbucket-datacenter provider annotation", func() {
+		bitbucketSnapshot := &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					gitops.PipelineAsCodeGitProviderAnnotation: gitops.PipelineAsCodeBitbucketDataCenterProviderType,
+				},
+			},
+		}
+		st := status.NewStatus(logr.Discard(), nil)
+		reporter := st.GetReporter(bitbucketSnapshot)
+		Expect(reporter).ToNot(BeNil())
+		Expect(reporter.GetReporterName()).To(Equal(status.BitbucketProvider))
+		_, ok := reporter.(*status.BitbucketReporter)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("returns the sink reporters requested by the snapshot", func() {
+		sinkSnapshot := &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					gitops.SnapshotReportersAnnotation: "kubernetes-events, webhook, unknown",
+				},
+			},
+		}
+		reporters := status.GetSinkReporters(logr.Discard(), nil, sinkSnapshot)
+		Expect(reporters).To(HaveLen(2))
+		Expect(reporters[0].GetReporterName()).To(Equal(status.KubernetesEventsProvider))
+		Expect(reporters[1].GetReporterName()).To(Equal(status.WebhookProvider))
+
+		delete(sinkSnapshot.Annotations, gitops.SnapshotReportersAnnotation)
+		Expect(status.GetSinkReporters(logr.Discard(), nil, sinkSnapshot)).To(BeEmpty())
+	})
+
 	It("can migrate snapshot to reportStatus in old way - migration test)", func() {
 		hasSnapshot.Annotations["test.appstudio.openshift.io/status"] = "[{\"scenario\":\"scenario1\",\"status\":\"InProgress\",\"startTime\":\"2023-07-26T16:57:49+02:00\",\"lastUpdateTime\":\"2023-08-26T17:57:50+02:00\",\"details\":\"Test in progress\"}]"
 		hasSnapshot.Annotations["test.appstudio.openshift.io/pr-last-update"] = "2023-08-26T17:57:50+02:00"
@@ -834,6 +867,8 @@ var _ = Describe("Status Adapter", func() {
 		Entry("In progress", integrationteststatus.IntegrationTestStatusInProgress, "is in progress"),
 		Entry("Invalid", integrationteststatus.IntegrationTestStatusTestInvalid, "is invalid"),
 		Entry("Warning", integrationteststatus.IntegrationTestStatusTestWarning, "has warning(s)"),
+		Entry("Skipped", integrationteststatus.IntegrationTestStatusTestSkipped, "was skipped because a scenario it depends on did not pass"),
+		Entry("Blocked", integrationteststatus.IntegrationTestStatusBlocked, "is blocked until the scenario(s) it depends on pass"),
 	)
 
 	DescribeTable(
@@ -868,6 +903,23 @@ var _ = Describe("Status Adapter", func() {
 		Entry("BuildPLRFailed", integrationteststatus.BuildPLRFailed, "has not run and is considered as failed because the build pipelinerun failed and snapshot was not created"),
 	)
 
+	DescribeTable(
+		"report the attempt of scenarios with a retry policy",
+		func(attempt, maxAttempts int, expectedSummaryEnding string) {
+			integrationTestStatusDetail := newIntegrationTestStatusDetail(integrationteststatus.IntegrationTestStatusPending, false)
+			integrationTestStatusDetail.Attempt = attempt
+			integrationTestStatusDetail.MaxAttempts = maxAttempts
+
+			expectedSummary := "Integration test for component component-sample snapshot snapshot-sample and scenario scenario1 is pending" + expectedSummaryEnding
+			testReport, err := status.GenerateTestReport(context.Background(), mockK8sClient, integrationTestStatusDetail, hasSnapshot, "component-sample")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testReport.Summary).To(Equal(expectedSummary))
+		},
+		Entry("No retry policy", 0, 0, ""),
+		Entry("First attempt", 0, 3, " (attempt 1/3)"),
+		Entry("Retried attempt", 2, 3, " (attempt 2/3)"),
+	)
+
 	It("check if GenerateSummary supports all integration test statuses", func() {
 		for _, teststatus := range integrationteststatus.IntegrationTestStatusValues() {
 			_, err := status.GenerateSummary(teststatus, "yolo", "yolo", "yoyo", false)
@@ -978,6 +1030,25 @@ var _ = Describe("Status Adapter", func() {
 			Expect(hasSRS.IsNewer(scenarioName, hasSnapshot.Name, tOld)).To(BeFalse())
 		})
 
+		It("Keeps separate last updated times for the sink reporters", func() {
+			tNew := now.Add(1 * time.Minute)
+			hasSRS.SetLastUpdateTime(scenarioName, hasSnapshot.Name, now)
+			hasSRS.SetReporterLastUpdateTime(status.WebhookProvider, scenarioName, hasSnapshot.Name, tNew)
+
+			// git provider reporters share the scenarios of the SRS
+			Expect(hasSRS.IsNewerForReporter("GithubReporter", scenarioName, hasSnapshot.Name, tNew)).To(BeTrue())
+			Expect(hasSRS.IsNewerForReporter(status.WebhookProvider, scenarioName, hasSnapshot.Name, tNew)).To(BeFalse())
+			Expect(hasSRS.IsNewerForReporter(status.KubernetesEventsProvider, scenarioName, hasSnapshot.Name, now)).To(BeTrue())
+			Expect(hasSRS.Scenarios).To(HaveLen(1))
+
+			annotation, err := hasSRS.ToAnnotationString()
+			Expect(err).ToNot(HaveOccurred())
+			newSRS, err := status.NewSnapshotReportStatus(annotation)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newSRS.Reporters).To(HaveKey(status.WebhookProvider))
+			Expect(newSRS.IsNewerForReporter(status.WebhookProvider, scenarioName, hasSnapshot.Name, now)).To(BeFalse())
+		})
+
 		It("Can export valid annotation", func() {
 			hasSRS.SetLastUpdateTime(scenarioName, hasSnapshot.Name, now)
 
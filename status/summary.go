@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+// SummaryCommentTitle is the title of the summary comment of a pull request, it is also used to find the comment
+// to edit in place
+const SummaryCommentTitle = "Integration test summary"
+
+// SummaryEntry is a row of the summary comment, holding the status of an integration test scenario for a Snapshot
+type SummaryEntry struct {
+	// ComponentNameOrPrGroup is the name of the component of a component Snapshot or the pr group of a group Snapshot
+	ComponentNameOrPrGroup string
+	SnapshotName           string
+	ScenarioName           string
+	Status                 intgteststat.IntegrationTestStatus
+	IsOptionalScenario     bool
+	StartTime              *time.Time
+	CompletionTime         *time.Time
+	TestPipelineRunName    string
+	Namespace              string
+}
+
+// PullRequestSummary holds the entries of the summary comment of a pull request, the entries of the Snapshots superseded
+// by a newer Snapshot of the same component or pr group are kept apart
+type PullRequestSummary struct {
+	Entries           []SummaryEntry
+	SupersededEntries []SummaryEntry
+}
+
+// GeneratePullRequestSummary builds the markdown summary comment of the pull request the given component Snapshot was built from.
+// It lists the integration test statuses of every component Snapshot of the pull request and of every group Snapshot of its pr group.
+func GeneratePullRequestSummary(ctx context.Context, c client.Client, snapshot *applicationapiv1alpha1.Snapshot) (string, error) {
+	summary, err := GetPullRequestSummary(ctx, c, snapshot)
+	if err != nil {
+		return "", err
+	}
+	return FormatPullRequestSummary(summary)
+}
+
+// GetPullRequestSummary collects the integration test statuses of the Snapshots of the pull request the given component Snapshot
+// was built from. The latest Snapshot of each component and pr group is the current one, the older ones are superseded.
+func GetPullRequestSummary(ctx context.Context, c client.Client, snapshot *applicationapiv1alpha1.Snapshot) (*PullRequestSummary, error) {
+	snapshots, err := getPullRequestSummarySnapshots(ctx, c, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &PullRequestSummary{}
+	currentSnapshots := map[string]bool{}
+	for _, s := range gitops.SortSnapshots(snapshots) {
+		s := s //G601
+		statuses, err := gitops.NewSnapshotIntegrationTestStatusesFromSnapshot(&s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get integration test statuses of snapshot %s/%s: %w", s.Namespace, s.Name, err)
+		}
+		key := getSummarySnapshotKey(&s)
+		isSuperseded := currentSnapshots[key]
+		currentSnapshots[key] = true
+
+		details := statuses.GetStatuses()
+		sort.Slice(details, func(i, j int) bool { return details[i].ScenarioName < details[j].ScenarioName })
+		for _, detail := range details {
+			entry := SummaryEntry{
+				ComponentNameOrPrGroup: getSummaryComponentNameOrPrGroup(&s),
+				SnapshotName:           s.Name,
+				ScenarioName:           detail.ScenarioName,
+				Status:                 detail.Status,
+				IsOptionalScenario:     detail.IsOptionalScenario,
+				StartTime:              detail.StartTime,
+				CompletionTime:         detail.CompletionTime,
+				TestPipelineRunName:    detail.TestPipelineRunName,
+				Namespace:              s.Namespace,
+			}
+			if isSuperseded {
+				summary.SupersededEntries = append(summary.SupersededEntries, entry)
+			} else {
+				summary.Entries = append(summary.Entries, entry)
+			}
+		}
+	}
+	// group the entries of each component and pr group, keeping the newest Snapshots first
+	for _, entries := range [][]SummaryEntry{summary.Entries, summary.SupersededEntries} {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].ComponentNameOrPrGroup < entries[j].ComponentNameOrPrGroup })
+	}
+	return summary, nil
+}
+
+// getPullRequestSummarySnapshots returns the component Snapshots built from the same pull request as the given component Snapshot,
+// and the group Snapshots created for its pr group
+func getPullRequestSummarySnapshots(ctx context.Context, c client.Client, snapshot *applicationapiv1alpha1.Snapshot) ([]applicationapiv1alpha1.Snapshot, error) {
+	pullRequest, ok := snapshot.GetLabels()[gitops.PipelineAsCodePullRequestAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("snapshot %s/%s was not built from a pull request", snapshot.Namespace, snapshot.Name)
+	}
+
+	componentSnapshots := &applicationapiv1alpha1.SnapshotList{}
+	if err := c.List(ctx, componentSnapshots, client.InNamespace(snapshot.Namespace), client.MatchingLabels{
+		gitops.SnapshotTypeLabel:                   gitops.SnapshotComponentType,
+		gitops.PipelineAsCodePullRequestAnnotation: pullRequest,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list the snapshots of pull request %s: %w", pullRequest, err)
+	}
+	var snapshots []applicationapiv1alpha1.Snapshot
+	for _, s := range componentSnapshots.Items {
+		s := s //G601
+		// the pull request number is only unique within a repository
+		if gitops.HasSameGitSourceAndPRWithProcessedSnapshot(snapshot, &s) {
+			snapshots = append(snapshots, s)
+		}
+	}
+
+	if prGroupHash, _ := gitops.GetPRGroup(snapshot); prGroupHash != "" {
+		groupSnapshots := &applicationapiv1alpha1.SnapshotList{}
+		if err := c.List(ctx, groupSnapshots, client.InNamespace(snapshot.Namespace), client.MatchingLabels{
+			gitops.SnapshotTypeLabel: gitops.SnapshotGroupType,
+			gitops.PRGroupHashLabel:  prGroupHash,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to list the group snapshots of pr group %s: %w", snapshot.GetAnnotations()[gitops.PRGroupAnnotation], err)
+		}
+		snapshots = append(snapshots, groupSnapshots.Items...)
+	}
+	return snapshots, nil
+}
+
+// getSummarySnapshotKey returns the key of the Snapshots superseding each other: the Snapshots of the same component,
+// or the group Snapshots of the same application or ComponentGroup
+func getSummarySnapshotKey(snapshot *applicationapiv1alpha1.Snapshot) string {
+	owner := snapshot.Spec.Application
+	if owner == "" {
+		owner = snapshot.Spec.ComponentGroup
+	}
+	if gitops.IsGroupSnapshot(snapshot) {
+		return fmt.Sprintf("%s/%s", gitops.SnapshotGroupType, owner)
+	}
+	return fmt.Sprintf("%s/%s/%s", gitops.SnapshotComponentType, owner, snapshot.GetLabels()[gitops.SnapshotComponentLabel])
+}
+
+// getSummaryComponentNameOrPrGroup returns the name the Snapshot is reported with, the component name of a component Snapshot
+// or the pr group of a group Snapshot, the same way as in the other integration test comments
+func getSummaryComponentNameOrPrGroup(snapshot *applicationapiv1alpha1.Snapshot) string {
+	if !gitops.IsGroupSnapshot(snapshot) {
+		return snapshot.GetLabels()[gitops.SnapshotComponentLabel]
+	}
+	if snapshot.Spec.Application != "" {
+		return gitops.ComponentNameForGroupSnapshot
+	}
+	return fmt.Sprintf("%s %s", gitops.ComponentNameForGroupSnapshot, snapshot.Spec.ComponentGroup)
+}
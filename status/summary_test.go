@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/gitops"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+const expectedPullRequestSummary = `### Integration test summary
+
+| Component | Snapshot | Scenario | Status | Duration | Logs |
+| --- | --- | --- | --- | --- | --- |
+| component-a | snapshot-a-new | scenario1 | :heavy_check_mark: TestPassed | 1m30s | <a href="https://definetly.not.prod/ns/default/pipelinerun/plr-a-new">plr-a-new</a> |
+| component-a | snapshot-a-new | scenario2 (optional) | :hourglass_flowing_sand: InProgress | - | - |
+| component-b | snapshot-b | scenario1 | :heavy_check_mark: TestPassed | 1m30s | <a href="https://definetly.not.prod/ns/default/pipelinerun/plr-b">plr-b</a> |
+| pr group | snapshot-group | scenario1 | :x: TestFail | 1m30s | <a href="https://definetly.not.prod/ns/default/pipelinerun/plr-group">plr-group</a> |
+
+<details>
+<summary>1 result(s) of superseded snapshots</summary>
+
+| Component | Snapshot | Scenario | Status | Duration | Logs |
+| --- | --- | --- | --- | --- | --- |
+| component-a | snapshot-a-old | scenario1 | :x: TestFail | 1m30s | <a href="https://definetly.not.prod/ns/default/pipelinerun/plr-a-old">plr-a-old</a> |
+</details>
+`
+
+var _ = Describe("Pull request summary", func() {
+	var (
+		destinationSnapshot *applicationapiv1alpha1.Snapshot
+		componentSnapshots  []applicationapiv1alpha1.Snapshot
+		groupSnapshots      []applicationapiv1alpha1.Snapshot
+		mockK8sClient       *MockK8sClient
+		startTime           = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	// newSnapshot returns a Snapshot of the pull request created at the given offset, with the given integration test statuses
+	newSnapshot := func(name, snapshotType, component, repoURL string, offset time.Duration, statuses string) applicationapiv1alpha1.Snapshot {
+		return applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(startTime.Add(offset)),
+				Labels: map[string]string{
+					gitops.SnapshotTypeLabel:                   snapshotType,
+					gitops.SnapshotComponentLabel:              component,
+					gitops.PipelineAsCodePullRequestAnnotation: "1",
+					gitops.PRGroupHashLabel:                    "featuresha",
+				},
+				Annotations: map[string]string{
+					gitops.PipelineAsCodeRepoURLAnnotation: repoURL,
+					gitops.PRGroupAnnotation:               "feature",
+					gitops.SnapshotTestsStatusAnnotation:   statuses,
+				},
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Application: "application-sample",
+			},
+		}
+	}
+	// testStatus returns the integration test status of a scenario which ran for 90 seconds
+	testStatus := func(scenario, state, pipelineRun string) string {
+		return fmt.Sprintf(`{"scenario":"%s","status":"%s","lastUpdateTime":"2026-01-01T10:02:00Z","details":"","startTime":"2026-01-01T10:00:00Z","completionTime":"2026-01-01T10:01:30Z","testPipelineRunName":"%s"}`,
+			scenario, state, pipelineRun)
+	}
+
+	BeforeEach(func() {
+		os.Setenv("CONSOLE_URL", "https://definetly.not.prod/ns/{{NAMESPACE}}/pipelinerun/{{PIPELINE_RUN_NAME}}")
+
+		destinationSnapshot = &applicationapiv1alpha1.Snapshot{}
+		componentSnapshots = []applicationapiv1alpha1.Snapshot{
+			newSnapshot("snapshot-a-old", gitops.SnapshotComponentType, "component-a", "https://github.com/org/repo", 0,
+				fmt.Sprintf("[%s]", testStatus("scenario1", "TestFail", "plr-a-old"))),
+			newSnapshot("snapshot-b", gitops.SnapshotComponentType, "component-b", "https://github.com/org/repo", time.Minute,
+				fmt.Sprintf("[%s]", testStatus("scenario1", "TestPassed", "plr-b"))),
+			newSnapshot("snapshot-a-new", gitops.SnapshotComponentType, "component-a", "https://github.com/org/repo", 2*time.Minute,
+				fmt.Sprintf(`[%s,{"scenario":"scenario2","status":"InProgress","lastUpdateTime":"2026-01-01T10:02:00Z","details":"","isOptionalScenario":true}]`,
+					testStatus("scenario1", "TestPassed", "plr-a-new"))),
+			// the same pull request number in another repository
+			newSnapshot("snapshot-other-repo", gitops.SnapshotComponentType, "component-c", "https://github.com/org/other-repo", 3*time.Minute,
+				fmt.Sprintf("[%s]", testStatus("scenario1", "TestFail", "plr-other-repo"))),
+		}
+		groupSnapshots = []applicationapiv1alpha1.Snapshot{
+			newSnapshot("snapshot-group", gitops.SnapshotGroupType, "", "", 3*time.Minute,
+				fmt.Sprintf("[%s]", testStatus("scenario1", "TestFail", "plr-group"))),
+		}
+		componentSnapshots[2].DeepCopyInto(destinationSnapshot)
+
+		mockK8sClient = &MockK8sClient{
+			listInterceptor: func(list client.ObjectList) {
+				snapshotList, ok := list.(*applicationapiv1alpha1.SnapshotList)
+				Expect(ok).To(BeTrue())
+				// the component snapshots of the pull request are listed first, then the group snapshots of its pr group
+				if len(snapshotList.Items) == 0 && componentSnapshots != nil {
+					snapshotList.Items = componentSnapshots
+					componentSnapshots = nil
+					return
+				}
+				snapshotList.Items = groupSnapshots
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.Setenv("CONSOLE_URL", "")
+	})
+
+	It("collects the statuses of the snapshots of the pull request and its pr group", func() {
+		summary, err := status.GetPullRequestSummary(context.Background(), mockK8sClient, destinationSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(summary.Entries).To(HaveLen(4))
+		Expect(summary.Entries[0].SnapshotName).To(Equal("snapshot-a-new"))
+		Expect(summary.Entries[0].ScenarioName).To(Equal("scenario1"))
+		Expect(summary.Entries[1].ScenarioName).To(Equal("scenario2"))
+		Expect(summary.Entries[1].Status).To(Equal(intgteststat.IntegrationTestStatusInProgress))
+		Expect(summary.Entries[2].ComponentNameOrPrGroup).To(Equal("component-b"))
+		Expect(summary.Entries[3].ComponentNameOrPrGroup).To(Equal(gitops.ComponentNameForGroupSnapshot))
+
+		Expect(summary.SupersededEntries).To(HaveLen(1))
+		Expect(summary.SupersededEntries[0].SnapshotName).To(Equal("snapshot-a-old"))
+	})
+
+	It("formats the summary comment with the superseded snapshots collapsed", func() {
+		comment, err := status.GeneratePullRequestSummary(context.Background(), mockK8sClient, destinationSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comment).To(Equal(expectedPullRequestSummary))
+	})
+
+	It("doesn't list the group snapshots when the snapshot isn't in a pr group", func() {
+		delete(destinationSnapshot.Labels, gitops.PRGroupHashLabel)
+		summary, err := status.GetPullRequestSummary(context.Background(), mockK8sClient, destinationSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summary.Entries).To(HaveLen(3))
+		Expect(summary.Entries[2].ComponentNameOrPrGroup).To(Equal("component-b"))
+	})
+
+	It("fails for a snapshot which wasn't built from a pull request", func() {
+		delete(destinationSnapshot.Labels, gitops.PipelineAsCodePullRequestAnnotation)
+		_, err := status.GetPullRequestSummary(context.Background(), mockK8sClient, destinationSnapshot)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,335 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/helpers"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+)
+
+const (
+	// ReportTemplatesConfigMapName is the name of the ConfigMap holding the report templates of a namespace
+	ReportTemplatesConfigMapName = "integration-service-report-templates"
+
+	// CheckRunTextTemplateKey is the key of the report templates ConfigMap holding the template of the check run text
+	CheckRunTextTemplateKey = "check-run-text"
+
+	// CommitStatusDescriptionTemplateKey is the key of the report templates ConfigMap holding the template of the
+	// commit status description
+	CommitStatusDescriptionTemplateKey = "commit-status-description"
+
+	// CommentTemplateKey is the key of the report templates ConfigMap holding the template of the comment of a test
+	CommentTemplateKey = "comment"
+
+	// maxReportTemplateOutput is the maximum size of a rendered report template, which is the size limit of
+	// GitHub comments and check run texts
+	maxReportTemplateOutput = 65535
+
+	// maxReportTemplateRangeDepth is the maximum number of nested ranges of a report template
+	maxReportTemplateRangeDepth = 2
+
+	// maxDescriptionLength is the maximum length of a rendered commit status description, which is the limit of GitHub
+	maxDescriptionLength = 140
+)
+
+// printfWidthRegex matches the printf verbs with a width or a precision larger than 999 or given as an argument
+var printfWidthRegex = regexp.MustCompile(`%[-+# 0]*(\d{4,}|\*|\.\d{4,}|\.\*|\d*\.\d{4,})`)
+
+// errReportTemplateOutputTooLarge is returned when a rendered report template exceeds maxReportTemplateOutput
+var errReportTemplateOutputTooLarge = errors.New("the rendered template is too large")
+
+// ReportTemplateData holds the data the report templates of a namespace are rendered with.
+type ReportTemplateData struct {
+	// Scenario is the name of the IntegrationTestScenario
+	Scenario string
+	// Optional is set for optional scenarios
+	Optional bool
+	// Status is the integration test status, e.g. TestPassed
+	Status string
+	// Summary is the default short summary of the status, used as commit status description
+	Summary string
+	// Text is the default markdown text with the details of the test, used as check run text
+	Text string
+	// Snapshot is the name of the tested Snapshot
+	Snapshot string
+	// Namespace is the namespace of the tested Snapshot
+	Namespace string
+	// Component is the name of the component of a component Snapshot or the pr group of a group Snapshot
+	Component string
+	// Components are the components of the tested Snapshot
+	Components []ReportTemplateComponent
+	// PipelineRun is the name of the test PipelineRun, if any
+	PipelineRun string
+	// PipelineRunURL is the link to the test PipelineRun in the console, if any
+	PipelineRunURL string
+	// TaskRuns are the tasks of the finished test PipelineRun
+	TaskRuns []ReportTemplateTaskRun
+	// TestCases are the JUnit test case results published by the test PipelineRun, if any
+	TestCases helpers.TestCaseResults
+	// Duration is the duration of the finished test, if any
+	Duration string
+}
+
+// ReportTemplateComponent holds the data of a component of the tested Snapshot.
+type ReportTemplateComponent struct {
+	Name           string
+	ContainerImage string
+	GitURL         string
+	GitRevision    string
+}
+
+// ReportTemplateTaskRun holds the data of a task of the test PipelineRun.
+type ReportTemplateTaskRun struct {
+	Name string
+	// Result is the result reported by the task, e.g. SUCCESS or FAILURE, empty if the task reported none
+	Result   string
+	Note     string
+	Duration string
+	LogURL   string
+}
+
+// ReportTemplates holds the report templates of a namespace, the default formatting is used for the missing ones.
+type ReportTemplates struct {
+	logger                  logr.Logger
+	checkRunText            *template.Template
+	commitStatusDescription *template.Template
+	comment                 *template.Template
+}
+
+// GetReportTemplates returns the report templates configured for the given namespace, nil if the namespace has no report
+// templates ConfigMap. Templates which can't be parsed or use unsafe constructs are ignored, so the defaults are used instead.
+func GetReportTemplates(ctx context.Context, c client.Client, namespace string, logger logr.Logger) (*ReportTemplates, error) {
+	configMap := &v1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ReportTemplatesConfigMapName}, configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	templates := &ReportTemplates{logger: logger}
+	for key, t := range map[string]**template.Template{
+		CheckRunTextTemplateKey:            &templates.checkRunText,
+		CommitStatusDescriptionTemplateKey: &templates.commitStatusDescription,
+		CommentTemplateKey:                 &templates.comment,
+	} {
+		text, ok := configMap.Data[key]
+		if !ok || strings.TrimSpace(text) == "" {
+			continue
+		}
+		parsed, err := parseReportTemplate(key, text)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid report template, the default is used instead",
+				"namespace", namespace, "configMap.Name", ReportTemplatesConfigMapName, "key", key)
+			continue
+		}
+		*t = parsed
+	}
+	return templates, nil
+}
+
+// parseReportTemplate parses the report template with the functions available to report templates and checks it
+// only uses the constructs which are safe to render in the controller
+func parseReportTemplate(name, text string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"formatTableCell": FormatTableCell,
+		"printf":          safePrintf,
+		"lower":           strings.ToLower,
+		"upper":           strings.ToUpper,
+		"trim":            strings.TrimSpace,
+	}
+	t, err := template.New(name).Funcs(funcMap).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.Templates()) > 1 {
+		return nil, fmt.Errorf("defining templates is not supported")
+	}
+	if t.Tree == nil {
+		return nil, fmt.Errorf("the template is empty")
+	}
+	if err := checkReportTemplateNode(t.Tree.Root, 0); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkReportTemplateNode returns an error if the node or one of its children invokes templates or ranges over
+// something else than the fields of the template data, or more than maxReportTemplateRangeDepth times nested,
+// which could make the rendering loop for a very long time
+func checkReportTemplateNode(node parse.Node, rangeDepth int) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkReportTemplateNode(child, rangeDepth); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("invoking templates is not supported")
+	case *parse.RangeNode:
+		if rangeDepth >= maxReportTemplateRangeDepth {
+			return fmt.Errorf("ranges can't be nested more than %d times", maxReportTemplateRangeDepth)
+		}
+		if len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+			return fmt.Errorf("range is only supported over a field of the template data")
+		}
+		switch arg := n.Pipe.Cmds[0].Args[0].(type) {
+		case *parse.FieldNode, *parse.ChainNode:
+		case *parse.VariableNode:
+			if len(arg.Ident) < 2 {
+				return fmt.Errorf("range is only supported over a field of the template data")
+			}
+		default:
+			return fmt.Errorf("range is only supported over a field of the template data")
+		}
+		if err := checkReportTemplateNode(n.List, rangeDepth+1); err != nil {
+			return err
+		}
+		return checkReportTemplateNode(n.ElseList, rangeDepth)
+	case *parse.IfNode:
+		if err := checkReportTemplateNode(n.List, rangeDepth); err != nil {
+			return err
+		}
+		return checkReportTemplateNode(n.ElseList, rangeDepth)
+	case *parse.WithNode:
+		if err := checkReportTemplateNode(n.List, rangeDepth); err != nil {
+			return err
+		}
+		return checkReportTemplateNode(n.ElseList, rangeDepth)
+	}
+	return nil
+}
+
+// safePrintf is fmt.Sprintf without the large widths and precisions which could allocate a lot of memory
+func safePrintf(format string, args ...interface{}) (string, error) {
+	if printfWidthRegex.MatchString(format) {
+		return "", fmt.Errorf("printf widths and precisions larger than 999 are not supported")
+	}
+	return fmt.Sprintf(format, args...), nil
+}
+
+// limitedBuffer is a strings.Builder which fails once more than maxReportTemplateOutput bytes are written
+type limitedBuffer struct {
+	strings.Builder
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxReportTemplateOutput {
+		return 0, errReportTemplateOutputTooLarge
+	}
+	return b.Builder.Write(p)
+}
+
+// render renders the template with the data, an empty string is returned if there is no template
+// or it fails to render, so the default is used instead
+func (rt *ReportTemplates) render(t *template.Template, data ReportTemplateData) string {
+	if t == nil {
+		return ""
+	}
+	buf := &limitedBuffer{}
+	if err := t.Execute(buf, data); err != nil {
+		rt.logger.Error(err, "Failed to render report template, the default is used instead",
+			"template", t.Name(), "snapshot.Name", data.Snapshot, "scenario.Name", data.Scenario)
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// Apply renders the report templates for the test report, the texts of the missing or failing templates are left
+// empty so the reporters use the default ones
+func (rt *ReportTemplates) Apply(report *TestReport, data ReportTemplateData) {
+	if rt == nil {
+		return
+	}
+	report.CheckRunText = rt.render(rt.checkRunText, data)
+	report.Comment = rt.render(rt.comment, data)
+	description := rt.render(rt.commitStatusDescription, data)
+	if runes := []rune(description); len(runes) > maxDescriptionLength {
+		description = string(runes[:maxDescriptionLength-3]) + "..."
+	}
+	report.Description = description
+}
+
+// NewReportTemplateData returns the data the report templates are rendered with for the test report
+func NewReportTemplateData(report *TestReport, detail intgteststat.IntegrationTestStatusDetail, snapshot *applicationapiv1alpha1.Snapshot,
+	taskRuns []*helpers.TaskRun, logger logr.Logger) ReportTemplateData {
+	data := ReportTemplateData{
+		Scenario:    report.ScenarioName,
+		Optional:    detail.IsOptionalScenario,
+		Status:      report.Status.String(),
+		Summary:     report.Summary,
+		Text:        report.Text,
+		Snapshot:    snapshot.Name,
+		Namespace:   snapshot.Namespace,
+		Component:   report.ComponentName,
+		PipelineRun: report.TestPipelineRunName,
+		Duration:    FormatSummaryDuration(SummaryEntry{StartTime: report.StartTime, CompletionTime: report.CompletionTime}),
+	}
+	if data.Duration == "-" {
+		data.Duration = ""
+	}
+	if report.TestPipelineRunName != "" {
+		data.PipelineRunURL = FormatPipelineURL(report.TestPipelineRunName, snapshot.Namespace, logger)
+	}
+	if detail.TestCaseResults != nil {
+		data.TestCases = *detail.TestCaseResults
+	}
+	for _, component := range snapshot.Spec.Components {
+		templateComponent := ReportTemplateComponent{
+			Name:           component.Name,
+			ContainerImage: component.ContainerImage,
+		}
+		if component.Source.GitSource != nil {
+			templateComponent.GitURL = component.Source.GitSource.URL
+			templateComponent.GitRevision = component.Source.GitSource.Revision
+		}
+		data.Components = append(data.Components, templateComponent)
+	}
+	for _, taskRun := range taskRuns {
+		templateTaskRun := ReportTemplateTaskRun{
+			Name:     taskRun.GetPipelineTaskName(),
+			Duration: taskRun.GetDuration().String(),
+			LogURL:   FormatTaskLogURL(taskRun, report.TestPipelineRunName, snapshot.Namespace, logger),
+		}
+		if result, err := taskRun.GetTestResult(); err == nil && result != nil && result.TestOutput != nil {
+			templateTaskRun.Result = result.TestOutput.Result
+			templateTaskRun.Note = result.TestOutput.Note
+		}
+		data.TaskRuns = append(data.TaskRuns, templateTaskRun)
+	}
+	return data
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-ci/integration-service/helpers"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	"github.com/konflux-ci/integration-service/status"
+)
+
+var _ = Describe("Report templates", func() {
+	var (
+		configMapData map[string]string
+		mockK8sClient *MockK8sClient
+		snapshot      *applicationapiv1alpha1.Snapshot
+		report        *status.TestReport
+		detail        intgteststat.IntegrationTestStatusDetail
+		taskRuns      []*helpers.TaskRun
+	)
+
+	// applyTemplates renders the report templates of the ConfigMap for the report
+	applyTemplates := func() {
+		templates, err := status.GetReportTemplates(context.Background(), mockK8sClient, "default", logr.Discard())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(templates).ToNot(BeNil())
+		templates.Apply(report, status.NewReportTemplateData(report, detail, snapshot, taskRuns, logr.Discard()))
+	}
+
+	BeforeEach(func() {
+		os.Setenv("CONSOLE_URL", "https://definetly.not.prod/ns/{{NAMESPACE}}/pipelinerun/{{PIPELINE_RUN_NAME}}")
+		os.Setenv("CONSOLE_URL_TASKLOG", "https://definetly.not.prod/ns/{{NAMESPACE}}/pipelinerun/{{PIPELINE_RUN_NAME}}/logs/{{TASK_NAME}}")
+
+		configMapData = map[string]string{}
+		mockK8sClient = &MockK8sClient{
+			getInterceptor: func(key client.ObjectKey, obj client.Object) {
+				if configMap, ok := obj.(*v1.ConfigMap); ok {
+					Expect(key.Name).To(Equal(status.ReportTemplatesConfigMapName))
+					configMap.Data = configMapData
+				}
+			},
+		}
+
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot-sample",
+				Namespace: "default",
+			},
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{
+						Name:           "component-sample",
+						ContainerImage: "quay.io/org/component-sample@sha256:abc",
+						Source: applicationapiv1alpha1.ComponentSource{
+							ComponentSourceUnion: applicationapiv1alpha1.ComponentSourceUnion{
+								GitSource: &applicationapiv1alpha1.GitSource{
+									URL:      "https://github.com/org/component-sample",
+									Revision: "abc123",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		startTime := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+		completionTime := startTime.Add(90 * time.Second)
+		report = &status.TestReport{
+			ScenarioName:        "scenario1",
+			SnapshotName:        "snapshot-sample",
+			ComponentName:       "component-sample",
+			Status:              intgteststat.IntegrationTestStatusTestFail,
+			Summary:             "Integration test for component component-sample snapshot snapshot-sample and scenario scenario1 has failed",
+			Text:                "default text",
+			StartTime:           &startTime,
+			CompletionTime:      &completionTime,
+			TestPipelineRunName: "pipelinerun-sample",
+		}
+		detail = intgteststat.IntegrationTestStatusDetail{
+			ScenarioName: "scenario1",
+			Status:       intgteststat.IntegrationTestStatusTestFail,
+			TestCaseResults: &helpers.TestCaseResults{
+				Total:  3,
+				Passed: 2,
+				Failed: 1,
+			},
+		}
+		taskRuns = nil
+	})
+
+	AfterEach(func() {
+		os.Setenv("CONSOLE_URL", "")
+		os.Setenv("CONSOLE_URL_TASKLOG", "")
+	})
+
+	It("returns no templates when the namespace has no report templates ConfigMap", func() {
+		mockK8sClient.err = apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, status.ReportTemplatesConfigMapName)
+		templates, err := status.GetReportTemplates(context.Background(), mockK8sClient, "default", logr.Discard())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(templates).To(BeNil())
+
+		// applying no templates keeps the defaults
+		templates.Apply(report, status.ReportTemplateData{})
+		Expect(report.GetCheckRunText()).To(Equal("default text"))
+		Expect(report.GetDescription()).To(Equal(report.Summary))
+	})
+
+	It("renders the check run text, commit status description and comment with the templates", func() {
+		configMapData[status.CheckRunTextTemplateKey] = `{{ .Scenario }} {{ .Status }} in {{ .Duration }}: {{ .TestCases.Failed }}/{{ .TestCases.Total }} failed, see {{ .PipelineRunURL }}`
+		configMapData[status.CommitStatusDescriptionTemplateKey] = `{{ .Scenario }}: {{ lower .Status }}{{ if .Optional }} (optional){{ end }}`
+		configMapData[status.CommentTemplateKey] = `{{ range .Components }}{{ .Name }}@{{ .GitRevision }} {{ .ContainerImage }}{{ end }}` +
+			`{{ range .TaskRuns }} | {{ .Name }} {{ .Result }} {{ .Duration }} {{ .LogURL }}{{ end }}`
+		now := time.Now()
+		taskRuns = []*helpers.TaskRun{
+			newTaskRunWithAppStudioTestOutput("task-1", now, now.Add(time.Minute), `{"result": "FAILURE", "timestamp": "2026-01-01T10:00:00+00:00", "namespace": "default", "successes": 0, "warnings": 0, "failures": 1}`),
+		}
+		applyTemplates()
+
+		Expect(report.GetCheckRunText()).To(Equal("scenario1 TestFail in 1m30s: 1/3 failed, see https://definetly.not.prod/ns/default/pipelinerun/pipelinerun-sample"))
+		Expect(report.GetDescription()).To(Equal("scenario1: testfail"))
+		Expect(report.Comment).To(Equal("component-sample@abc123 quay.io/org/component-sample@sha256:abc" +
+			" | task-1 FAILURE 1m0s https://definetly.not.prod/ns/default/pipelinerun/pipelinerun-sample/logs/task-1"))
+
+		comment, err := status.FormatTestReportComment(*report)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comment).To(HavePrefix(report.Comment))
+		// the summary is kept so that the comment can still be found and updated
+		Expect(comment).To(ContainSubstring(fmt.Sprintf("<!-- %s -->", report.Summary)))
+	})
+
+	It("truncates the commit status description", func() {
+		configMapData[status.CommitStatusDescriptionTemplateKey] = `{{ .Summary }} {{ .Summary }}`
+		applyTemplates()
+		Expect([]rune(report.GetDescription())).To(HaveLen(140))
+		Expect(report.GetDescription()).To(HaveSuffix("..."))
+	})
+
+	DescribeTable("falls back to the defaults for the invalid templates",
+		func(template string) {
+			configMapData[status.CheckRunTextTemplateKey] = template
+			configMapData[status.CommitStatusDescriptionTemplateKey] = `{{ .Scenario }}`
+			applyTemplates()
+			Expect(report.GetCheckRunText()).To(Equal("default text"))
+			Expect(report.GetDescription()).To(Equal("scenario1"))
+		},
+		Entry("when the template can't be parsed", `{{ .Scenario `),
+		Entry("when the template ranges over a number", `{{ range 1000000000 }}{{ end }}`),
+		Entry("when the template ranges over a variable", `{{ $n := 1000000000 }}{{ range $n }}{{ end }}`),
+		Entry("when the template nests too many ranges", `{{ range .TaskRuns }}{{ range $.TaskRuns }}{{ range $.TaskRuns }}{{ end }}{{ end }}{{ end }}`),
+		Entry("when the template defines templates", `{{ define "loop" }}{{ end }}{{ template "loop" }}`),
+		Entry("when the template refers to an unknown field", `{{ .Unknown }}`),
+		Entry("when the template uses a large printf width", `{{ printf "%0999999d" 1 }}`),
+	)
+
+	It("falls back to the default when the rendered template is too large", func() {
+		now := time.Now()
+		for i := 0; i < 100; i++ {
+			taskRuns = append(taskRuns, newTaskRun(fmt.Sprintf("task-%d", i), now, now.Add(time.Second)))
+		}
+		configMapData[status.CheckRunTextTemplateKey] = `{{ range .TaskRuns }}{{ printf "%999s" .Name }}{{ end }}`
+		applyTemplates()
+		Expect(report.GetCheckRunText()).To(Equal("default text"))
+	})
+
+	It("keeps the default comment without a comment template", func() {
+		comment, err := status.FormatTestReportComment(*report)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comment).To(Equal(fmt.Sprintf("### %s\n\ndefault text", report.Summary)))
+		Expect(strings.Contains(comment, "<!--")).To(BeFalse())
+	})
+})
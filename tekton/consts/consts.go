@@ -134,6 +134,24 @@ const (
 
 	// PipelineRunShouldReleaseResultName is the name of the SHOULD_RELEASE result in build PipelineRuns
 	PipelineRunShouldReleaseResultName = "SHOULD_RELEASE"
+
+	/*
+	 * Snapshot creator constants
+	 */
+	// TaskRunSnapshotCreatorType is the type denoting a TaskRun which creates the Snapshot for a ComponentGroup.
+	TaskRunSnapshotCreatorType = "snapshot-creator"
+
+	// SnapshotCreatorSnapshotParamName is the name of the param containing the Snapshot spec prepared by the integration service
+	SnapshotCreatorSnapshotParamName = "SNAPSHOT"
+
+	// SnapshotCreatorBuildResultsParamName is the name of the param containing the results of the build PipelineRun
+	SnapshotCreatorBuildResultsParamName = "BUILD_PIPELINERUN_RESULTS"
+
+	// SnapshotCreatorGlobalCandidateListParamName is the name of the param containing the Global Candidate List of the ComponentGroup
+	SnapshotCreatorGlobalCandidateListParamName = "GLOBAL_CANDIDATE_LIST"
+
+	// SnapshotCreatorSnapshotResultName is the name of the result containing the Snapshot spec emitted by the snapshot creator Task
+	SnapshotCreatorSnapshotResultName = "SNAPSHOT"
 )
 
 var (
@@ -166,4 +184,7 @@ var (
 
 	// OptionalLabel is the label used to specify if an IntegrationTestScenario is allowed to fail
 	OptionalLabel = fmt.Sprintf("%s/%s", TestLabelPrefix, "optional")
+
+	// BuildPipelineRunNameLabel is the label of the name of the build PipelineRun a snapshot creator TaskRun was created for
+	BuildPipelineRunNameLabel = fmt.Sprintf("%s/%s", TestLabelPrefix, "build-pipelinerun")
 )
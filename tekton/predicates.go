@@ -152,3 +152,23 @@ func BuildPipelineRunDeletingPredicate() predicate.Predicate {
 		},
 	}
 }
+
+// SnapshotCreatorTaskRunFinishedPredicate returns a predicate which filters out all objects except
+// snapshot creator TaskRuns which have just finished.
+func SnapshotCreatorTaskRunFinishedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(genericEvent event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return IsSnapshotCreatorTaskRun(e.ObjectNew) &&
+				hasTaskRunStateChangedToFinished(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
@@ -513,4 +513,61 @@ var _ = Describe("Predicates", func() {
 		})
 
 	})
+	Context("when testing SnapshotCreatorTaskRunFinishedPredicate", func() {
+		instance := tekton.SnapshotCreatorTaskRunFinishedPredicate()
+		var taskRun, newTaskRun *tektonv1.TaskRun
+
+		BeforeEach(func() {
+			taskRun = &tektonv1.TaskRun{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: prefix + "-",
+					Namespace:    namespace,
+					Labels: map[string]string{
+						"pipelines.appstudio.openshift.io/type":         "snapshot-creator",
+						"test.appstudio.openshift.io/build-pipelinerun": "build-pipelinerun-sample",
+					},
+				},
+			}
+			newTaskRun = taskRun.DeepCopy()
+		})
+
+		It("should ignore creation, deleting and generic events", func() {
+			Expect(instance.Create(event.CreateEvent{Object: taskRun})).To(BeFalse())
+			Expect(instance.Delete(event.DeleteEvent{Object: taskRun})).To(BeFalse())
+			Expect(instance.Generic(event.GenericEvent{Object: taskRun})).To(BeFalse())
+		})
+
+		It("should return false for an update event in which the TaskRun has not finished", func() {
+			contextEvent := event.UpdateEvent{
+				ObjectOld: taskRun,
+				ObjectNew: newTaskRun,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+
+		It("should return true for an update event in which the TaskRun finished", func() {
+			newTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: "False",
+			})
+			contextEvent := event.UpdateEvent{
+				ObjectOld: taskRun,
+				ObjectNew: newTaskRun,
+			}
+			Expect(instance.Update(contextEvent)).To(BeTrue())
+		})
+
+		It("should return false for an update event of a finished TaskRun which isn't a snapshot creator", func() {
+			delete(newTaskRun.Labels, "pipelines.appstudio.openshift.io/type")
+			newTaskRun.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: "True",
+			})
+			contextEvent := event.UpdateEvent{
+				ObjectOld: taskRun,
+				ObjectNew: newTaskRun,
+			}
+			Expect(instance.Update(contextEvent)).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/tekton/consts"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScenarioResolutionTimeout is the maximum time spent waiting for the dry-run resolution of an IntegrationTestScenario.
+var ScenarioResolutionTimeout = 2 * time.Minute
+
+// snapshotParamName is the name of the param which is always provided to integration PipelineRuns by the integration service.
+const snapshotParamName = "SNAPSHOT"
+
+// InvalidPipelineError indicates that the resource resolved for an IntegrationTestScenario can't be used as its pipeline.
+// Unlike failures of the resolution itself, which may be transient, it won't go away until the scenario or the
+// resolved resource is fixed.
+type InvalidPipelineError struct {
+	Message string
+}
+
+func (e *InvalidPipelineError) Error() string {
+	return e.Message
+}
+
+// IsInvalidPipelineError returns true if the error is caused by a resolved resource which can't be used as a pipeline.
+func IsInvalidPipelineError(err error) bool {
+	var target *InvalidPipelineError
+	return errors.As(err, &target)
+}
+
+// GetIntegrationTestScenarioPipelineSpec dry-runs the resolution of the IntegrationTestScenario's ResolverRef through
+// a ResolutionRequest and parses the resolved resource. It returns the spec of the resolved Pipeline, or the embedded
+// pipelineSpec of the resolved PipelineRun. A nil spec is returned when the resolved PipelineRun references its
+// Pipeline instead of embedding it, in which case its params can't be validated. An InvalidPipelineError is returned
+// when the resolved resource can't be used as a pipeline, other errors are failures of the resolution itself.
+func GetIntegrationTestScenarioPipelineSpec(ctx context.Context, client client.Client, loader loader.ObjectLoader, integrationTestScenario *v1beta2.IntegrationTestScenario) (*tektonv1.PipelineSpec, error) {
+	resolverRef := integrationTestScenario.Spec.ResolverRef
+	resourceKind := strings.ToLower(resolverRef.ResourceKind)
+	if resourceKind == "" {
+		resourceKind = consts.ResourceKindPipeline
+	}
+	if resourceKind != consts.ResourceKindPipeline && resourceKind != consts.ResourceKindPipelineRun {
+		return nil, &InvalidPipelineError{Message: fmt.Sprintf("unrecognized resolver type '%s'", resolverRef.ResourceKind)}
+	}
+
+	resolutionCtx, cancel := context.WithTimeout(ctx, ScenarioResolutionTimeout)
+	defer cancel()
+
+	data, err := getPipelineRunYamlFromPipelineRunResolver(client, resolutionCtx, loader, integrationTestScenario.Name,
+		integrationTestScenario.Namespace, resolverRef.Resolver, GenerateTektonResolverParams(resolverRef.Params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the %s: %w", resourceKind, err)
+	}
+
+	return parseResolvedPipelineSpec(data, resourceKind)
+}
+
+// parseResolvedPipelineSpec decodes the base64 data returned by a ResolutionRequest and returns the pipeline spec
+// of the resolved resource, making sure it is of the expected kind.
+func parseResolvedPipelineSpec(data, resourceKind string) (*tektonv1.PipelineSpec, error) {
+	resourceYaml, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, &InvalidPipelineError{Message: fmt.Sprintf("failed to decode the resolved %s: %s", resourceKind, err)}
+	}
+
+	var typeMeta struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(resourceYaml, &typeMeta); err != nil {
+		return nil, &InvalidPipelineError{Message: fmt.Sprintf("failed to parse the resolved %s: %s", resourceKind, err)}
+	}
+	if !strings.EqualFold(typeMeta.Kind, resourceKind) {
+		return nil, &InvalidPipelineError{Message: fmt.Sprintf("the resolved resource is of kind '%s' but the resourceKind '%s' was expected", typeMeta.Kind, resourceKind)}
+	}
+
+	if resourceKind == consts.ResourceKindPipeline {
+		var pipeline tektonv1.Pipeline
+		if err := yaml.Unmarshal(resourceYaml, &pipeline); err != nil {
+			return nil, &InvalidPipelineError{Message: fmt.Sprintf("failed to parse the resolved %s: %s", resourceKind, err)}
+		}
+		return &pipeline.Spec, nil
+	}
+
+	var pipelineRun tektonv1.PipelineRun
+	if err := yaml.Unmarshal(resourceYaml, &pipelineRun); err != nil {
+		return nil, &InvalidPipelineError{Message: fmt.Sprintf("failed to parse the resolved %s: %s", resourceKind, err)}
+	}
+	pipelineSpec := pipelineRun.Spec.PipelineSpec
+	if pipelineSpec == nil {
+		return nil, nil
+	}
+
+	// Params set by the PipelineRun itself are handled like params with a default value
+	for _, param := range pipelineRun.Spec.Params {
+		for i := range pipelineSpec.Params {
+			if pipelineSpec.Params[i].Name == param.Name && pipelineSpec.Params[i].Default == nil {
+				pipelineSpec.Params[i].Default = param.Value.DeepCopy()
+			}
+		}
+	}
+	return pipelineSpec, nil
+}
+
+// ValidateIntegrationTestScenarioParams checks that the params of the IntegrationTestScenario match the params
+// declared by its pipeline: every declared scenario param has to have a compatible type, and every declared
+// param without a default value has to be provided either by the scenario or by the integration service.
+// All validation failures are collected and returned as a single joined error. Scenario params which aren't
+// declared by the pipeline are ignored by Tekton, they are only returned as warnings.
+func ValidateIntegrationTestScenarioParams(integrationTestScenario *v1beta2.IntegrationTestScenario, pipelineSpec *tektonv1.PipelineSpec) ([]string, error) {
+	declaredParams := make(map[string]tektonv1.ParamSpec, len(pipelineSpec.Params))
+	for _, paramSpec := range pipelineSpec.Params {
+		declaredParams[paramSpec.Name] = paramSpec
+	}
+
+	var warnings []string
+	var errs error
+	providedParams := map[string]bool{snapshotParamName: true}
+	for _, param := range integrationTestScenario.Spec.Params {
+		providedParams[param.Name] = true
+
+		paramSpec, found := declaredParams[param.Name]
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("param '%s' isn't declared by the pipeline and will be ignored", param.Name))
+			continue
+		}
+
+		declaredType := paramSpec.Type
+		if declaredType == "" {
+			declaredType = tektonv1.ParamTypeString
+		}
+		if len(param.Values) > 0 && declaredType != tektonv1.ParamTypeArray {
+			errs = errors.Join(errs, fmt.Errorf("param '%s' has an array value but the pipeline declares it as %s", param.Name, declaredType))
+		} else if param.Value != "" && declaredType != tektonv1.ParamTypeString {
+			errs = errors.Join(errs, fmt.Errorf("param '%s' has a string value but the pipeline declares it as %s", param.Name, declaredType))
+		}
+	}
+
+	for _, paramSpec := range pipelineSpec.Params {
+		if paramSpec.Default == nil && !providedParams[paramSpec.Name] {
+			errs = errors.Join(errs, fmt.Errorf("param '%s' is required by the pipeline but isn't provided", paramSpec.Name))
+		}
+	}
+
+	return warnings, errs
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/loader"
+	"github.com/konflux-ci/integration-service/tekton"
+	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
+	toolkit "github.com/konflux-ci/operator-toolkit/loader"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knative "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const resolvedPipelineYAML = `---
+apiVersion: tekton.dev/v1
+kind: Pipeline
+metadata:
+  name: integration-pipeline
+spec:
+  params:
+    - name: SNAPSHOT
+      type: string
+    - name: POLICY
+      type: string
+    - name: TARGETS
+      type: array
+      default: []
+  tasks: []
+`
+
+var _ = Describe("IntegrationTestScenario validation", func() {
+
+	var (
+		scenario         *v1beta2.IntegrationTestScenario
+		pipelineSpec     *tektonv1.PipelineSpec
+		resolvedResponse func(data string) resolutionv1beta1.ResolutionRequest
+	)
+
+	BeforeEach(func() {
+		scenario = &v1beta2.IntegrationTestScenario{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scenario-validation-sample",
+				Namespace: "default",
+			},
+			Spec: v1beta2.IntegrationTestScenarioSpec{
+				ComponentGroup: "component-group-sample",
+				ResolverRef: v1beta2.ResolverRef{
+					Resolver: "git",
+					Params: []v1beta2.ResolverParameter{
+						{Name: "url", Value: "https://github.com/konflux-ci/integration-examples"},
+						{Name: "revision", Value: "main"},
+						{Name: "pathInRepo", Value: "pipelines/integration_pipeline.yaml"},
+					},
+				},
+				Params: []v1beta2.PipelineParameter{
+					{Name: "POLICY", Value: "default"},
+				},
+			},
+		}
+
+		pipelineSpec = &tektonv1.PipelineSpec{
+			Params: tektonv1.ParamSpecs{
+				{Name: "SNAPSHOT", Type: tektonv1.ParamTypeString},
+				{Name: "POLICY", Type: tektonv1.ParamTypeString},
+				{Name: "TARGETS", Type: tektonv1.ParamTypeArray, Default: tektonv1.NewStructuredValues("a", "b")},
+			},
+		}
+
+		resolvedResponse = func(data string) resolutionv1beta1.ResolutionRequest {
+			return resolutionv1beta1.ResolutionRequest{
+				Status: resolutionv1beta1.ResolutionRequestStatus{
+					ResolutionRequestStatusFields: resolutionv1beta1.ResolutionRequestStatusFields{
+						Data: data,
+					},
+					Status: duckv1.Status{
+						Conditions: []knative.Condition{
+							{
+								Type:   knative.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			}
+		}
+	})
+
+	It("accepts params which match the params of the pipeline", func() {
+		warnings, err := tekton.ValidateIntegrationTestScenarioParams(scenario, pipelineSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("rejects params which have the wrong type", func() {
+		scenario.Spec.Params = append(scenario.Spec.Params,
+			v1beta2.PipelineParameter{Name: "TARGETS", Value: "not-an-array"},
+		)
+		_, err := tekton.ValidateIntegrationTestScenarioParams(scenario, pipelineSpec)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("param 'TARGETS' has a string value but the pipeline declares it as array"))
+	})
+
+	It("only warns about params which aren't declared by the pipeline", func() {
+		scenario.Spec.Params = append(scenario.Spec.Params,
+			v1beta2.PipelineParameter{Name: "UNKNOWN", Value: "value"},
+		)
+		warnings, err := tekton.ValidateIntegrationTestScenarioParams(scenario, pipelineSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf("param 'UNKNOWN' isn't declared by the pipeline and will be ignored"))
+	})
+
+	It("rejects scenarios which don't provide the required params of the pipeline", func() {
+		scenario.Spec.Params = nil
+		_, err := tekton.ValidateIntegrationTestScenarioParams(scenario, pipelineSpec)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("param 'POLICY' is required by the pipeline but isn't provided"))
+	})
+
+	It("can dry-run the resolution of the pipeline referenced by the scenario", func() {
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.ResolutionRequestContextKey,
+				Resource:   resolvedResponse(tekton.GenerateCleanData(resolvedPipelineYAML)),
+			},
+		})
+
+		resolvedSpec, err := tekton.GetIntegrationTestScenarioPipelineSpec(mockContext, k8sClient, loader.NewMockLoader(), scenario)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resolvedSpec).NotTo(BeNil())
+		Expect(resolvedSpec.Params).To(HaveLen(3))
+		_, err = tekton.ValidateIntegrationTestScenarioParams(scenario, resolvedSpec)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("fails when the resolved resource isn't of the expected kind", func() {
+		scenario.Spec.ResolverRef.ResourceKind = tektonconsts.ResourceKindPipelineRun
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.ResolutionRequestContextKey,
+				Resource:   resolvedResponse(tekton.GenerateCleanData(resolvedPipelineYAML)),
+			},
+		})
+
+		_, err := tekton.GetIntegrationTestScenarioPipelineSpec(mockContext, k8sClient, loader.NewMockLoader(), scenario)
+		Expect(err).To(HaveOccurred())
+		Expect(tekton.IsInvalidPipelineError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("the resolved resource is of kind 'Pipeline'"))
+	})
+
+	It("fails when the pipeline can't be resolved", func() {
+		failedResolution := resolvedResponse("")
+		failedResolution.Status.Conditions[0].Status = corev1.ConditionFalse
+		failedResolution.Status.Conditions[0].Message = "file not found"
+		mockContext := toolkit.GetMockedContext(ctx, []toolkit.MockData{
+			{
+				ContextKey: loader.ResolutionRequestContextKey,
+				Resource:   failedResolution,
+			},
+		})
+
+		_, err := tekton.GetIntegrationTestScenarioPipelineSpec(mockContext, k8sClient, loader.NewMockLoader(), scenario)
+		Expect(err).To(HaveOccurred())
+		Expect(tekton.IsInvalidPipelineError(err)).To(BeFalse())
+		Expect(err.Error()).To(ContainSubstring("file not found"))
+	})
+})
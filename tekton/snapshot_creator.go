@@ -0,0 +1,132 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/tekton/consts"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SnapshotCreatorTaskRun is a TaskRun alias, so we can add new methods to it in this file.
+type SnapshotCreatorTaskRun struct {
+	tektonv1.TaskRun
+}
+
+// AsTaskRun casts the SnapshotCreatorTaskRun to TaskRun, so it can be used in the Kubernetes client.
+func (r *SnapshotCreatorTaskRun) AsTaskRun() *tektonv1.TaskRun {
+	return &r.TaskRun
+}
+
+// NewSnapshotCreatorTaskRun creates a TaskRun which runs the snapshot creator Task referenced by the ComponentGroup
+// for the given build PipelineRun. The name will be autogenerated from the name of the ComponentGroup.
+func NewSnapshotCreatorTaskRun(buildPipelineRun *tektonv1.PipelineRun, componentGroup *v1beta2.ComponentGroup) *SnapshotCreatorTaskRun {
+	taskRef := componentGroup.Spec.SnapshotCreator.TaskRef
+	taskRun := tektonv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: componentGroup.Name + "-snapshot-creator-",
+			Namespace:    componentGroup.Namespace,
+			Labels: map[string]string{
+				consts.PipelinesTypeLabel:        consts.TaskRunSnapshotCreatorType,
+				consts.ComponentGroupNameLabel:   componentGroup.Name,
+				consts.BuildPipelineRunNameLabel: buildPipelineRun.Name,
+			},
+		},
+		Spec: tektonv1.TaskRunSpec{
+			TaskRef: &tektonv1.TaskRef{
+				ResolverRef: tektonv1.ResolverRef{
+					Resolver: tektonv1.ResolverName(taskRef.Resolver),
+					Params:   GenerateTektonResolverParams(taskRef.Params),
+				},
+			},
+			ServiceAccountName: consts.DefaultIntegrationPipelineServiceAccount,
+		},
+	}
+
+	if componentName, found := buildPipelineRun.Labels[consts.PipelineRunComponentLabel]; found {
+		taskRun.Labels[consts.ComponentNameLabel] = componentName
+	}
+
+	return &SnapshotCreatorTaskRun{taskRun}
+}
+
+// WithSnapshotCreatorParams adds the params describing the build to the snapshot creator TaskRun: the Snapshot spec
+// prepared by the integration service, the results of the build PipelineRun and the Global Candidate List of the
+// ComponentGroup, all as json strings. Params which aren't declared by the Task are ignored by Tekton.
+func (r *SnapshotCreatorTaskRun) WithSnapshotCreatorParams(buildPipelineRun *tektonv1.PipelineRun, componentGroup *v1beta2.ComponentGroup, snapshot *applicationapiv1alpha1.Snapshot) *SnapshotCreatorTaskRun {
+	buildResults := map[string]string{}
+	for _, result := range buildPipelineRun.Status.Results {
+		buildResults[result.Name] = result.Value.StringVal
+	}
+
+	// We ignore the errors here because none should be raised when marshalling these types
+	snapshotString, _ := json.Marshal(snapshot.Spec)
+	buildResultsString, _ := json.Marshal(buildResults)
+	gclString, _ := json.Marshal(componentGroup.Status.GlobalCandidateList)
+
+	r.withStringParam(consts.SnapshotCreatorSnapshotParamName, string(snapshotString))
+	r.withStringParam(consts.SnapshotCreatorBuildResultsParamName, string(buildResultsString))
+	r.withStringParam(consts.SnapshotCreatorGlobalCandidateListParamName, string(gclString))
+
+	return r
+}
+
+// withStringParam adds a param with a string value to the snapshot creator TaskRun.
+func (r *SnapshotCreatorTaskRun) withStringParam(name, value string) {
+	r.Spec.Params = append(r.Spec.Params, tektonv1.Param{
+		Name: name,
+		Value: tektonv1.ParamValue{
+			Type:      tektonv1.ParamTypeString,
+			StringVal: value,
+		},
+	})
+}
+
+// IsSnapshotCreatorTaskRun returns a boolean indicating whether the object passed is a TaskRun
+// created to run the snapshot creator Task of a ComponentGroup.
+func IsSnapshotCreatorTaskRun(object client.Object) bool {
+	if taskRun, ok := object.(*tektonv1.TaskRun); ok {
+		return taskRun.Labels[consts.PipelinesTypeLabel] == consts.TaskRunSnapshotCreatorType &&
+			taskRun.Labels[consts.BuildPipelineRunNameLabel] != ""
+	}
+	return false
+}
+
+// GetSnapshotSpecFromSnapshotCreatorTaskRun returns the Snapshot spec emitted by the snapshot creator Task
+// in its SNAPSHOT result. An error is returned when the result is missing or doesn't contain a valid Snapshot spec.
+func GetSnapshotSpecFromSnapshotCreatorTaskRun(taskRun *tektonv1.TaskRun) (*applicationapiv1alpha1.SnapshotSpec, error) {
+	for _, result := range taskRun.Status.Results {
+		if result.Name != consts.SnapshotCreatorSnapshotResultName {
+			continue
+		}
+		snapshotSpec := &applicationapiv1alpha1.SnapshotSpec{}
+		decoder := json.NewDecoder(strings.NewReader(result.Value.StringVal))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(snapshotSpec); err != nil {
+			return nil, fmt.Errorf("result %s of taskRun %s doesn't contain a valid Snapshot spec: %w", result.Name, taskRun.Name, err)
+		}
+		return snapshotSpec, nil
+	}
+	return nil, fmt.Errorf("taskRun %s didn't emit the %s result", taskRun.Name, consts.SnapshotCreatorSnapshotResultName)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	applicationapiv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/integration-service/tekton"
+	tektonconsts "github.com/konflux-ci/integration-service/tekton/consts"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Snapshot creator TaskRun", func() {
+
+	const (
+		SampleImage = "quay.io/redhat-appstudio/sample-image@sha256:841328df1b9f8c4087adbdcfec6cc99ac8308805dea83f6d415d6fb8d40227c1"
+	)
+
+	var (
+		buildPipelineRun *tektonv1.PipelineRun
+		componentGroup   *v1beta2.ComponentGroup
+		snapshot         *applicationapiv1alpha1.Snapshot
+	)
+
+	BeforeEach(func() {
+		buildPipelineRun = &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "build-pipelinerun-sample",
+				Namespace: "default",
+				Labels: map[string]string{
+					"pipelines.appstudio.openshift.io/type": "build",
+					"appstudio.openshift.io/component":      "component-sample",
+				},
+			},
+			Status: tektonv1.PipelineRunStatus{
+				PipelineRunStatusFields: tektonv1.PipelineRunStatusFields{
+					Results: []tektonv1.PipelineRunResult{
+						{
+							Name:  "IMAGE_DIGEST",
+							Value: *tektonv1.NewStructuredValues("sha256:841328df1b9f8c4087adbdcfec6cc99ac8308805dea83f6d415d6fb8d40227c1"),
+						},
+					},
+				},
+			},
+		}
+
+		componentGroup = &v1beta2.ComponentGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "component-group-sample",
+				Namespace: "default",
+			},
+			Spec: v1beta2.ComponentGroupSpec{
+				SnapshotCreator: &v1beta2.SnapshotCreatorSpec{
+					TaskRef: &v1beta2.TaskRef{
+						Resolver: "git",
+						Params: []v1beta2.ResolverParameter{
+							{Name: "url", Value: "https://github.com/konflux-ci/integration-examples"},
+							{Name: "revision", Value: "main"},
+							{Name: "pathInRepo", Value: "tasks/create-snapshot.yaml"},
+						},
+					},
+				},
+			},
+			Status: v1beta2.ComponentGroupStatus{
+				GlobalCandidateList: []v1beta2.ComponentState{
+					{Name: "component-sample", Version: "v1", LastPromotedImage: SampleImage},
+				},
+			},
+		}
+
+		snapshot = &applicationapiv1alpha1.Snapshot{
+			Spec: applicationapiv1alpha1.SnapshotSpec{
+				ComponentGroup: componentGroup.Name,
+				Components: []applicationapiv1alpha1.SnapshotComponent{
+					{Name: "component-sample", Version: "v1", ContainerImage: SampleImage},
+				},
+			},
+		}
+	})
+
+	It("can create a snapshot creator TaskRun referencing the ComponentGroup Task", func() {
+		taskRun := tekton.NewSnapshotCreatorTaskRun(buildPipelineRun, componentGroup).
+			WithSnapshotCreatorParams(buildPipelineRun, componentGroup, snapshot).
+			AsTaskRun()
+
+		Expect(taskRun.GenerateName).To(Equal("component-group-sample-snapshot-creator-"))
+		Expect(taskRun.Namespace).To(Equal("default"))
+		Expect(taskRun.Labels).To(HaveKeyWithValue(tektonconsts.PipelinesTypeLabel, tektonconsts.TaskRunSnapshotCreatorType))
+		Expect(taskRun.Labels).To(HaveKeyWithValue(tektonconsts.ComponentGroupNameLabel, componentGroup.Name))
+		Expect(taskRun.Labels).To(HaveKeyWithValue(tektonconsts.BuildPipelineRunNameLabel, buildPipelineRun.Name))
+		Expect(taskRun.Labels).To(HaveKeyWithValue(tektonconsts.ComponentNameLabel, "component-sample"))
+		Expect(tekton.IsSnapshotCreatorTaskRun(taskRun)).To(BeTrue())
+
+		Expect(taskRun.Spec.TaskRef.Resolver).To(Equal(tektonv1.ResolverName("git")))
+		Expect(taskRun.Spec.TaskRef.Params).To(HaveLen(3))
+
+		Expect(taskRun.Spec.Params).To(HaveLen(3))
+		Expect(taskRun.Spec.Params[0].Name).To(Equal(tektonconsts.SnapshotCreatorSnapshotParamName))
+		Expect(taskRun.Spec.Params[0].Value.StringVal).To(ContainSubstring(SampleImage))
+		Expect(taskRun.Spec.Params[1].Name).To(Equal(tektonconsts.SnapshotCreatorBuildResultsParamName))
+		Expect(taskRun.Spec.Params[1].Value.StringVal).To(ContainSubstring("IMAGE_DIGEST"))
+		Expect(taskRun.Spec.Params[2].Name).To(Equal(tektonconsts.SnapshotCreatorGlobalCandidateListParamName))
+		Expect(taskRun.Spec.Params[2].Value.StringVal).To(ContainSubstring("lastPromotedImage"))
+	})
+
+	It("doesn't consider other TaskRuns to be snapshot creators", func() {
+		Expect(tekton.IsSnapshotCreatorTaskRun(&tektonv1.TaskRun{})).To(BeFalse())
+		Expect(tekton.IsSnapshotCreatorTaskRun(buildPipelineRun)).To(BeFalse())
+	})
+
+	It("can get the Snapshot spec emitted by the snapshot creator TaskRun", func() {
+		snapshotSpec, err := json.Marshal(snapshot.Spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		taskRun := &tektonv1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot-creator-sample"},
+			Status: tektonv1.TaskRunStatus{
+				TaskRunStatusFields: tektonv1.TaskRunStatusFields{
+					Results: []tektonv1.TaskRunResult{
+						{Name: "SNAPSHOT", Value: *tektonv1.NewStructuredValues(string(snapshotSpec))},
+					},
+				},
+			},
+		}
+		emittedSpec, err := tekton.GetSnapshotSpecFromSnapshotCreatorTaskRun(taskRun)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*emittedSpec).To(Equal(snapshot.Spec))
+
+		taskRun.Status.Results[0].Value = *tektonv1.NewStructuredValues(`{"unknownField": "value"}`)
+		_, err = tekton.GetSnapshotSpecFromSnapshotCreatorTaskRun(taskRun)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't contain a valid Snapshot spec"))
+
+		taskRun.Status.Results = nil
+		_, err = tekton.GetSnapshotSpecFromSnapshotCreatorTaskRun(taskRun)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("didn't emit the SNAPSHOT result"))
+	})
+})
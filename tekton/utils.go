@@ -63,6 +63,18 @@ func hasPipelineRunStateChangedToFinished(objectOld, objectNew client.Object) bo
 	return false
 }
 
+// hasTaskRunStateChangedToFinished returns a boolean indicating whether the TaskRun just finished.
+// If the objects passed to this function are not TaskRuns, the function will return false.
+func hasTaskRunStateChangedToFinished(objectOld, objectNew client.Object) bool {
+	if oldTaskRun, ok := objectOld.(*tektonv1.TaskRun); ok {
+		if newTaskRun, ok := objectNew.(*tektonv1.TaskRun); ok {
+			return oldTaskRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() && !newTaskRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+		}
+	}
+
+	return false
+}
+
 // hasPipelineRunStateChangedToStarted returns a boolean indicating whether the PipelineRun just started.
 // If the objects passed to this function are not PipelineRuns, the function will return false.
 func hasPipelineRunStateChangedToStarted(objectOld, objectNew client.Object) bool {